@@ -1,24 +1,38 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 	"strconv"
 	"strings"
 	"time"
 
 	"apocapoc-api/internal/application/commands"
 	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/services"
 	"apocapoc-api/internal/i18n"
 	"apocapoc-api/internal/infrastructure/auth"
 	"apocapoc-api/internal/infrastructure/backup"
 	"apocapoc-api/internal/infrastructure/config"
 	"apocapoc-api/internal/infrastructure/crypto"
+	"apocapoc-api/internal/infrastructure/digest"
 	"apocapoc-api/internal/infrastructure/email"
+	"apocapoc-api/internal/infrastructure/events"
 	httpInfra "apocapoc-api/internal/infrastructure/http"
+	"apocapoc-api/internal/infrastructure/inbound"
 	"apocapoc-api/internal/infrastructure/logger"
+	"apocapoc-api/internal/infrastructure/mqtt"
+	"apocapoc-api/internal/infrastructure/notifications"
 	"apocapoc-api/internal/infrastructure/persistence/sqlite"
+	"apocapoc-api/internal/infrastructure/reminder"
+	"apocapoc-api/internal/infrastructure/telegram"
+	"apocapoc-api/internal/infrastructure/webhook"
+	"apocapoc-api/internal/infrastructure/webpush"
+	"apocapoc-api/internal/shared/constants"
 )
 
 // @title Apocapoc API
@@ -40,6 +54,11 @@ import (
 // @description Type "Bearer" followed by a space and JWT token.
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "generate-vapid-keys" {
+		generateVAPIDKeys()
+		return
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
@@ -56,6 +75,11 @@ func main() {
 	}
 	defer db.Close()
 
+	if len(os.Args) > 1 && os.Args[1] == "rebuild-stats" {
+		rebuildStats(db, os.Args[2:])
+		return
+	}
+
 	backupInterval, err := parseDuration(cfg.BackupInterval)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Invalid BACKUP_INTERVAL")
@@ -90,21 +114,58 @@ func main() {
 	jwtService := auth.NewJWTService(cfg.JWTSecret, jwtExpiryHours)
 	passwordHasher := crypto.NewBcryptHasher()
 
-	var emailService *email.SMTPService
-	if cfg.SMTPHost != "" {
-		smtpPort, err := strconv.Atoi(cfg.SMTPPort)
-		if err != nil {
-			logger.Fatal().Err(err).Msg("Invalid SMTP_PORT")
-		}
+	emailOutboxRepo := sqlite.NewEmailOutboxRepository(db.Conn())
+
+	// Mail is queued in the email outbox and sent in the background, so a mail
+	// server outage does not fail the requests that send email.
+	var emailService services.EmailService
+	var emailOutbox *email.Outbox
 
-		emailService = email.NewSMTPService(email.SMTPConfig{
+	smtpPort, err := strconv.Atoi(cfg.SMTPPort)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid SMTP_PORT")
+	}
+	emailTransport, err := email.NewTransport(email.TransportConfig{
+		Name: cfg.EmailTransport,
+		From: cfg.EmailFrom,
+		SMTP: email.SMTPConfig{
 			Host:         cfg.SMTPHost,
 			Port:         smtpPort,
 			Username:     cfg.SMTPUser,
 			Password:     cfg.SMTPPassword,
-			From:         cfg.SMTPFrom,
 			SupportEmail: cfg.SupportEmail,
+		},
+		FileDir: cfg.EmailFileDir,
+		HTTP: email.HTTPConfig{
+			URL:        cfg.EmailHTTPURL,
+			Format:     cfg.EmailHTTPFormat,
+			APIKey:     cfg.EmailHTTPAPIKey,
+			AuthHeader: cfg.EmailHTTPAuthHeader,
+			HealthURL:  cfg.EmailHTTPHealthURL,
+		},
+	})
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid email transport configuration")
+	}
+
+	if emailTransport != nil {
+		emailOutboxInterval, err := parseDuration(cfg.EmailOutboxInterval)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Invalid EMAIL_OUTBOX_INTERVAL")
+		}
+		emailRetention, err := parseDuration(cfg.EmailRetention)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Invalid EMAIL_RETENTION")
+		}
+
+		emailOutbox = email.NewOutbox(emailOutboxRepo, emailTransport, email.OutboxConfig{
+			Interval:  emailOutboxInterval,
+			Retention: emailRetention,
 		})
+		emailOutbox.Start()
+		defer emailOutbox.Stop()
+
+		emailService = emailOutbox
 	}
 
 	sendWelcomeEmail := cfg.SendWelcomeEmail == "true"
@@ -112,44 +173,323 @@ func main() {
 	userRepo := sqlite.NewUserRepository(db.Conn())
 	habitRepo := sqlite.NewHabitRepository(db.Conn())
 	entryRepo := sqlite.NewHabitEntryRepository(db.Conn())
+	habitStatsRepo := sqlite.NewHabitStatsRepository(db.Conn())
+	transactor := sqlite.NewTransactor(db.Conn())
+	achievementRepo := sqlite.NewAchievementRepository(db.Conn())
+	pointsRepo := sqlite.NewPointsRepository(db.Conn())
+	rewardRepo := sqlite.NewRewardRepository(db.Conn())
 	refreshTokenRepo := sqlite.NewRefreshTokenRepository(db.Conn())
 	passwordResetTokenRepo := sqlite.NewPasswordResetTokenRepository(db.Conn())
+	digestSubscriptionRepo := sqlite.NewDigestSubscriptionRepository(db.Conn())
+	recapShareRepo := sqlite.NewRecapShareRepository(db.Conn())
+	reminderRepo := sqlite.NewHabitReminderRepository(db.Conn())
+	pushSubscriptionRepo := sqlite.NewPushSubscriptionRepository(db.Conn())
+	webhookRepo := sqlite.NewWebhookRepository(db.Conn())
+	webhookDeliveryRepo := sqlite.NewWebhookDeliveryRepository(db.Conn())
+	eventOutboxRepo := sqlite.NewEventOutboxRepository(db.Conn())
+	notificationPreferenceRepo := sqlite.NewNotificationPreferenceRepository(db.Conn())
+	notificationScheduleRepo := sqlite.NewNotificationScheduleRepository(db.Conn())
+	heldNotificationRepo := sqlite.NewHeldNotificationRepository(db.Conn())
+	notificationLogRepo := sqlite.NewNotificationLogRepository(db.Conn())
+	chatLinkRepo := sqlite.NewChatLinkRepository(db.Conn())
+	chatLinkCodeRepo := sqlite.NewChatLinkCodeRepository(db.Conn())
+	mqttIntegrationRepo := sqlite.NewMQTTIntegrationRepository(db.Conn())
+	notificationGate := notifications.NewGate(notificationPreferenceRepo, notificationScheduleRepo, heldNotificationRepo, notificationLogRepo)
+	unsubscribeTokens := auth.NewUnsubscribeTokens(cfg.JWTSecret)
 
 	translator, err := i18n.NewTranslator()
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to create translator")
 	}
 
-	registerHandler := commands.NewRegisterUserHandler(userRepo, passwordHasher, emailService, cfg.AppURL, cfg.RegistrationMode, sendWelcomeEmail)
+	// The mailer also renders the admin template previews, so it exists even
+	// when no email transport is configured.
+	emailRenderer := email.NewTemplateRenderer(constants.AppName, cfg.AppURL, cfg.SupportEmail)
+	templateMailer := email.NewMailer(emailService, emailRenderer, translator, notificationGate, unsubscribeTokens, cfg.APIURL+"/api/v1/unsubscribe")
+	var mailer services.Mailer
+	if emailService != nil {
+		mailer = templateMailer
+	}
+
+	eventRetryInterval, err := parseDuration(cfg.EventRetryInterval)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid EVENT_RETRY_INTERVAL")
+	}
+	eventRetention, err := parseDuration(cfg.EventRetention)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid EVENT_RETENTION")
+	}
+
+	eventBus := events.NewBus(eventOutboxRepo, events.Config{
+		Interval:  eventRetryInterval,
+		Retention: eventRetention,
+	})
+
+	if emailService != nil {
+		eventBus.SubscribeAsync("achievement_email", email.NewAchievementNotifier(mailer, userRepo).Handle, entities.EventAchievementUnlocked)
+		if sendWelcomeEmail {
+			eventBus.SubscribeAsync("welcome_email", email.NewWelcomeMailer(mailer, userRepo).Handle, entities.EventUserEmailVerified)
+		}
+	}
+	if cfg.WebhooksEnabled == "true" {
+		eventBus.SubscribeAsync("webhooks", commands.NewWebhookEventHandler(webhookRepo, webhookDeliveryRepo).Handle, entities.WebhookEventTypes...)
+	}
+
+	registerHandler := commands.NewRegisterUserHandler(userRepo, passwordHasher, mailer, cfg.AppURL, cfg.RegistrationMode, sendWelcomeEmail, eventBus, logger.ErrorLog{})
 	loginHandler := queries.NewLoginUserHandler(userRepo, passwordHasher)
 	refreshTokenHandler := queries.NewRefreshTokenHandler(refreshTokenRepo, userRepo)
 	revokeTokenHandler := commands.NewRevokeTokenHandler(refreshTokenRepo)
 	revokeAllTokensHandler := commands.NewRevokeAllTokensHandler(refreshTokenRepo)
-	verifyEmailHandler := commands.NewVerifyEmailHandler(userRepo, emailService, sendWelcomeEmail)
-	resendVerificationEmailHandler := commands.NewResendVerificationEmailHandler(userRepo, emailService, cfg.AppURL)
-	requestPasswordResetHandler := commands.NewRequestPasswordResetHandler(userRepo, passwordResetTokenRepo, emailService, cfg.AppURL)
+	verifyEmailHandler := commands.NewVerifyEmailHandler(userRepo, eventBus)
+	resendVerificationEmailHandler := commands.NewResendVerificationEmailHandler(userRepo, mailer, cfg.AppURL)
+	requestPasswordResetHandler := commands.NewRequestPasswordResetHandler(userRepo, passwordResetTokenRepo, mailer, cfg.AppURL)
 	resetPasswordHandler := commands.NewResetPasswordHandler(userRepo, passwordResetTokenRepo, passwordHasher)
 	deleteUserHandler := commands.NewDeleteUserHandler(userRepo)
-	createHandler := commands.NewCreateHabitHandler(habitRepo)
+
+	createHandler := commands.NewCreateHabitHandler(habitRepo, eventBus)
 	getTodaysHandler := queries.NewGetTodaysHabitsHandler(habitRepo, entryRepo)
 	getUserHabitsHandler := queries.NewGetUserHabitsHandler(habitRepo)
 	getHabitByIDHandler := queries.NewGetHabitByIDHandler(habitRepo)
 	getHabitEntriesHandler := queries.NewGetHabitEntriesHandler(habitRepo, entryRepo)
-	getHabitStatsHandler := queries.NewGetHabitStatsHandler(habitRepo, entryRepo)
+	getHabitStatsHandler := queries.NewGetHabitStatsHandler(habitRepo, entryRepo, habitStatsRepo)
+	getDashboardStatsHandler := queries.NewGetDashboardStatsHandler(habitRepo, entryRepo)
+	getHeatmapHandler := queries.NewGetHeatmapHandler(habitRepo, entryRepo)
+	getHabitSeriesHandler := queries.NewGetHabitSeriesHandler(habitRepo, entryRepo)
+	getCorrelationInsightsHandler := queries.NewGetCorrelationInsightsHandler(habitRepo, entryRepo)
+	getBehaviourInsightsHandler := queries.NewGetBehaviourInsightsHandler(habitRepo, entryRepo)
 	exportUserDataHandler := queries.NewExportUserDataHandler(habitRepo, entryRepo)
-	updateHandler := commands.NewUpdateHabitHandler(habitRepo)
-	archiveHandler := commands.NewArchiveHabitHandler(habitRepo)
-	markHandler := commands.NewMarkHabitHandler(entryRepo, habitRepo)
-	unmarkHandler := commands.NewUnmarkHabitHandler(habitRepo, entryRepo)
+	updateHandler := commands.NewUpdateHabitHandler(habitRepo, entryRepo, habitStatsRepo, transactor)
+	archiveHandler := commands.NewArchiveHabitHandler(habitRepo, eventBus)
+	achievementEvaluator := commands.NewAchievementEvaluator(habitRepo, entryRepo, habitStatsRepo, achievementRepo)
+	getUserAchievementsHandler := queries.NewGetUserAchievementsHandler(achievementRepo)
+	markHandler := commands.NewMarkHabitHandler(entryRepo, habitRepo, habitStatsRepo, pointsRepo, transactor, achievementEvaluator, eventBus)
+	unmarkHandler := commands.NewUnmarkHabitHandler(habitRepo, entryRepo, habitStatsRepo, pointsRepo, transactor, eventBus)
+	createRewardHandler := commands.NewCreateRewardHandler(rewardRepo)
+	archiveRewardHandler := commands.NewArchiveRewardHandler(rewardRepo)
+	redeemRewardHandler := commands.NewRedeemRewardHandler(rewardRepo, pointsRepo, transactor)
+	getPointsSummaryHandler := queries.NewGetPointsSummaryHandler(pointsRepo)
+	getPointTransactionsHandler := queries.NewGetPointTransactionsHandler(pointsRepo)
+	getRewardsHandler := queries.NewGetRewardsHandler(rewardRepo, pointsRepo)
+	getYearRecapHandler := queries.NewGetYearRecapHandler(habitRepo, entryRepo)
+	getSharedYearRecapHandler := queries.NewGetSharedYearRecapHandler(recapShareRepo, getYearRecapHandler)
+	shareYearRecapHandler := commands.NewShareYearRecapHandler(recapShareRepo)
+	revokeYearRecapShareHandler := commands.NewRevokeYearRecapShareHandler(recapShareRepo)
+	getDigestSubscriptionHandler := queries.NewGetDigestSubscriptionHandler(digestSubscriptionRepo)
+	updateDigestSubscriptionHandler := commands.NewUpdateDigestSubscriptionHandler(digestSubscriptionRepo)
+	updateUserLanguageHandler := commands.NewUpdateUserLanguageHandler(userRepo)
+	getNotificationPreferencesHandler := queries.NewGetNotificationPreferencesHandler(notificationPreferenceRepo)
+	updateNotificationPreferencesHandler := commands.NewUpdateNotificationPreferencesHandler(notificationPreferenceRepo, transactor)
+	getNotificationScheduleHandler := queries.NewGetNotificationScheduleHandler(notificationScheduleRepo)
+	updateNotificationScheduleHandler := commands.NewUpdateNotificationScheduleHandler(notificationScheduleRepo)
+	unsubscribeHandler := commands.NewUnsubscribeHandler(userRepo, notificationPreferenceRepo, unsubscribeTokens)
+	getProgressDigestHandler := queries.NewGetProgressDigestHandler(habitRepo, entryRepo)
+	getHabitRemindersHandler := queries.NewGetHabitRemindersHandler(habitRepo, reminderRepo)
+	createHabitReminderHandler := commands.NewCreateHabitReminderHandler(habitRepo, reminderRepo)
+	updateHabitReminderHandler := commands.NewUpdateHabitReminderHandler(reminderRepo)
+	deleteHabitReminderHandler := commands.NewDeleteHabitReminderHandler(reminderRepo)
+	registerPushSubscriptionHandler := commands.NewRegisterPushSubscriptionHandler(pushSubscriptionRepo)
+	unregisterPushSubscriptionHandler := commands.NewUnregisterPushSubscriptionHandler(pushSubscriptionRepo)
+	getWebhooksHandler := queries.NewGetWebhooksHandler(webhookRepo)
+	getWebhookDeliveriesHandler := queries.NewGetWebhookDeliveriesHandler(webhookRepo, webhookDeliveryRepo)
+	createWebhookHandler := commands.NewCreateWebhookHandler(webhookRepo, cfg.WebhookPrivateNetworks == "true")
+	updateWebhookHandler := commands.NewUpdateWebhookHandler(webhookRepo, cfg.WebhookPrivateNetworks == "true")
+	deleteWebhookHandler := commands.NewDeleteWebhookHandler(webhookRepo)
+	redeliverWebhookDeliveryHandler := commands.NewRedeliverWebhookDeliveryHandler(webhookRepo, webhookDeliveryRepo)
+
+	digestInterval, err := parseDuration(cfg.DigestInterval)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid DIGEST_INTERVAL")
+	}
+
+	digestLocation, err := time.LoadLocation(cfg.DefaultTimezone)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid DEFAULT_TIMEZONE")
+	}
+
+	// Reminders and digests only ask for replies when they can be received.
+	var replyAddresses *inbound.Addresses
+	var replies services.ReplyAddresses
+	if cfg.InboundEmailAddress != "" && emailService != nil {
+		replyAddresses, err = inbound.NewAddresses(auth.NewReplyTokens(cfg.JWTSecret), cfg.InboundEmailAddress)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Invalid INBOUND_EMAIL_ADDRESS")
+		}
+		replies = replyAddresses
+	}
+
+	var digestMailer digest.Mailer
+	if emailService != nil {
+		digestMailer = email.NewDigestMailer(mailer, replies)
+	}
+
+	digestScheduler := digest.NewScheduler(digestSubscriptionRepo, userRepo, notificationScheduleRepo, getProgressDigestHandler, digestMailer, digest.Config{
+		Enabled:  cfg.DigestEnabled == "true" && emailService != nil,
+		Interval: digestInterval,
+		Location: digestLocation,
+	})
+	digestScheduler.Start()
+	defer digestScheduler.Stop()
+
+	reminderInterval, err := parseDuration(cfg.ReminderInterval)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid REMINDER_INTERVAL")
+	}
+
+	var pushSender *webpush.Sender
+	var vapidPublicKey string
+	if cfg.VAPIDPublicKey != "" {
+		pushSender, err = webpush.NewSender(webpush.Config{
+			PublicKey:  cfg.VAPIDPublicKey,
+			PrivateKey: cfg.VAPIDPrivateKey,
+			Subject:    cfg.VAPIDSubject,
+		}, nil)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Invalid VAPID keys")
+		}
+		vapidPublicKey = pushSender.PublicKey()
+	}
+
+	telegramPollTimeout, err := parseDuration(cfg.TelegramPollTimeout)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid TELEGRAM_POLL_TIMEOUT")
+	}
+
+	// Long polling holds getUpdates open for the poll timeout, so the HTTP
+	// client allows a little longer than that.
+	var telegramClient *telegram.Client
+	if cfg.TelegramBotToken != "" {
+		telegramClient = telegram.NewClient(cfg.TelegramAPIURL, cfg.TelegramBotToken, &http.Client{
+			Timeout: telegramPollTimeout + 10*time.Second,
+		})
+	}
+
+	var reminderChannels []services.ReminderChannel
+	if emailService != nil {
+		reminderChannels = append(reminderChannels, email.NewReminderChannel(mailer, replies))
+	}
+	if pushSender != nil {
+		reminderChannels = append(reminderChannels, webpush.NewReminderChannel(pushSender, pushSubscriptionRepo, notificationGate, translator))
+	}
+	if telegramClient != nil {
+		reminderChannels = append(reminderChannels, telegram.NewReminderChannel(telegramClient, chatLinkRepo, notificationGate, translator))
+	}
+
+	reminderDispatcher := reminder.NewDispatcher(reminderRepo, habitRepo, entryRepo, userRepo, reminderChannels, reminder.Config{
+		Enabled:  cfg.RemindersEnabled == "true" && len(reminderChannels) > 0,
+		Interval: reminderInterval,
+	})
+	reminderDispatcher.Start()
+	defer reminderDispatcher.Stop()
+
+	notificationBatchInterval, err := parseDuration(cfg.NotificationBatchInterval)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid NOTIFICATION_BATCH_INTERVAL")
+	}
+
+	var batchSenders []services.NotificationBatchSender
+	if emailService != nil {
+		batchSenders = append(batchSenders, email.NewBatchMailer(mailer))
+	}
+	if pushSender != nil {
+		batchSenders = append(batchSenders, webpush.NewBatchNotifier(pushSender, pushSubscriptionRepo, translator))
+	}
+	if telegramClient != nil {
+		batchSenders = append(batchSenders, telegram.NewBatchNotifier(telegramClient, chatLinkRepo, translator))
+	}
+
+	notificationBatcher := notifications.NewBatcher(heldNotificationRepo, notificationScheduleRepo, notificationLogRepo, userRepo, batchSenders, notifications.Config{
+		Enabled:  len(batchSenders) > 0,
+		Interval: notificationBatchInterval,
+	})
+	notificationBatcher.Start()
+	defer notificationBatcher.Stop()
+
+	getChatLinkHandler := queries.NewGetChatLinkHandler(chatLinkRepo)
+	createChatLinkCodeHandler := commands.NewCreateChatLinkCodeHandler(chatLinkCodeRepo)
+	linkChatHandler := commands.NewLinkChatHandler(chatLinkCodeRepo, chatLinkRepo, userRepo, transactor)
+	unlinkChatHandler := commands.NewUnlinkChatHandler(chatLinkRepo)
+
+	chatBot := telegram.NewBot(telegramClient, chatLinkRepo, userRepo, linkChatHandler, unlinkChatHandler, getTodaysHandler, markHandler, unmarkHandler, translator, telegram.Config{
+		Enabled:     telegramClient != nil,
+		PollTimeout: telegramPollTimeout,
+	})
+	chatBot.Start()
+	defer chatBot.Stop()
+
+	mqttInterval, err := parseDuration(cfg.MQTTInterval)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid MQTT_INTERVAL")
+	}
+
+	getMQTTIntegrationHandler := queries.NewGetMQTTIntegrationHandler(mqttIntegrationRepo)
+	saveMQTTIntegrationHandler := commands.NewSaveMQTTIntegrationHandler(mqttIntegrationRepo)
+	deleteMQTTIntegrationHandler := commands.NewDeleteMQTTIntegrationHandler(mqttIntegrationRepo)
+
+	mqttBridge := mqtt.NewBridge(mqttIntegrationRepo, habitRepo, entryRepo, habitStatsRepo, markHandler, unmarkHandler, mqtt.Config{
+		Enabled:  cfg.MQTTEnabled == "true",
+		Interval: mqttInterval,
+	})
+	if cfg.MQTTEnabled == "true" {
+		eventBus.SubscribeAsync("mqtt", mqttBridge.HandleEvent, entities.EventHabitCreated, entities.EventHabitMarked, entities.EventHabitUnmarked, entities.EventHabitArchived)
+	}
+	mqttBridge.Start()
+	defer mqttBridge.Stop()
+
+	var inboundProcessor *inbound.Processor
+	if replyAddresses != nil {
+		inboundProcessor = inbound.NewProcessor(replyAddresses, userRepo, habitRepo, reminderRepo, notificationScheduleRepo, markHandler, mailer, digestLocation)
+	}
+	inboundServer := inbound.NewServer(inboundProcessor, inbound.Config{
+		Enabled: inboundProcessor != nil,
+		Addr:    cfg.InboundSMTPAddr,
+	})
+	inboundServer.Start()
+	defer inboundServer.Stop()
+
+	webhookInterval, err := parseDuration(cfg.WebhookInterval)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid WEBHOOK_INTERVAL")
+	}
+
+	var detectBrokenStreaksHandler *commands.DetectBrokenStreaksHandler
+	if cfg.WebhooksEnabled == "true" {
+		detectBrokenStreaksHandler = commands.NewDetectBrokenStreaksHandler(webhookRepo, habitRepo, habitStatsRepo, eventBus)
+	}
+
+	webhookWorker := webhook.NewWorker(webhookRepo, webhookDeliveryRepo, detectBrokenStreaksHandler, nil, webhook.Config{
+		Enabled:              cfg.WebhooksEnabled == "true",
+		Interval:             webhookInterval,
+		Location:             digestLocation,
+		AllowPrivateNetworks: cfg.WebhookPrivateNetworks == "true",
+	})
+	webhookWorker.Start()
+	defer webhookWorker.Stop()
+
+	eventBus.Start()
+	defer eventBus.Stop()
+
+	getEmailOutboxHandler := queries.NewGetEmailOutboxHandler(emailOutboxRepo)
+	retryOutgoingEmailHandler := commands.NewRetryOutgoingEmailHandler(emailOutboxRepo)
+	previewEmailTemplateHandler := queries.NewPreviewEmailTemplateHandler(templateMailer)
 
 	authHandlers := httpInfra.NewAuthHandlers(registerHandler, loginHandler, refreshTokenHandler, revokeTokenHandler, revokeAllTokensHandler, verifyEmailHandler, resendVerificationEmailHandler, requestPasswordResetHandler, resetPasswordHandler, jwtService, refreshTokenRepo, refreshTokenExpiry, translator)
 	habitHandlers := httpInfra.NewHabitHandlers(createHandler, getTodaysHandler, getUserHabitsHandler, getHabitByIDHandler, getHabitEntriesHandler, updateHandler, archiveHandler, markHandler, unmarkHandler, translator)
-	statsHandlers := httpInfra.NewStatsHandlers(getHabitStatsHandler, translator)
+	statsHandlers := httpInfra.NewStatsHandlers(getHabitStatsHandler, getDashboardStatsHandler, getHeatmapHandler, getHabitSeriesHandler, getCorrelationInsightsHandler, getBehaviourInsightsHandler, translator)
 	healthHandlers := httpInfra.NewHealthHandlers(db.Conn(), emailService)
-	userHandlers := httpInfra.NewUserHandlers(deleteUserHandler, translator)
+	userHandlers := httpInfra.NewUserHandlers(deleteUserHandler, getDigestSubscriptionHandler, updateDigestSubscriptionHandler, updateUserLanguageHandler, getNotificationPreferencesHandler, updateNotificationPreferencesHandler, getNotificationScheduleHandler, updateNotificationScheduleHandler, translator)
 	exportHandlers := httpInfra.NewExportHandlers(exportUserDataHandler, translator)
+	achievementHandlers := httpInfra.NewAchievementHandlers(getUserAchievementsHandler, translator)
+	pointsHandlers := httpInfra.NewPointsHandlers(getPointsSummaryHandler, getPointTransactionsHandler, getRewardsHandler, createRewardHandler, archiveRewardHandler, redeemRewardHandler, translator)
+	recapHandlers := httpInfra.NewRecapHandlers(getYearRecapHandler, getSharedYearRecapHandler, shareYearRecapHandler, revokeYearRecapShareHandler, translator)
+	reminderHandlers := httpInfra.NewReminderHandlers(getHabitRemindersHandler, createHabitReminderHandler, updateHabitReminderHandler, deleteHabitReminderHandler, translator)
+	pushHandlers := httpInfra.NewPushHandlers(registerPushSubscriptionHandler, unregisterPushSubscriptionHandler, vapidPublicKey, translator)
+	webhookHandlers := httpInfra.NewWebhookHandlers(getWebhooksHandler, getWebhookDeliveriesHandler, createWebhookHandler, updateWebhookHandler, deleteWebhookHandler, redeliverWebhookDeliveryHandler, translator)
+	adminHandlers := httpInfra.NewAdminHandlers(getEmailOutboxHandler, retryOutgoingEmailHandler, previewEmailTemplateHandler, translator)
+	unsubscribeHandlers := httpInfra.NewUnsubscribeHandlers(unsubscribeHandler, cfg.AppURL, translator)
+	mqttHandlers := httpInfra.NewMQTTHandlers(getMQTTIntegrationHandler, saveMQTTIntegrationHandler, deleteMQTTIntegrationHandler, cfg.MQTTEnabled == "true", translator)
+	chatHandlers := httpInfra.NewChatHandlers(getChatLinkHandler, createChatLinkCodeHandler, unlinkChatHandler, telegramClient != nil, cfg.TelegramBotUsername, translator)
 
-	router := httpInfra.NewRouter(cfg.AppURL, habitHandlers, authHandlers, statsHandlers, healthHandlers, userHandlers, exportHandlers, jwtService, translator)
+	router := httpInfra.NewRouter(cfg.AppURL, habitHandlers, authHandlers, statsHandlers, healthHandlers, userHandlers, exportHandlers, achievementHandlers, pointsHandlers, recapHandlers, reminderHandlers, pushHandlers, webhookHandlers, adminHandlers, unsubscribeHandlers, chatHandlers, mqttHandlers, jwtService, strings.Split(cfg.AdminEmails, ","), translator)
 
 	addr := fmt.Sprintf("0.0.0.0:%s", cfg.Port)
 	logger.Info().Str("address", addr).Msg("Server starting")
@@ -159,6 +499,40 @@ func main() {
 	}
 }
 
+// rebuildStats recalculates the materialized habit stats, either for the
+// habit IDs given as arguments or for every habit.
+func rebuildStats(db *sqlite.Database, habitIDs []string) {
+	handler := commands.NewRebuildHabitStatsHandler(
+		sqlite.NewHabitRepository(db.Conn()),
+		sqlite.NewHabitEntryRepository(db.Conn()),
+		sqlite.NewHabitStatsRepository(db.Conn()),
+		sqlite.NewTransactor(db.Conn()),
+	)
+
+	if len(habitIDs) == 0 {
+		habitIDs = []string{""}
+	}
+
+	ctx := context.Background()
+	for _, habitID := range habitIDs {
+		rebuilt, err := handler.Handle(ctx, commands.RebuildHabitStatsCommand{HabitID: habitID})
+		if err != nil {
+			logger.Fatal().Err(err).Str("habit_id", habitID).Int("rebuilt", rebuilt).Msg("Failed to rebuild habit stats")
+		}
+		logger.Info().Str("habit_id", habitID).Int("rebuilt", rebuilt).Msg("Habit stats rebuilt")
+	}
+}
+
+// generateVAPIDKeys prints a new key pair for the Web Push configuration.
+func generateVAPIDKeys() {
+	publicKey, privateKey, err := webpush.GenerateVAPIDKeys()
+	if err != nil {
+		log.Fatalf("Failed to generate VAPID keys: %v", err)
+	}
+
+	fmt.Printf("VAPID_PUBLIC_KEY=%s\nVAPID_PRIVATE_KEY=%s\n", publicKey, privateKey)
+}
+
 func parseJWTExpiry(expiry string) (int, error) {
 	expiry = strings.TrimSpace(expiry)
 	if strings.HasSuffix(expiry, "h") {
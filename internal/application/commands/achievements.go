@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+	"apocapoc-api/internal/shared/utils"
+)
+
+// AchievementEvaluator unlocks the catalog achievements a user has earned
+// after completing a habit. Each achievement is unlocked at most once.
+type AchievementEvaluator struct {
+	habitRepo       repositories.HabitRepository
+	entryRepo       repositories.HabitEntryRepository
+	statsRepo       repositories.HabitStatsRepository
+	achievementRepo repositories.AchievementRepository
+}
+
+func NewAchievementEvaluator(
+	habitRepo repositories.HabitRepository,
+	entryRepo repositories.HabitEntryRepository,
+	statsRepo repositories.HabitStatsRepository,
+	achievementRepo repositories.AchievementRepository,
+) *AchievementEvaluator {
+	return &AchievementEvaluator{
+		habitRepo:       habitRepo,
+		entryRepo:       entryRepo,
+		statsRepo:       statsRepo,
+		achievementRepo: achievementRepo,
+	}
+}
+
+// Evaluate expects the habit stats to already include the completion on date
+// and returns the achievements unlocked by it.
+func (e *AchievementEvaluator) Evaluate(ctx context.Context, habit *entities.Habit, date time.Time) ([]*entities.Achievement, error) {
+	existing, err := e.achievementRepo.FindByUserID(ctx, habit.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	unlocked := make(map[entities.AchievementCode]bool, len(existing))
+	for _, achievement := range existing {
+		unlocked[achievement.Code] = true
+	}
+
+	if len(unlocked) == len(entities.AchievementCatalog) {
+		return nil, nil
+	}
+
+	progress, err := e.progress(ctx, habit, date, !unlocked[entities.AchievementPerfectWeek])
+	if err != nil {
+		return nil, err
+	}
+
+	var achievements []*entities.Achievement
+	for _, rule := range entities.AchievementCatalog {
+		if unlocked[rule.Code] || !rule.IsUnlocked(progress) {
+			continue
+		}
+
+		var habitID *string
+		if rule.HabitScoped {
+			habitID = &habit.ID
+		}
+
+		achievement := entities.NewAchievement(habit.UserID, rule.Code, habitID)
+		if err := e.achievementRepo.Create(ctx, achievement); err != nil {
+			if err == errors.ErrAlreadyExists {
+				continue
+			}
+			return nil, err
+		}
+
+		achievements = append(achievements, achievement)
+	}
+
+	return achievements, nil
+}
+
+func (e *AchievementEvaluator) progress(ctx context.Context, habit *entities.Habit, date time.Time, checkPerfectWeek bool) (entities.AchievementProgress, error) {
+	var progress entities.AchievementProgress
+
+	stats, err := e.statsRepo.FindByUserID(ctx, habit.UserID)
+	if err != nil {
+		return progress, err
+	}
+
+	for _, s := range stats {
+		progress.TotalCompletions += s.TotalCompletions
+		if s.HabitID == habit.ID {
+			progress.LongestStreak = s.LongestStreak
+		}
+	}
+
+	if checkPerfectWeek {
+		progress.PerfectWeek, err = e.isPerfectWeek(ctx, habit.UserID, date)
+		if err != nil {
+			return progress, err
+		}
+	}
+
+	return progress, nil
+}
+
+// isPerfectWeek reports whether every scheduled occurrence of the user's
+// active habits in the Monday-based week containing date has been completed.
+func (e *AchievementEvaluator) isPerfectWeek(ctx context.Context, userID string, date time.Time) (bool, error) {
+	weekStart := utils.StartOfWeek(time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC), time.Monday)
+	weekEnd := weekStart.AddDate(0, 0, 6)
+
+	habits, err := e.habitRepo.FindActiveByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	entries, err := e.entryRepo.FindByUserIDAndDateRange(ctx, userID, weekStart, weekEnd)
+	if err != nil {
+		return false, err
+	}
+
+	completed := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		completed[entry.HabitID+"|"+entry.ScheduledDate.Format("2006-01-02")] = true
+	}
+
+	scheduled := 0
+	for _, habit := range habits {
+		createdDate := time.Date(habit.CreatedAt.Year(), habit.CreatedAt.Month(), habit.CreatedAt.Day(), 0, 0, 0, 0, time.UTC)
+
+		for day := weekStart; !day.After(weekEnd); day = day.AddDate(0, 0, 1) {
+			if day.Before(createdDate) || !habit.IsScheduledOn(day) {
+				continue
+			}
+			if !completed[habit.ID+"|"+day.Format("2006-01-02")] {
+				return false, nil
+			}
+			scheduled++
+		}
+	}
+
+	return scheduled > 0, nil
+}
@@ -0,0 +1,192 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type mockAchievementRepo struct {
+	achievements []*entities.Achievement
+}
+
+func (m *mockAchievementRepo) Create(ctx context.Context, achievement *entities.Achievement) error {
+	for _, existing := range m.achievements {
+		if existing.UserID == achievement.UserID && existing.Code == achievement.Code {
+			return errors.ErrAlreadyExists
+		}
+	}
+	m.achievements = append(m.achievements, achievement)
+	return nil
+}
+
+func (m *mockAchievementRepo) FindByUserID(ctx context.Context, userID string) ([]*entities.Achievement, error) {
+	return m.achievements, nil
+}
+
+type mockActiveHabitRepo struct {
+	mockHabitRepoForMark
+	habits []*entities.Habit
+}
+
+func (m *mockActiveHabitRepo) FindActiveByUserID(ctx context.Context, userID string) ([]*entities.Habit, error) {
+	return m.habits, nil
+}
+
+type mockWeekEntryRepo struct {
+	mockEntryRepo
+	entries []*entities.HabitEntry
+}
+
+func (m *mockWeekEntryRepo) FindByUserIDAndDateRange(ctx context.Context, userID string, from, to time.Time) ([]*entities.HabitEntry, error) {
+	var result []*entities.HabitEntry
+	for _, entry := range m.entries {
+		if !entry.ScheduledDate.Before(from) && !entry.ScheduledDate.After(to) {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+func hasAchievement(achievements []*entities.Achievement, code entities.AchievementCode) bool {
+	for _, achievement := range achievements {
+		if achievement.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func TestMarkHabitHandler_UnlocksFirstCompletionOnce(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+	habit.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	habitRepo := &mockHabitRepoForMark{habit: habit}
+	entryRepo := &mockEntryRepo{}
+	statsRepo := &mockStatsRepo{}
+	achievementRepo := &mockAchievementRepo{}
+	events := &mockEventPublisher{}
+
+	handler := NewMarkHabitHandler(entryRepo, habitRepo, statsRepo, &mockPointsRepo{}, mockTransactor{},
+		NewAchievementEvaluator(habitRepo, entryRepo, statsRepo, achievementRepo), events)
+
+	for _, day := range []int{1, 2} {
+		err := handler.Handle(context.Background(), MarkHabitCommand{
+			HabitID:       habit.ID,
+			ScheduledDate: time.Date(2025, 1, day, 0, 0, 0, 0, time.UTC),
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	if len(achievementRepo.achievements) != 1 || achievementRepo.achievements[0].Code != entities.AchievementFirstCompletion {
+		t.Fatalf("Expected only first_completion to be unlocked, got %+v", achievementRepo.achievements)
+	}
+	if achievementRepo.achievements[0].HabitID == nil || *achievementRepo.achievements[0].HabitID != habit.ID {
+		t.Errorf("Expected first_completion to be attributed to %s", habit.ID)
+	}
+	if unlocked := events.ofType(entities.EventAchievementUnlocked); len(unlocked) != 1 {
+		t.Errorf("Expected 1 achievement.unlocked event, got %d", len(unlocked))
+	}
+	if marked := events.ofType(entities.EventHabitMarked); len(marked) != 2 {
+		t.Errorf("Expected 2 habit.marked events, got %d", len(marked))
+	}
+}
+
+func TestMarkHabitHandler_UnlocksStreakAchievement(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+	habit.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	lastCompleted := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	statsRepo := &mockStatsRepo{saved: &entities.HabitStats{
+		HabitID:              habit.ID,
+		TotalCompletions:     6,
+		ScheduledCompletions: 6,
+		CurrentStreak:        6,
+		LongestStreak:        6,
+		LastCompletedDate:    &lastCompleted,
+	}}
+	achievementRepo := &mockAchievementRepo{achievements: []*entities.Achievement{
+		entities.NewAchievement("user-123", entities.AchievementFirstCompletion, &habit.ID),
+	}}
+
+	habitRepo := &mockHabitRepoForMark{habit: habit}
+	entryRepo := &mockEntryRepo{}
+
+	handler := NewMarkHabitHandler(entryRepo, habitRepo, statsRepo, &mockPointsRepo{}, mockTransactor{},
+		NewAchievementEvaluator(habitRepo, entryRepo, statsRepo, achievementRepo), nil)
+
+	err := handler.Handle(context.Background(), MarkHabitCommand{
+		HabitID:       habit.ID,
+		ScheduledDate: time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !hasAchievement(achievementRepo.achievements, entities.AchievementStreak7) {
+		t.Errorf("Expected streak_7 to be unlocked, got %+v", achievementRepo.achievements)
+	}
+	if hasAchievement(achievementRepo.achievements, entities.AchievementStreak30) {
+		t.Error("Expected streak_30 to stay locked")
+	}
+}
+
+func TestAchievementEvaluator_PerfectWeek(t *testing.T) {
+	daily := entities.NewHabit("user-123", "Reading", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	daily.ID = "habit-1"
+	daily.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Created on Thursday, so only Thursday to Sunday count for it.
+	late := entities.NewHabit("user-123", "Running", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	late.ID = "habit-2"
+	late.CreatedAt = time.Date(2025, 1, 9, 18, 0, 0, 0, time.UTC)
+
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	sunday := time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC)
+
+	var entries []*entities.HabitEntry
+	for day := monday; !day.After(sunday); day = day.AddDate(0, 0, 1) {
+		entries = append(entries, entities.NewHabitEntry(daily.ID, day, nil))
+		if !day.Before(time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC)) {
+			entries = append(entries, entities.NewHabitEntry(late.ID, day, nil))
+		}
+	}
+
+	tests := []struct {
+		name     string
+		entries  []*entities.HabitEntry
+		expected bool
+	}{
+		{"Every scheduled day completed", entries, true},
+		{"One scheduled day missed", entries[1:], false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			achievementRepo := &mockAchievementRepo{}
+			evaluator := NewAchievementEvaluator(
+				&mockActiveHabitRepo{habits: []*entities.Habit{daily, late}},
+				&mockWeekEntryRepo{entries: tt.entries},
+				&mockStatsRepo{},
+				achievementRepo,
+			)
+
+			unlocked, err := evaluator.Evaluate(context.Background(), daily, time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if hasAchievement(unlocked, entities.AchievementPerfectWeek) != tt.expected {
+				t.Errorf("Expected perfect_week unlocked to be %v, got %+v", tt.expected, unlocked)
+			}
+		})
+	}
+}
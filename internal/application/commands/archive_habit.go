@@ -3,7 +3,10 @@ package commands
 import (
 	"context"
 
+	"apocapoc-api/internal/domain/entities"
+
 	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/domain/services"
 	"apocapoc-api/internal/shared/errors"
 )
 
@@ -14,11 +17,13 @@ type ArchiveHabitCommand struct {
 
 type ArchiveHabitHandler struct {
 	habitRepo repositories.HabitRepository
+	events    services.EventPublisher
 }
 
-func NewArchiveHabitHandler(habitRepo repositories.HabitRepository) *ArchiveHabitHandler {
+func NewArchiveHabitHandler(habitRepo repositories.HabitRepository, events services.EventPublisher) *ArchiveHabitHandler {
 	return &ArchiveHabitHandler{
 		habitRepo: habitRepo,
+		events:    events,
 	}
 }
 
@@ -34,5 +39,11 @@ func (h *ArchiveHabitHandler) Handle(ctx context.Context, cmd ArchiveHabitComman
 
 	habit.Archive()
 
-	return h.habitRepo.Update(ctx, habit)
+	if err := h.habitRepo.Update(ctx, habit); err != nil {
+		return err
+	}
+
+	publishEvents(ctx, h.events, newHabitEvent(entities.EventHabitArchived, habit))
+
+	return nil
 }
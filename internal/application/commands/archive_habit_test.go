@@ -17,7 +17,7 @@ func TestArchiveHabitHandler_ArchivesSuccessfully(t *testing.T) {
 		habitToReturn: habit,
 	}
 
-	handler := NewArchiveHabitHandler(habitRepo)
+	handler := NewArchiveHabitHandler(habitRepo, nil)
 
 	cmd := ArchiveHabitCommand{
 		HabitID: "habit-1",
@@ -44,7 +44,7 @@ func TestArchiveHabitHandler_ReturnsErrorWhenHabitNotFound(t *testing.T) {
 		errorOnFind: errors.ErrNotFound,
 	}
 
-	handler := NewArchiveHabitHandler(habitRepo)
+	handler := NewArchiveHabitHandler(habitRepo, nil)
 
 	cmd := ArchiveHabitCommand{
 		HabitID: "non-existent",
@@ -66,7 +66,7 @@ func TestArchiveHabitHandler_ReturnsErrorWhenUserDoesNotOwnHabit(t *testing.T) {
 		habitToReturn: habit,
 	}
 
-	handler := NewArchiveHabitHandler(habitRepo)
+	handler := NewArchiveHabitHandler(habitRepo, nil)
 
 	cmd := ArchiveHabitCommand{
 		HabitID: "habit-1",
@@ -89,7 +89,7 @@ func TestArchiveHabitHandler_CanArchiveAlreadyArchivedHabit(t *testing.T) {
 		habitToReturn: habit,
 	}
 
-	handler := NewArchiveHabitHandler(habitRepo)
+	handler := NewArchiveHabitHandler(habitRepo, nil)
 
 	cmd := ArchiveHabitCommand{
 		HabitID: "habit-1",
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type ArchiveRewardCommand struct {
+	RewardID string
+	UserID   string
+}
+
+type ArchiveRewardHandler struct {
+	rewardRepo repositories.RewardRepository
+}
+
+func NewArchiveRewardHandler(rewardRepo repositories.RewardRepository) *ArchiveRewardHandler {
+	return &ArchiveRewardHandler{
+		rewardRepo: rewardRepo,
+	}
+}
+
+func (h *ArchiveRewardHandler) Handle(ctx context.Context, cmd ArchiveRewardCommand) error {
+	reward, err := h.rewardRepo.FindByID(ctx, cmd.RewardID)
+	if err != nil {
+		return err
+	}
+
+	if reward.UserID != cmd.UserID {
+		return errors.ErrUnauthorized
+	}
+
+	if !reward.IsActive() {
+		return errors.ErrNotFound
+	}
+
+	reward.Archive()
+
+	return h.rewardRepo.Update(ctx, reward)
+}
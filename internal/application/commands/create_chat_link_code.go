@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"context"
+	"crypto/rand"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+// chatLinkCodeAlphabet leaves out characters that are easily confused when
+// typed into a chat, such as 0 and O.
+const chatLinkCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// CreateChatLinkCodeCommand starts linking a chat to the user's account.
+// Timezone is the one "today" is taken in when checking in from the chat,
+// and defaults to UTC.
+type CreateChatLinkCodeCommand struct {
+	UserID   string
+	Timezone string
+}
+
+type CreateChatLinkCodeHandler struct {
+	codeRepo repositories.ChatLinkCodeRepository
+}
+
+func NewCreateChatLinkCodeHandler(codeRepo repositories.ChatLinkCodeRepository) *CreateChatLinkCodeHandler {
+	return &CreateChatLinkCodeHandler{
+		codeRepo: codeRepo,
+	}
+}
+
+// Handle returns a one-time code the user sends to the bot. It replaces any
+// code the user created before.
+func (h *CreateChatLinkCodeHandler) Handle(ctx context.Context, cmd CreateChatLinkCodeCommand) (*entities.ChatLinkCode, error) {
+	timezone := cmd.Timezone
+	if timezone == "" {
+		timezone = entities.DefaultNotificationTimezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, errors.ErrInvalidInput
+	}
+
+	code, err := generateChatLinkCode()
+	if err != nil {
+		return nil, err
+	}
+
+	linkCode := entities.NewChatLinkCode(cmd.UserID, code, timezone)
+	if err := h.codeRepo.Create(ctx, linkCode); err != nil {
+		return nil, err
+	}
+
+	return linkCode, nil
+}
+
+func generateChatLinkCode() (string, error) {
+	bytes := make([]byte, 8)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	for i, b := range bytes {
+		bytes[i] = chatLinkCodeAlphabet[int(b)%len(chatLinkCodeAlphabet)]
+	}
+	return string(bytes), nil
+}
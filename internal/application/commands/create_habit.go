@@ -5,6 +5,7 @@ import (
 
 	"apocapoc-api/internal/domain/entities"
 	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/domain/services"
 	"apocapoc-api/internal/domain/value_objects"
 	"apocapoc-api/internal/shared/errors"
 )
@@ -20,14 +21,19 @@ type CreateHabitCommand struct {
 	CarryOver     bool
 	IsNegative    bool
 	TargetValue   *float64
+	Difficulty    value_objects.HabitDifficulty
 }
 
 type CreateHabitHandler struct {
 	habitRepo repositories.HabitRepository
+	events    services.EventPublisher
 }
 
-func NewCreateHabitHandler(habitRepo repositories.HabitRepository) *CreateHabitHandler {
-	return &CreateHabitHandler{habitRepo: habitRepo}
+func NewCreateHabitHandler(habitRepo repositories.HabitRepository, events services.EventPublisher) *CreateHabitHandler {
+	return &CreateHabitHandler{
+		habitRepo: habitRepo,
+		events:    events,
+	}
 }
 
 func (h *CreateHabitHandler) Handle(ctx context.Context, cmd CreateHabitCommand) (string, error) {
@@ -47,15 +53,24 @@ func (h *CreateHabitHandler) Handle(ctx context.Context, cmd CreateHabitCommand)
 		return "", errors.ErrInvalidInput
 	}
 
+	if cmd.Difficulty != "" && !cmd.Difficulty.IsValid() {
+		return "", errors.ErrInvalidInput
+	}
+
 	habit := entities.NewHabit(cmd.UserID, cmd.Name, cmd.Type, cmd.Frequency, cmd.CarryOver, cmd.IsNegative)
 	habit.Description = cmd.Description
 	habit.SpecificDays = cmd.SpecificDays
 	habit.SpecificDates = cmd.SpecificDates
 	habit.TargetValue = cmd.TargetValue
+	if cmd.Difficulty != "" {
+		habit.Difficulty = cmd.Difficulty
+	}
 
 	if err := h.habitRepo.Create(ctx, habit); err != nil {
 		return "", err
 	}
 
+	publishEvents(ctx, h.events, newHabitEvent(entities.EventHabitCreated, habit))
+
 	return habit.ID, nil
 }
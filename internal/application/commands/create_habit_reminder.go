@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+const maxRemindersPerHabit = 5
+
+type CreateHabitReminderCommand struct {
+	HabitID   string
+	UserID    string
+	TimeOfDay string
+	Timezone  string
+}
+
+type CreateHabitReminderHandler struct {
+	habitRepo    repositories.HabitRepository
+	reminderRepo repositories.HabitReminderRepository
+}
+
+func NewCreateHabitReminderHandler(
+	habitRepo repositories.HabitRepository,
+	reminderRepo repositories.HabitReminderRepository,
+) *CreateHabitReminderHandler {
+	return &CreateHabitReminderHandler{
+		habitRepo:    habitRepo,
+		reminderRepo: reminderRepo,
+	}
+}
+
+func (h *CreateHabitReminderHandler) Handle(ctx context.Context, cmd CreateHabitReminderCommand) (*entities.HabitReminder, error) {
+	if !entities.IsValidReminderTime(cmd.TimeOfDay) || !isValidReminderTimezone(cmd.Timezone) {
+		return nil, errors.ErrInvalidInput
+	}
+
+	habit, err := h.habitRepo.FindByID(ctx, cmd.HabitID)
+	if err != nil {
+		return nil, err
+	}
+
+	if habit.UserID != cmd.UserID {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if !habit.IsActive() {
+		return nil, errors.ErrInvalidInput
+	}
+
+	existing, err := h.reminderRepo.FindByHabitID(ctx, habit.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxRemindersPerHabit {
+		return nil, errors.ErrInvalidInput
+	}
+
+	reminder := entities.NewHabitReminder(habit.ID, cmd.UserID, cmd.TimeOfDay, cmd.Timezone)
+
+	if err := h.reminderRepo.Create(ctx, reminder); err != nil {
+		return nil, err
+	}
+
+	return reminder, nil
+}
+
+// isValidReminderTimezone accepts IANA names only; the empty string and
+// "Local" would make the reminder follow the server's clock.
+func isValidReminderTimezone(timezone string) bool {
+	if strings.TrimSpace(timezone) == "" || timezone == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(timezone)
+	return err == nil
+}
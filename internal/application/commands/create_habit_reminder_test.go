@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type mockReminderRepo struct {
+	reminders []*entities.HabitReminder
+}
+
+func (m *mockReminderRepo) Create(ctx context.Context, reminder *entities.HabitReminder) error {
+	reminder.ID = "reminder-" + string(rune('a'+len(m.reminders)))
+	m.reminders = append(m.reminders, reminder)
+	return nil
+}
+
+func (m *mockReminderRepo) FindByID(ctx context.Context, id string) (*entities.HabitReminder, error) {
+	for _, reminder := range m.reminders {
+		if reminder.ID == id {
+			return reminder, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (m *mockReminderRepo) FindByHabitID(ctx context.Context, habitID string) ([]*entities.HabitReminder, error) {
+	var reminders []*entities.HabitReminder
+	for _, reminder := range m.reminders {
+		if reminder.HabitID == habitID {
+			reminders = append(reminders, reminder)
+		}
+	}
+	return reminders, nil
+}
+
+func (m *mockReminderRepo) FindEnabled(ctx context.Context) ([]*entities.HabitReminder, error) {
+	return m.reminders, nil
+}
+
+func (m *mockReminderRepo) Update(ctx context.Context, reminder *entities.HabitReminder) error {
+	return nil
+}
+
+func (m *mockReminderRepo) Delete(ctx context.Context, id string) error {
+	for i, reminder := range m.reminders {
+		if reminder.ID == id {
+			m.reminders = append(m.reminders[:i], m.reminders[i+1:]...)
+			return nil
+		}
+	}
+	return errors.ErrNotFound
+}
+
+func TestCreateHabitReminderHandler(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Meditate", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+	reminderRepo := &mockReminderRepo{}
+	handler := NewCreateHabitReminderHandler(&mockHabitRepoForMark{habit: habit}, reminderRepo)
+
+	tests := []struct {
+		name string
+		cmd  CreateHabitReminderCommand
+		err  error
+	}{
+		{"invalid time", CreateHabitReminderCommand{HabitID: habit.ID, UserID: "user-123", TimeOfDay: "7:5pm", Timezone: "UTC"}, errors.ErrInvalidInput},
+		{"invalid timezone", CreateHabitReminderCommand{HabitID: habit.ID, UserID: "user-123", TimeOfDay: "07:30", Timezone: "Mars/Olympus"}, errors.ErrInvalidInput},
+		{"server timezone", CreateHabitReminderCommand{HabitID: habit.ID, UserID: "user-123", TimeOfDay: "07:30", Timezone: "Local"}, errors.ErrInvalidInput},
+		{"other user's habit", CreateHabitReminderCommand{HabitID: habit.ID, UserID: "user-456", TimeOfDay: "07:30", Timezone: "UTC"}, errors.ErrUnauthorized},
+		{"valid reminder", CreateHabitReminderCommand{HabitID: habit.ID, UserID: "user-123", TimeOfDay: "07:30", Timezone: "Europe/Madrid"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reminder, err := handler.Handle(context.Background(), tt.cmd)
+			if err != tt.err {
+				t.Fatalf("Expected error %v, got %v", tt.err, err)
+			}
+			if err == nil && (!reminder.Enabled || reminder.TimeOfDay != "07:30" || reminder.Timezone != "Europe/Madrid") {
+				t.Errorf("Unexpected reminder: %+v", reminder)
+			}
+		})
+	}
+
+	for len(reminderRepo.reminders) < maxRemindersPerHabit {
+		reminderRepo.Create(context.Background(), entities.NewHabitReminder(habit.ID, "user-123", "12:00", "UTC"))
+	}
+	_, err := handler.Handle(context.Background(), CreateHabitReminderCommand{HabitID: habit.ID, UserID: "user-123", TimeOfDay: "20:00", Timezone: "UTC"})
+	if err != errors.ErrInvalidInput {
+		t.Errorf("Expected ErrInvalidInput above the reminder limit, got %v", err)
+	}
+}
+
+func TestUpdateAndDeleteHabitReminder(t *testing.T) {
+	reminderRepo := &mockReminderRepo{}
+	reminder := entities.NewHabitReminder("habit-1", "user-123", "07:30", "UTC")
+	reminderRepo.Create(context.Background(), reminder)
+
+	disabled := false
+	updated, err := NewUpdateHabitReminderHandler(reminderRepo).Handle(context.Background(), UpdateHabitReminderCommand{
+		ReminderID: reminder.ID,
+		HabitID:    "habit-1",
+		UserID:     "user-123",
+		TimeOfDay:  "21:15",
+		Enabled:    &disabled,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.TimeOfDay != "21:15" || updated.Timezone != "UTC" || updated.Enabled {
+		t.Errorf("Unexpected reminder after update: %+v", updated)
+	}
+
+	deleteHandler := NewDeleteHabitReminderHandler(reminderRepo)
+	if err := deleteHandler.Handle(context.Background(), DeleteHabitReminderCommand{ReminderID: reminder.ID, HabitID: "habit-2", UserID: "user-123"}); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound for a reminder of another habit, got %v", err)
+	}
+	if err := deleteHandler.Handle(context.Background(), DeleteHabitReminderCommand{ReminderID: reminder.ID, HabitID: "habit-1", UserID: "user-456"}); err != errors.ErrUnauthorized {
+		t.Errorf("Expected ErrUnauthorized for another user's reminder, got %v", err)
+	}
+	if err := deleteHandler.Handle(context.Background(), DeleteHabitReminderCommand{ReminderID: reminder.ID, HabitID: "habit-1", UserID: "user-123"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(reminderRepo.reminders) != 0 {
+		t.Error("Expected the reminder to be deleted")
+	}
+}
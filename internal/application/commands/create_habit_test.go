@@ -26,6 +26,10 @@ func (m *mockHabitRepo) FindByUserID(ctx context.Context, userID string) ([]*ent
 	return nil, nil
 }
 
+func (m *mockHabitRepo) FindAll(ctx context.Context) ([]*entities.Habit, error) {
+	return nil, nil
+}
+
 func (m *mockHabitRepo) FindActiveByUserID(ctx context.Context, userID string) ([]*entities.Habit, error) {
 	return nil, nil
 }
@@ -46,7 +50,7 @@ func TestCreateHabitHandler_Success(t *testing.T) {
 		},
 	}
 
-	handler := NewCreateHabitHandler(mock)
+	handler := NewCreateHabitHandler(mock, nil)
 
 	cmd := CreateHabitCommand{
 		UserID:      "user-123",
@@ -70,7 +74,7 @@ func TestCreateHabitHandler_Success(t *testing.T) {
 
 func TestCreateHabitHandler_InvalidType(t *testing.T) {
 	mock := &mockHabitRepo{}
-	handler := NewCreateHabitHandler(mock)
+	handler := NewCreateHabitHandler(mock, nil)
 
 	cmd := CreateHabitCommand{
 		UserID:    "user-123",
@@ -88,7 +92,7 @@ func TestCreateHabitHandler_InvalidType(t *testing.T) {
 
 func TestCreateHabitHandler_InvalidFrequency(t *testing.T) {
 	mock := &mockHabitRepo{}
-	handler := NewCreateHabitHandler(mock)
+	handler := NewCreateHabitHandler(mock, nil)
 
 	cmd := CreateHabitCommand{
 		UserID:    "user-123",
@@ -106,7 +110,7 @@ func TestCreateHabitHandler_InvalidFrequency(t *testing.T) {
 
 func TestCreateHabitHandler_WeeklyWithoutSpecificDays(t *testing.T) {
 	mock := &mockHabitRepo{}
-	handler := NewCreateHabitHandler(mock)
+	handler := NewCreateHabitHandler(mock, nil)
 
 	cmd := CreateHabitCommand{
 		UserID:       "user-123",
@@ -125,7 +129,7 @@ func TestCreateHabitHandler_WeeklyWithoutSpecificDays(t *testing.T) {
 
 func TestCreateHabitHandler_MonthlyWithoutSpecificDates(t *testing.T) {
 	mock := &mockHabitRepo{}
-	handler := NewCreateHabitHandler(mock)
+	handler := NewCreateHabitHandler(mock, nil)
 
 	cmd := CreateHabitCommand{
 		UserID:        "user-123",
@@ -153,7 +157,7 @@ func TestCreateHabitHandler_WeeklyWithSpecificDays(t *testing.T) {
 		},
 	}
 
-	handler := NewCreateHabitHandler(mock)
+	handler := NewCreateHabitHandler(mock, nil)
 
 	cmd := CreateHabitCommand{
 		UserID:       "user-123",
@@ -181,7 +185,7 @@ func TestCreateHabitHandler_MonthlyWithSpecificDates(t *testing.T) {
 		},
 	}
 
-	handler := NewCreateHabitHandler(mock)
+	handler := NewCreateHabitHandler(mock, nil)
 
 	cmd := CreateHabitCommand{
 		UserID:        "user-123",
@@ -209,7 +213,7 @@ func TestCreateHabitHandler_NegativeHabit(t *testing.T) {
 		},
 	}
 
-	handler := NewCreateHabitHandler(mock)
+	handler := NewCreateHabitHandler(mock, nil)
 
 	cmd := CreateHabitCommand{
 		UserID:      "user-123",
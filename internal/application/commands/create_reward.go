@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type CreateRewardCommand struct {
+	UserID string
+	Name   string
+	Cost   int
+}
+
+type CreateRewardHandler struct {
+	rewardRepo repositories.RewardRepository
+}
+
+func NewCreateRewardHandler(rewardRepo repositories.RewardRepository) *CreateRewardHandler {
+	return &CreateRewardHandler{rewardRepo: rewardRepo}
+}
+
+func (h *CreateRewardHandler) Handle(ctx context.Context, cmd CreateRewardCommand) (string, error) {
+	name := strings.TrimSpace(cmd.Name)
+	if name == "" || cmd.Cost <= 0 {
+		return "", errors.ErrInvalidInput
+	}
+
+	reward := entities.NewReward(cmd.UserID, name, cmd.Cost)
+
+	if err := h.rewardRepo.Create(ctx, reward); err != nil {
+		return "", err
+	}
+
+	return reward.ID, nil
+}
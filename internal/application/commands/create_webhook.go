@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+const maxWebhooksPerUser = 10
+
+type CreateWebhookCommand struct {
+	UserID string
+	URL    string
+	Events []string
+}
+
+type CreateWebhookHandler struct {
+	webhookRepo          repositories.WebhookRepository
+	allowPrivateNetworks bool
+}
+
+func NewCreateWebhookHandler(webhookRepo repositories.WebhookRepository, allowPrivateNetworks bool) *CreateWebhookHandler {
+	return &CreateWebhookHandler{
+		webhookRepo:          webhookRepo,
+		allowPrivateNetworks: allowPrivateNetworks,
+	}
+}
+
+// Handle registers the webhook with a new signing secret. The secret is only
+// returned here, so the caller must hand it to the user.
+func (h *CreateWebhookHandler) Handle(ctx context.Context, cmd CreateWebhookCommand) (*entities.Webhook, error) {
+	if !entities.IsValidWebhookURL(cmd.URL, h.allowPrivateNetworks) {
+		return nil, errors.ErrInvalidInput
+	}
+
+	events, ok := parseWebhookEvents(cmd.Events)
+	if !ok {
+		return nil, errors.ErrInvalidInput
+	}
+
+	count, err := h.webhookRepo.CountByUserID(ctx, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxWebhooksPerUser {
+		return nil, errors.ErrInvalidInput
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := entities.NewWebhook(cmd.UserID, cmd.URL, secret, events)
+
+	if err := h.webhookRepo.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// parseWebhookEvents requires at least one known event type and drops
+// duplicates.
+func parseWebhookEvents(names []string) ([]entities.EventType, bool) {
+	if len(names) == 0 {
+		return nil, false
+	}
+
+	events := make([]entities.EventType, 0, len(names))
+	seen := make(map[entities.EventType]bool)
+	for _, name := range names {
+		event := entities.EventType(name)
+		if !entities.IsWebhookEventType(event) {
+			return nil, false
+		}
+		if !seen[event] {
+			seen[event] = true
+			events = append(events, event)
+		}
+	}
+
+	return events, true
+}
+
+func generateWebhookSecret() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(bytes), nil
+}
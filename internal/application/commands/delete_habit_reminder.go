@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/repositories"
+)
+
+type DeleteHabitReminderCommand struct {
+	ReminderID string
+	HabitID    string
+	UserID     string
+}
+
+type DeleteHabitReminderHandler struct {
+	reminderRepo repositories.HabitReminderRepository
+}
+
+func NewDeleteHabitReminderHandler(reminderRepo repositories.HabitReminderRepository) *DeleteHabitReminderHandler {
+	return &DeleteHabitReminderHandler{
+		reminderRepo: reminderRepo,
+	}
+}
+
+func (h *DeleteHabitReminderHandler) Handle(ctx context.Context, cmd DeleteHabitReminderCommand) error {
+	reminder, err := findOwnedReminder(ctx, h.reminderRepo, cmd.ReminderID, cmd.HabitID, cmd.UserID)
+	if err != nil {
+		return err
+	}
+
+	return h.reminderRepo.Delete(ctx, reminder.ID)
+}
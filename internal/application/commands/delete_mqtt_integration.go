@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/repositories"
+)
+
+type DeleteMQTTIntegrationCommand struct {
+	UserID string
+}
+
+type DeleteMQTTIntegrationHandler struct {
+	integrationRepo repositories.MQTTIntegrationRepository
+}
+
+func NewDeleteMQTTIntegrationHandler(integrationRepo repositories.MQTTIntegrationRepository) *DeleteMQTTIntegrationHandler {
+	return &DeleteMQTTIntegrationHandler{
+		integrationRepo: integrationRepo,
+	}
+}
+
+// Handle returns ErrNotFound when the user has no MQTT integration.
+func (h *DeleteMQTTIntegrationHandler) Handle(ctx context.Context, cmd DeleteMQTTIntegrationCommand) error {
+	return h.integrationRepo.DeleteByUserID(ctx, cmd.UserID)
+}
@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/repositories"
+)
+
+type DeleteWebhookCommand struct {
+	WebhookID string
+	UserID    string
+}
+
+type DeleteWebhookHandler struct {
+	webhookRepo repositories.WebhookRepository
+}
+
+func NewDeleteWebhookHandler(webhookRepo repositories.WebhookRepository) *DeleteWebhookHandler {
+	return &DeleteWebhookHandler{
+		webhookRepo: webhookRepo,
+	}
+}
+
+func (h *DeleteWebhookHandler) Handle(ctx context.Context, cmd DeleteWebhookCommand) error {
+	webhook, err := findOwnedWebhook(ctx, h.webhookRepo, cmd.WebhookID, cmd.UserID)
+	if err != nil {
+		return err
+	}
+
+	return h.webhookRepo.Delete(ctx, webhook.ID)
+}
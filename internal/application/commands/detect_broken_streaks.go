@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// Streaks broken longer ago than this are not reported, so a webhook registered
+// today is not sent every streak its owner ever lost.
+const brokenStreakLookbackDays = 2
+
+// DetectBrokenStreaksHandler publishes a streak.broken event for every running
+// streak that ended with a missed occurrence, for the users with a webhook
+// subscribed to it.
+type DetectBrokenStreaksHandler struct {
+	webhookRepo repositories.WebhookRepository
+	habitRepo   repositories.HabitRepository
+	statsRepo   repositories.HabitStatsRepository
+	events      services.EventPublisher
+}
+
+func NewDetectBrokenStreaksHandler(
+	webhookRepo repositories.WebhookRepository,
+	habitRepo repositories.HabitRepository,
+	statsRepo repositories.HabitStatsRepository,
+	events services.EventPublisher,
+) *DetectBrokenStreaksHandler {
+	return &DetectBrokenStreaksHandler{
+		webhookRepo: webhookRepo,
+		habitRepo:   habitRepo,
+		statsRepo:   statsRepo,
+		events:      events,
+	}
+}
+
+// Handle checks the streaks as of today, given in the server's timezone, and
+// returns how many broken streaks were published. The event ID is derived from
+// the habit and its last completion, so running it again publishes nothing new.
+func (h *DetectBrokenStreaksHandler) Handle(ctx context.Context, today time.Time) (int, error) {
+	webhooks, err := h.webhookRepo.FindEnabled(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var userIDs []string
+	seen := make(map[string]bool)
+	for _, webhook := range webhooks {
+		if webhook.IsSubscribedTo(entities.EventStreakBroken) && !seen[webhook.UserID] {
+			seen[webhook.UserID] = true
+			userIDs = append(userIDs, webhook.UserID)
+		}
+	}
+
+	oldest := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -brokenStreakLookbackDays)
+	published := 0
+
+	for _, userID := range userIDs {
+		habits, err := h.habitRepo.FindActiveByUserID(ctx, userID)
+		if err != nil {
+			return published, err
+		}
+
+		allStats, err := h.statsRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			return published, err
+		}
+
+		statsByHabit := make(map[string]*entities.HabitStats, len(allStats))
+		for _, stats := range allStats {
+			statsByHabit[stats.HabitID] = stats
+		}
+
+		for _, habit := range habits {
+			stats, ok := statsByHabit[habit.ID]
+			if !ok {
+				continue
+			}
+
+			missedDate, broken := stats.StreakBrokenOn(habit, today)
+			if !broken || missedDate.Before(oldest) {
+				continue
+			}
+
+			if err := h.events.Publish(ctx, newStreakBrokenEvent(habit, stats, missedDate)); err != nil {
+				return published, err
+			}
+			published++
+		}
+	}
+
+	return published, nil
+}
+
+func newStreakBrokenEvent(habit *entities.Habit, stats *entities.HabitStats, missedDate time.Time) *entities.Event {
+	lastCompleted := stats.LastCompletedDate.Format("2006-01-02")
+
+	event := newHabitEvent(entities.EventStreakBroken, habit)
+	event.ID = uuid.NewSHA1(uuid.NameSpaceURL, []byte("streak.broken:"+habit.ID+":"+lastCompleted)).String()
+	event.Data["streak"] = stats.CurrentStreak
+	event.Data["last_completed_date"] = lastCompleted
+	event.Data["missed_date"] = missedDate.Format("2006-01-02")
+
+	return event
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/services"
+)
+
+// publishEvents is called once the change is stored, so a failure to publish
+// is not reported back to the caller.
+func publishEvents(ctx context.Context, publisher services.EventPublisher, events ...*entities.Event) {
+	if publisher == nil {
+		return
+	}
+	for _, event := range events {
+		_ = publisher.Publish(ctx, event)
+	}
+}
+
+// publishEventsInTransaction is called from within the transaction that stores
+// the change, so the change is rolled back when its events cannot be stored.
+func publishEventsInTransaction(ctx context.Context, publisher services.EventPublisher, events ...*entities.Event) error {
+	if publisher == nil {
+		return nil
+	}
+	for _, event := range events {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func habitEventData(habit *entities.Habit) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          habit.ID,
+		"name":        habit.Name,
+		"type":        habit.Type,
+		"frequency":   habit.Frequency,
+		"is_negative": habit.IsNegative,
+	}
+}
+
+func newHabitEvent(eventType entities.EventType, habit *entities.Habit) *entities.Event {
+	return entities.NewEvent(eventType, habit.UserID, map[string]interface{}{
+		"habit": habitEventData(habit),
+	})
+}
+
+func newHabitEntryEvent(eventType entities.EventType, habit *entities.Habit, date time.Time, value *float64) *entities.Event {
+	event := newHabitEvent(eventType, habit)
+	event.Data["scheduled_date"] = date.Format("2006-01-02")
+	if value != nil {
+		event.Data["value"] = *value
+	}
+	return event
+}
+
+func newAchievementEvent(achievement *entities.Achievement) *entities.Event {
+	data := map[string]interface{}{
+		"id":          achievement.ID,
+		"code":        string(achievement.Code),
+		"unlocked_at": achievement.UnlockedAt.UTC().Format(time.RFC3339),
+	}
+	if achievement.HabitID != nil {
+		data["habit_id"] = *achievement.HabitID
+	}
+	return entities.NewEvent(entities.EventAchievementUnlocked, achievement.UserID, map[string]interface{}{
+		"achievement": data,
+	})
+}
+
+func newUserEvent(eventType entities.EventType, user *entities.User) *entities.Event {
+	return entities.NewEvent(eventType, user.ID, map[string]interface{}{
+		"email": user.Email,
+	})
+}
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+)
+
+type mockEventPublisher struct {
+	published []*entities.Event
+	err       error
+}
+
+func (m *mockEventPublisher) Publish(ctx context.Context, event *entities.Event) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.published = append(m.published, event)
+	return nil
+}
+
+func (m *mockEventPublisher) ofType(eventType entities.EventType) []*entities.Event {
+	var result []*entities.Event
+	for _, event := range m.published {
+		if event.Type == eventType {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+func TestMarkHabitHandler_RollsBackWhenEventsCannotBeStored(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+
+	events := &mockEventPublisher{err: context.DeadlineExceeded}
+	handler := NewMarkHabitHandler(&mockEntryRepo{}, &mockHabitRepoForMark{habit: habit}, &mockStatsRepo{}, &mockPointsRepo{}, mockTransactor{}, nil, events)
+
+	err := handler.Handle(context.Background(), MarkHabitCommand{
+		HabitID:       "habit-1",
+		ScheduledDate: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+	})
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected the publish error to fail the transaction, got %v", err)
+	}
+}
+
+func TestRegisterUserHandler_PublishesUserRegistered(t *testing.T) {
+	repo := &mockUserRepo{
+		createFunc: func(ctx context.Context, user *entities.User) error {
+			user.ID = "user-123"
+			return nil
+		},
+	}
+	events := &mockEventPublisher{}
+	handler := NewRegisterUserHandler(repo, &mockPasswordHasher{}, nil, "", "open", false, events, nil)
+
+	result, err := handler.Handle(context.Background(), RegisterUserCommand{
+		Email:    "events@example.com",
+		Password: "Password123!",
+	})
+	if err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+
+	registered := events.ofType(entities.EventUserRegistered)
+	if len(registered) != 1 || registered[0].UserID != result.UserID || registered[0].Data["email"] != "events@example.com" {
+		t.Errorf("Expected a user.registered event, got %+v", events.published)
+	}
+}
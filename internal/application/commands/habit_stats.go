@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+func refreshHabitStats(
+	ctx context.Context,
+	habit *entities.Habit,
+	entryRepo repositories.HabitEntryRepository,
+	statsRepo repositories.HabitStatsRepository,
+) error {
+	entries, err := entryRepo.FindByHabitID(ctx, habit.ID)
+	if err != nil {
+		return err
+	}
+
+	return statsRepo.Save(ctx, entities.NewHabitStats(habit, entries))
+}
+
+// recordHabitCompletion updates the stats incrementally when the completion is
+// newer than the last one and falls back to a full refresh otherwise.
+func recordHabitCompletion(
+	ctx context.Context,
+	habit *entities.Habit,
+	date time.Time,
+	entryRepo repositories.HabitEntryRepository,
+	statsRepo repositories.HabitStatsRepository,
+) error {
+	stats, err := statsRepo.FindByHabitID(ctx, habit.ID)
+	if err == errors.ErrNotFound {
+		return refreshHabitStats(ctx, habit, entryRepo, statsRepo)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !stats.RecordCompletion(habit, date) {
+		return refreshHabitStats(ctx, habit, entryRepo, statsRepo)
+	}
+
+	return statsRepo.Save(ctx, stats)
+}
+
+// removeHabitCompletion updates the stats from the entries around the removed
+// completion and falls back to a full refresh when those are not enough.
+func removeHabitCompletion(
+	ctx context.Context,
+	habit *entities.Habit,
+	date time.Time,
+	entryRepo repositories.HabitEntryRepository,
+	statsRepo repositories.HabitStatsRepository,
+) error {
+	stats, err := statsRepo.FindByHabitID(ctx, habit.ID)
+	if err == errors.ErrNotFound {
+		return refreshHabitStats(ctx, habit, entryRepo, statsRepo)
+	}
+	if err != nil {
+		return err
+	}
+
+	from, to := stats.StreakWindow(habit, date)
+	entries, err := entryRepo.FindByHabitIDAndDateRange(ctx, habit.ID, from, to)
+	if err != nil {
+		return err
+	}
+
+	if !stats.RemoveCompletion(habit, date, entries) {
+		return refreshHabitStats(ctx, habit, entryRepo, statsRepo)
+	}
+
+	return statsRepo.Save(ctx, stats)
+}
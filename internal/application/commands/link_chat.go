@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+// LinkChatCommand links the chat a code was sent from to the account that
+// created the code.
+type LinkChatCommand struct {
+	Code   string
+	ChatID int64
+}
+
+type LinkChatHandler struct {
+	codeRepo   repositories.ChatLinkCodeRepository
+	linkRepo   repositories.ChatLinkRepository
+	userRepo   repositories.UserRepository
+	transactor repositories.Transactor
+}
+
+func NewLinkChatHandler(
+	codeRepo repositories.ChatLinkCodeRepository,
+	linkRepo repositories.ChatLinkRepository,
+	userRepo repositories.UserRepository,
+	transactor repositories.Transactor,
+) *LinkChatHandler {
+	return &LinkChatHandler{
+		codeRepo:   codeRepo,
+		linkRepo:   linkRepo,
+		userRepo:   userRepo,
+		transactor: transactor,
+	}
+}
+
+// Handle returns the linked user. Unknown, expired and already used codes
+// are rejected with ErrInvalidInput. The chat replaces any chat the user
+// linked before, and is unlinked from any other account.
+func (h *LinkChatHandler) Handle(ctx context.Context, cmd LinkChatCommand) (*entities.User, error) {
+	var userID string
+	err := h.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		code, err := h.codeRepo.FindByCode(ctx, strings.ToUpper(strings.TrimSpace(cmd.Code)))
+		if err != nil {
+			return err
+		}
+		if code.IsExpired() {
+			return errors.ErrNotFound
+		}
+
+		// Only the request that deletes the code links the chat, so a code
+		// sent twice at once is used once.
+		if err := h.codeRepo.Delete(ctx, code.Code); err != nil {
+			return err
+		}
+		userID = code.UserID
+		return h.linkRepo.Save(ctx, entities.NewChatLink(code.UserID, cmd.ChatID, code.Timezone))
+	})
+	if err == errors.ErrNotFound {
+		return nil, errors.ErrInvalidInput
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return h.userRepo.FindByID(ctx, userID)
+}
@@ -0,0 +1,173 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type mockChatLinkCodeRepo struct {
+	codes map[string]*entities.ChatLinkCode
+}
+
+func (r *mockChatLinkCodeRepo) Create(ctx context.Context, code *entities.ChatLinkCode) error {
+	for key, existing := range r.codes {
+		if existing.UserID == code.UserID {
+			delete(r.codes, key)
+		}
+	}
+	r.codes[code.Code] = code
+	return nil
+}
+
+func (r *mockChatLinkCodeRepo) FindByCode(ctx context.Context, code string) (*entities.ChatLinkCode, error) {
+	found, ok := r.codes[code]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	return found, nil
+}
+
+func (r *mockChatLinkCodeRepo) Delete(ctx context.Context, code string) error {
+	if _, ok := r.codes[code]; !ok {
+		return errors.ErrNotFound
+	}
+	delete(r.codes, code)
+	return nil
+}
+
+// staleChatLinkCodeRepo still finds codes after they are deleted, like a
+// request that looked the code up before another one used it.
+type staleChatLinkCodeRepo struct {
+	*mockChatLinkCodeRepo
+	found map[string]*entities.ChatLinkCode
+}
+
+func (r *staleChatLinkCodeRepo) FindByCode(ctx context.Context, code string) (*entities.ChatLinkCode, error) {
+	if found, ok := r.found[code]; ok {
+		return found, nil
+	}
+	found, err := r.mockChatLinkCodeRepo.FindByCode(ctx, code)
+	if err == nil {
+		r.found[code] = found
+	}
+	return found, err
+}
+
+type mockChatLinkRepo struct {
+	links map[string]*entities.ChatLink
+}
+
+func (r *mockChatLinkRepo) Save(ctx context.Context, link *entities.ChatLink) error {
+	r.links[link.UserID] = link
+	return nil
+}
+
+func (r *mockChatLinkRepo) FindByUserID(ctx context.Context, userID string) (*entities.ChatLink, error) {
+	link, ok := r.links[userID]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	return link, nil
+}
+
+func (r *mockChatLinkRepo) FindByChatID(ctx context.Context, chatID int64) (*entities.ChatLink, error) {
+	for _, link := range r.links {
+		if link.ChatID == chatID {
+			return link, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (r *mockChatLinkRepo) DeleteByUserID(ctx context.Context, userID string) error {
+	if _, ok := r.links[userID]; !ok {
+		return errors.ErrNotFound
+	}
+	delete(r.links, userID)
+	return nil
+}
+
+func TestCreateChatLinkCodeHandler(t *testing.T) {
+	codeRepo := &mockChatLinkCodeRepo{codes: map[string]*entities.ChatLinkCode{}}
+	handler := NewCreateChatLinkCodeHandler(codeRepo)
+
+	code, err := handler.Handle(context.Background(), CreateChatLinkCodeCommand{UserID: "user-123"})
+	if err != nil {
+		t.Fatalf("Handle() unexpected error = %v", err)
+	}
+	if len(code.Code) != 8 || strings.Trim(code.Code, chatLinkCodeAlphabet) != "" {
+		t.Errorf("Unexpected code %q", code.Code)
+	}
+	if code.Timezone != "UTC" || codeRepo.codes[code.Code] != code {
+		t.Errorf("Expected the code to be stored with the default timezone, got %+v", code)
+	}
+
+	if _, err := handler.Handle(context.Background(), CreateChatLinkCodeCommand{UserID: "user-123", Timezone: "Nowhere/Special"}); err != errors.ErrInvalidInput {
+		t.Errorf("Expected ErrInvalidInput for an unknown timezone, got %v", err)
+	}
+}
+
+func TestLinkChatHandler(t *testing.T) {
+	user := entities.NewUser("chat@example.com", "hash")
+	user.ID = "user-123"
+
+	codeRepo := &mockChatLinkCodeRepo{codes: map[string]*entities.ChatLinkCode{}}
+	linkRepo := &mockChatLinkRepo{links: map[string]*entities.ChatLink{}}
+	handler := NewLinkChatHandler(codeRepo, linkRepo, &languageUserRepo{user: user}, mockTransactor{})
+	ctx := context.Background()
+
+	codeRepo.Create(ctx, entities.NewChatLinkCode(user.ID, "ABCD2345", "Europe/Madrid"))
+
+	linked, err := handler.Handle(ctx, LinkChatCommand{Code: " abcd2345 ", ChatID: 42})
+	if err != nil {
+		t.Fatalf("Handle() unexpected error = %v", err)
+	}
+	if linked.ID != user.ID {
+		t.Errorf("Expected %s to be linked, got %s", user.ID, linked.ID)
+	}
+	link := linkRepo.links[user.ID]
+	if link == nil || link.ChatID != 42 || link.Timezone != "Europe/Madrid" {
+		t.Errorf("Unexpected link: %+v", link)
+	}
+
+	if _, err := handler.Handle(ctx, LinkChatCommand{Code: "ABCD2345", ChatID: 43}); err != errors.ErrInvalidInput {
+		t.Errorf("Expected a used code to be rejected, got %v", err)
+	}
+
+	expired := entities.NewChatLinkCode(user.ID, "EXPD2345", "UTC")
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	codeRepo.Create(ctx, expired)
+	if _, err := handler.Handle(ctx, LinkChatCommand{Code: "EXPD2345", ChatID: 43}); err != errors.ErrInvalidInput {
+		t.Errorf("Expected an expired code to be rejected, got %v", err)
+	}
+}
+
+func TestLinkChatHandler_UsesCodeOnce(t *testing.T) {
+	user := entities.NewUser("chat@example.com", "hash")
+	user.ID = "user-123"
+
+	codeRepo := &staleChatLinkCodeRepo{
+		mockChatLinkCodeRepo: &mockChatLinkCodeRepo{codes: map[string]*entities.ChatLinkCode{}},
+		found:                map[string]*entities.ChatLinkCode{},
+	}
+	linkRepo := &mockChatLinkRepo{links: map[string]*entities.ChatLink{}}
+	handler := NewLinkChatHandler(codeRepo, linkRepo, &languageUserRepo{user: user}, mockTransactor{})
+	ctx := context.Background()
+
+	codeRepo.Create(ctx, entities.NewChatLinkCode(user.ID, "ABCD2345", "UTC"))
+
+	if _, err := handler.Handle(ctx, LinkChatCommand{Code: "ABCD2345", ChatID: 42}); err != nil {
+		t.Fatalf("Handle() unexpected error = %v", err)
+	}
+	if _, err := handler.Handle(ctx, LinkChatCommand{Code: "ABCD2345", ChatID: 43}); err != errors.ErrInvalidInput {
+		t.Errorf("Expected the code to link only one chat, got %v", err)
+	}
+	if link := linkRepo.links[user.ID]; link.ChatID != 42 {
+		t.Errorf("Expected the first chat to stay linked, got %d", link.ChatID)
+	}
+}
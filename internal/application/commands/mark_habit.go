@@ -8,6 +8,7 @@ import (
 
 	"apocapoc-api/internal/domain/entities"
 	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/domain/services"
 	"apocapoc-api/internal/domain/value_objects"
 	"apocapoc-api/internal/shared/errors"
 )
@@ -19,17 +20,32 @@ type MarkHabitCommand struct {
 }
 
 type MarkHabitHandler struct {
-	entryRepo repositories.HabitEntryRepository
-	habitRepo repositories.HabitRepository
+	entryRepo    repositories.HabitEntryRepository
+	habitRepo    repositories.HabitRepository
+	statsRepo    repositories.HabitStatsRepository
+	pointsRepo   repositories.PointsRepository
+	transactor   repositories.Transactor
+	achievements *AchievementEvaluator
+	events       services.EventPublisher
 }
 
 func NewMarkHabitHandler(
 	entryRepo repositories.HabitEntryRepository,
 	habitRepo repositories.HabitRepository,
+	statsRepo repositories.HabitStatsRepository,
+	pointsRepo repositories.PointsRepository,
+	transactor repositories.Transactor,
+	achievements *AchievementEvaluator,
+	events services.EventPublisher,
 ) *MarkHabitHandler {
 	return &MarkHabitHandler{
-		entryRepo: entryRepo,
-		habitRepo: habitRepo,
+		entryRepo:    entryRepo,
+		habitRepo:    habitRepo,
+		statsRepo:    statsRepo,
+		pointsRepo:   pointsRepo,
+		transactor:   transactor,
+		achievements: achievements,
+		events:       events,
 	}
 }
 
@@ -84,7 +100,13 @@ func (h *MarkHabitHandler) Handle(ctx context.Context, cmd MarkHabitCommand) err
 			existingEntry.Value = finalValue
 			existingEntry.CompletedAt = time.Now()
 
-			return h.entryRepo.Update(ctx, existingEntry)
+			if err := h.entryRepo.Update(ctx, existingEntry); err != nil {
+				return err
+			}
+
+			publishEvents(ctx, h.events, newHabitEntryEvent(entities.EventHabitMarked, habit, existingEntry.ScheduledDate, finalValue))
+
+			return nil
 		} else {
 			if cmd.Value != nil {
 				value := *cmd.Value
@@ -103,5 +125,28 @@ func (h *MarkHabitHandler) Handle(ctx context.Context, cmd MarkHabitCommand) err
 
 	entry := entities.NewHabitEntry(cmd.HabitID, cmd.ScheduledDate, finalValue)
 
-	return h.entryRepo.Create(ctx, entry)
+	return h.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := h.entryRepo.Create(ctx, entry); err != nil {
+			return err
+		}
+		if err := recordHabitCompletion(ctx, habit, entry.ScheduledDate, h.entryRepo, h.statsRepo); err != nil {
+			return err
+		}
+		if err := h.pointsRepo.Create(ctx, entities.NewCompletionCredit(habit, entry)); err != nil {
+			return err
+		}
+
+		events := []*entities.Event{newHabitEntryEvent(entities.EventHabitMarked, habit, entry.ScheduledDate, entry.Value)}
+		if h.achievements != nil {
+			unlocked, err := h.achievements.Evaluate(ctx, habit, entry.ScheduledDate)
+			if err != nil {
+				return err
+			}
+			for _, achievement := range unlocked {
+				events = append(events, newAchievementEvent(achievement))
+			}
+		}
+
+		return publishEventsInTransaction(ctx, h.events, events...)
+	})
 }
@@ -44,6 +44,10 @@ func (m *mockEntryRepo) FindByUserID(ctx context.Context, userID string) ([]*ent
 	return nil, nil
 }
 
+func (m *mockEntryRepo) FindByUserIDAndDateRange(ctx context.Context, userID string, from, to time.Time) ([]*entities.HabitEntry, error) {
+	return nil, nil
+}
+
 func (m *mockEntryRepo) FindPendingByHabitID(ctx context.Context, habitID string, beforeDate time.Time) ([]*entities.HabitEntry, error) {
 	return nil, nil
 }
@@ -75,6 +79,10 @@ func (m *mockHabitRepoForMark) FindByUserID(ctx context.Context, userID string)
 	return nil, nil
 }
 
+func (m *mockHabitRepoForMark) FindAll(ctx context.Context) ([]*entities.Habit, error) {
+	return nil, nil
+}
+
 func (m *mockHabitRepoForMark) FindActiveByUserID(ctx context.Context, userID string) ([]*entities.Habit, error) {
 	return nil, nil
 }
@@ -87,6 +95,81 @@ func (m *mockHabitRepoForMark) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+type mockStatsRepo struct {
+	saved *entities.HabitStats
+}
+
+func (m *mockStatsRepo) FindByHabitID(ctx context.Context, habitID string) (*entities.HabitStats, error) {
+	if m.saved == nil {
+		return nil, errors.ErrNotFound
+	}
+	return m.saved, nil
+}
+
+func (m *mockStatsRepo) FindByUserID(ctx context.Context, userID string) ([]*entities.HabitStats, error) {
+	if m.saved == nil {
+		return nil, nil
+	}
+	return []*entities.HabitStats{m.saved}, nil
+}
+
+func (m *mockStatsRepo) Save(ctx context.Context, stats *entities.HabitStats) error {
+	m.saved = stats
+	return nil
+}
+
+func (m *mockStatsRepo) SaveStrength(ctx context.Context, habitID string, date time.Time, strength float64) error {
+	if m.saved != nil {
+		m.saved.StrengthDate = &date
+		m.saved.Strength = strength
+	}
+	return nil
+}
+
+type mockPointsRepo struct {
+	transactions []*entities.PointTransaction
+}
+
+func (m *mockPointsRepo) Create(ctx context.Context, transaction *entities.PointTransaction) error {
+	m.transactions = append(m.transactions, transaction)
+	return nil
+}
+
+func (m *mockPointsRepo) FindByUserID(ctx context.Context, userID string, params pagination.Params) ([]*entities.PointTransaction, error) {
+	return m.transactions, nil
+}
+
+func (m *mockPointsRepo) CountByUserID(ctx context.Context, userID string) (int, error) {
+	return len(m.transactions), nil
+}
+
+func (m *mockPointsRepo) SumByEntryID(ctx context.Context, entryID string) (int, error) {
+	sum := 0
+	for _, transaction := range m.transactions {
+		if transaction.EntryID != nil && *transaction.EntryID == entryID {
+			sum += transaction.Points
+		}
+	}
+	return sum, nil
+}
+
+func (m *mockPointsRepo) GetBalance(ctx context.Context, userID string) (entities.PointsBalance, error) {
+	var balance entities.PointsBalance
+	for _, transaction := range m.transactions {
+		if transaction.Type != entities.PointTransactionRedemption {
+			balance.XP += transaction.Points
+		}
+		balance.Balance += transaction.Points
+	}
+	return balance, nil
+}
+
+type mockTransactor struct{}
+
+func (mockTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 func TestMarkHabitHandler_Success(t *testing.T) {
 	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
 	habit.ID = "habit-1"
@@ -102,7 +185,7 @@ func TestMarkHabitHandler_Success(t *testing.T) {
 		},
 	}
 
-	handler := NewMarkHabitHandler(entryRepo, habitRepo)
+	handler := NewMarkHabitHandler(entryRepo, habitRepo, &mockStatsRepo{}, &mockPointsRepo{}, mockTransactor{}, nil, nil)
 
 	cmd := MarkHabitCommand{
 		HabitID:       "habit-1",
@@ -121,7 +204,7 @@ func TestMarkHabitHandler_HabitNotFound(t *testing.T) {
 	habitRepo := &mockHabitRepoForMark{habit: nil}
 	entryRepo := &mockEntryRepo{}
 
-	handler := NewMarkHabitHandler(entryRepo, habitRepo)
+	handler := NewMarkHabitHandler(entryRepo, habitRepo, &mockStatsRepo{}, &mockPointsRepo{}, mockTransactor{}, nil, nil)
 
 	cmd := MarkHabitCommand{
 		HabitID:       "non-existent",
@@ -143,7 +226,7 @@ func TestMarkHabitHandler_ArchivedHabit(t *testing.T) {
 	habitRepo := &mockHabitRepoForMark{habit: habit}
 	entryRepo := &mockEntryRepo{}
 
-	handler := NewMarkHabitHandler(entryRepo, habitRepo)
+	handler := NewMarkHabitHandler(entryRepo, habitRepo, &mockStatsRepo{}, &mockPointsRepo{}, mockTransactor{}, nil, nil)
 
 	cmd := MarkHabitCommand{
 		HabitID:       "habit-1",
@@ -177,7 +260,7 @@ func TestMarkHabitHandler_WithValue(t *testing.T) {
 		},
 	}
 
-	handler := NewMarkHabitHandler(entryRepo, habitRepo)
+	handler := NewMarkHabitHandler(entryRepo, habitRepo, &mockStatsRepo{}, &mockPointsRepo{}, mockTransactor{}, nil, nil)
 
 	cmd := MarkHabitCommand{
 		HabitID:       "habit-1",
@@ -203,7 +286,7 @@ func TestMarkHabitHandler_DuplicateEntry(t *testing.T) {
 		},
 	}
 
-	handler := NewMarkHabitHandler(entryRepo, habitRepo)
+	handler := NewMarkHabitHandler(entryRepo, habitRepo, &mockStatsRepo{}, &mockPointsRepo{}, mockTransactor{}, nil, nil)
 
 	cmd := MarkHabitCommand{
 		HabitID:       "habit-1",
@@ -224,7 +307,7 @@ func TestMarkHabitHandler_CounterOnlyAcceptsIntegers(t *testing.T) {
 	habitRepo := &mockHabitRepoForMark{habit: habit}
 	entryRepo := &mockEntryRepo{}
 
-	handler := NewMarkHabitHandler(entryRepo, habitRepo)
+	handler := NewMarkHabitHandler(entryRepo, habitRepo, &mockStatsRepo{}, &mockPointsRepo{}, mockTransactor{}, nil, nil)
 
 	decimalValue := 2.5
 	cmd := MarkHabitCommand{
@@ -254,7 +337,7 @@ func TestMarkHabitHandler_CounterAcceptsIntegers(t *testing.T) {
 		},
 	}
 
-	handler := NewMarkHabitHandler(entryRepo, habitRepo)
+	handler := NewMarkHabitHandler(entryRepo, habitRepo, &mockStatsRepo{}, &mockPointsRepo{}, mockTransactor{}, nil, nil)
 
 	intValue := 3.0
 	cmd := MarkHabitCommand{
@@ -287,7 +370,7 @@ func TestMarkHabitHandler_CounterAutoIncrementFirstMark(t *testing.T) {
 		},
 	}
 
-	handler := NewMarkHabitHandler(entryRepo, habitRepo)
+	handler := NewMarkHabitHandler(entryRepo, habitRepo, &mockStatsRepo{}, &mockPointsRepo{}, mockTransactor{}, nil, nil)
 
 	cmd := MarkHabitCommand{
 		HabitID:       "habit-1",
@@ -328,7 +411,7 @@ func TestMarkHabitHandler_CounterAutoIncrementSubsequentMarks(t *testing.T) {
 		},
 	}
 
-	handler := NewMarkHabitHandler(entryRepo, habitRepo)
+	handler := NewMarkHabitHandler(entryRepo, habitRepo, &mockStatsRepo{}, &mockPointsRepo{}, mockTransactor{}, nil, nil)
 
 	cmd := MarkHabitCommand{
 		HabitID:       "habit-1",
@@ -369,7 +452,7 @@ func TestMarkHabitHandler_CounterAutoIncrementWithCustomValue(t *testing.T) {
 		},
 	}
 
-	handler := NewMarkHabitHandler(entryRepo, habitRepo)
+	handler := NewMarkHabitHandler(entryRepo, habitRepo, &mockStatsRepo{}, &mockPointsRepo{}, mockTransactor{}, nil, nil)
 
 	increment := 2.0
 	cmd := MarkHabitCommand{
@@ -411,7 +494,7 @@ func TestMarkHabitHandler_CounterCanDecrement(t *testing.T) {
 		},
 	}
 
-	handler := NewMarkHabitHandler(entryRepo, habitRepo)
+	handler := NewMarkHabitHandler(entryRepo, habitRepo, &mockStatsRepo{}, &mockPointsRepo{}, mockTransactor{}, nil, nil)
 
 	decrement := -2.0
 	cmd := MarkHabitCommand{
@@ -453,7 +536,7 @@ func TestMarkHabitHandler_CounterMinimumZero(t *testing.T) {
 		},
 	}
 
-	handler := NewMarkHabitHandler(entryRepo, habitRepo)
+	handler := NewMarkHabitHandler(entryRepo, habitRepo, &mockStatsRepo{}, &mockPointsRepo{}, mockTransactor{}, nil, nil)
 
 	decrement := -3.0
 	cmd := MarkHabitCommand{
@@ -495,7 +578,7 @@ func TestMarkHabitHandler_CounterStaysAtZero(t *testing.T) {
 		},
 	}
 
-	handler := NewMarkHabitHandler(entryRepo, habitRepo)
+	handler := NewMarkHabitHandler(entryRepo, habitRepo, &mockStatsRepo{}, &mockPointsRepo{}, mockTransactor{}, nil, nil)
 
 	decrement := -1.0
 	cmd := MarkHabitCommand{
@@ -528,7 +611,7 @@ func TestMarkHabitHandler_CounterFirstMarkWithNegative(t *testing.T) {
 		},
 	}
 
-	handler := NewMarkHabitHandler(entryRepo, habitRepo)
+	handler := NewMarkHabitHandler(entryRepo, habitRepo, &mockStatsRepo{}, &mockPointsRepo{}, mockTransactor{}, nil, nil)
 
 	negativeValue := -5.0
 	cmd := MarkHabitCommand{
@@ -575,3 +658,94 @@ func (m *mockHabitRepoForMark) FindByUserIDFiltered(ctx context.Context, userID
 func (m *mockHabitRepoForMark) CountByUserIDFiltered(ctx context.Context, userID string, filter repositories.HabitFilter) (int, error) {
 	return 0, nil
 }
+
+func TestMarkHabitHandler_UpdatesStats(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+	habit.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	lastCompleted := time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC)
+	statsRepo := &mockStatsRepo{saved: &entities.HabitStats{
+		HabitID:           habit.ID,
+		TotalCompletions:  4,
+		CurrentStreak:     4,
+		LongestStreak:     4,
+		LastCompletedDate: &lastCompleted,
+	}}
+
+	handler := NewMarkHabitHandler(&mockEntryRepo{}, &mockHabitRepoForMark{habit: habit}, statsRepo, &mockPointsRepo{}, mockTransactor{}, nil, nil)
+
+	err := handler.Handle(context.Background(), MarkHabitCommand{
+		HabitID:       "habit-1",
+		ScheduledDate: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if statsRepo.saved.TotalCompletions != 5 || statsRepo.saved.CurrentStreak != 5 || statsRepo.saved.LongestStreak != 5 {
+		t.Errorf("Expected stats to be updated incrementally, got %+v", statsRepo.saved)
+	}
+}
+
+func TestMarkHabitHandler_DoesNotUpdateStatsWhenCreateFails(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+
+	entryRepo := &mockEntryRepo{
+		createFunc: func(ctx context.Context, entry *entities.HabitEntry) error {
+			return errors.ErrAlreadyExists
+		},
+	}
+	statsRepo := &mockStatsRepo{}
+
+	handler := NewMarkHabitHandler(entryRepo, &mockHabitRepoForMark{habit: habit}, statsRepo, &mockPointsRepo{}, mockTransactor{}, nil, nil)
+
+	err := handler.Handle(context.Background(), MarkHabitCommand{
+		HabitID:       "habit-1",
+		ScheduledDate: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+	})
+	if err != errors.ErrAlreadyExists {
+		t.Fatalf("Expected ErrAlreadyExists, got %v", err)
+	}
+
+	if statsRepo.saved != nil {
+		t.Error("Expected stats not to be saved")
+	}
+}
+
+func TestMarkHabitHandler_CreditsPointsByDifficulty(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+	habit.Difficulty = value_objects.HabitDifficultyHard
+
+	entryRepo := &mockEntryRepo{
+		createFunc: func(ctx context.Context, entry *entities.HabitEntry) error {
+			entry.ID = "entry-1"
+			return nil
+		},
+	}
+	pointsRepo := &mockPointsRepo{}
+
+	handler := NewMarkHabitHandler(entryRepo, &mockHabitRepoForMark{habit: habit}, &mockStatsRepo{}, pointsRepo, mockTransactor{}, nil, nil)
+
+	err := handler.Handle(context.Background(), MarkHabitCommand{
+		HabitID:       habit.ID,
+		ScheduledDate: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(pointsRepo.transactions) != 1 {
+		t.Fatalf("Expected 1 point transaction, got %d", len(pointsRepo.transactions))
+	}
+
+	credit := pointsRepo.transactions[0]
+	if credit.Type != entities.PointTransactionCompletion || credit.Points != value_objects.HabitDifficultyHard.Points() {
+		t.Errorf("Expected a completion credit of %d points, got %+v", value_objects.HabitDifficultyHard.Points(), credit)
+	}
+	if credit.EntryID == nil || *credit.EntryID != "entry-1" {
+		t.Errorf("Expected credit to reference entry-1, got %v", credit.EntryID)
+	}
+}
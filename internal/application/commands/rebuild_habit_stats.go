@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+)
+
+type RebuildHabitStatsCommand struct {
+	HabitID string
+}
+
+type RebuildHabitStatsHandler struct {
+	habitRepo  repositories.HabitRepository
+	entryRepo  repositories.HabitEntryRepository
+	statsRepo  repositories.HabitStatsRepository
+	transactor repositories.Transactor
+}
+
+func NewRebuildHabitStatsHandler(
+	habitRepo repositories.HabitRepository,
+	entryRepo repositories.HabitEntryRepository,
+	statsRepo repositories.HabitStatsRepository,
+	transactor repositories.Transactor,
+) *RebuildHabitStatsHandler {
+	return &RebuildHabitStatsHandler{
+		habitRepo:  habitRepo,
+		entryRepo:  entryRepo,
+		statsRepo:  statsRepo,
+		transactor: transactor,
+	}
+}
+
+// Handle recalculates the stats of the given habit, or of every habit when no
+// habit ID is given, and returns the number of habits rebuilt.
+func (h *RebuildHabitStatsHandler) Handle(ctx context.Context, cmd RebuildHabitStatsCommand) (int, error) {
+	var habits []*entities.Habit
+
+	if cmd.HabitID != "" {
+		habit, err := h.habitRepo.FindByID(ctx, cmd.HabitID)
+		if err != nil {
+			return 0, err
+		}
+		habits = append(habits, habit)
+	} else {
+		all, err := h.habitRepo.FindAll(ctx)
+		if err != nil {
+			return 0, err
+		}
+		habits = all
+	}
+
+	for i, habit := range habits {
+		err := h.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return refreshHabitStats(ctx, habit, h.entryRepo, h.statsRepo)
+		})
+		if err != nil {
+			return i, err
+		}
+	}
+
+	return len(habits), nil
+}
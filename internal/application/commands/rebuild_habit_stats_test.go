@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+)
+
+type mockEntryRepoForRebuild struct {
+	mockEntryRepo
+	entries []*entities.HabitEntry
+}
+
+func (m *mockEntryRepoForRebuild) FindByHabitID(ctx context.Context, habitID string) ([]*entities.HabitEntry, error) {
+	return m.entries, nil
+}
+
+func TestRebuildHabitStatsHandler_RebuildsHabit(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+	habit.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	entryRepo := &mockEntryRepoForRebuild{
+		entries: []*entities.HabitEntry{
+			entities.NewHabitEntry("habit-1", time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), nil),
+			entities.NewHabitEntry("habit-1", time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), nil),
+			entities.NewHabitEntry("habit-1", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), nil),
+		},
+	}
+	statsRepo := &mockStatsRepo{}
+
+	handler := NewRebuildHabitStatsHandler(&mockHabitRepoForMark{habit: habit}, entryRepo, statsRepo, mockTransactor{})
+
+	rebuilt, err := handler.Handle(context.Background(), RebuildHabitStatsCommand{HabitID: "habit-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if rebuilt != 1 {
+		t.Errorf("Expected 1 habit rebuilt, got %d", rebuilt)
+	}
+	if statsRepo.saved == nil || statsRepo.saved.TotalCompletions != 3 || statsRepo.saved.LongestStreak != 3 {
+		t.Errorf("Expected rebuilt stats with 3 completions in a row, got %+v", statsRepo.saved)
+	}
+}
@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type RedeemRewardCommand struct {
+	RewardID string
+	UserID   string
+}
+
+type RedeemRewardHandler struct {
+	rewardRepo repositories.RewardRepository
+	pointsRepo repositories.PointsRepository
+	transactor repositories.Transactor
+}
+
+func NewRedeemRewardHandler(
+	rewardRepo repositories.RewardRepository,
+	pointsRepo repositories.PointsRepository,
+	transactor repositories.Transactor,
+) *RedeemRewardHandler {
+	return &RedeemRewardHandler{
+		rewardRepo: rewardRepo,
+		pointsRepo: pointsRepo,
+		transactor: transactor,
+	}
+}
+
+// Handle debits the reward cost from the user's balance and returns the
+// balance after the redemption.
+func (h *RedeemRewardHandler) Handle(ctx context.Context, cmd RedeemRewardCommand) (entities.PointsBalance, error) {
+	var balance entities.PointsBalance
+
+	reward, err := h.rewardRepo.FindByID(ctx, cmd.RewardID)
+	if err != nil {
+		return balance, err
+	}
+
+	if reward.UserID != cmd.UserID {
+		return balance, errors.ErrUnauthorized
+	}
+
+	if !reward.IsActive() {
+		return balance, errors.ErrNotFound
+	}
+
+	err = h.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		balance, err = h.pointsRepo.GetBalance(ctx, cmd.UserID)
+		if err != nil {
+			return err
+		}
+
+		if balance.Balance < reward.Cost {
+			return errors.ErrInsufficientPoints
+		}
+
+		if err := h.pointsRepo.Create(ctx, entities.NewRedemption(reward)); err != nil {
+			return err
+		}
+
+		balance.Balance -= reward.Cost
+		return nil
+	})
+
+	return balance, err
+}
@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type mockRewardRepo struct {
+	rewards map[string]*entities.Reward
+}
+
+func (m *mockRewardRepo) Create(ctx context.Context, reward *entities.Reward) error {
+	reward.ID = "reward-new"
+	if m.rewards == nil {
+		m.rewards = map[string]*entities.Reward{}
+	}
+	m.rewards[reward.ID] = reward
+	return nil
+}
+
+func (m *mockRewardRepo) FindByID(ctx context.Context, id string) (*entities.Reward, error) {
+	reward, ok := m.rewards[id]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	return reward, nil
+}
+
+func (m *mockRewardRepo) FindActiveByUserID(ctx context.Context, userID string) ([]*entities.Reward, error) {
+	var rewards []*entities.Reward
+	for _, reward := range m.rewards {
+		if reward.UserID == userID && reward.IsActive() {
+			rewards = append(rewards, reward)
+		}
+	}
+	return rewards, nil
+}
+
+func (m *mockRewardRepo) Update(ctx context.Context, reward *entities.Reward) error {
+	m.rewards[reward.ID] = reward
+	return nil
+}
+
+func newRewardFixture(cost int) (*mockRewardRepo, *entities.Reward) {
+	reward := entities.NewReward("user-123", "Buy a book", cost)
+	reward.ID = "reward-1"
+	return &mockRewardRepo{rewards: map[string]*entities.Reward{reward.ID: reward}}, reward
+}
+
+func TestRedeemRewardHandler_DebitsBalance(t *testing.T) {
+	rewardRepo, reward := newRewardFixture(30)
+	pointsRepo := &mockPointsRepo{transactions: []*entities.PointTransaction{
+		{UserID: "user-123", Type: entities.PointTransactionCompletion, Points: 20},
+		{UserID: "user-123", Type: entities.PointTransactionCompletion, Points: 20},
+	}}
+
+	handler := NewRedeemRewardHandler(rewardRepo, pointsRepo, mockTransactor{})
+
+	balance, err := handler.Handle(context.Background(), RedeemRewardCommand{RewardID: reward.ID, UserID: "user-123"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if balance.Balance != 10 || balance.XP != 40 {
+		t.Errorf("Expected balance 10 and XP 40, got %+v", balance)
+	}
+
+	last := pointsRepo.transactions[len(pointsRepo.transactions)-1]
+	if last.Type != entities.PointTransactionRedemption || last.Points != -30 || last.RewardID == nil || *last.RewardID != reward.ID {
+		t.Errorf("Expected a redemption of 30 points for %s, got %+v", reward.ID, last)
+	}
+}
+
+func TestRedeemRewardHandler_InsufficientPoints(t *testing.T) {
+	rewardRepo, reward := newRewardFixture(500)
+	pointsRepo := &mockPointsRepo{transactions: []*entities.PointTransaction{
+		{UserID: "user-123", Type: entities.PointTransactionCompletion, Points: 10},
+	}}
+
+	handler := NewRedeemRewardHandler(rewardRepo, pointsRepo, mockTransactor{})
+
+	_, err := handler.Handle(context.Background(), RedeemRewardCommand{RewardID: reward.ID, UserID: "user-123"})
+	if err != errors.ErrInsufficientPoints {
+		t.Errorf("Expected ErrInsufficientPoints, got %v", err)
+	}
+	if len(pointsRepo.transactions) != 1 {
+		t.Errorf("Expected no redemption to be recorded, got %d transactions", len(pointsRepo.transactions))
+	}
+}
+
+func TestRedeemRewardHandler_RejectsOtherUsersAndArchivedRewards(t *testing.T) {
+	rewardRepo, reward := newRewardFixture(10)
+	handler := NewRedeemRewardHandler(rewardRepo, &mockPointsRepo{}, mockTransactor{})
+
+	_, err := handler.Handle(context.Background(), RedeemRewardCommand{RewardID: reward.ID, UserID: "other-user"})
+	if err != errors.ErrUnauthorized {
+		t.Errorf("Expected ErrUnauthorized, got %v", err)
+	}
+
+	reward.Archive()
+	_, err = handler.Handle(context.Background(), RedeemRewardCommand{RewardID: reward.ID, UserID: "user-123"})
+	if err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound for an archived reward, got %v", err)
+	}
+}
+
+func TestCreateRewardHandler_Validation(t *testing.T) {
+	handler := NewCreateRewardHandler(&mockRewardRepo{})
+
+	tests := []struct {
+		name    string
+		cmd     CreateRewardCommand
+		wantErr error
+	}{
+		{"Valid reward", CreateRewardCommand{UserID: "user-123", Name: "Buy a book", Cost: 500}, nil},
+		{"Empty name", CreateRewardCommand{UserID: "user-123", Name: "  ", Cost: 500}, errors.ErrInvalidInput},
+		{"Zero cost", CreateRewardCommand{UserID: "user-123", Name: "Buy a book", Cost: 0}, errors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := handler.Handle(context.Background(), tt.cmd); err != tt.wantErr {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type RedeliverWebhookDeliveryCommand struct {
+	WebhookID  string
+	DeliveryID string
+	UserID     string
+}
+
+type RedeliverWebhookDeliveryHandler struct {
+	webhookRepo  repositories.WebhookRepository
+	deliveryRepo repositories.WebhookDeliveryRepository
+}
+
+func NewRedeliverWebhookDeliveryHandler(
+	webhookRepo repositories.WebhookRepository,
+	deliveryRepo repositories.WebhookDeliveryRepository,
+) *RedeliverWebhookDeliveryHandler {
+	return &RedeliverWebhookDeliveryHandler{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+	}
+}
+
+// Handle queues a logged delivery to be sent again with the original payload.
+// The webhook must be enabled, otherwise the delivery would be dropped again.
+func (h *RedeliverWebhookDeliveryHandler) Handle(ctx context.Context, cmd RedeliverWebhookDeliveryCommand) (*entities.WebhookDelivery, error) {
+	webhook, err := findOwnedWebhook(ctx, h.webhookRepo, cmd.WebhookID, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := h.deliveryRepo.FindByID(ctx, cmd.DeliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery.WebhookID != webhook.ID {
+		return nil, errors.ErrNotFound
+	}
+
+	if !webhook.Enabled {
+		return nil, errors.ErrInvalidInput
+	}
+
+	delivery.Redeliver(time.Now())
+
+	if err := h.deliveryRepo.Update(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
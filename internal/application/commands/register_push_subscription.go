@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type RegisterPushSubscriptionCommand struct {
+	UserID    string
+	Endpoint  string
+	P256dh    string
+	Auth      string
+	UserAgent string
+}
+
+type RegisterPushSubscriptionHandler struct {
+	subscriptionRepo repositories.PushSubscriptionRepository
+}
+
+func NewRegisterPushSubscriptionHandler(subscriptionRepo repositories.PushSubscriptionRepository) *RegisterPushSubscriptionHandler {
+	return &RegisterPushSubscriptionHandler{
+		subscriptionRepo: subscriptionRepo,
+	}
+}
+
+// Handle registers the device's subscription. Registering an endpoint that is
+// already known moves it to the user, as the browser only keeps one per
+// profile.
+func (h *RegisterPushSubscriptionHandler) Handle(ctx context.Context, cmd RegisterPushSubscriptionCommand) error {
+	subscription := entities.NewPushSubscription(cmd.UserID, cmd.Endpoint, cmd.P256dh, cmd.Auth, cmd.UserAgent)
+	if !subscription.IsValid() {
+		return errors.ErrInvalidInput
+	}
+
+	return h.subscriptionRepo.Save(ctx, subscription)
+}
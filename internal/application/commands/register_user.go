@@ -14,9 +14,18 @@ import (
 	"apocapoc-api/internal/shared/validation"
 )
 
+// RegisterUserCommand's Language is the language the user's emails are sent
+// in. It defaults to English.
 type RegisterUserCommand struct {
 	Email    string
 	Password string
+	Language string
+}
+
+// ErrorLogger records the errors a command recovers from instead of
+// returning them.
+type ErrorLogger interface {
+	LogError(ctx context.Context, err error, userID, message string)
 }
 
 type RegisterUserResult struct {
@@ -27,27 +36,33 @@ type RegisterUserResult struct {
 type RegisterUserHandler struct {
 	userRepo         repositories.UserRepository
 	passwordHasher   services.PasswordHasher
-	emailService     services.EmailService
+	mailer           services.Mailer
 	appURL           string
 	registrationMode string
 	sendWelcomeEmail bool
+	events           services.EventPublisher
+	errorLog         ErrorLogger
 }
 
 func NewRegisterUserHandler(
 	userRepo repositories.UserRepository,
 	passwordHasher services.PasswordHasher,
-	emailService services.EmailService,
+	mailer services.Mailer,
 	appURL string,
 	registrationMode string,
 	sendWelcomeEmail bool,
+	events services.EventPublisher,
+	errorLog ErrorLogger,
 ) *RegisterUserHandler {
 	return &RegisterUserHandler{
 		userRepo:         userRepo,
 		passwordHasher:   passwordHasher,
-		emailService:     emailService,
+		mailer:           mailer,
 		appURL:           appURL,
 		registrationMode: registrationMode,
 		sendWelcomeEmail: sendWelcomeEmail,
+		events:           events,
+		errorLog:         errorLog,
 	}
 }
 
@@ -71,9 +86,12 @@ func (h *RegisterUserHandler) Handle(ctx context.Context, cmd RegisterUserComman
 	}
 
 	user := entities.NewUser(cmd.Email, hashedPassword)
+	if cmd.Language != "" {
+		user.Language = cmd.Language
+	}
 
 	emailVerificationRequired := false
-	if h.emailService != nil {
+	if h.mailer != nil {
 		token, err := h.generateVerificationToken()
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate verification token: %w", err)
@@ -83,10 +101,6 @@ func (h *RegisterUserHandler) Handle(ctx context.Context, cmd RegisterUserComman
 		user.EmailVerificationToken = &token
 		user.EmailVerificationExpiry = &expiry
 		emailVerificationRequired = true
-
-		if err := h.sendVerificationEmail(user); err != nil {
-			return nil, fmt.Errorf("failed to send verification email: %w", err)
-		}
 	} else {
 		user.EmailVerified = true
 	}
@@ -95,6 +109,16 @@ func (h *RegisterUserHandler) Handle(ctx context.Context, cmd RegisterUserComman
 		return nil, err
 	}
 
+	if emailVerificationRequired {
+		// The account is stored, so a verification email that cannot be
+		// queued does not fail the registration. It can be requested again.
+		if err := h.sendVerificationEmail(ctx, user); err != nil && h.errorLog != nil {
+			h.errorLog.LogError(ctx, err, user.ID, "Failed to send verification email")
+		}
+	}
+
+	publishEvents(ctx, h.events, newUserEvent(entities.EventUserRegistered, user))
+
 	return &RegisterUserResult{
 		UserID:                    user.ID,
 		EmailVerificationRequired: emailVerificationRequired,
@@ -109,27 +133,18 @@ func (h *RegisterUserHandler) generateVerificationToken() (string, error) {
 	return hex.EncodeToString(bytes), nil
 }
 
-func (h *RegisterUserHandler) sendVerificationEmail(user *entities.User) error {
+func (h *RegisterUserHandler) sendVerificationEmail(ctx context.Context, user *entities.User) error {
 	if user.EmailVerificationToken == nil {
 		return nil
 	}
 
-	verificationLink := fmt.Sprintf("%s/verify-email?token=%s", h.appURL, *user.EmailVerificationToken)
-
-	emailBody := fmt.Sprintf(`
-		<h2>Welcome! Please verify your email</h2>
-		<p>Thank you for registering. Please click the link below to verify your email address:</p>
-		<p><a href="%s">Verify Email</a></p>
-		<p>This link will expire in 24 hours.</p>
-		<p>If you didn't create an account, you can safely ignore this email.</p>
-	`, verificationLink)
-
-	message := services.EmailMessage{
-		To:      user.Email,
-		Subject: "Verify your email address",
-		Body:    emailBody,
-		IsHTML:  true,
-	}
-
-	return h.emailService.Send(message)
+	return h.mailer.SendTemplate(ctx, services.TemplatedEmail{
+		UserID:   user.ID,
+		To:       user.Email,
+		Language: user.Language,
+		Template: services.EmailTemplateVerifyEmail,
+		Data: map[string]interface{}{
+			"URL": fmt.Sprintf("%s/verify-email?token=%s", h.appURL, *user.EmailVerificationToken),
+		},
+	})
 }
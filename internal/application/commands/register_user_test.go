@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/services"
 	appErrors "apocapoc-api/internal/shared/errors"
 )
 
@@ -71,7 +72,7 @@ func TestRegisterUserHandler_Success(t *testing.T) {
 		},
 	}
 	hasher := &mockPasswordHasher{}
-	handler := NewRegisterUserHandler(repo, hasher, nil, "", "open", false)
+	handler := NewRegisterUserHandler(repo, hasher, nil, "", "open", false, nil, nil)
 
 	cmd := RegisterUserCommand{
 		Email:    "test@example.com",
@@ -88,7 +89,7 @@ func TestRegisterUserHandler_Success(t *testing.T) {
 	}
 
 	if result.EmailVerificationRequired {
-		t.Error("expected email verification to not be required when emailService is nil")
+		t.Error("expected email verification to not be required when mailer is nil")
 	}
 
 	if createdUser == nil {
@@ -103,7 +104,7 @@ func TestRegisterUserHandler_Success(t *testing.T) {
 func TestRegisterUserHandler_InvalidEmail(t *testing.T) {
 	repo := &mockUserRepo{}
 	hasher := &mockPasswordHasher{}
-	handler := NewRegisterUserHandler(repo, hasher, nil, "", "open", false)
+	handler := NewRegisterUserHandler(repo, hasher, nil, "", "open", false, nil, nil)
 
 	tests := []struct {
 		name  string
@@ -135,7 +136,7 @@ func TestRegisterUserHandler_InvalidEmail(t *testing.T) {
 func TestRegisterUserHandler_InvalidPassword(t *testing.T) {
 	repo := &mockUserRepo{}
 	hasher := &mockPasswordHasher{}
-	handler := NewRegisterUserHandler(repo, hasher, nil, "", "open", false)
+	handler := NewRegisterUserHandler(repo, hasher, nil, "", "open", false, nil, nil)
 
 	tests := []struct {
 		name     string
@@ -174,7 +175,7 @@ func TestRegisterUserHandler_EmailAlreadyExists(t *testing.T) {
 		},
 	}
 	hasher := &mockPasswordHasher{}
-	handler := NewRegisterUserHandler(repo, hasher, nil, "", "open", false)
+	handler := NewRegisterUserHandler(repo, hasher, nil, "", "open", false, nil, nil)
 
 	cmd := RegisterUserCommand{
 		Email:    "test@example.com",
@@ -195,7 +196,7 @@ func TestRegisterUserHandler_PasswordHashingError(t *testing.T) {
 			return "", expectedErr
 		},
 	}
-	handler := NewRegisterUserHandler(repo, hasher, nil, "", "open", false)
+	handler := NewRegisterUserHandler(repo, hasher, nil, "", "open", false, nil, nil)
 
 	cmd := RegisterUserCommand{
 		Email:    "test@example.com",
@@ -216,7 +217,7 @@ func TestRegisterUserHandler_RepositoryError(t *testing.T) {
 		},
 	}
 	hasher := &mockPasswordHasher{}
-	handler := NewRegisterUserHandler(repo, hasher, nil, "", "open", false)
+	handler := NewRegisterUserHandler(repo, hasher, nil, "", "open", false, nil, nil)
 
 	cmd := RegisterUserCommand{
 		Email:    "test@example.com",
@@ -232,7 +233,7 @@ func TestRegisterUserHandler_RepositoryError(t *testing.T) {
 func TestRegisterUserHandler_EdgeCases(t *testing.T) {
 	repo := &mockUserRepo{}
 	hasher := &mockPasswordHasher{}
-	handler := NewRegisterUserHandler(repo, hasher, nil, "", "open", false)
+	handler := NewRegisterUserHandler(repo, hasher, nil, "", "open", false, nil, nil)
 
 	tests := []struct {
 		name    string
@@ -285,7 +286,7 @@ func TestRegisterUserHandler_EdgeCases(t *testing.T) {
 func TestRegisterUserHandler_ClosedRegistration(t *testing.T) {
 	repo := &mockUserRepo{}
 	hasher := &mockPasswordHasher{}
-	handler := NewRegisterUserHandler(repo, hasher, nil, "", "closed", false)
+	handler := NewRegisterUserHandler(repo, hasher, nil, "", "closed", false, nil, nil)
 
 	cmd := RegisterUserCommand{
 		Email:    "test@example.com",
@@ -313,3 +314,38 @@ func (m *mockUserRepo) FindByUserIDFiltered(ctx context.Context, userID string,
 func (m *mockUserRepo) CountByUserIDFiltered(ctx context.Context, userID string, filter repositories.HabitFilter) (int, error) {
 	return 0, nil
 }
+
+func TestRegisterUserHandler_SendsVerificationEmailInUserLanguage(t *testing.T) {
+	var createdUser *entities.User
+	repo := &mockUserRepo{
+		createFunc: func(ctx context.Context, user *entities.User) error {
+			user.ID = "user-123"
+			createdUser = user
+			return nil
+		},
+	}
+	mailer := &mockMailer{}
+	handler := NewRegisterUserHandler(repo, &mockPasswordHasher{}, mailer, "https://apocapoc.app", "open", false, nil, nil)
+
+	_, err := handler.Handle(context.Background(), RegisterUserCommand{
+		Email:    "test@example.com",
+		Password: "Secure123!",
+		Language: "es",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if createdUser.Language != "es" {
+		t.Errorf("expected the language to be stored, got %q", createdUser.Language)
+	}
+	if len(mailer.sentMessages) != 1 {
+		t.Fatalf("expected 1 email, got %d", len(mailer.sentMessages))
+	}
+
+	sent := mailer.sentMessages[0]
+	wantURL := "https://apocapoc.app/verify-email?token=" + *createdUser.EmailVerificationToken
+	if sent.Template != services.EmailTemplateVerifyEmail || sent.Language != "es" || sent.Data["URL"] != wantURL {
+		t.Errorf("unexpected verification email: %+v", sent)
+	}
+}
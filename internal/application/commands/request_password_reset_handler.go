@@ -20,20 +20,20 @@ type RequestPasswordResetCommand struct {
 type RequestPasswordResetHandler struct {
 	userRepo               repositories.UserRepository
 	passwordResetTokenRepo repositories.PasswordResetTokenRepository
-	emailService           services.EmailService
+	mailer                 services.Mailer
 	appURL                 string
 }
 
 func NewRequestPasswordResetHandler(
 	userRepo repositories.UserRepository,
 	passwordResetTokenRepo repositories.PasswordResetTokenRepository,
-	emailService services.EmailService,
+	mailer services.Mailer,
 	appURL string,
 ) *RequestPasswordResetHandler {
 	return &RequestPasswordResetHandler{
 		userRepo:               userRepo,
 		passwordResetTokenRepo: passwordResetTokenRepo,
-		emailService:           emailService,
+		mailer:                 mailer,
 		appURL:                 appURL,
 	}
 }
@@ -64,24 +64,16 @@ func (h *RequestPasswordResetHandler) Handle(ctx context.Context, cmd RequestPas
 		return fmt.Errorf("failed to save reset token: %w", err)
 	}
 
-	resetLink := fmt.Sprintf("%s/reset-password?token=%s", h.appURL, tokenStr)
-
-	emailBody := fmt.Sprintf(`
-		<h2>Password Reset Request</h2>
-		<p>You requested to reset your password. Click the link below to reset it:</p>
-		<p><a href="%s">Reset Password</a></p>
-		<p>This link will expire in 1 hour.</p>
-		<p>If you didn't request this, you can safely ignore this email.</p>
-	`, resetLink)
-
-	message := services.EmailMessage{
-		To:      user.Email,
-		Subject: "Password Reset Request",
-		Body:    emailBody,
-		IsHTML:  true,
-	}
-
-	if err := h.emailService.Send(message); err != nil {
+	err = h.mailer.SendTemplate(ctx, services.TemplatedEmail{
+		UserID:   user.ID,
+		To:       user.Email,
+		Language: user.Language,
+		Template: services.EmailTemplatePasswordReset,
+		Data: map[string]interface{}{
+			"URL": fmt.Sprintf("%s/reset-password?token=%s", h.appURL, tokenStr),
+		},
+	})
+	if err != nil {
 		return fmt.Errorf("failed to send reset email: %w", err)
 	}
 
@@ -72,20 +72,16 @@ func (m *mockRequestResetTokenRepo) DeleteExpired(ctx context.Context) error {
 	return nil
 }
 
-type mockRequestResetEmailService struct {
-	sendFunc     func(message services.EmailMessage) error
-	sentMessages []services.EmailMessage
+type mockMailer struct {
+	sendFunc     func(email services.TemplatedEmail) error
+	sentMessages []services.TemplatedEmail
 }
 
-func (m *mockRequestResetEmailService) Send(message services.EmailMessage) error {
+func (m *mockMailer) SendTemplate(ctx context.Context, email services.TemplatedEmail) error {
 	if m.sendFunc != nil {
-		return m.sendFunc(message)
+		return m.sendFunc(email)
 	}
-	m.sentMessages = append(m.sentMessages, message)
-	return nil
-}
-
-func (m *mockRequestResetEmailService) HealthCheck() error {
+	m.sentMessages = append(m.sentMessages, email)
 	return nil
 }
 
@@ -104,8 +100,8 @@ func TestRequestPasswordResetHandler_Success(t *testing.T) {
 		tokens: []*entities.PasswordResetToken{},
 	}
 
-	emailService := &mockRequestResetEmailService{
-		sentMessages: []services.EmailMessage{},
+	emailService := &mockMailer{
+		sentMessages: []services.TemplatedEmail{},
 	}
 
 	handler := NewRequestPasswordResetHandler(userRepo, tokenRepo, emailService, "http://localhost:8080")
@@ -151,16 +147,12 @@ func TestRequestPasswordResetHandler_Success(t *testing.T) {
 		t.Errorf("Email To = %v, want %v", sentEmail.To, user.Email)
 	}
 
-	if sentEmail.Subject != "Password Reset Request" {
-		t.Errorf("Email Subject = %v, want %v", sentEmail.Subject, "Password Reset Request")
-	}
-
-	if !sentEmail.IsHTML {
-		t.Error("Email should be HTML")
+	if sentEmail.Template != services.EmailTemplatePasswordReset {
+		t.Errorf("Email Template = %v, want %v", sentEmail.Template, services.EmailTemplatePasswordReset)
 	}
 
-	if sentEmail.Body == "" {
-		t.Error("Email body is empty")
+	if sentEmail.Language != user.Language {
+		t.Errorf("Email Language = %v, want %v", sentEmail.Language, user.Language)
 	}
 }
 
@@ -168,7 +160,7 @@ func TestRequestPasswordResetHandler_EmptyEmail(t *testing.T) {
 	handler := NewRequestPasswordResetHandler(
 		&mockRequestResetUserRepo{users: make(map[string]*entities.User)},
 		&mockRequestResetTokenRepo{tokens: []*entities.PasswordResetToken{}},
-		&mockRequestResetEmailService{},
+		&mockMailer{},
 		"http://localhost:8080",
 	)
 
@@ -186,7 +178,7 @@ func TestRequestPasswordResetHandler_UserNotFound(t *testing.T) {
 	handler := NewRequestPasswordResetHandler(
 		&mockRequestResetUserRepo{users: make(map[string]*entities.User)},
 		&mockRequestResetTokenRepo{tokens: []*entities.PasswordResetToken{}},
-		&mockRequestResetEmailService{},
+		&mockMailer{},
 		"http://localhost:8080",
 	)
 
@@ -214,7 +206,7 @@ func TestRequestPasswordResetHandler_EmailNotVerified(t *testing.T) {
 	handler := NewRequestPasswordResetHandler(
 		userRepo,
 		&mockRequestResetTokenRepo{tokens: []*entities.PasswordResetToken{}},
-		&mockRequestResetEmailService{},
+		&mockMailer{},
 		"http://localhost:8080",
 	)
 
@@ -245,7 +237,7 @@ func TestRequestPasswordResetHandler_TokenCreationFailure(t *testing.T) {
 		},
 	}
 
-	emailService := &mockRequestResetEmailService{}
+	emailService := &mockMailer{}
 
 	handler := NewRequestPasswordResetHandler(userRepo, tokenRepo, emailService, "http://localhost:8080")
 
@@ -278,8 +270,8 @@ func TestRequestPasswordResetHandler_EmailSendFailure(t *testing.T) {
 		tokens: []*entities.PasswordResetToken{},
 	}
 
-	emailService := &mockRequestResetEmailService{
-		sendFunc: func(message services.EmailMessage) error {
+	emailService := &mockMailer{
+		sendFunc: func(email services.TemplatedEmail) error {
 			return errors.ErrInvalidInput
 		},
 	}
@@ -315,8 +307,8 @@ func TestRequestPasswordResetHandler_ResetLinkFormat(t *testing.T) {
 		tokens: []*entities.PasswordResetToken{},
 	}
 
-	emailService := &mockRequestResetEmailService{
-		sentMessages: []services.EmailMessage{},
+	emailService := &mockMailer{
+		sentMessages: []services.TemplatedEmail{},
 	}
 
 	appURL := "https://myapp.com"
@@ -336,8 +328,9 @@ func TestRequestPasswordResetHandler_ResetLinkFormat(t *testing.T) {
 	}
 
 	sentEmail := emailService.sentMessages[0]
-	if sentEmail.Body == "" {
-		t.Fatal("Email body is empty")
+	wantURL := appURL + "/reset-password?token=" + tokenRepo.tokens[0].Token
+	if sentEmail.Data["URL"] != wantURL {
+		t.Errorf("Email URL = %v, want %v", sentEmail.Data["URL"], wantURL)
 	}
 }
 
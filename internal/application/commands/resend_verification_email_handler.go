@@ -17,20 +17,20 @@ type ResendVerificationEmailCommand struct {
 }
 
 type ResendVerificationEmailHandler struct {
-	userRepo     repositories.UserRepository
-	emailService services.EmailService
-	appURL       string
+	userRepo repositories.UserRepository
+	mailer   services.Mailer
+	appURL   string
 }
 
 func NewResendVerificationEmailHandler(
 	userRepo repositories.UserRepository,
-	emailService services.EmailService,
+	mailer services.Mailer,
 	appURL string,
 ) *ResendVerificationEmailHandler {
 	return &ResendVerificationEmailHandler{
-		userRepo:     userRepo,
-		emailService: emailService,
-		appURL:       appURL,
+		userRepo: userRepo,
+		mailer:   mailer,
+		appURL:   appURL,
 	}
 }
 
@@ -62,24 +62,16 @@ func (h *ResendVerificationEmailHandler) Handle(ctx context.Context, cmd ResendV
 		return fmt.Errorf("failed to update user: %w", err)
 	}
 
-	verificationLink := fmt.Sprintf("%s/verify-email?token=%s", h.appURL, token)
-
-	emailBody := fmt.Sprintf(`
-		<h2>Verify your email address</h2>
-		<p>Please click the link below to verify your email address:</p>
-		<p><a href="%s">Verify Email</a></p>
-		<p>This link will expire in 24 hours.</p>
-		<p>If you didn't create an account, you can safely ignore this email.</p>
-	`, verificationLink)
-
-	message := services.EmailMessage{
-		To:      user.Email,
-		Subject: "Verify your email address",
-		Body:    emailBody,
-		IsHTML:  true,
-	}
-
-	if err := h.emailService.Send(message); err != nil {
+	err = h.mailer.SendTemplate(ctx, services.TemplatedEmail{
+		UserID:   user.ID,
+		To:       user.Email,
+		Language: user.Language,
+		Template: services.EmailTemplateResendVerification,
+		Data: map[string]interface{}{
+			"URL": fmt.Sprintf("%s/verify-email?token=%s", h.appURL, token),
+		},
+	})
+	if err != nil {
 		return fmt.Errorf("failed to send verification email: %w", err)
 	}
 
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type RetryOutgoingEmailHandler struct {
+	emailOutboxRepo repositories.EmailOutboxRepository
+}
+
+func NewRetryOutgoingEmailHandler(emailOutboxRepo repositories.EmailOutboxRepository) *RetryOutgoingEmailHandler {
+	return &RetryOutgoingEmailHandler{
+		emailOutboxRepo: emailOutboxRepo,
+	}
+}
+
+// Handle queues an email that has not been sent yet to be attempted right
+// away with a fresh set of attempts.
+func (h *RetryOutgoingEmailHandler) Handle(ctx context.Context, id string) (*entities.OutgoingEmail, error) {
+	email, err := h.emailOutboxRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if email.Status == entities.OutgoingEmailSent {
+		return nil, errors.ErrInvalidInput
+	}
+
+	email.Retry(time.Now())
+
+	if err := h.emailOutboxRepo.Update(ctx, email); err != nil {
+		return nil, err
+	}
+
+	return email, nil
+}
@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/services"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type mockEmailOutboxRepo struct {
+	emails []*entities.OutgoingEmail
+}
+
+func (m *mockEmailOutboxRepo) Create(ctx context.Context, email *entities.OutgoingEmail) error {
+	m.emails = append(m.emails, email)
+	return nil
+}
+
+func (m *mockEmailOutboxRepo) FindByID(ctx context.Context, id string) (*entities.OutgoingEmail, error) {
+	for _, email := range m.emails {
+		if email.ID == id {
+			return email, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (m *mockEmailOutboxRepo) FindByStatus(ctx context.Context, status entities.OutgoingEmailStatus, limit int) ([]*entities.OutgoingEmail, error) {
+	return nil, nil
+}
+
+func (m *mockEmailOutboxRepo) FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.OutgoingEmail, error) {
+	return nil, nil
+}
+
+func (m *mockEmailOutboxRepo) CountByStatus(ctx context.Context) (map[entities.OutgoingEmailStatus]int, error) {
+	return nil, nil
+}
+
+func (m *mockEmailOutboxRepo) Update(ctx context.Context, email *entities.OutgoingEmail) error {
+	return nil
+}
+
+func (m *mockEmailOutboxRepo) DeleteSentBefore(ctx context.Context, before time.Time) (int, error) {
+	return 0, nil
+}
+
+func TestRetryOutgoingEmailHandler(t *testing.T) {
+	failed := entities.NewOutgoingEmail("user@example.com", "Verify your email address", "Body", true)
+	failed.ID = "failed"
+	for i := 0; i < entities.OutgoingEmailMaxAttempts; i++ {
+		failed.RecordFailure("connection refused", time.Now())
+	}
+
+	sent := entities.NewOutgoingEmail("user@example.com", "Reminder", "Body", false)
+	sent.ID = "sent"
+	sent.RecordSuccess(time.Now())
+
+	handler := NewRetryOutgoingEmailHandler(&mockEmailOutboxRepo{emails: []*entities.OutgoingEmail{failed, sent}})
+
+	email, err := handler.Handle(context.Background(), "failed")
+	if err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+	if email.Status != entities.OutgoingEmailPending || email.Attempts != 0 {
+		t.Errorf("Expected the email to be queued again, got %+v", email)
+	}
+
+	if _, err := handler.Handle(context.Background(), "sent"); err != errors.ErrInvalidInput {
+		t.Errorf("Expected ErrInvalidInput for a sent email, got %v", err)
+	}
+	if _, err := handler.Handle(context.Background(), "missing"); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+type recordingErrorLog struct {
+	entries []string
+}
+
+func (l *recordingErrorLog) LogError(ctx context.Context, err error, userID, message string) {
+	l.entries = append(l.entries, fmt.Sprintf("%s: %s: %v", userID, message, err))
+}
+
+type failingMailer struct{}
+
+func (failingMailer) SendTemplate(ctx context.Context, email services.TemplatedEmail) error {
+	return fmt.Errorf("smtp unavailable")
+}
+
+func TestRegisterUserHandler_SucceedsWhenVerificationEmailFails(t *testing.T) {
+	created := false
+	repo := &mockUserRepo{
+		createFunc: func(ctx context.Context, user *entities.User) error {
+			user.ID = "user-123"
+			created = true
+			return nil
+		},
+	}
+	errorLog := &recordingErrorLog{}
+	handler := NewRegisterUserHandler(repo, &mockPasswordHasher{}, failingMailer{}, "", "open", false, nil, errorLog)
+
+	result, err := handler.Handle(context.Background(), RegisterUserCommand{
+		Email:    "test@example.com",
+		Password: "Secure123!",
+	})
+	if err != nil {
+		t.Fatalf("Expected registration to succeed, got %v", err)
+	}
+	if !created || !result.EmailVerificationRequired {
+		t.Errorf("Expected the user to be stored awaiting verification, got %+v", result)
+	}
+	if len(errorLog.entries) != 1 || errorLog.entries[0] != "user-123: Failed to send verification email: smtp unavailable" {
+		t.Errorf("Expected the failed email to be logged, got %v", errorLog.entries)
+	}
+}
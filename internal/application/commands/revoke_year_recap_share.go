@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/repositories"
+)
+
+type RevokeYearRecapShareCommand struct {
+	UserID string
+	Year   int
+}
+
+type RevokeYearRecapShareHandler struct {
+	shareRepo repositories.RecapShareRepository
+}
+
+func NewRevokeYearRecapShareHandler(shareRepo repositories.RecapShareRepository) *RevokeYearRecapShareHandler {
+	return &RevokeYearRecapShareHandler{
+		shareRepo: shareRepo,
+	}
+}
+
+func (h *RevokeYearRecapShareHandler) Handle(ctx context.Context, cmd RevokeYearRecapShareCommand) error {
+	share, err := h.shareRepo.FindByUserIDAndYear(ctx, cmd.UserID, cmd.Year)
+	if err != nil {
+		return err
+	}
+
+	return h.shareRepo.Delete(ctx, share.ID)
+}
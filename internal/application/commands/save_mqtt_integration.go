@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+// SaveMQTTIntegrationCommand connects the user's account to their MQTT
+// broker. A nil Password keeps the stored one, so the settings can be edited
+// without sending the password again. Empty prefixes and timezone take their
+// defaults.
+type SaveMQTTIntegrationCommand struct {
+	UserID          string
+	BrokerURL       string
+	Username        string
+	Password        *string
+	TopicPrefix     string
+	DiscoveryPrefix string
+	Timezone        string
+}
+
+type SaveMQTTIntegrationHandler struct {
+	integrationRepo repositories.MQTTIntegrationRepository
+}
+
+func NewSaveMQTTIntegrationHandler(integrationRepo repositories.MQTTIntegrationRepository) *SaveMQTTIntegrationHandler {
+	return &SaveMQTTIntegrationHandler{
+		integrationRepo: integrationRepo,
+	}
+}
+
+func (h *SaveMQTTIntegrationHandler) Handle(ctx context.Context, cmd SaveMQTTIntegrationCommand) (*entities.MQTTIntegration, error) {
+	if !entities.IsValidMQTTBrokerURL(cmd.BrokerURL) {
+		return nil, errors.ErrInvalidInput
+	}
+
+	topicPrefix := cmd.TopicPrefix
+	if topicPrefix == "" {
+		topicPrefix = entities.DefaultMQTTTopicPrefix
+	}
+	discoveryPrefix := cmd.DiscoveryPrefix
+	if discoveryPrefix == "" {
+		discoveryPrefix = entities.DefaultMQTTDiscoveryPrefix
+	}
+	if !entities.IsValidMQTTTopicPrefix(topicPrefix) || !entities.IsValidMQTTTopicPrefix(discoveryPrefix) {
+		return nil, errors.ErrInvalidInput
+	}
+
+	timezone := cmd.Timezone
+	if timezone == "" {
+		timezone = entities.DefaultNotificationTimezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, errors.ErrInvalidInput
+	}
+
+	existing, err := h.integrationRepo.FindByUserID(ctx, cmd.UserID)
+	if err != nil && err != errors.ErrNotFound {
+		return nil, err
+	}
+
+	integration := entities.NewMQTTIntegration(cmd.UserID, cmd.BrokerURL, cmd.Username, "", topicPrefix, discoveryPrefix, timezone)
+	if cmd.Password != nil {
+		integration.Password = *cmd.Password
+	} else if existing != nil {
+		integration.Password = existing.Password
+	}
+	if existing != nil {
+		integration.CreatedAt = existing.CreatedAt
+	}
+
+	if err := h.integrationRepo.Save(ctx, integration); err != nil {
+		return nil, err
+	}
+
+	return integration, nil
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type mockMQTTIntegrationRepo struct {
+	integrations map[string]*entities.MQTTIntegration
+}
+
+func (r *mockMQTTIntegrationRepo) Save(ctx context.Context, integration *entities.MQTTIntegration) error {
+	r.integrations[integration.UserID] = integration
+	return nil
+}
+
+func (r *mockMQTTIntegrationRepo) FindByUserID(ctx context.Context, userID string) (*entities.MQTTIntegration, error) {
+	integration, ok := r.integrations[userID]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	return integration, nil
+}
+
+func (r *mockMQTTIntegrationRepo) FindAll(ctx context.Context) ([]*entities.MQTTIntegration, error) {
+	var integrations []*entities.MQTTIntegration
+	for _, integration := range r.integrations {
+		integrations = append(integrations, integration)
+	}
+	return integrations, nil
+}
+
+func (r *mockMQTTIntegrationRepo) DeleteByUserID(ctx context.Context, userID string) error {
+	if _, ok := r.integrations[userID]; !ok {
+		return errors.ErrNotFound
+	}
+	delete(r.integrations, userID)
+	return nil
+}
+
+func TestSaveMQTTIntegrationHandler(t *testing.T) {
+	repo := &mockMQTTIntegrationRepo{integrations: map[string]*entities.MQTTIntegration{}}
+	handler := NewSaveMQTTIntegrationHandler(repo)
+	ctx := context.Background()
+	password := "secret"
+
+	t.Run("Applies defaults", func(t *testing.T) {
+		integration, err := handler.Handle(ctx, SaveMQTTIntegrationCommand{
+			UserID:    "user-123",
+			BrokerURL: "mqtt://broker.local:1883",
+			Username:  "ha",
+			Password:  &password,
+		})
+		if err != nil {
+			t.Fatalf("Handle() unexpected error = %v", err)
+		}
+		if integration.TopicPrefix != "apocapoc" || integration.DiscoveryPrefix != "homeassistant" || integration.Timezone != "UTC" {
+			t.Errorf("Unexpected defaults: %+v", integration)
+		}
+		if repo.integrations["user-123"].Password != "secret" {
+			t.Error("Expected the password to be stored")
+		}
+	})
+
+	t.Run("Keeps the stored password when none is given", func(t *testing.T) {
+		integration, err := handler.Handle(ctx, SaveMQTTIntegrationCommand{
+			UserID:      "user-123",
+			BrokerURL:   "mqtts://broker.example.com",
+			Username:    "ha",
+			TopicPrefix: "home/habits",
+		})
+		if err != nil {
+			t.Fatalf("Handle() unexpected error = %v", err)
+		}
+		if integration.Password != "secret" || integration.TopicPrefix != "home/habits" {
+			t.Errorf("Unexpected integration: %+v", integration)
+		}
+	})
+
+	t.Run("Clears the password when an empty one is given", func(t *testing.T) {
+		empty := ""
+		integration, err := handler.Handle(ctx, SaveMQTTIntegrationCommand{
+			UserID:    "user-123",
+			BrokerURL: "mqtt://broker.local",
+			Password:  &empty,
+		})
+		if err != nil {
+			t.Fatalf("Handle() unexpected error = %v", err)
+		}
+		if integration.Password != "" {
+			t.Errorf("Expected no password, got %q", integration.Password)
+		}
+	})
+
+	t.Run("Rejects invalid settings", func(t *testing.T) {
+		for _, cmd := range []SaveMQTTIntegrationCommand{
+			{UserID: "user-123", BrokerURL: "http://broker.local"},
+			{UserID: "user-123", BrokerURL: "mqtt://broker.local", TopicPrefix: "apocapoc/#"},
+			{UserID: "user-123", BrokerURL: "mqtt://broker.local", DiscoveryPrefix: "/homeassistant"},
+			{UserID: "user-123", BrokerURL: "mqtt://broker.local", Timezone: "Nowhere/Special"},
+		} {
+			if _, err := handler.Handle(ctx, cmd); err != errors.ErrInvalidInput {
+				t.Errorf("Expected ErrInvalidInput for %+v, got %v", cmd, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type ShareYearRecapCommand struct {
+	UserID string
+	Year   int
+	Today  time.Time
+}
+
+type ShareYearRecapHandler struct {
+	shareRepo repositories.RecapShareRepository
+}
+
+func NewShareYearRecapHandler(shareRepo repositories.RecapShareRepository) *ShareYearRecapHandler {
+	return &ShareYearRecapHandler{
+		shareRepo: shareRepo,
+	}
+}
+
+// Handle returns the public share of the year recap, creating it on first use.
+func (h *ShareYearRecapHandler) Handle(ctx context.Context, cmd ShareYearRecapCommand) (*entities.RecapShare, error) {
+	if cmd.Year < 1 || cmd.Year > cmd.Today.Year() {
+		return nil, errors.ErrInvalidInput
+	}
+
+	share, err := h.shareRepo.FindByUserIDAndYear(ctx, cmd.UserID, cmd.Year)
+	if err == nil {
+		return share, nil
+	}
+	if err != errors.ErrNotFound {
+		return nil, err
+	}
+
+	token, err := generateRecapShareToken()
+	if err != nil {
+		return nil, err
+	}
+
+	share = entities.NewRecapShare(cmd.UserID, cmd.Year, token)
+	if err := h.shareRepo.Create(ctx, share); err != nil {
+		if err == errors.ErrAlreadyExists {
+			return h.shareRepo.FindByUserIDAndYear(ctx, cmd.UserID, cmd.Year)
+		}
+		return nil, err
+	}
+
+	return share, nil
+}
+
+func generateRecapShareToken() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
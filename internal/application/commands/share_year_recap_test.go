@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type mockRecapShareRepo struct {
+	shares []*entities.RecapShare
+}
+
+func (m *mockRecapShareRepo) Create(ctx context.Context, share *entities.RecapShare) error {
+	m.shares = append(m.shares, share)
+	return nil
+}
+
+func (m *mockRecapShareRepo) FindByToken(ctx context.Context, token string) (*entities.RecapShare, error) {
+	for _, share := range m.shares {
+		if share.Token == token {
+			return share, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (m *mockRecapShareRepo) FindByUserIDAndYear(ctx context.Context, userID string, year int) (*entities.RecapShare, error) {
+	for _, share := range m.shares {
+		if share.UserID == userID && share.Year == year {
+			return share, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (m *mockRecapShareRepo) Delete(ctx context.Context, id string) error {
+	for i, share := range m.shares {
+		if share.ID == id {
+			m.shares = append(m.shares[:i], m.shares[i+1:]...)
+			return nil
+		}
+	}
+	return errors.ErrNotFound
+}
+
+func TestShareYearRecapHandler_ReusesExistingShare(t *testing.T) {
+	repo := &mockRecapShareRepo{}
+	handler := NewShareYearRecapHandler(repo)
+	today := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	first, err := handler.Handle(context.Background(), ShareYearRecapCommand{UserID: "user-123", Year: 2024, Today: today})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(first.Token) != 32 {
+		t.Errorf("Expected a 32 character token, got %q", first.Token)
+	}
+
+	second, err := handler.Handle(context.Background(), ShareYearRecapCommand{UserID: "user-123", Year: 2024, Today: today})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if second.Token != first.Token || len(repo.shares) != 1 {
+		t.Errorf("Expected the existing share to be reused")
+	}
+
+	_, err = handler.Handle(context.Background(), ShareYearRecapCommand{UserID: "user-123", Year: 2026, Today: today})
+	if err != errors.ErrInvalidInput {
+		t.Errorf("Expected ErrInvalidInput for a future year, got %v", err)
+	}
+}
+
+func TestRevokeYearRecapShareHandler(t *testing.T) {
+	repo := &mockRecapShareRepo{shares: []*entities.RecapShare{entities.NewRecapShare("user-123", 2024, "token")}}
+	handler := NewRevokeYearRecapShareHandler(repo)
+
+	if err := handler.Handle(context.Background(), RevokeYearRecapShareCommand{UserID: "other-user", Year: 2024}); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound for another user's year, got %v", err)
+	}
+
+	if err := handler.Handle(context.Background(), RevokeYearRecapShareCommand{UserID: "user-123", Year: 2024}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(repo.shares) != 0 {
+		t.Error("Expected the share to be deleted")
+	}
+}
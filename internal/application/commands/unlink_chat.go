@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/repositories"
+)
+
+type UnlinkChatCommand struct {
+	UserID string
+}
+
+type UnlinkChatHandler struct {
+	linkRepo repositories.ChatLinkRepository
+}
+
+func NewUnlinkChatHandler(linkRepo repositories.ChatLinkRepository) *UnlinkChatHandler {
+	return &UnlinkChatHandler{
+		linkRepo: linkRepo,
+	}
+}
+
+// Handle returns ErrNotFound when the user has no linked chat.
+func (h *UnlinkChatHandler) Handle(ctx context.Context, cmd UnlinkChatCommand) error {
+	return h.linkRepo.DeleteByUserID(ctx, cmd.UserID)
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"time"
 
+	"apocapoc-api/internal/domain/entities"
 	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/domain/services"
 	"apocapoc-api/internal/shared/errors"
 )
 
@@ -15,17 +17,29 @@ type UnmarkHabitCommand struct {
 }
 
 type UnmarkHabitHandler struct {
-	habitRepo repositories.HabitRepository
-	entryRepo repositories.HabitEntryRepository
+	habitRepo  repositories.HabitRepository
+	entryRepo  repositories.HabitEntryRepository
+	statsRepo  repositories.HabitStatsRepository
+	pointsRepo repositories.PointsRepository
+	transactor repositories.Transactor
+	events     services.EventPublisher
 }
 
 func NewUnmarkHabitHandler(
 	habitRepo repositories.HabitRepository,
 	entryRepo repositories.HabitEntryRepository,
+	statsRepo repositories.HabitStatsRepository,
+	pointsRepo repositories.PointsRepository,
+	transactor repositories.Transactor,
+	events services.EventPublisher,
 ) *UnmarkHabitHandler {
 	return &UnmarkHabitHandler{
-		habitRepo: habitRepo,
-		entryRepo: entryRepo,
+		habitRepo:  habitRepo,
+		entryRepo:  entryRepo,
+		statsRepo:  statsRepo,
+		pointsRepo: pointsRepo,
+		transactor: transactor,
+		events:     events,
 	}
 }
 
@@ -53,17 +67,37 @@ func (h *UnmarkHabitHandler) Handle(ctx context.Context, cmd UnmarkHabitCommand)
 		return err
 	}
 
-	var targetEntryID string
+	var target *entities.HabitEntry
 	for _, entry := range entries {
 		if entry.ScheduledDate.Equal(cmd.ScheduledDate) {
-			targetEntryID = entry.ID
+			target = entry
 			break
 		}
 	}
 
-	if targetEntryID == "" {
+	if target == nil {
 		return errors.ErrNotFound
 	}
+	targetEntryID := target.ID
 
-	return h.entryRepo.Delete(ctx, targetEntryID)
+	return h.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := h.entryRepo.Delete(ctx, targetEntryID); err != nil {
+			return err
+		}
+		if err := removeHabitCompletion(ctx, habit, target.ScheduledDate, h.entryRepo, h.statsRepo); err != nil {
+			return err
+		}
+
+		credited, err := h.pointsRepo.SumByEntryID(ctx, targetEntryID)
+		if err != nil {
+			return err
+		}
+		if credited > 0 {
+			if err := h.pointsRepo.Create(ctx, entities.NewCompletionReversal(habit, targetEntryID, credited)); err != nil {
+				return err
+			}
+		}
+
+		return publishEventsInTransaction(ctx, h.events, newHabitEntryEvent(entities.EventHabitUnmarked, habit, target.ScheduledDate, nil))
+	})
 }
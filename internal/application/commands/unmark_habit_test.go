@@ -47,7 +47,7 @@ func TestUnmarkHabitHandler_UnmarksSuccessfully(t *testing.T) {
 		entries: []*entities.HabitEntry{entry},
 	}
 
-	handler := NewUnmarkHabitHandler(habitRepo, entryRepo)
+	handler := NewUnmarkHabitHandler(habitRepo, entryRepo, &mockStatsRepo{}, &mockPointsRepo{}, mockTransactor{}, nil)
 
 	cmd := UnmarkHabitCommand{
 		HabitID:       "habit-1",
@@ -66,6 +66,46 @@ func TestUnmarkHabitHandler_UnmarksSuccessfully(t *testing.T) {
 	}
 }
 
+func TestUnmarkHabitHandler_RefreshesStats(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+
+	scheduledDate := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	entry := entities.NewHabitEntry("habit-1", scheduledDate, nil)
+	entry.ID = "entry-1"
+
+	lastCompleted := scheduledDate
+	statsRepo := &mockStatsRepo{saved: &entities.HabitStats{
+		HabitID:           habit.ID,
+		TotalCompletions:  1,
+		CurrentStreak:     1,
+		LongestStreak:     1,
+		LastCompletedDate: &lastCompleted,
+	}}
+
+	handler := NewUnmarkHabitHandler(
+		&mockHabitRepoForUpdate{habitToReturn: habit},
+		&mockEntryRepoForUnmark{entries: []*entities.HabitEntry{entry}},
+		statsRepo,
+		&mockPointsRepo{},
+		mockTransactor{},
+		nil,
+	)
+
+	err := handler.Handle(context.Background(), UnmarkHabitCommand{
+		HabitID:       "habit-1",
+		UserID:        "user-123",
+		ScheduledDate: scheduledDate,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if statsRepo.saved.TotalCompletions != 0 || statsRepo.saved.LastCompletedDate != nil {
+		t.Errorf("Expected stats to be recalculated without the entry, got %+v", statsRepo.saved)
+	}
+}
+
 func TestUnmarkHabitHandler_ReturnsErrorWhenHabitNotFound(t *testing.T) {
 	habitRepo := &mockHabitRepoForUpdate{
 		errorOnFind: errors.ErrNotFound,
@@ -73,7 +113,7 @@ func TestUnmarkHabitHandler_ReturnsErrorWhenHabitNotFound(t *testing.T) {
 
 	entryRepo := &mockEntryRepoForUnmark{}
 
-	handler := NewUnmarkHabitHandler(habitRepo, entryRepo)
+	handler := NewUnmarkHabitHandler(habitRepo, entryRepo, &mockStatsRepo{}, &mockPointsRepo{}, mockTransactor{}, nil)
 
 	cmd := UnmarkHabitCommand{
 		HabitID:       "non-existent",
@@ -98,7 +138,7 @@ func TestUnmarkHabitHandler_ReturnsErrorWhenUserDoesNotOwnHabit(t *testing.T) {
 
 	entryRepo := &mockEntryRepoForUnmark{}
 
-	handler := NewUnmarkHabitHandler(habitRepo, entryRepo)
+	handler := NewUnmarkHabitHandler(habitRepo, entryRepo, &mockStatsRepo{}, &mockPointsRepo{}, mockTransactor{}, nil)
 
 	cmd := UnmarkHabitCommand{
 		HabitID:       "habit-1",
@@ -125,7 +165,7 @@ func TestUnmarkHabitHandler_ReturnsErrorWhenEntryNotFound(t *testing.T) {
 		entries: []*entities.HabitEntry{},
 	}
 
-	handler := NewUnmarkHabitHandler(habitRepo, entryRepo)
+	handler := NewUnmarkHabitHandler(habitRepo, entryRepo, &mockStatsRepo{}, &mockPointsRepo{}, mockTransactor{}, nil)
 
 	cmd := UnmarkHabitCommand{
 		HabitID:       "habit-1",
@@ -155,3 +195,42 @@ func (m *mockEntryRepoForUnmark) FindByUserIDFiltered(ctx context.Context, userI
 func (m *mockEntryRepoForUnmark) CountByUserIDFiltered(ctx context.Context, userID string, filter repositories.HabitFilter) (int, error) {
 	return 0, nil
 }
+
+func TestUnmarkHabitHandler_ReversesPoints(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+
+	scheduledDate := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	entry := entities.NewHabitEntry("habit-1", scheduledDate, nil)
+	entry.ID = "entry-1"
+
+	pointsRepo := &mockPointsRepo{transactions: []*entities.PointTransaction{
+		entities.NewCompletionCredit(habit, entry),
+	}}
+
+	handler := NewUnmarkHabitHandler(
+		&mockHabitRepoForUpdate{habitToReturn: habit},
+		&mockEntryRepoForUnmark{entries: []*entities.HabitEntry{entry}},
+		&mockStatsRepo{},
+		pointsRepo,
+		mockTransactor{},
+		nil,
+	)
+
+	err := handler.Handle(context.Background(), UnmarkHabitCommand{
+		HabitID:       "habit-1",
+		UserID:        "user-123",
+		ScheduledDate: scheduledDate,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	balance, _ := pointsRepo.GetBalance(context.Background(), "user-123")
+	if balance.Balance != 0 || balance.XP != 0 {
+		t.Errorf("Expected the credit to be reversed, got %+v", balance)
+	}
+	if len(pointsRepo.transactions) != 2 || pointsRepo.transactions[1].Type != entities.PointTransactionReversal {
+		t.Errorf("Expected a reversal transaction, got %+v", pointsRepo.transactions)
+	}
+}
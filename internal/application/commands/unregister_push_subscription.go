@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type UnregisterPushSubscriptionCommand struct {
+	UserID   string
+	Endpoint string
+}
+
+type UnregisterPushSubscriptionHandler struct {
+	subscriptionRepo repositories.PushSubscriptionRepository
+}
+
+func NewUnregisterPushSubscriptionHandler(subscriptionRepo repositories.PushSubscriptionRepository) *UnregisterPushSubscriptionHandler {
+	return &UnregisterPushSubscriptionHandler{
+		subscriptionRepo: subscriptionRepo,
+	}
+}
+
+func (h *UnregisterPushSubscriptionHandler) Handle(ctx context.Context, cmd UnregisterPushSubscriptionCommand) error {
+	subscription, err := h.subscriptionRepo.FindByEndpoint(ctx, cmd.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	if subscription.UserID != cmd.UserID {
+		return errors.ErrNotFound
+	}
+
+	return h.subscriptionRepo.Delete(ctx, subscription.ID)
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/domain/services"
+	"apocapoc-api/internal/shared/errors"
+)
+
+// UnsubscribeCommand turns off the email category named by a signed token
+// from an unsubscribe link.
+type UnsubscribeCommand struct {
+	Token string
+}
+
+type UnsubscribeHandler struct {
+	userRepo          repositories.UserRepository
+	preferenceRepo    repositories.NotificationPreferenceRepository
+	unsubscribeTokens services.UnsubscribeTokens
+}
+
+func NewUnsubscribeHandler(userRepo repositories.UserRepository, preferenceRepo repositories.NotificationPreferenceRepository, unsubscribeTokens services.UnsubscribeTokens) *UnsubscribeHandler {
+	return &UnsubscribeHandler{
+		userRepo:          userRepo,
+		preferenceRepo:    preferenceRepo,
+		unsubscribeTokens: unsubscribeTokens,
+	}
+}
+
+// Handle returns the category that was turned off. Unsubscribing again is
+// not an error, so links can be followed more than once.
+func (h *UnsubscribeHandler) Handle(ctx context.Context, cmd UnsubscribeCommand) (entities.NotificationCategory, error) {
+	userID, category, err := h.unsubscribeTokens.Verify(cmd.Token)
+	if err != nil {
+		return "", errors.ErrInvalidInput
+	}
+	if category.IsMandatory() || !category.Supports(entities.NotificationEmail) {
+		return "", errors.ErrInvalidInput
+	}
+
+	if _, err := h.userRepo.FindByID(ctx, userID); err != nil {
+		return "", err
+	}
+
+	preference := entities.NewNotificationPreference(userID, category, entities.NotificationEmail, false)
+	if err := h.preferenceRepo.Save(ctx, preference); err != nil {
+		return "", err
+	}
+
+	return category, nil
+}
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type stubUnsubscribeTokens struct{}
+
+func (stubUnsubscribeTokens) Sign(userID string, category entities.NotificationCategory) string {
+	return userID + ":" + string(category)
+}
+
+func (stubUnsubscribeTokens) Verify(token string) (string, entities.NotificationCategory, error) {
+	userID, category, ok := strings.Cut(token, ":")
+	if !ok {
+		return "", "", fmt.Errorf("invalid token")
+	}
+	return userID, entities.NotificationCategory(category), nil
+}
+
+func TestUnsubscribeHandler(t *testing.T) {
+	user := entities.NewUser("test@example.com", "hash")
+	user.ID = "user-123"
+	preferenceRepo := &mockPreferenceRepo{}
+	handler := NewUnsubscribeHandler(&languageUserRepo{user: user}, preferenceRepo, stubUnsubscribeTokens{})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		category, err := handler.Handle(ctx, UnsubscribeCommand{Token: "user-123:digests"})
+		if err != nil {
+			t.Fatalf("Handle() unexpected error = %v", err)
+		}
+		if category != entities.NotificationDigests {
+			t.Errorf("Expected digests, got %s", category)
+		}
+	}
+
+	preferences, _ := preferenceRepo.FindByUserID(ctx, user.ID)
+	if len(preferences) != 1 || preferences.IsEnabled(entities.NotificationDigests, entities.NotificationEmail) {
+		t.Errorf("Expected digest emails to be turned off once, got %+v", preferences)
+	}
+}
+
+func TestUnsubscribeHandler_RejectsInvalidTokens(t *testing.T) {
+	user := entities.NewUser("test@example.com", "hash")
+	user.ID = "user-123"
+	handler := NewUnsubscribeHandler(&languageUserRepo{user: user}, &mockPreferenceRepo{}, stubUnsubscribeTokens{})
+
+	tests := []struct {
+		name     string
+		token    string
+		expected error
+	}{
+		{"Bad signature", "garbage", errors.ErrInvalidInput},
+		{"Security category", "user-123:security", errors.ErrInvalidInput},
+		{"Unknown category", "user-123:marketing", errors.ErrInvalidInput},
+		{"Deleted user", "user-456:digests", errors.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := handler.Handle(context.Background(), UnsubscribeCommand{Token: tt.token}); err != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
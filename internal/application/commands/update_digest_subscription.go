@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+// UpdateDigestSubscriptionCommand leaves nil flags unchanged.
+type UpdateDigestSubscriptionCommand struct {
+	UserID  string
+	Weekly  *bool
+	Monthly *bool
+}
+
+type UpdateDigestSubscriptionHandler struct {
+	subscriptionRepo repositories.DigestSubscriptionRepository
+}
+
+func NewUpdateDigestSubscriptionHandler(subscriptionRepo repositories.DigestSubscriptionRepository) *UpdateDigestSubscriptionHandler {
+	return &UpdateDigestSubscriptionHandler{
+		subscriptionRepo: subscriptionRepo,
+	}
+}
+
+func (h *UpdateDigestSubscriptionHandler) Handle(ctx context.Context, cmd UpdateDigestSubscriptionCommand) (*entities.DigestSubscription, error) {
+	subscription, err := h.subscriptionRepo.FindByUserID(ctx, cmd.UserID)
+	if err == errors.ErrNotFound {
+		subscription = entities.NewDigestSubscription(cmd.UserID)
+	} else if err != nil {
+		return nil, err
+	}
+
+	if cmd.Weekly != nil {
+		subscription.SetEnabled(entities.DigestWeekly, *cmd.Weekly)
+	}
+	if cmd.Monthly != nil {
+		subscription.SetEnabled(entities.DigestMonthly, *cmd.Monthly)
+	}
+
+	if err := h.subscriptionRepo.Save(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
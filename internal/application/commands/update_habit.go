@@ -2,9 +2,11 @@ package commands
 
 import (
 	"context"
+	"slices"
 	"strings"
 
 	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/domain/value_objects"
 	"apocapoc-api/internal/shared/errors"
 )
 
@@ -17,15 +19,28 @@ type UpdateHabitCommand struct {
 	TargetValue   *float64
 	SpecificDays  []int
 	SpecificDates []int
+	// Difficulty is left unchanged when empty.
+	Difficulty value_objects.HabitDifficulty
 }
 
 type UpdateHabitHandler struct {
-	habitRepo repositories.HabitRepository
+	habitRepo  repositories.HabitRepository
+	entryRepo  repositories.HabitEntryRepository
+	statsRepo  repositories.HabitStatsRepository
+	transactor repositories.Transactor
 }
 
-func NewUpdateHabitHandler(habitRepo repositories.HabitRepository) *UpdateHabitHandler {
+func NewUpdateHabitHandler(
+	habitRepo repositories.HabitRepository,
+	entryRepo repositories.HabitEntryRepository,
+	statsRepo repositories.HabitStatsRepository,
+	transactor repositories.Transactor,
+) *UpdateHabitHandler {
 	return &UpdateHabitHandler{
-		habitRepo: habitRepo,
+		habitRepo:  habitRepo,
+		entryRepo:  entryRepo,
+		statsRepo:  statsRepo,
+		transactor: transactor,
 	}
 }
 
@@ -47,12 +62,29 @@ func (h *UpdateHabitHandler) Handle(ctx context.Context, cmd UpdateHabitCommand)
 		return errors.ErrInvalidInput
 	}
 
+	if cmd.Difficulty != "" && !cmd.Difficulty.IsValid() {
+		return errors.ErrInvalidInput
+	}
+
+	scheduleChanged := !slices.Equal(habit.SpecificDays, cmd.SpecificDays) || !slices.Equal(habit.SpecificDates, cmd.SpecificDates)
+
 	habit.Name = cmd.Name
 	habit.Description = cmd.Description
 	habit.CarryOver = cmd.CarryOver
 	habit.TargetValue = cmd.TargetValue
 	habit.SpecificDays = cmd.SpecificDays
 	habit.SpecificDates = cmd.SpecificDates
+	if cmd.Difficulty != "" {
+		habit.Difficulty = cmd.Difficulty
+	}
 
-	return h.habitRepo.Update(ctx, habit)
+	return h.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := h.habitRepo.Update(ctx, habit); err != nil {
+			return err
+		}
+		if !scheduleChanged {
+			return nil
+		}
+		return refreshHabitStats(ctx, habit, h.entryRepo, h.statsRepo)
+	})
 }
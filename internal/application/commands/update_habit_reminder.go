@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+// UpdateHabitReminderCommand leaves an empty time or timezone and a nil
+// Enabled unchanged.
+type UpdateHabitReminderCommand struct {
+	ReminderID string
+	HabitID    string
+	UserID     string
+	TimeOfDay  string
+	Timezone   string
+	Enabled    *bool
+}
+
+type UpdateHabitReminderHandler struct {
+	reminderRepo repositories.HabitReminderRepository
+}
+
+func NewUpdateHabitReminderHandler(reminderRepo repositories.HabitReminderRepository) *UpdateHabitReminderHandler {
+	return &UpdateHabitReminderHandler{
+		reminderRepo: reminderRepo,
+	}
+}
+
+func (h *UpdateHabitReminderHandler) Handle(ctx context.Context, cmd UpdateHabitReminderCommand) (*entities.HabitReminder, error) {
+	if cmd.TimeOfDay != "" && !entities.IsValidReminderTime(cmd.TimeOfDay) {
+		return nil, errors.ErrInvalidInput
+	}
+	if cmd.Timezone != "" && !isValidReminderTimezone(cmd.Timezone) {
+		return nil, errors.ErrInvalidInput
+	}
+
+	reminder, err := findOwnedReminder(ctx, h.reminderRepo, cmd.ReminderID, cmd.HabitID, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.TimeOfDay != "" {
+		reminder.TimeOfDay = cmd.TimeOfDay
+	}
+	if cmd.Timezone != "" {
+		reminder.Timezone = cmd.Timezone
+	}
+	if cmd.Enabled != nil {
+		reminder.Enabled = *cmd.Enabled
+	}
+	reminder.UpdatedAt = time.Now()
+
+	if err := h.reminderRepo.Update(ctx, reminder); err != nil {
+		return nil, err
+	}
+
+	return reminder, nil
+}
+
+func findOwnedReminder(ctx context.Context, reminderRepo repositories.HabitReminderRepository, reminderID, habitID, userID string) (*entities.HabitReminder, error) {
+	reminder, err := reminderRepo.FindByID(ctx, reminderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reminder.UserID != userID {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if reminder.HabitID != habitID {
+		return nil, errors.ErrNotFound
+	}
+
+	return reminder, nil
+}
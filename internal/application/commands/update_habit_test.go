@@ -40,7 +40,7 @@ func TestUpdateHabitHandler_UpdatesSuccessfully(t *testing.T) {
 		habitToReturn: habit,
 	}
 
-	handler := NewUpdateHabitHandler(habitRepo)
+	handler := NewUpdateHabitHandler(habitRepo, &mockEntryRepo{}, &mockStatsRepo{}, mockTransactor{})
 
 	newTargetValue := 5.0
 	cmd := UpdateHabitCommand{
@@ -85,7 +85,7 @@ func TestUpdateHabitHandler_ReturnsErrorWhenHabitNotFound(t *testing.T) {
 		errorOnFind: errors.ErrNotFound,
 	}
 
-	handler := NewUpdateHabitHandler(habitRepo)
+	handler := NewUpdateHabitHandler(habitRepo, &mockEntryRepo{}, &mockStatsRepo{}, mockTransactor{})
 
 	cmd := UpdateHabitCommand{
 		HabitID: "non-existent",
@@ -108,7 +108,7 @@ func TestUpdateHabitHandler_ReturnsErrorWhenUserDoesNotOwnHabit(t *testing.T) {
 		habitToReturn: habit,
 	}
 
-	handler := NewUpdateHabitHandler(habitRepo)
+	handler := NewUpdateHabitHandler(habitRepo, &mockEntryRepo{}, &mockStatsRepo{}, mockTransactor{})
 
 	cmd := UpdateHabitCommand{
 		HabitID: "habit-1",
@@ -132,7 +132,7 @@ func TestUpdateHabitHandler_CannotUpdateArchivedHabit(t *testing.T) {
 		habitToReturn: habit,
 	}
 
-	handler := NewUpdateHabitHandler(habitRepo)
+	handler := NewUpdateHabitHandler(habitRepo, &mockEntryRepo{}, &mockStatsRepo{}, mockTransactor{})
 
 	cmd := UpdateHabitCommand{
 		HabitID: "habit-1",
@@ -155,7 +155,7 @@ func TestUpdateHabitHandler_ValidatesInput(t *testing.T) {
 		habitToReturn: habit,
 	}
 
-	handler := NewUpdateHabitHandler(habitRepo)
+	handler := NewUpdateHabitHandler(habitRepo, &mockEntryRepo{}, &mockStatsRepo{}, mockTransactor{})
 
 	cmd := UpdateHabitCommand{
 		HabitID: "habit-1",
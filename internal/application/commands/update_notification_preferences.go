@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+// UpdateNotificationPreferencesCommand turns categories on or off per
+// channel. Categories and channels that are left out are unchanged.
+type UpdateNotificationPreferencesCommand struct {
+	UserID      string
+	Preferences map[entities.NotificationCategory]map[entities.NotificationChannel]bool
+}
+
+type UpdateNotificationPreferencesHandler struct {
+	preferenceRepo repositories.NotificationPreferenceRepository
+	transactor     repositories.Transactor
+}
+
+func NewUpdateNotificationPreferencesHandler(preferenceRepo repositories.NotificationPreferenceRepository, transactor repositories.Transactor) *UpdateNotificationPreferencesHandler {
+	return &UpdateNotificationPreferencesHandler{
+		preferenceRepo: preferenceRepo,
+		transactor:     transactor,
+	}
+}
+
+// Handle rejects unknown categories and channels, and turning off a category
+// that cannot be turned off.
+func (h *UpdateNotificationPreferencesHandler) Handle(ctx context.Context, cmd UpdateNotificationPreferencesCommand) (entities.NotificationPreferences, error) {
+	var updates []*entities.NotificationPreference
+	for category, channels := range cmd.Preferences {
+		for channel, enabled := range channels {
+			if !category.Supports(channel) {
+				return nil, errors.ErrInvalidInput
+			}
+			if category.IsMandatory() && !enabled {
+				return nil, errors.ErrInvalidInput
+			}
+			updates = append(updates, entities.NewNotificationPreference(cmd.UserID, category, channel, enabled))
+		}
+	}
+
+	err := h.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, preference := range updates {
+			if err := h.preferenceRepo.Save(ctx, preference); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return h.preferenceRepo.FindByUserID(ctx, cmd.UserID)
+}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type mockPreferenceRepo struct {
+	preferences entities.NotificationPreferences
+}
+
+func (r *mockPreferenceRepo) FindByUserID(ctx context.Context, userID string) (entities.NotificationPreferences, error) {
+	var found entities.NotificationPreferences
+	for _, preference := range r.preferences {
+		if preference.UserID == userID {
+			found = append(found, preference)
+		}
+	}
+	return found, nil
+}
+
+func (r *mockPreferenceRepo) Save(ctx context.Context, preference *entities.NotificationPreference) error {
+	for i, existing := range r.preferences {
+		if existing.UserID == preference.UserID && existing.Category == preference.Category && existing.Channel == preference.Channel {
+			r.preferences[i] = preference
+			return nil
+		}
+	}
+	r.preferences = append(r.preferences, preference)
+	return nil
+}
+
+func TestUpdateNotificationPreferencesHandler(t *testing.T) {
+	repo := &mockPreferenceRepo{}
+	handler := NewUpdateNotificationPreferencesHandler(repo, mockTransactor{})
+	ctx := context.Background()
+
+	preferences, err := handler.Handle(ctx, UpdateNotificationPreferencesCommand{
+		UserID: "user-123",
+		Preferences: map[entities.NotificationCategory]map[entities.NotificationChannel]bool{
+			entities.NotificationReminders: {entities.NotificationPush: false},
+			entities.NotificationDigests:   {entities.NotificationEmail: false},
+			entities.NotificationSecurity:  {entities.NotificationEmail: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Handle() unexpected error = %v", err)
+	}
+
+	if preferences.IsEnabled(entities.NotificationReminders, entities.NotificationPush) {
+		t.Error("Expected push reminders to be off")
+	}
+	if !preferences.IsEnabled(entities.NotificationReminders, entities.NotificationEmail) {
+		t.Error("Expected email reminders to be left on")
+	}
+	if preferences.IsEnabled(entities.NotificationDigests, entities.NotificationEmail) {
+		t.Error("Expected digest emails to be off")
+	}
+}
+
+func TestUpdateNotificationPreferencesHandler_RejectsInvalidPreferences(t *testing.T) {
+	tests := []struct {
+		name        string
+		preferences map[entities.NotificationCategory]map[entities.NotificationChannel]bool
+	}{
+		{"Unknown category", map[entities.NotificationCategory]map[entities.NotificationChannel]bool{"marketing": {entities.NotificationEmail: false}}},
+		{"Unsupported channel", map[entities.NotificationCategory]map[entities.NotificationChannel]bool{entities.NotificationDigests: {entities.NotificationPush: false}}},
+		{"Security turned off", map[entities.NotificationCategory]map[entities.NotificationChannel]bool{entities.NotificationSecurity: {entities.NotificationEmail: false}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockPreferenceRepo{}
+			handler := NewUpdateNotificationPreferencesHandler(repo, mockTransactor{})
+
+			_, err := handler.Handle(context.Background(), UpdateNotificationPreferencesCommand{UserID: "user-123", Preferences: tt.preferences})
+			if err != errors.ErrInvalidInput {
+				t.Errorf("Expected ErrInvalidInput, got %v", err)
+			}
+			if len(repo.preferences) != 0 {
+				t.Errorf("Expected nothing to be saved, got %d preferences", len(repo.preferences))
+			}
+		})
+	}
+}
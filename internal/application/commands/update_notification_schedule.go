@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+// UpdateNotificationScheduleCommand replaces the user's quiet hours and
+// hourly limit. Empty quiet hours turn them off, an empty timezone means UTC
+// and a MaxPerHour of 0 means no limit.
+type UpdateNotificationScheduleCommand struct {
+	UserID          string
+	QuietHoursStart string
+	QuietHoursEnd   string
+	Timezone        string
+	MaxPerHour      int
+}
+
+type UpdateNotificationScheduleHandler struct {
+	scheduleRepo repositories.NotificationScheduleRepository
+}
+
+func NewUpdateNotificationScheduleHandler(scheduleRepo repositories.NotificationScheduleRepository) *UpdateNotificationScheduleHandler {
+	return &UpdateNotificationScheduleHandler{
+		scheduleRepo: scheduleRepo,
+	}
+}
+
+func (h *UpdateNotificationScheduleHandler) Handle(ctx context.Context, cmd UpdateNotificationScheduleCommand) (*entities.NotificationSchedule, error) {
+	if (cmd.QuietHoursStart == "") != (cmd.QuietHoursEnd == "") {
+		return nil, errors.ErrInvalidInput
+	}
+	if cmd.QuietHoursStart != "" && (!entities.IsValidReminderTime(cmd.QuietHoursStart) || !entities.IsValidReminderTime(cmd.QuietHoursEnd)) {
+		return nil, errors.ErrInvalidInput
+	}
+	if cmd.MaxPerHour < 0 {
+		return nil, errors.ErrInvalidInput
+	}
+
+	timezone := cmd.Timezone
+	if timezone == "" {
+		timezone = entities.DefaultNotificationTimezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, errors.ErrInvalidInput
+	}
+
+	schedule := entities.NewNotificationSchedule(cmd.UserID)
+	schedule.QuietHoursStart = cmd.QuietHoursStart
+	schedule.QuietHoursEnd = cmd.QuietHoursEnd
+	schedule.Timezone = timezone
+	schedule.MaxPerHour = cmd.MaxPerHour
+
+	if err := h.scheduleRepo.Save(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type mockScheduleRepo struct {
+	schedules map[string]*entities.NotificationSchedule
+}
+
+func (r *mockScheduleRepo) FindByUserID(ctx context.Context, userID string) (*entities.NotificationSchedule, error) {
+	schedule, ok := r.schedules[userID]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	return schedule, nil
+}
+
+func (r *mockScheduleRepo) Save(ctx context.Context, schedule *entities.NotificationSchedule) error {
+	r.schedules[schedule.UserID] = schedule
+	return nil
+}
+
+func TestUpdateNotificationScheduleHandler(t *testing.T) {
+	repo := &mockScheduleRepo{schedules: map[string]*entities.NotificationSchedule{}}
+	handler := NewUpdateNotificationScheduleHandler(repo)
+	ctx := context.Background()
+
+	schedule, err := handler.Handle(ctx, UpdateNotificationScheduleCommand{
+		UserID:          "user-123",
+		QuietHoursStart: "22:00",
+		QuietHoursEnd:   "07:30",
+		Timezone:        "America/Mexico_City",
+		MaxPerHour:      4,
+	})
+	if err != nil {
+		t.Fatalf("Handle() unexpected error = %v", err)
+	}
+	if repo.schedules["user-123"] != schedule || schedule.Timezone != "America/Mexico_City" || schedule.MaxPerHour != 4 {
+		t.Errorf("Unexpected saved schedule: %+v", repo.schedules["user-123"])
+	}
+
+	schedule, err = handler.Handle(ctx, UpdateNotificationScheduleCommand{UserID: "user-123"})
+	if err != nil {
+		t.Fatalf("Handle() unexpected error = %v", err)
+	}
+	if schedule.HasQuietHours() || schedule.Timezone != entities.DefaultNotificationTimezone || schedule.MaxPerHour != 0 {
+		t.Errorf("Expected an empty command to clear the schedule, got %+v", schedule)
+	}
+}
+
+func TestUpdateNotificationScheduleHandler_RejectsInvalidSchedules(t *testing.T) {
+	handler := NewUpdateNotificationScheduleHandler(&mockScheduleRepo{schedules: map[string]*entities.NotificationSchedule{}})
+
+	tests := []struct {
+		name string
+		cmd  UpdateNotificationScheduleCommand
+	}{
+		{"Start without end", UpdateNotificationScheduleCommand{QuietHoursStart: "22:00"}},
+		{"Invalid time", UpdateNotificationScheduleCommand{QuietHoursStart: "25:00", QuietHoursEnd: "07:00"}},
+		{"Unknown timezone", UpdateNotificationScheduleCommand{Timezone: "Mars/Olympus_Mons"}},
+		{"Negative limit", UpdateNotificationScheduleCommand{MaxPerHour: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cmd.UserID = "user-123"
+			if _, err := handler.Handle(context.Background(), tt.cmd); err != errors.ErrInvalidInput {
+				t.Errorf("Expected ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
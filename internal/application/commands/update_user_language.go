@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+// UpdateUserLanguageCommand sets the language the user's emails are sent in.
+type UpdateUserLanguageCommand struct {
+	UserID   string
+	Language string
+}
+
+type UpdateUserLanguageHandler struct {
+	userRepo repositories.UserRepository
+}
+
+func NewUpdateUserLanguageHandler(userRepo repositories.UserRepository) *UpdateUserLanguageHandler {
+	return &UpdateUserLanguageHandler{
+		userRepo: userRepo,
+	}
+}
+
+func (h *UpdateUserLanguageHandler) Handle(ctx context.Context, cmd UpdateUserLanguageCommand) (*entities.User, error) {
+	if cmd.Language == "" {
+		return nil, errors.ErrInvalidInput
+	}
+
+	user, err := h.userRepo.FindByID(ctx, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Language = cmd.Language
+	user.UpdatedAt = time.Now()
+
+	if err := h.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type languageUserRepo struct {
+	mockUserRepo
+	user    *entities.User
+	updated *entities.User
+}
+
+func (r *languageUserRepo) FindByID(ctx context.Context, id string) (*entities.User, error) {
+	if r.user == nil || r.user.ID != id {
+		return nil, errors.ErrNotFound
+	}
+	return r.user, nil
+}
+
+func (r *languageUserRepo) Update(ctx context.Context, user *entities.User) error {
+	r.updated = user
+	return nil
+}
+
+func TestUpdateUserLanguageHandler(t *testing.T) {
+	user := entities.NewUser("test@example.com", "hash")
+	user.ID = "user-123"
+	repo := &languageUserRepo{user: user}
+	handler := NewUpdateUserLanguageHandler(repo)
+
+	updated, err := handler.Handle(context.Background(), UpdateUserLanguageCommand{UserID: user.ID, Language: "es"})
+	if err != nil {
+		t.Fatalf("Handle() unexpected error = %v", err)
+	}
+	if updated.Language != "es" || repo.updated == nil || repo.updated.Language != "es" {
+		t.Errorf("Expected the language to be saved, got %+v", repo.updated)
+	}
+
+	if _, err := handler.Handle(context.Background(), UpdateUserLanguageCommand{UserID: user.ID}); err != errors.ErrInvalidInput {
+		t.Errorf("Expected ErrInvalidInput for an empty language, got %v", err)
+	}
+	if _, err := handler.Handle(context.Background(), UpdateUserLanguageCommand{UserID: "missing", Language: "es"}); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound for an unknown user, got %v", err)
+	}
+}
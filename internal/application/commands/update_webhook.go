@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+// UpdateWebhookCommand leaves an empty URL, nil Events and a nil Enabled
+// unchanged. Enabling a webhook that was disabled after failing clears its
+// failure count.
+type UpdateWebhookCommand struct {
+	WebhookID string
+	UserID    string
+	URL       string
+	Events    []string
+	Enabled   *bool
+}
+
+type UpdateWebhookHandler struct {
+	webhookRepo          repositories.WebhookRepository
+	allowPrivateNetworks bool
+}
+
+func NewUpdateWebhookHandler(webhookRepo repositories.WebhookRepository, allowPrivateNetworks bool) *UpdateWebhookHandler {
+	return &UpdateWebhookHandler{
+		webhookRepo:          webhookRepo,
+		allowPrivateNetworks: allowPrivateNetworks,
+	}
+}
+
+func (h *UpdateWebhookHandler) Handle(ctx context.Context, cmd UpdateWebhookCommand) (*entities.Webhook, error) {
+	if cmd.URL != "" && !entities.IsValidWebhookURL(cmd.URL, h.allowPrivateNetworks) {
+		return nil, errors.ErrInvalidInput
+	}
+
+	var events []entities.EventType
+	if cmd.Events != nil {
+		var ok bool
+		if events, ok = parseWebhookEvents(cmd.Events); !ok {
+			return nil, errors.ErrInvalidInput
+		}
+	}
+
+	webhook, err := findOwnedWebhook(ctx, h.webhookRepo, cmd.WebhookID, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.URL != "" {
+		webhook.URL = cmd.URL
+	}
+	if events != nil {
+		webhook.Events = events
+	}
+	if cmd.Enabled != nil && *cmd.Enabled != webhook.Enabled {
+		if *cmd.Enabled {
+			webhook.Enable()
+		} else {
+			webhook.Disable()
+		}
+	}
+	webhook.UpdatedAt = time.Now()
+
+	if err := h.webhookRepo.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func findOwnedWebhook(ctx context.Context, webhookRepo repositories.WebhookRepository, webhookID, userID string) (*entities.Webhook, error) {
+	webhook, err := webhookRepo.FindByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook.UserID != userID {
+		return nil, errors.ErrUnauthorized
+	}
+
+	return webhook, nil
+}
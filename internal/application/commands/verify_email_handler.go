@@ -16,20 +16,14 @@ type VerifyEmailCommand struct {
 }
 
 type VerifyEmailHandler struct {
-	userRepo         repositories.UserRepository
-	emailService     services.EmailService
-	sendWelcomeEmail bool
+	userRepo repositories.UserRepository
+	events   services.EventPublisher
 }
 
-func NewVerifyEmailHandler(
-	userRepo repositories.UserRepository,
-	emailService services.EmailService,
-	sendWelcomeEmail bool,
-) *VerifyEmailHandler {
+func NewVerifyEmailHandler(userRepo repositories.UserRepository, events services.EventPublisher) *VerifyEmailHandler {
 	return &VerifyEmailHandler{
-		userRepo:         userRepo,
-		emailService:     emailService,
-		sendWelcomeEmail: sendWelcomeEmail,
+		userRepo: userRepo,
+		events:   events,
 	}
 }
 
@@ -60,27 +54,7 @@ func (h *VerifyEmailHandler) Handle(ctx context.Context, cmd VerifyEmailCommand)
 		return fmt.Errorf("failed to verify email: %w", err)
 	}
 
-	if h.sendWelcomeEmail && h.emailService != nil {
-		h.sendWelcomeEmailToUser(user)
-	}
+	publishEvents(ctx, h.events, newUserEvent(entities.EventUserEmailVerified, user))
 
 	return nil
 }
-
-func (h *VerifyEmailHandler) sendWelcomeEmailToUser(user *entities.User) error {
-	emailBody := fmt.Sprintf(`
-		<h2>Welcome to Apocapoc!</h2>
-		<p>Your email has been successfully verified.</p>
-		<p>You can now start tracking your habits and building better routines.</p>
-		<p>If you have any questions or need help, please don't hesitate to contact us.</p>
-	`)
-
-	message := services.EmailMessage{
-		To:      user.Email,
-		Subject: "Welcome to Apocapoc!",
-		Body:    emailBody,
-		IsHTML:  true,
-	}
-
-	return h.emailService.Send(message)
-}
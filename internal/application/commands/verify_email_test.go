@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"apocapoc-api/internal/domain/entities"
-	"apocapoc-api/internal/domain/services"
 	"apocapoc-api/internal/shared/errors"
 )
 
@@ -52,23 +51,6 @@ func (m *mockVerifyEmailUserRepo) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-type mockEmailService struct {
-	sendFunc     func(message services.EmailMessage) error
-	sentMessages []services.EmailMessage
-}
-
-func (m *mockEmailService) Send(message services.EmailMessage) error {
-	m.sentMessages = append(m.sentMessages, message)
-	if m.sendFunc != nil {
-		return m.sendFunc(message)
-	}
-	return nil
-}
-
-func (m *mockEmailService) HealthCheck() error {
-	return nil
-}
-
 func TestVerifyEmailHandler_Success(t *testing.T) {
 	token := "valid-token"
 	expiry := time.Now().Add(24 * time.Hour)
@@ -90,7 +72,7 @@ func TestVerifyEmailHandler_Success(t *testing.T) {
 		},
 	}
 
-	handler := NewVerifyEmailHandler(repo, nil, false)
+	handler := NewVerifyEmailHandler(repo, nil)
 
 	cmd := VerifyEmailCommand{
 		Token: token,
@@ -122,7 +104,7 @@ func TestVerifyEmailHandler_EmptyToken(t *testing.T) {
 	repo := &mockVerifyEmailUserRepo{
 		users: make(map[string]*entities.User),
 	}
-	handler := NewVerifyEmailHandler(repo, nil, false)
+	handler := NewVerifyEmailHandler(repo, nil)
 
 	cmd := VerifyEmailCommand{
 		Token: "",
@@ -138,7 +120,7 @@ func TestVerifyEmailHandler_TokenNotFound(t *testing.T) {
 	repo := &mockVerifyEmailUserRepo{
 		users: make(map[string]*entities.User),
 	}
-	handler := NewVerifyEmailHandler(repo, nil, false)
+	handler := NewVerifyEmailHandler(repo, nil)
 
 	cmd := VerifyEmailCommand{
 		Token: "non-existent-token",
@@ -166,7 +148,7 @@ func TestVerifyEmailHandler_AlreadyVerified(t *testing.T) {
 		},
 	}
 
-	handler := NewVerifyEmailHandler(repo, nil, false)
+	handler := NewVerifyEmailHandler(repo, nil)
 
 	cmd := VerifyEmailCommand{
 		Token: token,
@@ -194,7 +176,7 @@ func TestVerifyEmailHandler_ExpiredToken(t *testing.T) {
 		},
 	}
 
-	handler := NewVerifyEmailHandler(repo, nil, false)
+	handler := NewVerifyEmailHandler(repo, nil)
 
 	cmd := VerifyEmailCommand{
 		Token: token,
@@ -221,7 +203,7 @@ func TestVerifyEmailHandler_NilExpiry(t *testing.T) {
 		},
 	}
 
-	handler := NewVerifyEmailHandler(repo, nil, false)
+	handler := NewVerifyEmailHandler(repo, nil)
 
 	cmd := VerifyEmailCommand{
 		Token: token,
@@ -233,7 +215,7 @@ func TestVerifyEmailHandler_NilExpiry(t *testing.T) {
 	}
 }
 
-func TestVerifyEmailHandler_WithWelcomeEmail(t *testing.T) {
+func TestVerifyEmailHandler_PublishesEmailVerified(t *testing.T) {
 	token := "valid-token"
 	expiry := time.Now().Add(24 * time.Hour)
 
@@ -249,8 +231,8 @@ func TestVerifyEmailHandler_WithWelcomeEmail(t *testing.T) {
 		},
 	}
 
-	emailService := &mockEmailService{}
-	handler := NewVerifyEmailHandler(repo, emailService, true)
+	events := &mockEventPublisher{}
+	handler := NewVerifyEmailHandler(repo, events)
 
 	cmd := VerifyEmailCommand{
 		Token: token,
@@ -261,54 +243,13 @@ func TestVerifyEmailHandler_WithWelcomeEmail(t *testing.T) {
 		t.Fatalf("Handle() unexpected error = %v", err)
 	}
 
-	if len(emailService.sentMessages) != 1 {
-		t.Fatalf("Expected 1 email sent, got %d", len(emailService.sentMessages))
-	}
-
-	sentEmail := emailService.sentMessages[0]
-	if sentEmail.To != "test@example.com" {
-		t.Errorf("Email To = %v, want %v", sentEmail.To, "test@example.com")
-	}
-
-	if sentEmail.Subject != "Welcome to Apocapoc!" {
-		t.Errorf("Email Subject = %v, want %v", sentEmail.Subject, "Welcome to Apocapoc!")
-	}
-
-	if !sentEmail.IsHTML {
-		t.Error("Email should be HTML")
-	}
-}
-
-func TestVerifyEmailHandler_WithoutWelcomeEmail(t *testing.T) {
-	token := "valid-token"
-	expiry := time.Now().Add(24 * time.Hour)
-
-	user := entities.NewUser("test@example.com", "hashedPassword")
-	user.ID = "user-123"
-	user.EmailVerified = false
-	user.EmailVerificationToken = &token
-	user.EmailVerificationExpiry = &expiry
-
-	repo := &mockVerifyEmailUserRepo{
-		users: map[string]*entities.User{
-			token: user,
-		},
-	}
-
-	emailService := &mockEmailService{}
-	handler := NewVerifyEmailHandler(repo, emailService, false)
-
-	cmd := VerifyEmailCommand{
-		Token: token,
-	}
-
-	err := handler.Handle(context.Background(), cmd)
-	if err != nil {
-		t.Fatalf("Handle() unexpected error = %v", err)
+	if len(events.published) != 1 {
+		t.Fatalf("Expected 1 event published, got %d", len(events.published))
 	}
 
-	if len(emailService.sentMessages) != 0 {
-		t.Errorf("Expected 0 emails sent, got %d", len(emailService.sentMessages))
+	event := events.published[0]
+	if event.Type != entities.EventUserEmailVerified || event.UserID != "user-123" {
+		t.Errorf("Unexpected event: %+v", event)
 	}
 }
 
@@ -331,7 +272,7 @@ func TestVerifyEmailHandler_UpdateError(t *testing.T) {
 		},
 	}
 
-	handler := NewVerifyEmailHandler(repo, nil, false)
+	handler := NewVerifyEmailHandler(repo, nil)
 
 	cmd := VerifyEmailCommand{
 		Token: token,
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type webhookPayload struct {
+	ID        string                 `json:"id"`
+	Type      entities.EventType     `json:"type"`
+	CreatedAt time.Time              `json:"created_at"`
+	Data      map[string]interface{} `json:"data"`
+}
+
+// WebhookEventHandler is an event subscriber that stores a delivery of the
+// event for each enabled webhook of the user subscribed to it. The deliveries
+// are sent in the background.
+type WebhookEventHandler struct {
+	webhookRepo  repositories.WebhookRepository
+	deliveryRepo repositories.WebhookDeliveryRepository
+}
+
+func NewWebhookEventHandler(
+	webhookRepo repositories.WebhookRepository,
+	deliveryRepo repositories.WebhookDeliveryRepository,
+) *WebhookEventHandler {
+	return &WebhookEventHandler{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+	}
+}
+
+func (p *WebhookEventHandler) Handle(ctx context.Context, event *entities.Event) error {
+	webhooks, err := p.webhookRepo.FindByUserID(ctx, event.UserID)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(webhookPayload{
+		ID:        event.ID,
+		Type:      event.Type,
+		CreatedAt: event.OccurredAt.UTC(),
+		Data:      event.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		if !webhook.Enabled || !webhook.IsSubscribedTo(event.Type) {
+			continue
+		}
+
+		err := p.deliveryRepo.Create(ctx, entities.NewWebhookDelivery(webhook.ID, event, string(payload)))
+		if err != nil && err != errors.ErrAlreadyExists {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type mockWebhookRepo struct {
+	webhooks []*entities.Webhook
+}
+
+func (m *mockWebhookRepo) Create(ctx context.Context, webhook *entities.Webhook) error {
+	m.webhooks = append(m.webhooks, webhook)
+	return nil
+}
+
+func (m *mockWebhookRepo) FindByID(ctx context.Context, id string) (*entities.Webhook, error) {
+	for _, webhook := range m.webhooks {
+		if webhook.ID == id {
+			return webhook, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (m *mockWebhookRepo) FindByUserID(ctx context.Context, userID string) ([]*entities.Webhook, error) {
+	var result []*entities.Webhook
+	for _, webhook := range m.webhooks {
+		if webhook.UserID == userID {
+			result = append(result, webhook)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockWebhookRepo) FindEnabled(ctx context.Context) ([]*entities.Webhook, error) {
+	var result []*entities.Webhook
+	for _, webhook := range m.webhooks {
+		if webhook.Enabled {
+			result = append(result, webhook)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockWebhookRepo) CountByUserID(ctx context.Context, userID string) (int, error) {
+	webhooks, _ := m.FindByUserID(ctx, userID)
+	return len(webhooks), nil
+}
+
+func (m *mockWebhookRepo) Update(ctx context.Context, webhook *entities.Webhook) error {
+	return nil
+}
+
+func (m *mockWebhookRepo) Delete(ctx context.Context, id string) error {
+	return nil
+}
+
+type mockWebhookDeliveryRepo struct {
+	deliveries []*entities.WebhookDelivery
+}
+
+func (m *mockWebhookDeliveryRepo) Create(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	for _, existing := range m.deliveries {
+		if existing.WebhookID == delivery.WebhookID && existing.EventID == delivery.EventID {
+			return errors.ErrAlreadyExists
+		}
+	}
+	m.deliveries = append(m.deliveries, delivery)
+	return nil
+}
+
+func (m *mockWebhookDeliveryRepo) FindByID(ctx context.Context, id string) (*entities.WebhookDelivery, error) {
+	for _, delivery := range m.deliveries {
+		if delivery.ID == id {
+			return delivery, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (m *mockWebhookDeliveryRepo) FindByWebhookID(ctx context.Context, webhookID string, limit int) ([]*entities.WebhookDelivery, error) {
+	return nil, nil
+}
+
+func (m *mockWebhookDeliveryRepo) FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.WebhookDelivery, error) {
+	return nil, nil
+}
+
+func (m *mockWebhookDeliveryRepo) Update(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	return nil
+}
+
+func newTestWebhook(id, userID string, events ...entities.EventType) *entities.Webhook {
+	webhook := entities.NewWebhook(userID, "https://example.com/"+id, "secret", events)
+	webhook.ID = id
+	return webhook
+}
+
+func TestWebhookEventHandler_Handle(t *testing.T) {
+	disabled := newTestWebhook("disabled", "user-123", entities.EventHabitMarked)
+	disabled.Disable()
+
+	webhookRepo := &mockWebhookRepo{webhooks: []*entities.Webhook{
+		newTestWebhook("marked", "user-123", entities.EventHabitMarked),
+		newTestWebhook("created", "user-123", entities.EventHabitCreated),
+		newTestWebhook("other-user", "user-456", entities.EventHabitMarked),
+		disabled,
+	}}
+	deliveryRepo := &mockWebhookDeliveryRepo{}
+	handler := NewWebhookEventHandler(webhookRepo, deliveryRepo)
+
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+	event := newHabitEntryEvent(entities.EventHabitMarked, habit, time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), nil)
+
+	for i := 0; i < 2; i++ {
+		if err := handler.Handle(context.Background(), event); err != nil {
+			t.Fatalf("Handle failed: %v", err)
+		}
+	}
+
+	if len(deliveryRepo.deliveries) != 1 || deliveryRepo.deliveries[0].WebhookID != "marked" {
+		t.Fatalf("Expected a single delivery to the subscribed webhook, got %+v", deliveryRepo.deliveries)
+	}
+
+	var payload struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+		Data struct {
+			Habit struct {
+				ID string `json:"id"`
+			} `json:"habit"`
+			ScheduledDate string `json:"scheduled_date"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(deliveryRepo.deliveries[0].Payload), &payload); err != nil {
+		t.Fatalf("Invalid payload: %v", err)
+	}
+	if payload.ID != event.ID || payload.Type != "habit.marked" || payload.Data.Habit.ID != "habit-1" || payload.Data.ScheduledDate != "2025-01-15" {
+		t.Errorf("Unexpected payload: %s", deliveryRepo.deliveries[0].Payload)
+	}
+}
+
+func TestDetectBrokenStreaksHandler(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+	habit.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	lastCompleted := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	statsRepo := &mockStatsRepo{saved: &entities.HabitStats{HabitID: habit.ID, CurrentStreak: 4, LastCompletedDate: &lastCompleted}}
+	webhookRepo := &mockWebhookRepo{webhooks: []*entities.Webhook{newTestWebhook("streaks", "user-123", entities.EventStreakBroken)}}
+	events := &mockEventPublisher{}
+
+	handler := NewDetectBrokenStreaksHandler(webhookRepo, &mockActiveHabitRepo{habits: []*entities.Habit{habit}}, statsRepo, events)
+
+	tests := []struct {
+		name     string
+		today    time.Time
+		expected int
+	}{
+		{"Today still pending", lastCompleted.AddDate(0, 0, 1), 0},
+		{"Missed yesterday", lastCompleted.AddDate(0, 0, 2), 1},
+		{"Broken too long ago", lastCompleted.AddDate(0, 0, 10), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			published, err := handler.Handle(context.Background(), tt.today)
+			if err != nil {
+				t.Fatalf("Handle failed: %v", err)
+			}
+			if published != tt.expected {
+				t.Errorf("Expected %d broken streaks, got %d", tt.expected, published)
+			}
+		})
+	}
+
+	if _, err := handler.Handle(context.Background(), lastCompleted.AddDate(0, 0, 3)); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+	if len(events.published) != 2 || events.published[0].ID != events.published[1].ID {
+		t.Errorf("Expected the same broken streak to keep its event ID, got %+v", events.published)
+	}
+}
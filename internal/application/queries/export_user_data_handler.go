@@ -9,18 +9,19 @@ import (
 )
 
 type ExportHabitDTO struct {
-	ID            string                  `json:"id"`
-	Name          string                  `json:"name"`
-	Description   string                  `json:"description"`
-	Type          value_objects.HabitType `json:"type"`
-	Frequency     value_objects.Frequency `json:"frequency"`
-	SpecificDays  []int                   `json:"specific_days,omitempty"`
-	SpecificDates []int                   `json:"specific_dates,omitempty"`
-	CarryOver     bool                    `json:"carry_over"`
-	IsNegative    bool                    `json:"is_negative"`
-	TargetValue   *float64                `json:"target_value,omitempty"`
-	CreatedAt     time.Time               `json:"created_at"`
-	ArchivedAt    *time.Time              `json:"archived_at,omitempty"`
+	ID            string                        `json:"id"`
+	Name          string                        `json:"name"`
+	Description   string                        `json:"description"`
+	Type          value_objects.HabitType       `json:"type"`
+	Frequency     value_objects.Frequency       `json:"frequency"`
+	SpecificDays  []int                         `json:"specific_days,omitempty"`
+	SpecificDates []int                         `json:"specific_dates,omitempty"`
+	CarryOver     bool                          `json:"carry_over"`
+	IsNegative    bool                          `json:"is_negative"`
+	TargetValue   *float64                      `json:"target_value,omitempty"`
+	Difficulty    value_objects.HabitDifficulty `json:"difficulty"`
+	CreatedAt     time.Time                     `json:"created_at"`
+	ArchivedAt    *time.Time                    `json:"archived_at,omitempty"`
 }
 
 type ExportEntryDTO struct {
@@ -80,6 +81,7 @@ func (h *ExportUserDataHandler) Handle(ctx context.Context, query ExportUserData
 			CarryOver:     habit.CarryOver,
 			IsNegative:    habit.IsNegative,
 			TargetValue:   habit.TargetValue,
+			Difficulty:    habit.Difficulty,
 			CreatedAt:     habit.CreatedAt,
 			ArchivedAt:    habit.ArchivedAt,
 		})
@@ -0,0 +1,200 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type WeekdayCompletionDTO struct {
+	Weekday        string  `json:"weekday"`
+	Scheduled      int     `json:"scheduled"`
+	Completed      int     `json:"completed"`
+	CompletionRate float64 `json:"completion_rate"`
+}
+
+type HourCompletionDTO struct {
+	Hour        int     `json:"hour"`
+	Completions int     `json:"completions"`
+	Share       float64 `json:"share"`
+}
+
+type BehaviourInsightsDTO struct {
+	HabitID        string                 `json:"habit_id,omitempty"`
+	From           time.Time              `json:"from"`
+	To             time.Time              `json:"to"`
+	Timezone       string                 `json:"timezone"`
+	Weekdays       []WeekdayCompletionDTO `json:"weekdays"`
+	WeakestWeekday *string                `json:"weakest_weekday,omitempty"`
+	Hours          []HourCompletionDTO    `json:"hours"`
+	PeakHour       *int                   `json:"peak_hour,omitempty"`
+}
+
+// GetBehaviourInsightsQuery covers every habit of the user unless HabitID is
+// set. Completion times are reported in Location.
+type GetBehaviourInsightsQuery struct {
+	UserID    string
+	HabitID   string
+	From      time.Time
+	To        time.Time
+	Location  *time.Location
+	WeekStart time.Weekday
+}
+
+type GetBehaviourInsightsHandler struct {
+	habitRepo repositories.HabitRepository
+	entryRepo repositories.HabitEntryRepository
+}
+
+func NewGetBehaviourInsightsHandler(
+	habitRepo repositories.HabitRepository,
+	entryRepo repositories.HabitEntryRepository,
+) *GetBehaviourInsightsHandler {
+	return &GetBehaviourInsightsHandler{
+		habitRepo: habitRepo,
+		entryRepo: entryRepo,
+	}
+}
+
+func (h *GetBehaviourInsightsHandler) Handle(ctx context.Context, query GetBehaviourInsightsQuery) (*BehaviourInsightsDTO, error) {
+	from := toDate(query.From)
+	to := toDate(query.To)
+
+	if to.Before(from) || int(to.Sub(from).Hours()/24) >= maxInsightsDays {
+		return nil, errors.ErrInvalidInput
+	}
+
+	loc := query.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	var (
+		habits  []*entities.Habit
+		entries []*entities.HabitEntry
+		err     error
+	)
+
+	if query.HabitID != "" {
+		habit, err := h.habitRepo.FindByID(ctx, query.HabitID)
+		if err != nil {
+			return nil, err
+		}
+		if habit.UserID != query.UserID {
+			return nil, errors.ErrUnauthorized
+		}
+		habits = []*entities.Habit{habit}
+		entries, err = h.entryRepo.FindByHabitIDAndDateRange(ctx, habit.ID, from, to)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		habits, err = h.habitRepo.FindByUserID(ctx, query.UserID)
+		if err != nil {
+			return nil, err
+		}
+		entries, err = h.entryRepo.FindByUserIDAndDateRange(ctx, query.UserID, from, to)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	completed := indexEntriesByHabitAndDate(entries)
+	var weekdays [7]completionCounter
+
+	for _, habit := range habits {
+		start := maxDate(from, toDate(habit.CreatedAt.In(loc)))
+		end := to
+		if habit.ArchivedAt != nil {
+			end = minDate(end, toDate(habit.ArchivedAt.In(loc)).AddDate(0, 0, -1))
+		}
+
+		done := completed[habit.ID]
+		for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+			weekdays[date.Weekday()].add(habit.IsScheduledOn(date), done[date.Format("2006-01-02")])
+		}
+	}
+
+	result := &BehaviourInsightsDTO{
+		HabitID:  query.HabitID,
+		From:     from,
+		To:       to,
+		Timezone: loc.String(),
+		Weekdays: make([]WeekdayCompletionDTO, 0, 7),
+		Hours:    completionHours(entries, loc),
+	}
+
+	var weakest *WeekdayCompletionDTO
+	scheduledWeekdays := 0
+
+	for i := 0; i < 7; i++ {
+		weekday := (query.WeekStart + time.Weekday(i)) % 7
+		counter := weekdays[weekday]
+
+		result.Weekdays = append(result.Weekdays, WeekdayCompletionDTO{
+			Weekday:        weekday.String(),
+			Scheduled:      counter.scheduled,
+			Completed:      counter.completed,
+			CompletionRate: counter.rate(),
+		})
+
+		if counter.scheduled == 0 {
+			continue
+		}
+		scheduledWeekdays++
+
+		current := &result.Weekdays[len(result.Weekdays)-1]
+		if weakest == nil || current.CompletionRate < weakest.CompletionRate {
+			weakest = current
+		}
+	}
+
+	if weakest != nil && scheduledWeekdays > 1 {
+		result.WeakestWeekday = &weakest.Weekday
+	}
+
+	for i := range result.Hours {
+		if result.Hours[i].Completions == 0 {
+			continue
+		}
+		if result.PeakHour == nil || result.Hours[i].Completions > result.Hours[*result.PeakHour].Completions {
+			hour := result.Hours[i].Hour
+			result.PeakHour = &hour
+		}
+	}
+
+	return result, nil
+}
+
+// completionHours distributes completions over the hours of the day in loc.
+// Entries logged on a different day than the one they were scheduled for are
+// backfills and would skew the distribution, so they are left out.
+func completionHours(entries []*entities.HabitEntry, loc *time.Location) []HourCompletionDTO {
+	hours := make([]HourCompletionDTO, 24)
+	for i := range hours {
+		hours[i].Hour = i
+	}
+
+	total := 0
+	for _, entry := range entries {
+		completedAt := entry.CompletedAt.In(loc)
+		if !toDate(completedAt).Equal(toDate(entry.ScheduledDate)) {
+			continue
+		}
+		hours[completedAt.Hour()].Completions++
+		total++
+	}
+
+	if total == 0 {
+		return hours
+	}
+
+	for i := range hours {
+		hours[i].Share = float64(hours[i].Completions) / float64(total) * 100
+	}
+
+	return hours
+}
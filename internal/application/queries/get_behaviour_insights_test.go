@@ -0,0 +1,134 @@
+package queries
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/shared/errors"
+)
+
+func TestGetBehaviourInsightsHandler_WeekdaysAndHours(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+	habit.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	loc, err := time.LoadLocation("Europe/Madrid")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	// Two weeks from Monday 2025-01-06, done every day except Fridays.
+	var entries []*entities.HabitEntry
+	from := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 19, 0, 0, 0, 0, time.UTC)
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		if date.Weekday() == time.Friday {
+			continue
+		}
+		entry := entities.NewHabitEntry(habit.ID, date, nil)
+		entry.CompletedAt = time.Date(date.Year(), date.Month(), date.Day(), 6, 30, 0, 0, time.UTC)
+		entries = append(entries, entry)
+	}
+
+	backfilled := entities.NewHabitEntry(habit.ID, time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), nil)
+	backfilled.CompletedAt = time.Date(2025, 1, 12, 22, 0, 0, 0, time.UTC)
+	entries = append(entries, backfilled)
+
+	handler := NewGetBehaviourInsightsHandler(
+		&mockHeatmapHabitRepo{mockHabitRepo{habits: []*entities.Habit{habit}}},
+		&mockEntryRepo{entries: entries},
+	)
+
+	insights, err := handler.Handle(context.Background(), GetBehaviourInsightsQuery{
+		UserID:    "user-123",
+		HabitID:   habit.ID,
+		From:      from,
+		To:        to,
+		Location:  loc,
+		WeekStart: time.Monday,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(insights.Weekdays) != 7 || insights.Weekdays[0].Weekday != "Monday" {
+		t.Fatalf("Expected 7 weekdays starting on Monday, got %+v", insights.Weekdays)
+	}
+
+	friday := insights.Weekdays[4]
+	if friday.Scheduled != 2 || friday.Completed != 1 || friday.CompletionRate != 50 {
+		t.Errorf("Expected Friday to be 1 of 2, got %+v", friday)
+	}
+	if insights.WeakestWeekday == nil || *insights.WeakestWeekday != "Friday" {
+		t.Errorf("Expected Friday as weakest weekday, got %v", insights.WeakestWeekday)
+	}
+
+	if insights.PeakHour == nil || *insights.PeakHour != 7 {
+		t.Errorf("Expected peak hour 7 in Madrid, got %v", insights.PeakHour)
+	}
+	if insights.Hours[7].Completions != 12 || insights.Hours[7].Share != 100 {
+		t.Errorf("Expected all 12 same-day completions at 7h, got %+v", insights.Hours[7])
+	}
+}
+
+func TestGetBehaviourInsightsHandler_AllHabits(t *testing.T) {
+	daily := entities.NewHabit("user-123", "Reading", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	daily.ID = "habit-1"
+	daily.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	archivedAt := time.Date(2025, 1, 8, 12, 0, 0, 0, time.UTC)
+	archived := entities.NewHabit("user-123", "Running", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	archived.ID = "habit-2"
+	archived.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	archived.ArchivedAt = &archivedAt
+
+	handler := NewGetBehaviourInsightsHandler(
+		&mockHeatmapHabitRepo{mockHabitRepo{habits: []*entities.Habit{daily, archived}}},
+		&mockHeatmapEntryRepo{mockEntryRepo{}},
+	)
+
+	insights, err := handler.Handle(context.Background(), GetBehaviourInsightsQuery{
+		UserID:    "user-123",
+		From:      time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC),
+		To:        time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC),
+		WeekStart: time.Sunday,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if insights.Weekdays[0].Weekday != "Sunday" {
+		t.Errorf("Expected weekdays to start on Sunday, got %s", insights.Weekdays[0].Weekday)
+	}
+
+	// The archived habit only counts until the day before it was archived.
+	if insights.Weekdays[1].Scheduled != 2 || insights.Weekdays[3].Scheduled != 1 {
+		t.Errorf("Expected Monday to have 2 and Wednesday 1 scheduled, got %d and %d", insights.Weekdays[1].Scheduled, insights.Weekdays[3].Scheduled)
+	}
+	if insights.PeakHour != nil {
+		t.Errorf("Expected no peak hour without completions, got %d", *insights.PeakHour)
+	}
+}
+
+func TestGetBehaviourInsightsHandler_Unauthorized(t *testing.T) {
+	habit := entities.NewHabit("other-user", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+
+	handler := NewGetBehaviourInsightsHandler(
+		&mockHeatmapHabitRepo{mockHabitRepo{habits: []*entities.Habit{habit}}},
+		&mockEntryRepo{},
+	)
+
+	_, err := handler.Handle(context.Background(), GetBehaviourInsightsQuery{
+		UserID:  "user-123",
+		HabitID: habit.ID,
+		From:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+	})
+	if err != errors.ErrUnauthorized {
+		t.Errorf("Expected ErrUnauthorized, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type ChatLinkDTO struct {
+	Linked   bool       `json:"linked"`
+	Timezone string     `json:"timezone,omitempty"`
+	LinkedAt *time.Time `json:"linked_at,omitempty"`
+}
+
+type GetChatLinkQuery struct {
+	UserID string
+}
+
+type GetChatLinkHandler struct {
+	linkRepo repositories.ChatLinkRepository
+}
+
+func NewGetChatLinkHandler(linkRepo repositories.ChatLinkRepository) *GetChatLinkHandler {
+	return &GetChatLinkHandler{
+		linkRepo: linkRepo,
+	}
+}
+
+func (h *GetChatLinkHandler) Handle(ctx context.Context, query GetChatLinkQuery) (*ChatLinkDTO, error) {
+	link, err := h.linkRepo.FindByUserID(ctx, query.UserID)
+	if err == errors.ErrNotFound {
+		return &ChatLinkDTO{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChatLinkDTO{
+		Linked:   true,
+		Timezone: link.Timezone,
+		LinkedAt: &link.CreatedAt,
+	}, nil
+}
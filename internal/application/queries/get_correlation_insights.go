@@ -0,0 +1,252 @@
+package queries
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/shared/errors"
+)
+
+const maxInsightsDays = 366
+
+type LiftDTO struct {
+	WhenDoneAvg    float64  `json:"when_done_avg"`
+	WhenNotDoneAvg float64  `json:"when_not_done_avg"`
+	DoneSamples    int      `json:"done_samples"`
+	NotDoneSamples int      `json:"not_done_samples"`
+	LiftPercent    *float64 `json:"lift_percent,omitempty"`
+}
+
+type CorrelationInsightDTO struct {
+	HabitID        string   `json:"habit_id"`
+	HabitName      string   `json:"habit_name"`
+	OtherHabitID   string   `json:"other_habit_id"`
+	OtherHabitName string   `json:"other_habit_name"`
+	Samples        int      `json:"samples"`
+	Correlation    *float64 `json:"correlation,omitempty"`
+	Lift           *LiftDTO `json:"lift,omitempty"`
+}
+
+type CorrelationInsightsDTO struct {
+	From       time.Time               `json:"from"`
+	To         time.Time               `json:"to"`
+	MinSamples int                     `json:"min_samples"`
+	Insights   []CorrelationInsightDTO `json:"insights"`
+}
+
+type GetCorrelationInsightsQuery struct {
+	UserID     string
+	From       time.Time
+	To         time.Time
+	MinSamples int
+}
+
+type GetCorrelationInsightsHandler struct {
+	habitRepo repositories.HabitRepository
+	entryRepo repositories.HabitEntryRepository
+}
+
+func NewGetCorrelationInsightsHandler(
+	habitRepo repositories.HabitRepository,
+	entryRepo repositories.HabitEntryRepository,
+) *GetCorrelationInsightsHandler {
+	return &GetCorrelationInsightsHandler{
+		habitRepo: habitRepo,
+		entryRepo: entryRepo,
+	}
+}
+
+func (h *GetCorrelationInsightsHandler) Handle(ctx context.Context, query GetCorrelationInsightsQuery) (*CorrelationInsightsDTO, error) {
+	from := toDate(query.From)
+	to := toDate(query.To)
+
+	if to.Before(from) || int(to.Sub(from).Hours()/24) >= maxInsightsDays || query.MinSamples < 0 {
+		return nil, errors.ErrInvalidInput
+	}
+
+	habits, err := h.habitRepo.FindByUserID(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := h.entryRepo.FindByUserIDAndDateRange(ctx, query.UserID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	days := int(to.Sub(from).Hours()/24) + 1
+	entriesByHabit := make(map[string]map[int]*entities.HabitEntry)
+	for _, entry := range entries {
+		if entriesByHabit[entry.HabitID] == nil {
+			entriesByHabit[entry.HabitID] = make(map[int]*entities.HabitEntry)
+		}
+		entriesByHabit[entry.HabitID][int(toDate(entry.ScheduledDate).Sub(from).Hours()/24)] = entry
+	}
+
+	series := make([][]*float64, len(habits))
+	for i, habit := range habits {
+		series[i] = dailyObservations(habit, entriesByHabit[habit.ID], from, days)
+	}
+
+	result := &CorrelationInsightsDTO{
+		From:       from,
+		To:         to,
+		MinSamples: query.MinSamples,
+		Insights:   []CorrelationInsightDTO{},
+	}
+
+	for i := 0; i < len(habits); i++ {
+		for j := i + 1; j < len(habits); j++ {
+			condition, outcome := i, j
+			if !isBinaryHabit(habits[condition]) && isBinaryHabit(habits[outcome]) {
+				condition, outcome = outcome, condition
+			}
+
+			insight := correlate(habits[condition], habits[outcome], series[condition], series[outcome])
+			if insight.Samples < query.MinSamples || insight.Samples == 0 {
+				continue
+			}
+			result.Insights = append(result.Insights, insight)
+		}
+	}
+
+	sort.SliceStable(result.Insights, func(a, b int) bool {
+		return correlationStrength(result.Insights[a]) > correlationStrength(result.Insights[b])
+	})
+
+	return result, nil
+}
+
+// dailyObservations returns one value per day while the habit was active.
+// Missing BOOLEAN and COUNTER entries count as zero, whereas days without a
+// VALUE entry are unknown rather than zero.
+func dailyObservations(habit *entities.Habit, entries map[int]*entities.HabitEntry, from time.Time, days int) []*float64 {
+	observations := make([]*float64, days)
+	createdDate := toDate(habit.CreatedAt)
+
+	for i := range observations {
+		date := from.AddDate(0, 0, i)
+		entry := entries[i]
+
+		if entry == nil && (date.Before(createdDate) || (habit.ArchivedAt != nil && !date.Before(toDate(*habit.ArchivedAt)))) {
+			continue
+		}
+
+		var value float64
+		switch {
+		case entry == nil && habit.Type == value_objects.HabitTypeValue:
+			continue
+		case entry == nil:
+			value = 0
+		case habit.Type == value_objects.HabitTypeBoolean || entry.Value == nil:
+			value = 1
+		default:
+			value = *entry.Value
+		}
+
+		observations[i] = &value
+	}
+
+	return observations
+}
+
+func correlate(condition, outcome *entities.Habit, xs, ys []*float64) CorrelationInsightDTO {
+	insight := CorrelationInsightDTO{
+		HabitID:        condition.ID,
+		HabitName:      condition.Name,
+		OtherHabitID:   outcome.ID,
+		OtherHabitName: outcome.Name,
+	}
+
+	var pairedX, pairedY []float64
+	for i := range xs {
+		if xs[i] == nil || ys[i] == nil {
+			continue
+		}
+		pairedX = append(pairedX, *xs[i])
+		pairedY = append(pairedY, *ys[i])
+	}
+
+	insight.Samples = len(pairedX)
+	insight.Correlation = pearson(pairedX, pairedY)
+
+	if isBinaryHabit(condition) {
+		insight.Lift = lift(pairedX, pairedY)
+	}
+
+	return insight
+}
+
+func pearson(xs, ys []float64) *float64 {
+	n := float64(len(xs))
+	if n < 2 {
+		return nil
+	}
+
+	var sumX, sumY float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var covariance, varianceX, varianceY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		covariance += dx * dy
+		varianceX += dx * dx
+		varianceY += dy * dy
+	}
+
+	if varianceX == 0 || varianceY == 0 {
+		return nil
+	}
+
+	r := covariance / math.Sqrt(varianceX*varianceY)
+	return &r
+}
+
+func lift(done, values []float64) *LiftDTO {
+	result := &LiftDTO{}
+	var doneSum, notDoneSum float64
+
+	for i := range done {
+		if done[i] > 0 {
+			result.DoneSamples++
+			doneSum += values[i]
+		} else {
+			result.NotDoneSamples++
+			notDoneSum += values[i]
+		}
+	}
+
+	if result.DoneSamples == 0 || result.NotDoneSamples == 0 {
+		return nil
+	}
+
+	result.WhenDoneAvg = doneSum / float64(result.DoneSamples)
+	result.WhenNotDoneAvg = notDoneSum / float64(result.NotDoneSamples)
+
+	if result.WhenNotDoneAvg != 0 {
+		percent := (result.WhenDoneAvg - result.WhenNotDoneAvg) / math.Abs(result.WhenNotDoneAvg) * 100
+		result.LiftPercent = &percent
+	}
+
+	return result
+}
+
+func isBinaryHabit(habit *entities.Habit) bool {
+	return habit.Type == value_objects.HabitTypeBoolean
+}
+
+func correlationStrength(insight CorrelationInsightDTO) float64 {
+	if insight.Correlation == nil {
+		return 0
+	}
+	return math.Abs(*insight.Correlation)
+}
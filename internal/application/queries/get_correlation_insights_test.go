@@ -0,0 +1,127 @@
+package queries
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/shared/errors"
+)
+
+func TestGetCorrelationInsightsHandler_BooleanAndValue(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	sleep := entities.NewHabit("user-123", "Sleep score", value_objects.HabitTypeValue, value_objects.FrequencyDaily, false, false)
+	sleep.ID = "habit-sleep"
+	sleep.CreatedAt = createdAt
+
+	exercise := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	exercise.ID = "habit-exercise"
+	exercise.CreatedAt = createdAt
+
+	var entries []*entities.HabitEntry
+	for day := 0; day < 10; day++ {
+		date := createdAt.AddDate(0, 0, day)
+		score := 70.0
+		if day%2 == 0 {
+			entries = append(entries, entities.NewHabitEntry(exercise.ID, date, nil))
+			score = 80.0
+		}
+		if day == 9 {
+			continue
+		}
+		entries = append(entries, entities.NewHabitEntry(sleep.ID, date, &score))
+	}
+
+	handler := NewGetCorrelationInsightsHandler(
+		&mockHeatmapHabitRepo{mockHabitRepo{habits: []*entities.Habit{sleep, exercise}}},
+		&mockHeatmapEntryRepo{mockEntryRepo{entries: entries}},
+	)
+
+	result, err := handler.Handle(context.Background(), GetCorrelationInsightsQuery{
+		UserID:     "user-123",
+		From:       createdAt,
+		To:         createdAt.AddDate(0, 0, 9),
+		MinSamples: 5,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result.Insights) != 1 {
+		t.Fatalf("Expected 1 insight, got %d", len(result.Insights))
+	}
+
+	insight := result.Insights[0]
+	if insight.HabitID != exercise.ID || insight.OtherHabitID != sleep.ID {
+		t.Errorf("Expected the boolean habit as the condition, got %s -> %s", insight.HabitID, insight.OtherHabitID)
+	}
+	if insight.Samples != 9 {
+		t.Errorf("Expected 9 samples since missing values are unknown, got %d", insight.Samples)
+	}
+	if insight.Correlation == nil || math.Abs(*insight.Correlation-1) > 1e-9 {
+		t.Errorf("Expected perfect correlation, got %v", insight.Correlation)
+	}
+	if insight.Lift == nil {
+		t.Fatal("Expected lift to be reported")
+	}
+	if insight.Lift.WhenDoneAvg != 80 || insight.Lift.WhenNotDoneAvg != 70 {
+		t.Errorf("Expected averages 80 and 70, got %f and %f", insight.Lift.WhenDoneAvg, insight.Lift.WhenNotDoneAvg)
+	}
+	if insight.Lift.DoneSamples != 5 || insight.Lift.NotDoneSamples != 4 {
+		t.Errorf("Expected 5 and 4 samples, got %d and %d", insight.Lift.DoneSamples, insight.Lift.NotDoneSamples)
+	}
+	if insight.Lift.LiftPercent == nil || math.Abs(*insight.Lift.LiftPercent-100.0/7) > 1e-9 {
+		t.Errorf("Expected lift of ~14.29%%, got %v", insight.Lift.LiftPercent)
+	}
+}
+
+func TestGetCorrelationInsightsHandler_FiltersBySampleSize(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	first := entities.NewHabit("user-123", "Reading", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	first.ID = "habit-1"
+	first.CreatedAt = createdAt
+
+	second := entities.NewHabit("user-123", "Meditation", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	second.ID = "habit-2"
+	second.CreatedAt = createdAt.AddDate(0, 0, 7)
+
+	handler := NewGetCorrelationInsightsHandler(
+		&mockHeatmapHabitRepo{mockHabitRepo{habits: []*entities.Habit{first, second}}},
+		&mockHeatmapEntryRepo{mockEntryRepo{}},
+	)
+
+	result, err := handler.Handle(context.Background(), GetCorrelationInsightsQuery{
+		UserID:     "user-123",
+		From:       createdAt,
+		To:         createdAt.AddDate(0, 0, 9),
+		MinSamples: 4,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result.Insights) != 0 {
+		t.Errorf("Expected pairs with 3 overlapping days to be filtered out, got %d", len(result.Insights))
+	}
+}
+
+func TestGetCorrelationInsightsHandler_InvalidRange(t *testing.T) {
+	handler := NewGetCorrelationInsightsHandler(
+		&mockHeatmapHabitRepo{mockHabitRepo{}},
+		&mockHeatmapEntryRepo{mockEntryRepo{}},
+	)
+
+	_, err := handler.Handle(context.Background(), GetCorrelationInsightsQuery{
+		UserID: "user-123",
+		From:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != errors.ErrInvalidInput {
+		t.Errorf("Expected ErrInvalidInput, got %v", err)
+	}
+}
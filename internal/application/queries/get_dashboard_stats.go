@@ -0,0 +1,238 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/utils"
+)
+
+type HabitRankDTO struct {
+	HabitID        string  `json:"habit_id"`
+	HabitName      string  `json:"habit_name"`
+	CompletionRate float64 `json:"completion_rate"`
+}
+
+type DailyCompletionDTO struct {
+	Date           time.Time `json:"date"`
+	Scheduled      int       `json:"scheduled"`
+	Completed      int       `json:"completed"`
+	CompletionRate float64   `json:"completion_rate"`
+}
+
+type DashboardStatsDTO struct {
+	TotalHabits             int                  `json:"total_habits"`
+	CompletionRateToday     float64              `json:"completion_rate_today"`
+	CompletionRateThisWeek  float64              `json:"completion_rate_this_week"`
+	CompletionRateThisMonth float64              `json:"completion_rate_this_month"`
+	ActiveStreaks           int                  `json:"active_streaks"`
+	BestHabit               *HabitRankDTO        `json:"best_habit,omitempty"`
+	WorstHabit              *HabitRankDTO        `json:"worst_habit,omitempty"`
+	Daily                   []DailyCompletionDTO `json:"daily"`
+}
+
+type GetDashboardStatsQuery struct {
+	UserID string
+	Date   time.Time
+	Days   int
+}
+
+type GetDashboardStatsHandler struct {
+	habitRepo repositories.HabitRepository
+	entryRepo repositories.HabitEntryRepository
+}
+
+func NewGetDashboardStatsHandler(
+	habitRepo repositories.HabitRepository,
+	entryRepo repositories.HabitEntryRepository,
+) *GetDashboardStatsHandler {
+	return &GetDashboardStatsHandler{
+		habitRepo: habitRepo,
+		entryRepo: entryRepo,
+	}
+}
+
+type completionCounter struct {
+	scheduled int
+	completed int
+}
+
+func (c *completionCounter) add(scheduled, completed bool) {
+	if scheduled {
+		c.scheduled++
+		if completed {
+			c.completed++
+		}
+	}
+}
+
+func (c completionCounter) rate() float64 {
+	if c.scheduled == 0 {
+		return 0
+	}
+	return float64(c.completed) / float64(c.scheduled) * 100
+}
+
+func (h *GetDashboardStatsHandler) Handle(ctx context.Context, query GetDashboardStatsQuery) (*DashboardStatsDTO, error) {
+	habits, err := h.habitRepo.FindActiveByUserID(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := h.entryRepo.FindByUserID(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	days := query.Days
+	if days < 1 {
+		days = 30
+	}
+
+	today := toDate(query.Date)
+	seriesStart := today.AddDate(0, 0, -(days - 1))
+	weekStart := utils.StartOfWeek(today, time.Monday)
+	monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	start := seriesStart
+	if weekStart.Before(start) {
+		start = weekStart
+	}
+	if monthStart.Before(start) {
+		start = monthStart
+	}
+
+	completed := indexEntriesByHabitAndDate(entries)
+
+	stats := &DashboardStatsDTO{
+		TotalHabits: len(habits),
+		Daily:       make([]DailyCompletionDTO, 0, days),
+	}
+
+	var todayCounter, weekCounter, monthCounter completionCounter
+	dailyCounters := make([]completionCounter, days)
+	habitCounters := make([]completionCounter, len(habits))
+
+	for i, habit := range habits {
+		createdDate := toDate(habit.CreatedAt)
+		done := completed[habit.ID]
+
+		for date := start; !date.After(today); date = date.AddDate(0, 0, 1) {
+			if date.Before(createdDate) {
+				continue
+			}
+
+			key := date.Format("2006-01-02")
+			isScheduled := utils.ShouldAppearToday(string(habit.Frequency), habit.SpecificDays, habit.SpecificDates, date)
+			isCompleted := done[key]
+
+			if date.Equal(today) {
+				todayCounter.add(isScheduled, isCompleted)
+			}
+			if !date.Before(weekStart) {
+				weekCounter.add(isScheduled, isCompleted)
+			}
+			if !date.Before(monthStart) {
+				monthCounter.add(isScheduled, isCompleted)
+			}
+			if !date.Before(seriesStart) {
+				dailyCounters[int(date.Sub(seriesStart).Hours()/24)].add(isScheduled, isCompleted)
+				habitCounters[i].add(isScheduled, isCompleted)
+			}
+		}
+
+		if scheduledStreak(habit, done, today) > 0 {
+			stats.ActiveStreaks++
+		}
+	}
+
+	stats.CompletionRateToday = todayCounter.rate()
+	stats.CompletionRateThisWeek = weekCounter.rate()
+	stats.CompletionRateThisMonth = monthCounter.rate()
+
+	for i, counter := range dailyCounters {
+		stats.Daily = append(stats.Daily, DailyCompletionDTO{
+			Date:           seriesStart.AddDate(0, 0, i),
+			Scheduled:      counter.scheduled,
+			Completed:      counter.completed,
+			CompletionRate: counter.rate(),
+		})
+	}
+
+	stats.BestHabit, stats.WorstHabit = rankHabits(habits, habitCounters)
+
+	return stats, nil
+}
+
+// rankHabits returns the habits with the highest and lowest completion rate,
+// ignoring habits with nothing scheduled. There is no worst habit unless at
+// least two habits are ranked.
+func rankHabits(habits []*entities.Habit, counters []completionCounter) (*HabitRankDTO, *HabitRankDTO) {
+	var ranked []HabitRankDTO
+	for i, habit := range habits {
+		if counters[i].scheduled == 0 {
+			continue
+		}
+		ranked = append(ranked, HabitRankDTO{
+			HabitID:        habit.ID,
+			HabitName:      habit.Name,
+			CompletionRate: counters[i].rate(),
+		})
+	}
+
+	var best, worst *HabitRankDTO
+	for i := range ranked {
+		if best == nil || ranked[i].CompletionRate > best.CompletionRate {
+			best = &ranked[i]
+		}
+		if worst == nil || ranked[i].CompletionRate < worst.CompletionRate {
+			worst = &ranked[i]
+		}
+	}
+
+	if len(ranked) < 2 {
+		worst = nil
+	}
+
+	return best, worst
+}
+
+func indexEntriesByHabitAndDate(entries []*entities.HabitEntry) map[string]map[string]bool {
+	index := make(map[string]map[string]bool)
+	for _, entry := range entries {
+		if index[entry.HabitID] == nil {
+			index[entry.HabitID] = make(map[string]bool)
+		}
+		index[entry.HabitID][entry.ScheduledDate.Format("2006-01-02")] = true
+	}
+	return index
+}
+
+// scheduledStreak counts consecutive completed occurrences ending today,
+// skipping days the habit is not scheduled on. An incomplete today does not
+// break the streak since the day is still in progress.
+func scheduledStreak(habit *entities.Habit, done map[string]bool, today time.Time) int {
+	createdDate := toDate(habit.CreatedAt)
+	streak := 0
+
+	for date := today; ; date = date.AddDate(0, 0, -1) {
+		key := date.Format("2006-01-02")
+		if done[key] {
+			streak++
+			continue
+		}
+
+		if date.Before(createdDate) {
+			break
+		}
+
+		scheduled := utils.ShouldAppearToday(string(habit.Frequency), habit.SpecificDays, habit.SpecificDates, date)
+		if scheduled && !date.Equal(today) {
+			break
+		}
+	}
+
+	return streak
+}
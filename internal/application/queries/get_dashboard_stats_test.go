@@ -0,0 +1,127 @@
+package queries
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+)
+
+type mockEntryRepoWithFindByUserID struct {
+	mockEntryRepo
+}
+
+func (m *mockEntryRepoWithFindByUserID) FindByUserID(ctx context.Context, userID string) ([]*entities.HabitEntry, error) {
+	return m.entries, nil
+}
+
+func TestGetDashboardStatsHandler_AggregatesAcrossHabits(t *testing.T) {
+	today := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC) // Wednesday
+
+	exercise := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	exercise.ID = "habit-1"
+	exercise.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	reading := entities.NewHabit("user-123", "Reading", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	reading.ID = "habit-2"
+	reading.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var entries []*entities.HabitEntry
+	for day := 1; day <= 15; day++ {
+		entries = append(entries, entities.NewHabitEntry("habit-1", time.Date(2025, 1, day, 0, 0, 0, 0, time.UTC), nil))
+	}
+	entries = append(entries, entities.NewHabitEntry("habit-2", time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), nil))
+
+	habitRepo := &mockHabitRepo{habits: []*entities.Habit{exercise, reading}}
+	entryRepo := &mockEntryRepoWithFindByUserID{mockEntryRepo{entries: entries}}
+
+	handler := NewGetDashboardStatsHandler(habitRepo, entryRepo)
+
+	stats, err := handler.Handle(context.Background(), GetDashboardStatsQuery{
+		UserID: "user-123",
+		Date:   today,
+		Days:   7,
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if stats.TotalHabits != 2 {
+		t.Errorf("Expected 2 habits, got %d", stats.TotalHabits)
+	}
+
+	if stats.CompletionRateToday != 50 {
+		t.Errorf("Expected today's completion rate 50, got %f", stats.CompletionRateToday)
+	}
+
+	// Week starts Monday 13th: 3 days x 2 habits, 3 completions.
+	if stats.CompletionRateThisWeek != 50 {
+		t.Errorf("Expected weekly completion rate 50, got %f", stats.CompletionRateThisWeek)
+	}
+
+	if stats.ActiveStreaks != 1 {
+		t.Errorf("Expected 1 active streak, got %d", stats.ActiveStreaks)
+	}
+
+	if stats.BestHabit == nil || stats.BestHabit.HabitID != "habit-1" {
+		t.Errorf("Expected best habit habit-1, got %+v", stats.BestHabit)
+	}
+
+	if stats.WorstHabit == nil || stats.WorstHabit.HabitID != "habit-2" {
+		t.Errorf("Expected worst habit habit-2, got %+v", stats.WorstHabit)
+	}
+
+	if len(stats.Daily) != 7 {
+		t.Fatalf("Expected 7 daily points, got %d", len(stats.Daily))
+	}
+
+	if !stats.Daily[6].Date.Equal(today) {
+		t.Errorf("Expected last daily point to be today, got %s", stats.Daily[6].Date)
+	}
+
+	if stats.Daily[6].Scheduled != 2 || stats.Daily[6].Completed != 1 {
+		t.Errorf("Expected 1 of 2 completed today, got %d of %d", stats.Daily[6].Completed, stats.Daily[6].Scheduled)
+	}
+}
+
+func TestGetDashboardStatsHandler_NoHabits(t *testing.T) {
+	handler := NewGetDashboardStatsHandler(&mockHabitRepo{}, &mockEntryRepoWithFindByUserID{})
+
+	stats, err := handler.Handle(context.Background(), GetDashboardStatsQuery{
+		UserID: "user-123",
+		Date:   time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if stats.BestHabit != nil || stats.WorstHabit != nil {
+		t.Error("Expected no best or worst habit without habits")
+	}
+
+	if len(stats.Daily) != 30 {
+		t.Errorf("Expected default series of 30 days, got %d", len(stats.Daily))
+	}
+}
+
+func TestScheduledStreak_SkipsUnscheduledDays(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Gym", value_objects.HabitTypeBoolean, value_objects.FrequencyWeekly, false, false)
+	habit.SpecificDays = []int{1, 3} // Monday, Wednesday
+	habit.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	done := map[string]bool{
+		"2025-01-06": true,
+		"2025-01-08": true,
+		"2025-01-13": true,
+	}
+
+	// Tuesday 14th: today is not scheduled, Monday 13th, Wednesday 8th and Monday 6th were completed.
+	streak := scheduledStreak(habit, done, time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC))
+	if streak != 3 {
+		t.Errorf("Expected streak of 3, got %d", streak)
+	}
+}
@@ -0,0 +1,48 @@
+package queries
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type DigestSubscriptionDTO struct {
+	Weekly  bool `json:"weekly"`
+	Monthly bool `json:"monthly"`
+}
+
+type GetDigestSubscriptionQuery struct {
+	UserID string
+}
+
+type GetDigestSubscriptionHandler struct {
+	subscriptionRepo repositories.DigestSubscriptionRepository
+}
+
+func NewGetDigestSubscriptionHandler(subscriptionRepo repositories.DigestSubscriptionRepository) *GetDigestSubscriptionHandler {
+	return &GetDigestSubscriptionHandler{
+		subscriptionRepo: subscriptionRepo,
+	}
+}
+
+// Handle returns the user's digest settings, which are opted out until the
+// user subscribes.
+func (h *GetDigestSubscriptionHandler) Handle(ctx context.Context, query GetDigestSubscriptionQuery) (*DigestSubscriptionDTO, error) {
+	subscription, err := h.subscriptionRepo.FindByUserID(ctx, query.UserID)
+	if err == errors.ErrNotFound {
+		subscription = entities.NewDigestSubscription(query.UserID)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return NewDigestSubscriptionDTO(subscription), nil
+}
+
+func NewDigestSubscriptionDTO(subscription *entities.DigestSubscription) *DigestSubscriptionDTO {
+	return &DigestSubscriptionDTO{
+		Weekly:  subscription.Weekly,
+		Monthly: subscription.Monthly,
+	}
+}
@@ -41,5 +41,6 @@ func (h *GetHabitByIDHandler) Handle(ctx context.Context, query GetHabitByIDQuer
 		CarryOver:    habit.CarryOver,
 		IsNegative:   habit.IsNegative,
 		SpecificDays: habit.SpecificDays,
+		Difficulty:   habit.Difficulty,
 	}, nil
 }
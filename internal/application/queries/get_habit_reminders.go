@@ -0,0 +1,78 @@
+package queries
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type HabitReminderDTO struct {
+	ID         string  `json:"id"`
+	HabitID    string  `json:"habit_id"`
+	Time       string  `json:"time"`
+	Timezone   string  `json:"timezone"`
+	Enabled    bool    `json:"enabled"`
+	LastSentOn *string `json:"last_sent_on,omitempty"`
+}
+
+type GetHabitRemindersQuery struct {
+	HabitID string
+	UserID  string
+}
+
+type GetHabitRemindersHandler struct {
+	habitRepo    repositories.HabitRepository
+	reminderRepo repositories.HabitReminderRepository
+}
+
+func NewGetHabitRemindersHandler(
+	habitRepo repositories.HabitRepository,
+	reminderRepo repositories.HabitReminderRepository,
+) *GetHabitRemindersHandler {
+	return &GetHabitRemindersHandler{
+		habitRepo:    habitRepo,
+		reminderRepo: reminderRepo,
+	}
+}
+
+func (h *GetHabitRemindersHandler) Handle(ctx context.Context, query GetHabitRemindersQuery) ([]HabitReminderDTO, error) {
+	habit, err := h.habitRepo.FindByID(ctx, query.HabitID)
+	if err != nil {
+		return nil, err
+	}
+
+	if habit.UserID != query.UserID {
+		return nil, errors.ErrUnauthorized
+	}
+
+	reminders, err := h.reminderRepo.FindByHabitID(ctx, habit.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]HabitReminderDTO, 0, len(reminders))
+	for _, reminder := range reminders {
+		dtos = append(dtos, *NewHabitReminderDTO(reminder))
+	}
+
+	return dtos, nil
+}
+
+func NewHabitReminderDTO(reminder *entities.HabitReminder) *HabitReminderDTO {
+	dto := &HabitReminderDTO{
+		ID:       reminder.ID,
+		HabitID:  reminder.HabitID,
+		Time:     reminder.TimeOfDay,
+		Timezone: reminder.Timezone,
+		Enabled:  reminder.Enabled,
+	}
+
+	if reminder.LastSentOn != nil {
+		lastSentOn := reminder.LastSentOn.Format("2006-01-02")
+		dto.LastSentOn = &lastSentOn
+	}
+
+	return dto
+}
@@ -0,0 +1,246 @@
+package queries
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/shared/errors"
+	"apocapoc-api/internal/shared/utils"
+)
+
+type SeriesGranularity string
+
+const (
+	SeriesGranularityDay   SeriesGranularity = "day"
+	SeriesGranularityWeek  SeriesGranularity = "week"
+	SeriesGranularityMonth SeriesGranularity = "month"
+	SeriesGranularityYear  SeriesGranularity = "year"
+)
+
+const maxSeriesBuckets = 1000
+
+// MaxMovingAverages bounds how many moving averages a series can ask for,
+// since each one walks every bucket.
+const MaxMovingAverages = 5
+
+func (g SeriesGranularity) IsValid() bool {
+	switch g {
+	case SeriesGranularityDay, SeriesGranularityWeek, SeriesGranularityMonth, SeriesGranularityYear:
+		return true
+	}
+	return false
+}
+
+type SeriesBucketDTO struct {
+	Start          time.Time          `json:"start"`
+	End            time.Time          `json:"end"`
+	Count          int                `json:"count"`
+	Sum            float64            `json:"sum"`
+	Avg            *float64           `json:"avg,omitempty"`
+	Min            *float64           `json:"min,omitempty"`
+	Max            *float64           `json:"max,omitempty"`
+	MovingAverages map[string]float64 `json:"moving_averages,omitempty"`
+}
+
+type HabitSeriesDTO struct {
+	HabitID     string                  `json:"habit_id"`
+	HabitType   value_objects.HabitType `json:"habit_type"`
+	Granularity SeriesGranularity       `json:"granularity"`
+	WeekStart   string                  `json:"week_start"`
+	Buckets     []SeriesBucketDTO       `json:"buckets"`
+}
+
+type GetHabitSeriesQuery struct {
+	HabitID        string
+	UserID         string
+	Granularity    SeriesGranularity
+	WeekStart      time.Weekday
+	From           time.Time
+	To             time.Time
+	MovingAverages []int
+}
+
+type GetHabitSeriesHandler struct {
+	habitRepo repositories.HabitRepository
+	entryRepo repositories.HabitEntryRepository
+}
+
+func NewGetHabitSeriesHandler(
+	habitRepo repositories.HabitRepository,
+	entryRepo repositories.HabitEntryRepository,
+) *GetHabitSeriesHandler {
+	return &GetHabitSeriesHandler{
+		habitRepo: habitRepo,
+		entryRepo: entryRepo,
+	}
+}
+
+func (h *GetHabitSeriesHandler) Handle(ctx context.Context, query GetHabitSeriesQuery) (*HabitSeriesDTO, error) {
+	if !query.Granularity.IsValid() {
+		return nil, errors.ErrInvalidInput
+	}
+
+	if len(query.MovingAverages) > MaxMovingAverages {
+		return nil, errors.ErrInvalidInput
+	}
+	for _, window := range query.MovingAverages {
+		if window < 2 || window > maxSeriesBuckets {
+			return nil, errors.ErrInvalidInput
+		}
+	}
+
+	from := bucketStart(toDate(query.From), query.Granularity, query.WeekStart)
+	to := toDate(query.To)
+	if to.Before(from) {
+		return nil, errors.ErrInvalidInput
+	}
+
+	var starts []time.Time
+	for start := from; !start.After(to); start = nextBucket(start, query.Granularity) {
+		starts = append(starts, start)
+		if len(starts) > maxSeriesBuckets {
+			return nil, errors.ErrInvalidInput
+		}
+	}
+
+	habit, err := h.habitRepo.FindByID(ctx, query.HabitID)
+	if err != nil {
+		return nil, err
+	}
+
+	if habit.UserID != query.UserID {
+		return nil, errors.ErrUnauthorized
+	}
+
+	entries, err := h.entryRepo.FindByHabitIDAndDateRange(ctx, habit.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make([]SeriesBucketDTO, len(starts))
+	index := make(map[time.Time]int, len(starts))
+	for i, start := range starts {
+		buckets[i] = SeriesBucketDTO{
+			Start: start,
+			End:   nextBucket(start, query.Granularity).AddDate(0, 0, -1),
+		}
+		index[start] = i
+	}
+
+	for _, entry := range entries {
+		i, ok := index[bucketStart(toDate(entry.ScheduledDate), query.Granularity, query.WeekStart)]
+		if !ok {
+			continue
+		}
+		addToBucket(&buckets[i], habit, entry)
+	}
+
+	for i := range buckets {
+		if buckets[i].Count > 0 {
+			avg := buckets[i].Sum / float64(buckets[i].Count)
+			buckets[i].Avg = &avg
+		}
+	}
+
+	applyMovingAverages(buckets, habit.Type, query.MovingAverages)
+
+	return &HabitSeriesDTO{
+		HabitID:     habit.ID,
+		HabitType:   habit.Type,
+		Granularity: query.Granularity,
+		WeekStart:   query.WeekStart.String(),
+		Buckets:     buckets,
+	}, nil
+}
+
+func addToBucket(bucket *SeriesBucketDTO, habit *entities.Habit, entry *entities.HabitEntry) {
+	value := 1.0
+	if habit.Type != value_objects.HabitTypeBoolean && entry.Value != nil {
+		value = *entry.Value
+	}
+
+	bucket.Count++
+	bucket.Sum += value
+
+	if bucket.Min == nil || value < *bucket.Min {
+		minValue := value
+		bucket.Min = &minValue
+	}
+	if bucket.Max == nil || value > *bucket.Max {
+		maxValue := value
+		bucket.Max = &maxValue
+	}
+}
+
+// applyMovingAverages averages the bucket metric over trailing windows of
+// buckets. COUNTER habits use the bucket sum, VALUE habits the bucket average
+// and BOOLEAN habits the number of completions. Empty buckets count as zero
+// except for VALUE habits, where they are skipped.
+func applyMovingAverages(buckets []SeriesBucketDTO, habitType value_objects.HabitType, windows []int) {
+	for _, window := range windows {
+		key := strconv.Itoa(window)
+
+		for i := range buckets {
+			total := 0.0
+			samples := 0
+
+			first := i - window + 1
+			if first < 0 {
+				first = 0
+			}
+
+			for j := first; j <= i; j++ {
+				switch habitType {
+				case value_objects.HabitTypeValue:
+					if buckets[j].Avg != nil {
+						total += *buckets[j].Avg
+						samples++
+					}
+				case value_objects.HabitTypeCounter:
+					total += buckets[j].Sum
+					samples++
+				default:
+					total += float64(buckets[j].Count)
+					samples++
+				}
+			}
+
+			if samples == 0 {
+				continue
+			}
+
+			if buckets[i].MovingAverages == nil {
+				buckets[i].MovingAverages = make(map[string]float64)
+			}
+			buckets[i].MovingAverages[key] = total / float64(samples)
+		}
+	}
+}
+
+func bucketStart(date time.Time, granularity SeriesGranularity, weekStart time.Weekday) time.Time {
+	switch granularity {
+	case SeriesGranularityWeek:
+		return utils.StartOfWeek(date, weekStart)
+	case SeriesGranularityMonth:
+		return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case SeriesGranularityYear:
+		return time.Date(date.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return date
+}
+
+func nextBucket(start time.Time, granularity SeriesGranularity) time.Time {
+	switch granularity {
+	case SeriesGranularityWeek:
+		return start.AddDate(0, 0, 7)
+	case SeriesGranularityMonth:
+		return start.AddDate(0, 1, 0)
+	case SeriesGranularityYear:
+		return start.AddDate(1, 0, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
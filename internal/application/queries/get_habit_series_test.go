@@ -0,0 +1,135 @@
+package queries
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/shared/errors"
+)
+
+func newSeriesTestHandler(habit *entities.Habit, entries []*entities.HabitEntry) *GetHabitSeriesHandler {
+	return NewGetHabitSeriesHandler(
+		&mockHeatmapHabitRepo{mockHabitRepo{habits: []*entities.Habit{habit}}},
+		&mockEntryRepo{entries: entries},
+	)
+}
+
+func TestGetHabitSeriesHandler_WeeklyBucketsRespectWeekStart(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Push-ups", value_objects.HabitTypeCounter, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+
+	values := map[int]float64{12: 10, 13: 20, 14: 30} // Sunday, Monday, Tuesday
+	var entries []*entities.HabitEntry
+	for day, value := range values {
+		v := value
+		entries = append(entries, entities.NewHabitEntry("habit-1", time.Date(2025, 1, day, 0, 0, 0, 0, time.UTC), &v))
+	}
+
+	tests := []struct {
+		name      string
+		weekStart time.Weekday
+		expected  []float64
+	}{
+		{"Monday start", time.Monday, []float64{10, 50}},
+		{"Sunday start", time.Sunday, []float64{60}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series, err := newSeriesTestHandler(habit, entries).Handle(context.Background(), GetHabitSeriesQuery{
+				HabitID:     "habit-1",
+				UserID:      "user-123",
+				Granularity: SeriesGranularityWeek,
+				WeekStart:   tt.weekStart,
+				From:        time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC),
+				To:          time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC),
+			})
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if len(series.Buckets) != len(tt.expected) {
+				t.Fatalf("Expected %d buckets, got %d", len(tt.expected), len(series.Buckets))
+			}
+
+			for i, sum := range tt.expected {
+				if series.Buckets[i].Sum != sum {
+					t.Errorf("Expected bucket %d sum %f, got %f", i, sum, series.Buckets[i].Sum)
+				}
+				if series.Buckets[i].Start.Weekday() != tt.weekStart {
+					t.Errorf("Expected bucket to start on %s, got %s", tt.weekStart, series.Buckets[i].Start.Weekday())
+				}
+			}
+		})
+	}
+}
+
+func TestGetHabitSeriesHandler_MonthlyAggregates(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Weight", value_objects.HabitTypeValue, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+
+	v1, v2, v3 := 80.0, 82.0, 78.0
+	entries := []*entities.HabitEntry{
+		entities.NewHabitEntry("habit-1", time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC), &v1),
+		entities.NewHabitEntry("habit-1", time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC), &v2),
+		entities.NewHabitEntry("habit-1", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), &v3),
+	}
+
+	series, err := newSeriesTestHandler(habit, entries).Handle(context.Background(), GetHabitSeriesQuery{
+		HabitID:        "habit-1",
+		UserID:         "user-123",
+		Granularity:    SeriesGranularityMonth,
+		From:           time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+		To:             time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+		MovingAverages: []int{2},
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(series.Buckets) != 3 {
+		t.Fatalf("Expected 3 buckets, got %d", len(series.Buckets))
+	}
+
+	january := series.Buckets[0]
+	if !january.Start.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) || !january.End.Equal(time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected January bucket, got %s - %s", january.Start, january.End)
+	}
+	if january.Count != 2 || *january.Avg != 81 || *january.Min != 80 || *january.Max != 82 {
+		t.Errorf("Unexpected January aggregates: %+v", january)
+	}
+
+	february := series.Buckets[1]
+	if february.Count != 0 || february.Avg != nil {
+		t.Errorf("Expected empty February bucket, got %+v", february)
+	}
+	if february.MovingAverages["2"] != 81 {
+		t.Errorf("Expected February moving average 81, got %f", february.MovingAverages["2"])
+	}
+
+	if series.Buckets[2].MovingAverages["2"] != 78 {
+		t.Errorf("Expected March moving average 78, got %f", series.Buckets[2].MovingAverages["2"])
+	}
+}
+
+func TestGetHabitSeriesHandler_RejectsTooManyBuckets(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Steps", value_objects.HabitTypeValue, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+
+	_, err := newSeriesTestHandler(habit, nil).Handle(context.Background(), GetHabitSeriesQuery{
+		HabitID:     "habit-1",
+		UserID:      "user-123",
+		Granularity: SeriesGranularityDay,
+		From:        time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:          time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	if err != errors.ErrInvalidInput {
+		t.Errorf("Expected ErrInvalidInput, got %v", err)
+	}
+}
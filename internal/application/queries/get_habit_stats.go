@@ -2,41 +2,63 @@ package queries
 
 import (
 	"context"
+	"math"
 	"time"
 
 	"apocapoc-api/internal/domain/entities"
 	"apocapoc-api/internal/domain/repositories"
 	"apocapoc-api/internal/shared/errors"
+	"apocapoc-api/internal/shared/utils"
 )
 
+const maxStatsDays = 366
+
 type HabitStatsDTO struct {
-	HabitID              string  `json:"habit_id"`
-	HabitName            string  `json:"habit_name"`
-	TotalCompletions     int     `json:"total_completions"`
-	CurrentStreak        int     `json:"current_streak"`
-	LongestStreak        int     `json:"longest_streak"`
-	CompletionRate       float64 `json:"completion_rate"`
-	CompletionsThisWeek  int     `json:"completions_this_week"`
-	CompletionsThisMonth int     `json:"completions_this_month"`
+	HabitID              string                  `json:"habit_id"`
+	HabitName            string                  `json:"habit_name"`
+	From                 time.Time               `json:"from"`
+	To                   time.Time               `json:"to"`
+	WeekStart            string                  `json:"week_start"`
+	TotalCompletions     int                     `json:"total_completions"`
+	CurrentStreak        int                     `json:"current_streak"`
+	LongestStreak        int                     `json:"longest_streak"`
+	CompletionRate       float64                 `json:"completion_rate"`
+	CompletionsThisWeek  int                     `json:"completions_this_week"`
+	CompletionsThisMonth int                     `json:"completions_this_month"`
+	Strength             float64                 `json:"strength"`
+	StrengthHistory      []HabitStrengthPointDTO `json:"strength_history"`
+	LastCompletedDate    *time.Time              `json:"last_completed_date,omitempty"`
+	Trend                string                  `json:"trend"`
+	Comparisons          []PeriodComparisonDTO   `json:"comparisons"`
 }
 
+// GetHabitStatsQuery computes stats as seen from Date, whose location is the
+// user's timezone. From defaults to the habit creation date and To to the
+// date of Date. A range may span at most maxStatsDays days.
 type GetHabitStatsQuery struct {
-	HabitID string
-	UserID  string
+	HabitID   string
+	UserID    string
+	Date      time.Time
+	WeekStart time.Weekday
+	From      time.Time
+	To        time.Time
 }
 
 type GetHabitStatsHandler struct {
 	habitRepo repositories.HabitRepository
 	entryRepo repositories.HabitEntryRepository
+	statsRepo repositories.HabitStatsRepository
 }
 
 func NewGetHabitStatsHandler(
 	habitRepo repositories.HabitRepository,
 	entryRepo repositories.HabitEntryRepository,
+	statsRepo repositories.HabitStatsRepository,
 ) *GetHabitStatsHandler {
 	return &GetHabitStatsHandler{
 		habitRepo: habitRepo,
 		entryRepo: entryRepo,
+		statsRepo: statsRepo,
 	}
 }
 
@@ -50,121 +72,188 @@ func (h *GetHabitStatsHandler) Handle(ctx context.Context, query GetHabitStatsQu
 		return nil, errors.ErrUnauthorized
 	}
 
-	entries, err := h.entryRepo.FindByHabitID(ctx, query.HabitID)
+	now := query.Date
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+	today := toDate(now)
+
+	// Creation dates are compared against local calendar days, so the habit
+	// is evaluated with its creation time expressed in the user's timezone.
+	localHabit := *habit
+	localHabit.CreatedAt = toDate(habit.CreatedAt.In(now.Location()))
+
+	from := localHabit.CreatedAt
+	if !query.From.IsZero() {
+		from = toDate(query.From)
+	}
+	to := today
+	if !query.To.IsZero() {
+		to = toDate(query.To)
+	}
+	ranged := !query.From.IsZero() || !query.To.IsZero()
+	if to.Before(from) || (ranged && int(to.Sub(from).Hours()/24) >= maxStatsDays) {
+		return nil, errors.ErrInvalidInput
+	}
+
+	cached, err := h.findStats(ctx, habit)
 	if err != nil {
 		return nil, err
 	}
 
-	stats := &HabitStatsDTO{
-		HabitID:   habit.ID,
-		HabitName: habit.Name,
+	// Period metrics only look back as far as the same month of the previous
+	// year, so only that window of entries is loaded; lifetime counters come
+	// from the materialized stats, and the strength score carries on from the
+	// checkpoint saved there.
+	weekFrom := utils.StartOfWeek(today, query.WeekStart)
+	monthFrom := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	historyFrom := monthFrom.AddDate(-1, 0, 0)
+
+	windowFrom := historyFrom
+	windowTo := maxDate(monthFrom.AddDate(0, 1, -1), weekFrom.AddDate(0, 0, 6))
+	if ranged {
+		windowFrom = minDate(windowFrom, from)
+		windowTo = maxDate(windowTo, to)
 	}
 
-	if len(entries) == 0 {
-		return stats, nil
+	var seed *strengthCheckpoint
+	if cached.StrengthDate != nil && cached.StrengthDate.Before(historyFrom) {
+		seed = &strengthCheckpoint{Date: *cached.StrengthDate, Score: cached.Strength}
+		windowFrom = minDate(windowFrom, seed.Date.AddDate(0, 0, 1))
 	}
 
-	stats.TotalCompletions = len(entries)
-	stats.CurrentStreak = calculateCurrentStreak(entries)
-	stats.LongestStreak = calculateLongestStreak(entries)
-	stats.CompletionRate = calculateCompletionRate(entries, habit.CreatedAt)
-	stats.CompletionsThisWeek = countCompletionsInPeriod(entries, 7)
-	stats.CompletionsThisMonth = countCompletionsInPeriod(entries, 30)
+	var entries []*entities.HabitEntry
+	if seed == nil {
+		// The score starts at the habit's first occurrence, so the first
+		// read without a checkpoint needs every entry.
+		entries, err = h.entryRepo.FindByHabitID(ctx, habit.ID)
+	} else {
+		entries, err = h.entryRepo.FindByHabitIDAndDateRange(ctx, habit.ID, windowFrom, windowTo)
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	return stats, nil
-}
+	stats := &HabitStatsDTO{
+		HabitID:           habit.ID,
+		HabitName:         habit.Name,
+		From:              from,
+		To:                to,
+		WeekStart:         query.WeekStart.String(),
+		LastCompletedDate: cached.LastCompletedDate,
+	}
 
-func calculateCurrentStreak(entries []*entities.HabitEntry) int {
-	if len(entries) == 0 {
-		return 0
+	var checkpoint strengthCheckpoint
+	stats.Strength, stats.StrengthHistory, checkpoint = calculateStrength(&localHabit, entries, seed, historyFrom, today)
+	if seed == nil || seed.Date.Before(checkpoint.Date) {
+		if err := h.statsRepo.SaveStrength(ctx, habit.ID, checkpoint.Date, checkpoint.Score); err != nil {
+			return nil, err
+		}
 	}
+	stats.Trend = classifyTrend(&localHabit, entries, today)
+	stats.Comparisons = comparePeriods(&localHabit, entries, today, query.WeekStart)
+	stats.CompletionsThisWeek = countCompletionsInPeriod(entries, weekFrom, weekFrom.AddDate(0, 0, 6))
+	stats.CompletionsThisMonth = countCompletionsInPeriod(entries, monthFrom, monthFrom.AddDate(0, 1, -1))
+	stats.CurrentStreak = cached.CurrentStreakAt(&localHabit, today)
 
-	dateMap := make(map[string]bool)
-	for _, entry := range entries {
-		dateStr := entry.ScheduledDate.Format("2006-01-02")
-		dateMap[dateStr] = true
+	if !ranged {
+		stats.TotalCompletions = cached.TotalCompletions
+		stats.LongestStreak = cached.LongestStreak
+		stats.CompletionRate = lifetimeCompletionRate(&localHabit, cached, today)
+		return stats, nil
 	}
 
-	streak := 0
-	currentDate := time.Now().UTC()
+	done := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		date := toDate(entry.ScheduledDate)
+		done[date.Format("2006-01-02")] = true
+	}
 
-	for {
-		dateStr := currentDate.Format("2006-01-02")
-		if !dateMap[dateStr] {
-			break
-		}
-		streak++
-		currentDate = currentDate.AddDate(0, 0, -1)
+	stats.TotalCompletions = countCompletionsInPeriod(entries, from, to)
+	stats.CompletionRate = summarizePeriod(&localHabit, indexEntriesByDate(entries), maxDate(from, localHabit.CreatedAt), to).CompletionRate
+	stats.LongestStreak = longestScheduledStreak(&localHabit, done, from, to)
+	if to.Before(today) {
+		stats.CurrentStreak = scheduledStreak(&localHabit, done, to)
 	}
 
-	return streak
+	return stats, nil
 }
 
-func calculateLongestStreak(entries []*entities.HabitEntry) int {
-	if len(entries) == 0 {
-		return 0
+// findStats returns the materialized stats of the habit. Habits whose stats
+// were never materialized have them rebuilt from their entries once and
+// saved, so later reads do not load the whole history again.
+func (h *GetHabitStatsHandler) findStats(ctx context.Context, habit *entities.Habit) (*entities.HabitStats, error) {
+	stats, err := h.statsRepo.FindByHabitID(ctx, habit.ID)
+	if err != errors.ErrNotFound {
+		return stats, err
 	}
 
-	dateMap := make(map[string]bool)
-	var dates []time.Time
-	for _, entry := range entries {
-		date := time.Date(entry.ScheduledDate.Year(), entry.ScheduledDate.Month(), entry.ScheduledDate.Day(), 0, 0, 0, 0, time.UTC)
-		dateStr := date.Format("2006-01-02")
-		if !dateMap[dateStr] {
-			dateMap[dateStr] = true
-			dates = append(dates, date)
-		}
+	entries, err := h.entryRepo.FindByHabitID(ctx, habit.ID)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(dates) == 0 {
-		return 0
+	stats = entities.NewHabitStats(habit, entries)
+	if err := h.statsRepo.Save(ctx, stats); err != nil {
+		return nil, err
 	}
 
-	longestStreak := 1
-	currentStreak := 1
+	return stats, nil
+}
+
+// longestScheduledStreak returns the longest run of completed occurrences
+// between from and to. Days the habit is not scheduled on do not break a run.
+func longestScheduledStreak(habit *entities.Habit, done map[string]bool, from, to time.Time) int {
+	createdDate := toDate(habit.CreatedAt)
+	longest := 0
+	current := 0
 
-	for i := 1; i < len(dates); i++ {
-		diff := dates[i].Sub(dates[i-1]).Hours() / 24
-		if diff == 1 {
-			currentStreak++
-			if currentStreak > longestStreak {
-				longestStreak = currentStreak
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		if done[date.Format("2006-01-02")] {
+			current++
+			if current > longest {
+				longest = current
 			}
-		} else {
-			currentStreak = 1
+			continue
 		}
-	}
 
-	return longestStreak
-}
+		if date.Before(createdDate) {
+			continue
+		}
 
-func calculateCompletionRate(entries []*entities.HabitEntry, createdAt time.Time) float64 {
-	if len(entries) == 0 {
-		return 0
+		if utils.ShouldAppearToday(string(habit.Frequency), habit.SpecificDays, habit.SpecificDates, date) {
+			current = 0
+		}
 	}
 
-	daysSinceCreation := int(time.Since(createdAt).Hours() / 24)
-	if daysSinceCreation == 0 {
-		daysSinceCreation = 1
-	}
+	return longest
+}
 
-	rate := float64(len(entries)) / float64(daysSinceCreation) * 100
-	if rate > 100 {
-		rate = 100
+func lifetimeCompletionRate(habit *entities.Habit, stats *entities.HabitStats, today time.Time) float64 {
+	scheduled := habit.OccurrencesBetween(habit.CreatedAt, today)
+	if scheduled == 0 {
+		return 0
 	}
 
-	return rate
+	return math.Min(float64(stats.ScheduledCompletions)/float64(scheduled)*100, 100)
 }
 
-func countCompletionsInPeriod(entries []*entities.HabitEntry, days int) int {
-	cutoff := time.Now().UTC().AddDate(0, 0, -days)
+func countCompletionsInPeriod(entries []*entities.HabitEntry, from, to time.Time) int {
 	count := 0
 
 	for _, entry := range entries {
-		if entry.ScheduledDate.After(cutoff) {
+		date := toDate(entry.ScheduledDate)
+		if !date.Before(from) && !date.After(to) {
 			count++
 		}
 	}
 
 	return count
 }
+
+func maxDate(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,351 @@
+package queries
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type mockStatsEntryRepo struct {
+	mockEntryRepo
+}
+
+func (m *mockStatsEntryRepo) FindByHabitID(ctx context.Context, habitID string) ([]*entities.HabitEntry, error) {
+	return m.entries, nil
+}
+
+type mockHabitStatsRepo struct {
+	stats *entities.HabitStats
+}
+
+func (m *mockHabitStatsRepo) FindByHabitID(ctx context.Context, habitID string) (*entities.HabitStats, error) {
+	if m.stats == nil {
+		return nil, errors.ErrNotFound
+	}
+	return m.stats, nil
+}
+
+func (m *mockHabitStatsRepo) FindByUserID(ctx context.Context, userID string) ([]*entities.HabitStats, error) {
+	if m.stats == nil {
+		return nil, nil
+	}
+	return []*entities.HabitStats{m.stats}, nil
+}
+
+func (m *mockHabitStatsRepo) Save(ctx context.Context, stats *entities.HabitStats) error {
+	m.stats = stats
+	return nil
+}
+
+func (m *mockHabitStatsRepo) SaveStrength(ctx context.Context, habitID string, date time.Time, strength float64) error {
+	if m.stats != nil {
+		m.stats.StrengthDate = &date
+		m.stats.Strength = strength
+	}
+	return nil
+}
+
+func newStatsHandler(habit *entities.Habit, entries []*entities.HabitEntry) *GetHabitStatsHandler {
+	return NewGetHabitStatsHandler(
+		&mockHeatmapHabitRepo{mockHabitRepo{habits: []*entities.Habit{habit}}},
+		&mockStatsEntryRepo{mockEntryRepo{entries: entries}},
+		&mockHabitStatsRepo{},
+	)
+}
+
+func TestGetHabitStatsHandler_CalendarPeriods(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+	habit.CreatedAt = time.Date(2025, 2, 20, 12, 0, 0, 0, time.UTC)
+
+	// Sunday 2025-03-02 to Tuesday 2025-03-04 every day, plus the last two
+	// days of February.
+	entries := dailyEntries(habit.ID, time.Date(2025, 2, 27, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC), nil)
+
+	handler := newStatsHandler(habit, entries)
+	now := time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		weekStart time.Weekday
+		thisWeek  int
+	}{
+		{"Week starts on Monday", time.Monday, 2},
+		{"Week starts on Sunday", time.Sunday, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := handler.Handle(context.Background(), GetHabitStatsQuery{
+				HabitID:   habit.ID,
+				UserID:    "user-123",
+				Date:      now,
+				WeekStart: tt.weekStart,
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if stats.CompletionsThisWeek != tt.thisWeek {
+				t.Errorf("Expected %d completions this week, got %d", tt.thisWeek, stats.CompletionsThisWeek)
+			}
+			if stats.CompletionsThisMonth != 4 {
+				t.Errorf("Expected 4 completions this month, got %d", stats.CompletionsThisMonth)
+			}
+			if stats.CurrentStreak != 6 || stats.LongestStreak != 6 {
+				t.Errorf("Expected current and longest streak of 6, got %d and %d", stats.CurrentStreak, stats.LongestStreak)
+			}
+		})
+	}
+}
+
+func TestGetHabitStatsHandler_UsesUserTimezone(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+	habit.CreatedAt = time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC)
+
+	entries := []*entities.HabitEntry{
+		entities.NewHabitEntry(habit.ID, time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), nil),
+	}
+
+	// 2025-03-01 02:00 UTC is still February 28th in New York.
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	stats, err := newStatsHandler(habit, entries).Handle(context.Background(), GetHabitStatsQuery{
+		HabitID:   habit.ID,
+		UserID:    "user-123",
+		Date:      habit.CreatedAt.Add(time.Hour).In(loc),
+		WeekStart: time.Monday,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !stats.To.Equal(time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected today to be 2025-02-28, got %s", stats.To)
+	}
+	if stats.CompletionsThisMonth != 1 {
+		t.Errorf("Expected 1 completion in February, got %d", stats.CompletionsThisMonth)
+	}
+	if stats.CompletionRate != 100 {
+		t.Errorf("Expected 100%% completion rate, got %f", stats.CompletionRate)
+	}
+	if stats.CurrentStreak != 1 {
+		t.Errorf("Expected current streak of 1, got %d", stats.CurrentStreak)
+	}
+}
+
+func TestGetHabitStatsHandler_DateRange(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+	habit.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	entries := append(
+		dailyEntries(habit.ID, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), nil),
+		dailyEntries(habit.ID, time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC), nil)...,
+	)
+
+	handler := newStatsHandler(habit, entries)
+
+	stats, err := handler.Handle(context.Background(), GetHabitStatsQuery{
+		HabitID:   habit.ID,
+		UserID:    "user-123",
+		Date:      time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		WeekStart: time.Monday,
+		From:      time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC),
+		To:        time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if stats.TotalCompletions != 6 {
+		t.Errorf("Expected 6 completions in range, got %d", stats.TotalCompletions)
+	}
+	if stats.CompletionRate != 60 {
+		t.Errorf("Expected 60%% completion rate, got %f", stats.CompletionRate)
+	}
+	if stats.LongestStreak != 3 {
+		t.Errorf("Expected longest streak of 3 within range, got %d", stats.LongestStreak)
+	}
+	if stats.CurrentStreak != 3 {
+		t.Errorf("Expected streak of 3 at the end of the range, got %d", stats.CurrentStreak)
+	}
+
+	_, err = handler.Handle(context.Background(), GetHabitStatsQuery{
+		HabitID: habit.ID,
+		UserID:  "user-123",
+		From:    time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != errors.ErrInvalidInput {
+		t.Errorf("Expected ErrInvalidInput for inverted range, got %v", err)
+	}
+
+	_, err = handler.Handle(context.Background(), GetHabitStatsQuery{
+		HabitID: habit.ID,
+		UserID:  "user-123",
+		From:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != errors.ErrInvalidInput {
+		t.Errorf("Expected ErrInvalidInput for a range over %d days, got %v", maxStatsDays, err)
+	}
+}
+
+func TestGetHabitStatsHandler_ReadsMaterializedStats(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+	habit.CreatedAt = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	lastCompleted := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	cached := &entities.HabitStats{
+		HabitID:              habit.ID,
+		TotalCompletions:     1500,
+		ScheduledCompletions: 1500,
+		CurrentStreak:        40,
+		LongestStreak:        200,
+		LastCompletedDate:    &lastCompleted,
+	}
+
+	handler := NewGetHabitStatsHandler(
+		&mockHeatmapHabitRepo{mockHabitRepo{habits: []*entities.Habit{habit}}},
+		&mockStatsEntryRepo{mockEntryRepo{}},
+		&mockHabitStatsRepo{stats: cached},
+	)
+
+	stats, err := handler.Handle(context.Background(), GetHabitStatsQuery{
+		HabitID:   habit.ID,
+		UserID:    "user-123",
+		Date:      time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC),
+		WeekStart: time.Monday,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if stats.TotalCompletions != 1500 || stats.LongestStreak != 200 {
+		t.Errorf("Expected cached totals, got %d completions and longest streak %d", stats.TotalCompletions, stats.LongestStreak)
+	}
+	if stats.CurrentStreak != 40 {
+		t.Errorf("Expected current streak of 40 while today is pending, got %d", stats.CurrentStreak)
+	}
+	if stats.LastCompletedDate == nil || !stats.LastCompletedDate.Equal(lastCompleted) {
+		t.Errorf("Expected last completed date %s, got %v", lastCompleted, stats.LastCompletedDate)
+	}
+
+	stats, err = handler.Handle(context.Background(), GetHabitStatsQuery{
+		HabitID:   habit.ID,
+		UserID:    "user-123",
+		Date:      time.Date(2025, 3, 5, 10, 0, 0, 0, time.UTC),
+		WeekStart: time.Monday,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if stats.CurrentStreak != 0 {
+		t.Errorf("Expected streak to break after a missed day, got %d", stats.CurrentStreak)
+	}
+}
+
+func TestGetHabitStatsHandler_SavesRebuiltStats(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+	habit.CreatedAt = time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	entries := dailyEntries(habit.ID, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), nil)
+	statsRepo := &mockHabitStatsRepo{}
+
+	handler := NewGetHabitStatsHandler(
+		&mockHeatmapHabitRepo{mockHabitRepo{habits: []*entities.Habit{habit}}},
+		&mockStatsEntryRepo{mockEntryRepo{entries: entries}},
+		statsRepo,
+	)
+
+	stats, err := handler.Handle(context.Background(), GetHabitStatsQuery{
+		HabitID:   habit.ID,
+		UserID:    "user-123",
+		Date:      time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC),
+		WeekStart: time.Monday,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if stats.TotalCompletions != 3 || stats.CompletionRate != 75 {
+		t.Errorf("Expected 3 completions at 75%%, got %d at %v", stats.TotalCompletions, stats.CompletionRate)
+	}
+	if statsRepo.stats == nil || statsRepo.stats.TotalCompletions != 3 {
+		t.Errorf("Expected the rebuilt stats to be saved, got %+v", statsRepo.stats)
+	}
+}
+
+// windowedEntryRepo only returns the entries within the requested range and
+// counts the reads of the whole history.
+type windowedEntryRepo struct {
+	mockStatsEntryRepo
+	fullReads int
+}
+
+func (m *windowedEntryRepo) FindByHabitID(ctx context.Context, habitID string) ([]*entities.HabitEntry, error) {
+	m.fullReads++
+	return m.entries, nil
+}
+
+func (m *windowedEntryRepo) FindByHabitIDAndDateRange(ctx context.Context, habitID string, from, to time.Time) ([]*entities.HabitEntry, error) {
+	var result []*entities.HabitEntry
+	for _, entry := range m.entries {
+		if !entry.ScheduledDate.Before(from) && !entry.ScheduledDate.After(to) {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+func TestGetHabitStatsHandler_CarriesStrengthFromCheckpoint(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+	habit.CreatedAt = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	entries := dailyEntries(habit.ID, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), nil)
+	entryRepo := &windowedEntryRepo{mockStatsEntryRepo: mockStatsEntryRepo{mockEntryRepo{entries: entries}}}
+	statsRepo := &mockHabitStatsRepo{stats: entities.NewHabitStats(habit, entries)}
+	handler := NewGetHabitStatsHandler(&mockHeatmapHabitRepo{mockHabitRepo{habits: []*entities.Habit{habit}}}, entryRepo, statsRepo)
+
+	query := GetHabitStatsQuery{
+		HabitID:   habit.ID,
+		UserID:    "user-123",
+		Date:      time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC),
+		WeekStart: time.Monday,
+	}
+	first, err := handler.Handle(context.Background(), query)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if statsRepo.stats.StrengthDate == nil || !statsRepo.stats.StrengthDate.Equal(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Expected the strength checkpoint on the day before the history, got %v", statsRepo.stats.StrengthDate)
+	}
+
+	second, err := handler.Handle(context.Background(), query)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if entryRepo.fullReads != 1 {
+		t.Errorf("Expected the whole history to be read once, got %d reads", entryRepo.fullReads)
+	}
+	if math.Abs(second.Strength-first.Strength) > 1e-9 || len(second.StrengthHistory) != len(first.StrengthHistory) {
+		t.Errorf("Expected the carried strength %f to match %f", second.Strength, first.Strength)
+	}
+	if first.StrengthHistory[0].Strength < 99 {
+		t.Errorf("Expected the score to build up from the habit's first occurrence, got %f", first.StrengthHistory[0].Strength)
+	}
+}
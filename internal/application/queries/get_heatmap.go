@@ -0,0 +1,193 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/shared/errors"
+	"apocapoc-api/internal/shared/utils"
+)
+
+const (
+	HeatmapLevelNone    = "none"
+	HeatmapLevelPartial = "partial"
+	HeatmapLevelFull    = "full"
+)
+
+const maxHeatmapDays = 366
+
+type HeatmapDayDTO struct {
+	Date      time.Time `json:"date"`
+	Level     string    `json:"level"`
+	Intensity float64   `json:"intensity"`
+	Scheduled int       `json:"scheduled"`
+	Completed int       `json:"completed"`
+	Value     *float64  `json:"value,omitempty"`
+}
+
+type HeatmapDTO struct {
+	HabitID string          `json:"habit_id,omitempty"`
+	From    time.Time       `json:"from"`
+	To      time.Time       `json:"to"`
+	Days    []HeatmapDayDTO `json:"days"`
+}
+
+type GetHeatmapQuery struct {
+	UserID  string
+	HabitID string
+	From    time.Time
+	To      time.Time
+}
+
+type GetHeatmapHandler struct {
+	habitRepo repositories.HabitRepository
+	entryRepo repositories.HabitEntryRepository
+}
+
+func NewGetHeatmapHandler(
+	habitRepo repositories.HabitRepository,
+	entryRepo repositories.HabitEntryRepository,
+) *GetHeatmapHandler {
+	return &GetHeatmapHandler{
+		habitRepo: habitRepo,
+		entryRepo: entryRepo,
+	}
+}
+
+func (h *GetHeatmapHandler) Handle(ctx context.Context, query GetHeatmapQuery) (*HeatmapDTO, error) {
+	from := toDate(query.From)
+	to := toDate(query.To)
+
+	if to.Before(from) || int(to.Sub(from).Hours()/24) >= maxHeatmapDays {
+		return nil, errors.ErrInvalidInput
+	}
+
+	var (
+		habits  []*entities.Habit
+		entries []*entities.HabitEntry
+		err     error
+	)
+
+	if query.HabitID != "" {
+		habit, err := h.habitRepo.FindByID(ctx, query.HabitID)
+		if err != nil {
+			return nil, err
+		}
+
+		if habit.UserID != query.UserID {
+			return nil, errors.ErrUnauthorized
+		}
+
+		habits = []*entities.Habit{habit}
+		entries, err = h.entryRepo.FindByHabitIDAndDateRange(ctx, habit.ID, from, to)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		habits, err = h.habitRepo.FindByUserID(ctx, query.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		entries, err = h.entryRepo.FindByUserIDAndDateRange(ctx, query.UserID, from, to)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	days := int(to.Sub(from).Hours()/24) + 1
+	intensities := make([]float64, days)
+	result := &HeatmapDTO{
+		HabitID: query.HabitID,
+		From:    from,
+		To:      to,
+		Days:    make([]HeatmapDayDTO, days),
+	}
+
+	for i := range result.Days {
+		result.Days[i].Date = from.AddDate(0, 0, i)
+	}
+
+	entriesByHabit := make(map[string]map[int]*entities.HabitEntry)
+	for _, entry := range entries {
+		if entriesByHabit[entry.HabitID] == nil {
+			entriesByHabit[entry.HabitID] = make(map[int]*entities.HabitEntry)
+		}
+		entriesByHabit[entry.HabitID][int(toDate(entry.ScheduledDate).Sub(from).Hours()/24)] = entry
+	}
+
+	for _, habit := range habits {
+		habitEntries := entriesByHabit[habit.ID]
+		maxValue := maxEntryValue(habitEntries)
+		createdDate := toDate(habit.CreatedAt)
+
+		for i := range result.Days {
+			day := &result.Days[i]
+			entry := habitEntries[i]
+
+			active := !day.Date.Before(createdDate) && (habit.ArchivedAt == nil || day.Date.Before(toDate(*habit.ArchivedAt)))
+			scheduled := active && utils.ShouldAppearToday(string(habit.Frequency), habit.SpecificDays, habit.SpecificDates, day.Date)
+
+			if !scheduled && entry == nil {
+				continue
+			}
+
+			day.Scheduled++
+			if entry == nil {
+				continue
+			}
+
+			day.Completed++
+			intensities[i] += heatmapIntensity(habit, entry, maxValue)
+
+			if query.HabitID != "" {
+				day.Value = entry.Value
+			}
+		}
+	}
+
+	for i := range result.Days {
+		day := &result.Days[i]
+		if day.Scheduled > 0 {
+			day.Intensity = intensities[i] / float64(day.Scheduled)
+		}
+		day.Level = heatmapLevel(day.Intensity)
+	}
+
+	return result, nil
+}
+
+func heatmapIntensity(habit *entities.Habit, entry *entities.HabitEntry, maxValue float64) float64 {
+	if habit.Type == value_objects.HabitTypeValue && (habit.TargetValue == nil || *habit.TargetValue <= 0) {
+		if entry.Value == nil || maxValue <= 0 {
+			return 1
+		}
+		return *entry.Value / maxValue
+	}
+
+	return entryCompletion(habit, entry)
+}
+
+func heatmapLevel(intensity float64) string {
+	switch {
+	case intensity <= 0:
+		return HeatmapLevelNone
+	case intensity >= 1:
+		return HeatmapLevelFull
+	default:
+		return HeatmapLevelPartial
+	}
+}
+
+func maxEntryValue(entries map[int]*entities.HabitEntry) float64 {
+	maxValue := 0.0
+	for _, entry := range entries {
+		if entry.Value != nil && *entry.Value > maxValue {
+			maxValue = *entry.Value
+		}
+	}
+	return maxValue
+}
@@ -0,0 +1,158 @@
+package queries
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type mockHeatmapHabitRepo struct {
+	mockHabitRepo
+}
+
+func (m *mockHeatmapHabitRepo) FindByID(ctx context.Context, id string) (*entities.Habit, error) {
+	for _, habit := range m.habits {
+		if habit.ID == id {
+			return habit, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (m *mockHeatmapHabitRepo) FindByUserID(ctx context.Context, userID string) ([]*entities.Habit, error) {
+	return m.habits, nil
+}
+
+type mockHeatmapEntryRepo struct {
+	mockEntryRepo
+}
+
+func (m *mockHeatmapEntryRepo) FindByUserIDAndDateRange(ctx context.Context, userID string, from, to time.Time) ([]*entities.HabitEntry, error) {
+	return m.entries, nil
+}
+
+func TestGetHeatmapHandler_AllHabits(t *testing.T) {
+	exercise := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	exercise.ID = "habit-1"
+	exercise.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	reading := entities.NewHabit("user-123", "Reading", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	reading.ID = "habit-2"
+	reading.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	entries := []*entities.HabitEntry{
+		entities.NewHabitEntry("habit-1", time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), nil),
+		entities.NewHabitEntry("habit-1", time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), nil),
+		entities.NewHabitEntry("habit-2", time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), nil),
+	}
+
+	handler := NewGetHeatmapHandler(
+		&mockHeatmapHabitRepo{mockHabitRepo{habits: []*entities.Habit{exercise, reading}}},
+		&mockHeatmapEntryRepo{mockEntryRepo{entries: entries}},
+	)
+
+	heatmap, err := handler.Handle(context.Background(), GetHeatmapQuery{
+		UserID: "user-123",
+		From:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC),
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(heatmap.Days) != 3 {
+		t.Fatalf("Expected 3 days, got %d", len(heatmap.Days))
+	}
+
+	expected := []string{HeatmapLevelNone, HeatmapLevelPartial, HeatmapLevelFull}
+	for i, level := range expected {
+		if heatmap.Days[i].Level != level {
+			t.Errorf("Expected level %s on %s, got %s", level, heatmap.Days[i].Date.Format("2006-01-02"), heatmap.Days[i].Level)
+		}
+	}
+
+	if heatmap.Days[1].Intensity != 0.5 {
+		t.Errorf("Expected intensity 0.5, got %f", heatmap.Days[1].Intensity)
+	}
+}
+
+func TestGetHeatmapHandler_ValueHabitNormalizedToMax(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Sleep score", value_objects.HabitTypeValue, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+	habit.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	low := 40.0
+	high := 80.0
+	entries := []*entities.HabitEntry{
+		entities.NewHabitEntry("habit-1", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), &low),
+		entities.NewHabitEntry("habit-1", time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), &high),
+	}
+
+	handler := NewGetHeatmapHandler(
+		&mockHeatmapHabitRepo{mockHabitRepo{habits: []*entities.Habit{habit}}},
+		&mockEntryRepo{entries: entries},
+	)
+
+	heatmap, err := handler.Handle(context.Background(), GetHeatmapQuery{
+		UserID:  "user-123",
+		HabitID: "habit-1",
+		From:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if heatmap.Days[0].Intensity != 0.5 || heatmap.Days[0].Level != HeatmapLevelPartial {
+		t.Errorf("Expected partial intensity 0.5, got %s %f", heatmap.Days[0].Level, heatmap.Days[0].Intensity)
+	}
+
+	if heatmap.Days[1].Intensity != 1 || heatmap.Days[1].Level != HeatmapLevelFull {
+		t.Errorf("Expected full intensity 1, got %s %f", heatmap.Days[1].Level, heatmap.Days[1].Intensity)
+	}
+
+	if heatmap.Days[1].Value == nil || *heatmap.Days[1].Value != high {
+		t.Errorf("Expected raw value %f, got %v", high, heatmap.Days[1].Value)
+	}
+}
+
+func TestGetHeatmapHandler_RejectsRangeOverOneYear(t *testing.T) {
+	handler := NewGetHeatmapHandler(&mockHeatmapHabitRepo{}, &mockHeatmapEntryRepo{})
+
+	_, err := handler.Handle(context.Background(), GetHeatmapQuery{
+		UserID: "user-123",
+		From:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	if err != errors.ErrInvalidInput {
+		t.Errorf("Expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestGetHeatmapHandler_ReturnsErrorWhenUserDoesNotOwnHabit(t *testing.T) {
+	habit := entities.NewHabit("other-user", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+
+	handler := NewGetHeatmapHandler(
+		&mockHeatmapHabitRepo{mockHabitRepo{habits: []*entities.Habit{habit}}},
+		&mockHeatmapEntryRepo{},
+	)
+
+	_, err := handler.Handle(context.Background(), GetHeatmapQuery{
+		UserID:  "user-123",
+		HabitID: "habit-1",
+		From:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+	})
+
+	if err != errors.ErrUnauthorized {
+		t.Errorf("Expected ErrUnauthorized, got %v", err)
+	}
+}
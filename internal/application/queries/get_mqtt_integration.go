@@ -0,0 +1,57 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+)
+
+// MQTTIntegrationDTO leaves out the broker password, which is never returned
+// once saved. HasPassword tells whether one is stored.
+type MQTTIntegrationDTO struct {
+	BrokerURL       string    `json:"broker_url"`
+	Username        string    `json:"username"`
+	HasPassword     bool      `json:"has_password"`
+	TopicPrefix     string    `json:"topic_prefix"`
+	DiscoveryPrefix string    `json:"discovery_prefix"`
+	Timezone        string    `json:"timezone"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func NewMQTTIntegrationDTO(integration *entities.MQTTIntegration) *MQTTIntegrationDTO {
+	return &MQTTIntegrationDTO{
+		BrokerURL:       integration.BrokerURL,
+		Username:        integration.Username,
+		HasPassword:     integration.Password != "",
+		TopicPrefix:     integration.TopicPrefix,
+		DiscoveryPrefix: integration.DiscoveryPrefix,
+		Timezone:        integration.Timezone,
+		UpdatedAt:       integration.UpdatedAt,
+	}
+}
+
+type GetMQTTIntegrationQuery struct {
+	UserID string
+}
+
+type GetMQTTIntegrationHandler struct {
+	integrationRepo repositories.MQTTIntegrationRepository
+}
+
+func NewGetMQTTIntegrationHandler(integrationRepo repositories.MQTTIntegrationRepository) *GetMQTTIntegrationHandler {
+	return &GetMQTTIntegrationHandler{
+		integrationRepo: integrationRepo,
+	}
+}
+
+// Handle returns ErrNotFound when the user has no MQTT integration.
+func (h *GetMQTTIntegrationHandler) Handle(ctx context.Context, query GetMQTTIntegrationQuery) (*MQTTIntegrationDTO, error) {
+	integration, err := h.integrationRepo.FindByUserID(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMQTTIntegrationDTO(integration), nil
+}
@@ -0,0 +1,47 @@
+package queries
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+)
+
+// NotificationPreferencesDTO tells, for every category, whether the user
+// receives it on each channel it is sent on.
+type NotificationPreferencesDTO map[entities.NotificationCategory]map[entities.NotificationChannel]bool
+
+type GetNotificationPreferencesQuery struct {
+	UserID string
+}
+
+type GetNotificationPreferencesHandler struct {
+	preferenceRepo repositories.NotificationPreferenceRepository
+}
+
+func NewGetNotificationPreferencesHandler(preferenceRepo repositories.NotificationPreferenceRepository) *GetNotificationPreferencesHandler {
+	return &GetNotificationPreferencesHandler{
+		preferenceRepo: preferenceRepo,
+	}
+}
+
+func (h *GetNotificationPreferencesHandler) Handle(ctx context.Context, query GetNotificationPreferencesQuery) (NotificationPreferencesDTO, error) {
+	preferences, err := h.preferenceRepo.FindByUserID(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewNotificationPreferencesDTO(preferences), nil
+}
+
+func NewNotificationPreferencesDTO(preferences entities.NotificationPreferences) NotificationPreferencesDTO {
+	dto := NotificationPreferencesDTO{}
+	for _, category := range entities.NotificationCategories {
+		channels := map[entities.NotificationChannel]bool{}
+		for _, channel := range category.Channels() {
+			channels[channel] = preferences.IsEnabled(category, channel)
+		}
+		dto[category] = channels
+	}
+	return dto
+}
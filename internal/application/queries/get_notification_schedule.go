@@ -0,0 +1,52 @@
+package queries
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type NotificationScheduleDTO struct {
+	QuietHoursStart string `json:"quiet_hours_start"`
+	QuietHoursEnd   string `json:"quiet_hours_end"`
+	Timezone        string `json:"timezone"`
+	MaxPerHour      int    `json:"max_per_hour"`
+}
+
+type GetNotificationScheduleQuery struct {
+	UserID string
+}
+
+type GetNotificationScheduleHandler struct {
+	scheduleRepo repositories.NotificationScheduleRepository
+}
+
+func NewGetNotificationScheduleHandler(scheduleRepo repositories.NotificationScheduleRepository) *GetNotificationScheduleHandler {
+	return &GetNotificationScheduleHandler{
+		scheduleRepo: scheduleRepo,
+	}
+}
+
+// Handle returns the user's quiet hours and hourly limit, which are unset
+// until the user sets them.
+func (h *GetNotificationScheduleHandler) Handle(ctx context.Context, query GetNotificationScheduleQuery) (*NotificationScheduleDTO, error) {
+	schedule, err := h.scheduleRepo.FindByUserID(ctx, query.UserID)
+	if err == errors.ErrNotFound {
+		schedule = entities.NewNotificationSchedule(query.UserID)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return NewNotificationScheduleDTO(schedule), nil
+}
+
+func NewNotificationScheduleDTO(schedule *entities.NotificationSchedule) *NotificationScheduleDTO {
+	return &NotificationScheduleDTO{
+		QuietHoursStart: schedule.QuietHoursStart,
+		QuietHoursEnd:   schedule.QuietHoursEnd,
+		Timezone:        schedule.Timezone,
+		MaxPerHour:      schedule.MaxPerHour,
+	}
+}
@@ -0,0 +1,117 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+const (
+	defaultOutgoingEmailsLimit = 50
+	maxOutgoingEmailsLimit     = 200
+)
+
+// OutgoingEmailDTO leaves out the body, which may hold verification and
+// password reset links.
+type OutgoingEmailDTO struct {
+	ID            string     `json:"id"`
+	To            string     `json:"to"`
+	Subject       string     `json:"subject"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+}
+
+type EmailOutboxDTO struct {
+	Pending int                `json:"pending"`
+	Failed  int                `json:"failed"`
+	Sent    int                `json:"sent"`
+	Emails  []OutgoingEmailDTO `json:"emails"`
+}
+
+type GetEmailOutboxQuery struct {
+	// Status selects PENDING or FAILED emails. Both are returned when empty.
+	Status string
+	Limit  int
+}
+
+type GetEmailOutboxHandler struct {
+	emailOutboxRepo repositories.EmailOutboxRepository
+}
+
+func NewGetEmailOutboxHandler(emailOutboxRepo repositories.EmailOutboxRepository) *GetEmailOutboxHandler {
+	return &GetEmailOutboxHandler{
+		emailOutboxRepo: emailOutboxRepo,
+	}
+}
+
+// Handle returns the email counts by status and the most recent emails that
+// are still pending or have failed, newest first.
+func (h *GetEmailOutboxHandler) Handle(ctx context.Context, query GetEmailOutboxQuery) (*EmailOutboxDTO, error) {
+	statuses := []entities.OutgoingEmailStatus{entities.OutgoingEmailPending, entities.OutgoingEmailFailed}
+	if query.Status != "" {
+		status := entities.OutgoingEmailStatus(query.Status)
+		if status != entities.OutgoingEmailPending && status != entities.OutgoingEmailFailed {
+			return nil, errors.ErrInvalidInput
+		}
+		statuses = []entities.OutgoingEmailStatus{status}
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultOutgoingEmailsLimit
+	}
+	if limit > maxOutgoingEmailsLimit {
+		limit = maxOutgoingEmailsLimit
+	}
+
+	counts, err := h.emailOutboxRepo.CountByStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := &EmailOutboxDTO{
+		Pending: counts[entities.OutgoingEmailPending],
+		Failed:  counts[entities.OutgoingEmailFailed],
+		Sent:    counts[entities.OutgoingEmailSent],
+		Emails:  []OutgoingEmailDTO{},
+	}
+
+	for _, status := range statuses {
+		emails, err := h.emailOutboxRepo.FindByStatus(ctx, status, limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, email := range emails {
+			dto.Emails = append(dto.Emails, *NewOutgoingEmailDTO(email))
+		}
+	}
+
+	return dto, nil
+}
+
+func NewOutgoingEmailDTO(email *entities.OutgoingEmail) *OutgoingEmailDTO {
+	dto := &OutgoingEmailDTO{
+		ID:        email.ID,
+		To:        email.To,
+		Subject:   email.Subject,
+		Status:    string(email.Status),
+		Attempts:  email.Attempts,
+		LastError: email.LastError,
+		CreatedAt: email.CreatedAt,
+		SentAt:    email.SentAt,
+	}
+
+	if email.Status == entities.OutgoingEmailPending {
+		nextAttemptAt := email.NextAttemptAt
+		dto.NextAttemptAt = &nextAttemptAt
+	}
+
+	return dto
+}
@@ -0,0 +1,69 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/pagination"
+)
+
+type PointTransactionDTO struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Points    int       `json:"points"`
+	HabitID   *string   `json:"habit_id,omitempty"`
+	EntryID   *string   `json:"entry_id,omitempty"`
+	RewardID  *string   `json:"reward_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GetPointTransactionsQuery struct {
+	UserID           string
+	PaginationParams pagination.Params
+}
+
+type GetPointTransactionsResult struct {
+	Transactions []PointTransactionDTO `json:"transactions"`
+	Pagination   pagination.Response   `json:"pagination"`
+}
+
+type GetPointTransactionsHandler struct {
+	pointsRepo repositories.PointsRepository
+}
+
+func NewGetPointTransactionsHandler(pointsRepo repositories.PointsRepository) *GetPointTransactionsHandler {
+	return &GetPointTransactionsHandler{
+		pointsRepo: pointsRepo,
+	}
+}
+
+func (h *GetPointTransactionsHandler) Handle(ctx context.Context, query GetPointTransactionsQuery) (*GetPointTransactionsResult, error) {
+	transactions, err := h.pointsRepo.FindByUserID(ctx, query.UserID, query.PaginationParams)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := h.pointsRepo.CountByUserID(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]PointTransactionDTO, 0, len(transactions))
+	for _, transaction := range transactions {
+		dtos = append(dtos, PointTransactionDTO{
+			ID:        transaction.ID,
+			Type:      string(transaction.Type),
+			Points:    transaction.Points,
+			HabitID:   transaction.HabitID,
+			EntryID:   transaction.EntryID,
+			RewardID:  transaction.RewardID,
+			CreatedAt: transaction.CreatedAt,
+		})
+	}
+
+	return &GetPointTransactionsResult{
+		Transactions: dtos,
+		Pagination:   pagination.NewResponse(query.PaginationParams, total),
+	}, nil
+}
@@ -0,0 +1,54 @@
+package queries
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+)
+
+type PointsSummaryDTO struct {
+	XP            int `json:"xp"`
+	Balance       int `json:"balance"`
+	Level         int `json:"level"`
+	LevelXP       int `json:"level_xp"`
+	NextLevelXP   int `json:"next_level_xp"`
+	XPToNextLevel int `json:"xp_to_next_level"`
+}
+
+type GetPointsSummaryQuery struct {
+	UserID string
+}
+
+type GetPointsSummaryHandler struct {
+	pointsRepo repositories.PointsRepository
+}
+
+func NewGetPointsSummaryHandler(pointsRepo repositories.PointsRepository) *GetPointsSummaryHandler {
+	return &GetPointsSummaryHandler{
+		pointsRepo: pointsRepo,
+	}
+}
+
+func (h *GetPointsSummaryHandler) Handle(ctx context.Context, query GetPointsSummaryQuery) (*PointsSummaryDTO, error) {
+	balance, err := h.pointsRepo.GetBalance(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPointsSummaryDTO(balance), nil
+}
+
+func NewPointsSummaryDTO(balance entities.PointsBalance) *PointsSummaryDTO {
+	level := balance.Level()
+	nextLevelXP := entities.XPForLevel(level + 1)
+
+	return &PointsSummaryDTO{
+		XP:            balance.XP,
+		Balance:       balance.Balance,
+		Level:         level,
+		LevelXP:       entities.XPForLevel(level),
+		NextLevelXP:   nextLevelXP,
+		XPToNextLevel: nextLevelXP - balance.XP,
+	}
+}
@@ -0,0 +1,143 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/utils"
+)
+
+type HabitStreakDTO struct {
+	HabitID   string `json:"habit_id"`
+	HabitName string `json:"habit_name"`
+	Streak    int    `json:"streak"`
+}
+
+type ProgressDigestDTO struct {
+	From           time.Time        `json:"from"`
+	To             time.Time        `json:"to"`
+	Scheduled      int              `json:"scheduled"`
+	Completed      int              `json:"completed"`
+	CompletionRate float64          `json:"completion_rate"`
+	StreaksGained  []HabitStreakDTO `json:"streaks_gained"`
+	StreaksLost    []HabitStreakDTO `json:"streaks_lost"`
+	BestHabit      *HabitRankDTO    `json:"best_habit,omitempty"`
+	WorstHabit     *HabitRankDTO    `json:"worst_habit,omitempty"`
+}
+
+type GetProgressDigestQuery struct {
+	UserID string
+	From   time.Time
+	To     time.Time
+}
+
+type GetProgressDigestHandler struct {
+	habitRepo repositories.HabitRepository
+	entryRepo repositories.HabitEntryRepository
+}
+
+func NewGetProgressDigestHandler(
+	habitRepo repositories.HabitRepository,
+	entryRepo repositories.HabitEntryRepository,
+) *GetProgressDigestHandler {
+	return &GetProgressDigestHandler{
+		habitRepo: habitRepo,
+		entryRepo: entryRepo,
+	}
+}
+
+// Handle summarises the user's active habits over the closed period From..To.
+// A streak is gained when a habit ends the period on a longer streak than it
+// started with, and lost when a scheduled occurrence is missed while a streak
+// was running; the longest streak broken in the period is reported.
+func (h *GetProgressDigestHandler) Handle(ctx context.Context, query GetProgressDigestQuery) (*ProgressDigestDTO, error) {
+	habits, err := h.habitRepo.FindActiveByUserID(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := h.entryRepo.FindByUserID(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	from := toDate(query.From)
+	to := toDate(query.To)
+	completed := indexEntriesByHabitAndDate(entries)
+
+	digest := &ProgressDigestDTO{
+		From:          from,
+		To:            to,
+		StreaksGained: []HabitStreakDTO{},
+		StreaksLost:   []HabitStreakDTO{},
+	}
+
+	var total completionCounter
+	habitCounters := make([]completionCounter, len(habits))
+
+	for i, habit := range habits {
+		createdDate := toDate(habit.CreatedAt)
+		done := completed[habit.ID]
+
+		startStreak := closedStreak(habit, done, from.AddDate(0, 0, -1))
+		streak := startStreak
+		longestBroken := 0
+
+		for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+			if date.Before(createdDate) {
+				continue
+			}
+
+			isScheduled := utils.ShouldAppearToday(string(habit.Frequency), habit.SpecificDays, habit.SpecificDates, date)
+			isCompleted := done[date.Format("2006-01-02")]
+
+			total.add(isScheduled, isCompleted)
+			habitCounters[i].add(isScheduled, isCompleted)
+
+			if isCompleted {
+				streak++
+			} else if isScheduled {
+				if streak > longestBroken {
+					longestBroken = streak
+				}
+				streak = 0
+			}
+		}
+
+		if streak > startStreak {
+			digest.StreaksGained = append(digest.StreaksGained, HabitStreakDTO{HabitID: habit.ID, HabitName: habit.Name, Streak: streak})
+		}
+		if longestBroken > 0 {
+			digest.StreaksLost = append(digest.StreaksLost, HabitStreakDTO{HabitID: habit.ID, HabitName: habit.Name, Streak: longestBroken})
+		}
+	}
+
+	digest.Scheduled = total.scheduled
+	digest.Completed = total.completed
+	digest.CompletionRate = total.rate()
+	digest.BestHabit, digest.WorstHabit = rankHabits(habits, habitCounters)
+
+	return digest, nil
+}
+
+// closedStreak counts consecutive completed occurrences ending on date, which
+// is treated as a finished day: missing it breaks the streak.
+func closedStreak(habit *entities.Habit, done map[string]bool, date time.Time) int {
+	createdDate := toDate(habit.CreatedAt)
+	streak := 0
+
+	for ; !date.Before(createdDate); date = date.AddDate(0, 0, -1) {
+		if done[date.Format("2006-01-02")] {
+			streak++
+			continue
+		}
+
+		if utils.ShouldAppearToday(string(habit.Frequency), habit.SpecificDays, habit.SpecificDates, date) {
+			break
+		}
+	}
+
+	return streak
+}
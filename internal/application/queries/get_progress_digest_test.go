@@ -0,0 +1,89 @@
+package queries
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+)
+
+func TestGetProgressDigestHandler_SummarisesPeriod(t *testing.T) {
+	exercise := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	exercise.ID = "habit-1"
+	exercise.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	reading := entities.NewHabit("user-123", "Reading", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	reading.ID = "habit-2"
+	reading.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var entries []*entities.HabitEntry
+	for day := 1; day <= 14; day++ {
+		entries = append(entries, entities.NewHabitEntry(exercise.ID, time.Date(2025, 1, day, 0, 0, 0, 0, time.UTC), nil))
+	}
+	// Reading enters the week on a 3 day streak, extends it to 7 and misses the 10th.
+	for _, day := range []int{3, 4, 5, 6, 7, 8, 9, 11, 12} {
+		entries = append(entries, entities.NewHabitEntry(reading.ID, time.Date(2025, 1, day, 0, 0, 0, 0, time.UTC), nil))
+	}
+
+	handler := NewGetProgressDigestHandler(
+		&mockHabitRepo{habits: []*entities.Habit{exercise, reading}},
+		&mockEntryRepoWithFindByUserID{mockEntryRepo{entries: entries}},
+	)
+
+	digest, err := handler.Handle(context.Background(), GetProgressDigestQuery{
+		UserID: "user-123",
+		From:   time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if digest.Scheduled != 14 || digest.Completed != 13 {
+		t.Errorf("Expected 13 of 14 completed, got %d of %d", digest.Completed, digest.Scheduled)
+	}
+
+	if len(digest.StreaksGained) != 1 || digest.StreaksGained[0].HabitID != exercise.ID || digest.StreaksGained[0].Streak != 12 {
+		t.Errorf("Expected exercise to gain a 12 day streak, got %+v", digest.StreaksGained)
+	}
+
+	if len(digest.StreaksLost) != 1 || digest.StreaksLost[0].HabitID != reading.ID || digest.StreaksLost[0].Streak != 7 {
+		t.Errorf("Expected reading to lose a 7 day streak, got %+v", digest.StreaksLost)
+	}
+
+	if digest.BestHabit == nil || digest.BestHabit.HabitID != exercise.ID {
+		t.Errorf("Expected best habit %s, got %+v", exercise.ID, digest.BestHabit)
+	}
+	if digest.WorstHabit == nil || digest.WorstHabit.HabitID != reading.ID {
+		t.Errorf("Expected worst habit %s, got %+v", reading.ID, digest.WorstHabit)
+	}
+}
+
+func TestGetProgressDigestHandler_EmptyPeriod(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+	habit.CreatedAt = time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	handler := NewGetProgressDigestHandler(
+		&mockHabitRepo{habits: []*entities.Habit{habit}},
+		&mockEntryRepoWithFindByUserID{},
+	)
+
+	digest, err := handler.Handle(context.Background(), GetProgressDigestQuery{
+		UserID: "user-123",
+		From:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if digest.Scheduled != 0 || digest.CompletionRate != 0 {
+		t.Errorf("Expected nothing scheduled before the habit existed, got %+v", digest)
+	}
+	if digest.BestHabit != nil || len(digest.StreaksGained) != 0 || len(digest.StreaksLost) != 0 {
+		t.Errorf("Expected an empty digest, got %+v", digest)
+	}
+}
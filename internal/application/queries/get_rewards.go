@@ -0,0 +1,57 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/repositories"
+)
+
+type RewardDTO struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Cost       int       `json:"cost"`
+	Affordable bool      `json:"affordable"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type GetRewardsQuery struct {
+	UserID string
+}
+
+type GetRewardsHandler struct {
+	rewardRepo repositories.RewardRepository
+	pointsRepo repositories.PointsRepository
+}
+
+func NewGetRewardsHandler(rewardRepo repositories.RewardRepository, pointsRepo repositories.PointsRepository) *GetRewardsHandler {
+	return &GetRewardsHandler{
+		rewardRepo: rewardRepo,
+		pointsRepo: pointsRepo,
+	}
+}
+
+func (h *GetRewardsHandler) Handle(ctx context.Context, query GetRewardsQuery) ([]RewardDTO, error) {
+	rewards, err := h.rewardRepo.FindActiveByUserID(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := h.pointsRepo.GetBalance(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]RewardDTO, 0, len(rewards))
+	for _, reward := range rewards {
+		dtos = append(dtos, RewardDTO{
+			ID:         reward.ID,
+			Name:       reward.Name,
+			Cost:       reward.Cost,
+			Affordable: balance.Balance >= reward.Cost,
+			CreatedAt:  reward.CreatedAt,
+		})
+	}
+
+	return dtos, nil
+}
@@ -0,0 +1,39 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/repositories"
+)
+
+type GetSharedYearRecapQuery struct {
+	Token string
+	Today time.Time
+}
+
+type GetSharedYearRecapHandler struct {
+	shareRepo    repositories.RecapShareRepository
+	recapHandler *GetYearRecapHandler
+}
+
+func NewGetSharedYearRecapHandler(shareRepo repositories.RecapShareRepository, recapHandler *GetYearRecapHandler) *GetSharedYearRecapHandler {
+	return &GetSharedYearRecapHandler{
+		shareRepo:    shareRepo,
+		recapHandler: recapHandler,
+	}
+}
+
+// Handle resolves a public share token to the recap it points to.
+func (h *GetSharedYearRecapHandler) Handle(ctx context.Context, query GetSharedYearRecapQuery) (*YearRecapDTO, error) {
+	share, err := h.shareRepo.FindByToken(ctx, query.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.recapHandler.Handle(ctx, GetYearRecapQuery{
+		UserID: share.UserID,
+		Year:   share.Year,
+		Today:  query.Today,
+	})
+}
@@ -27,6 +27,10 @@ func (m *mockHabitRepo) FindByUserID(ctx context.Context, userID string) ([]*ent
 	return nil, nil
 }
 
+func (m *mockHabitRepo) FindAll(ctx context.Context) ([]*entities.Habit, error) {
+	return m.habits, nil
+}
+
 func (m *mockHabitRepo) FindActiveByUserID(ctx context.Context, userID string) ([]*entities.Habit, error) {
 	return m.habits, nil
 }
@@ -77,6 +81,10 @@ func (m *mockEntryRepo) FindByUserID(ctx context.Context, userID string) ([]*ent
 	return nil, nil
 }
 
+func (m *mockEntryRepo) FindByUserIDAndDateRange(ctx context.Context, userID string, from, to time.Time) ([]*entities.HabitEntry, error) {
+	return nil, nil
+}
+
 func (m *mockEntryRepo) FindPendingByHabitID(ctx context.Context, habitID string, beforeDate time.Time) ([]*entities.HabitEntry, error) {
 	return nil, nil
 }
@@ -0,0 +1,60 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+)
+
+type AchievementDTO struct {
+	Code       string     `json:"code"`
+	Unlocked   bool       `json:"unlocked"`
+	UnlockedAt *time.Time `json:"unlocked_at,omitempty"`
+	HabitID    *string    `json:"habit_id,omitempty"`
+}
+
+type GetUserAchievementsQuery struct {
+	UserID string
+}
+
+type GetUserAchievementsHandler struct {
+	achievementRepo repositories.AchievementRepository
+}
+
+func NewGetUserAchievementsHandler(achievementRepo repositories.AchievementRepository) *GetUserAchievementsHandler {
+	return &GetUserAchievementsHandler{
+		achievementRepo: achievementRepo,
+	}
+}
+
+// Handle returns the whole achievement catalog in order, marking the ones the
+// user has unlocked.
+func (h *GetUserAchievementsHandler) Handle(ctx context.Context, query GetUserAchievementsQuery) ([]AchievementDTO, error) {
+	achievements, err := h.achievementRepo.FindByUserID(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	unlocked := make(map[entities.AchievementCode]*entities.Achievement, len(achievements))
+	for _, achievement := range achievements {
+		unlocked[achievement.Code] = achievement
+	}
+
+	result := make([]AchievementDTO, 0, len(entities.AchievementCatalog))
+	for _, rule := range entities.AchievementCatalog {
+		dto := AchievementDTO{Code: string(rule.Code)}
+
+		if achievement, ok := unlocked[rule.Code]; ok {
+			unlockedAt := achievement.UnlockedAt
+			dto.Unlocked = true
+			dto.UnlockedAt = &unlockedAt
+			dto.HabitID = achievement.HabitID
+		}
+
+		result = append(result, dto)
+	}
+
+	return result, nil
+}
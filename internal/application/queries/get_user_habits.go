@@ -18,6 +18,7 @@ type HabitDTO struct {
 	CarryOver    bool
 	IsNegative   bool
 	SpecificDays []int
+	Difficulty   value_objects.HabitDifficulty
 }
 
 type FilterParams struct {
@@ -106,6 +107,7 @@ func (h *GetUserHabitsHandler) Handle(ctx context.Context, query GetUserHabitsQu
 			CarryOver:    habit.CarryOver,
 			IsNegative:   habit.IsNegative,
 			SpecificDays: habit.SpecificDays,
+			Difficulty:   habit.Difficulty,
 		})
 	}
 
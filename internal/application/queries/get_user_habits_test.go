@@ -26,6 +26,10 @@ func (m *mockGetUserHabitsRepo) FindByUserID(ctx context.Context, userID string)
 	return nil, nil
 }
 
+func (m *mockGetUserHabitsRepo) FindAll(ctx context.Context) ([]*entities.Habit, error) {
+	return m.habits, nil
+}
+
 func (m *mockGetUserHabitsRepo) FindActiveByUserID(ctx context.Context, userID string) ([]*entities.Habit, error) {
 	return m.habits, nil
 }
@@ -0,0 +1,105 @@
+package queries
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+)
+
+const (
+	defaultWebhookDeliveriesLimit = 50
+	maxWebhookDeliveriesLimit     = 200
+)
+
+type WebhookDeliveryDTO struct {
+	ID             string          `json:"id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	LastStatusCode *int            `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+}
+
+type GetWebhookDeliveriesQuery struct {
+	WebhookID string
+	UserID    string
+	Limit     int
+}
+
+type GetWebhookDeliveriesHandler struct {
+	webhookRepo  repositories.WebhookRepository
+	deliveryRepo repositories.WebhookDeliveryRepository
+}
+
+func NewGetWebhookDeliveriesHandler(
+	webhookRepo repositories.WebhookRepository,
+	deliveryRepo repositories.WebhookDeliveryRepository,
+) *GetWebhookDeliveriesHandler {
+	return &GetWebhookDeliveriesHandler{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+	}
+}
+
+// Handle returns the most recent deliveries of the webhook, newest first.
+func (h *GetWebhookDeliveriesHandler) Handle(ctx context.Context, query GetWebhookDeliveriesQuery) ([]WebhookDeliveryDTO, error) {
+	webhook, err := h.webhookRepo.FindByID(ctx, query.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook.UserID != query.UserID {
+		return nil, errors.ErrUnauthorized
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultWebhookDeliveriesLimit
+	}
+	if limit > maxWebhookDeliveriesLimit {
+		limit = maxWebhookDeliveriesLimit
+	}
+
+	deliveries, err := h.deliveryRepo.FindByWebhookID(ctx, webhook.ID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]WebhookDeliveryDTO, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		dtos = append(dtos, *NewWebhookDeliveryDTO(delivery))
+	}
+
+	return dtos, nil
+}
+
+func NewWebhookDeliveryDTO(delivery *entities.WebhookDelivery) *WebhookDeliveryDTO {
+	dto := &WebhookDeliveryDTO{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		EventType:      string(delivery.EventType),
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		Payload:        json.RawMessage(delivery.Payload),
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+
+	if delivery.Status == entities.WebhookDeliveryPending {
+		nextAttemptAt := delivery.NextAttemptAt
+		dto.NextAttemptAt = &nextAttemptAt
+	}
+
+	return dto
+}
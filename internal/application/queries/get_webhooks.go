@@ -0,0 +1,68 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+)
+
+type WebhookDTO struct {
+	ID                  string     `json:"id"`
+	URL                 string     `json:"url"`
+	Events              []string   `json:"events"`
+	Enabled             bool       `json:"enabled"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+type GetWebhooksQuery struct {
+	UserID string
+}
+
+type GetWebhooksHandler struct {
+	webhookRepo repositories.WebhookRepository
+}
+
+func NewGetWebhooksHandler(webhookRepo repositories.WebhookRepository) *GetWebhooksHandler {
+	return &GetWebhooksHandler{
+		webhookRepo: webhookRepo,
+	}
+}
+
+func (h *GetWebhooksHandler) Handle(ctx context.Context, query GetWebhooksQuery) ([]WebhookDTO, error) {
+	webhooks, err := h.webhookRepo.FindByUserID(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]WebhookDTO, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		dtos = append(dtos, *NewWebhookDTO(webhook))
+	}
+
+	return dtos, nil
+}
+
+// NewWebhookDTO never includes the signing secret, which is only shown when
+// the webhook is created.
+func NewWebhookDTO(webhook *entities.Webhook) *WebhookDTO {
+	events := make([]string, 0, len(webhook.Events))
+	for _, event := range webhook.Events {
+		events = append(events, string(event))
+	}
+
+	return &WebhookDTO{
+		ID:                  webhook.ID,
+		URL:                 webhook.URL,
+		Events:              events,
+		Enabled:             webhook.Enabled,
+		ConsecutiveFailures: webhook.ConsecutiveFailures,
+		DisabledAt:          webhook.DisabledAt,
+		CreatedAt:           webhook.CreatedAt,
+		UpdatedAt:           webhook.UpdatedAt,
+	}
+}
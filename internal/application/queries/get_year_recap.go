@@ -0,0 +1,164 @@
+package queries
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/shared/errors"
+	"apocapoc-api/internal/shared/utils"
+)
+
+const (
+	recapTopStreaks = 3
+	// Habits scheduled fewer times than this in the year are not considered for
+	// the most consistent habit, so a habit created on December 30th does not win.
+	recapMinConsistencyOccurrences = 7
+)
+
+type RecapMonthDTO struct {
+	Month          int     `json:"month"`
+	Completions    int     `json:"completions"`
+	Scheduled      int     `json:"scheduled"`
+	CompletionRate float64 `json:"completion_rate"`
+}
+
+type YearRecapDTO struct {
+	Year                int              `json:"year"`
+	From                time.Time        `json:"from"`
+	To                  time.Time        `json:"to"`
+	TotalCompletions    int              `json:"total_completions"`
+	ActiveDays          int              `json:"active_days"`
+	CompletionRate      float64          `json:"completion_rate"`
+	LongestStreaks      []HabitStreakDTO `json:"longest_streaks"`
+	MostConsistentHabit *HabitRankDTO    `json:"most_consistent_habit,omitempty"`
+	BusiestMonth        *RecapMonthDTO   `json:"busiest_month,omitempty"`
+	Months              []RecapMonthDTO  `json:"months"`
+	Heatmap             *HeatmapDTO      `json:"heatmap"`
+}
+
+type GetYearRecapQuery struct {
+	UserID string
+	Year   int
+	Today  time.Time
+}
+
+type GetYearRecapHandler struct {
+	habitRepo repositories.HabitRepository
+	entryRepo repositories.HabitEntryRepository
+	heatmap   *GetHeatmapHandler
+}
+
+func NewGetYearRecapHandler(
+	habitRepo repositories.HabitRepository,
+	entryRepo repositories.HabitEntryRepository,
+) *GetYearRecapHandler {
+	return &GetYearRecapHandler{
+		habitRepo: habitRepo,
+		entryRepo: entryRepo,
+		heatmap:   NewGetHeatmapHandler(habitRepo, entryRepo),
+	}
+}
+
+// Handle computes the recap of a calendar year from the stored entries of all
+// the user's habits, archived ones included. The current year is summarised up
+// to today.
+func (h *GetYearRecapHandler) Handle(ctx context.Context, query GetYearRecapQuery) (*YearRecapDTO, error) {
+	today := toDate(query.Today)
+	if query.Year < 1 || query.Year > today.Year() {
+		return nil, errors.ErrInvalidInput
+	}
+
+	from := time.Date(query.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := minDate(time.Date(query.Year, time.December, 31, 0, 0, 0, 0, time.UTC), today)
+
+	habits, err := h.habitRepo.FindByUserID(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := h.entryRepo.FindByUserIDAndDateRange(ctx, query.UserID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	heatmap, err := h.heatmap.Handle(ctx, GetHeatmapQuery{UserID: query.UserID, From: from, To: to})
+	if err != nil {
+		return nil, err
+	}
+
+	recap := &YearRecapDTO{
+		Year:           query.Year,
+		From:           from,
+		To:             to,
+		LongestStreaks: []HabitStreakDTO{},
+		Months:         make([]RecapMonthDTO, 12),
+		Heatmap:        heatmap,
+	}
+
+	for i := range recap.Months {
+		recap.Months[i].Month = i + 1
+	}
+
+	activeDays := make(map[string]bool)
+	for _, entry := range entries {
+		recap.TotalCompletions++
+		activeDays[entry.ScheduledDate.Format("2006-01-02")] = true
+		recap.Months[entry.ScheduledDate.Month()-1].Completions++
+	}
+	recap.ActiveDays = len(activeDays)
+
+	completed := indexEntriesByHabitAndDate(entries)
+	var total completionCounter
+	monthCounters := make([]completionCounter, 12)
+	habitCounters := make([]completionCounter, len(habits))
+
+	for i, habit := range habits {
+		done := completed[habit.ID]
+		createdDate := toDate(habit.CreatedAt)
+
+		for date := maxDate(from, createdDate); !date.After(to); date = date.AddDate(0, 0, 1) {
+			if habit.ArchivedAt != nil && !date.Before(toDate(*habit.ArchivedAt)) {
+				break
+			}
+
+			isScheduled := utils.ShouldAppearToday(string(habit.Frequency), habit.SpecificDays, habit.SpecificDates, date)
+			isCompleted := done[date.Format("2006-01-02")]
+
+			total.add(isScheduled, isCompleted)
+			monthCounters[date.Month()-1].add(isScheduled, isCompleted)
+			habitCounters[i].add(isScheduled, isCompleted)
+		}
+
+		if streak := longestScheduledStreak(habit, done, from, to); streak > 0 {
+			recap.LongestStreaks = append(recap.LongestStreaks, HabitStreakDTO{HabitID: habit.ID, HabitName: habit.Name, Streak: streak})
+		}
+
+		if habitCounters[i].scheduled < recapMinConsistencyOccurrences {
+			habitCounters[i] = completionCounter{}
+		}
+	}
+
+	recap.CompletionRate = total.rate()
+	recap.MostConsistentHabit, _ = rankHabits(habits, habitCounters)
+
+	sort.SliceStable(recap.LongestStreaks, func(i, j int) bool {
+		return recap.LongestStreaks[i].Streak > recap.LongestStreaks[j].Streak
+	})
+	if len(recap.LongestStreaks) > recapTopStreaks {
+		recap.LongestStreaks = recap.LongestStreaks[:recapTopStreaks]
+	}
+
+	for i := range recap.Months {
+		month := &recap.Months[i]
+		month.Scheduled = monthCounters[i].scheduled
+		month.CompletionRate = monthCounters[i].rate()
+
+		if month.Completions > 0 && (recap.BusiestMonth == nil || month.Completions > recap.BusiestMonth.Completions) {
+			recap.BusiestMonth = month
+		}
+	}
+
+	return recap, nil
+}
@@ -0,0 +1,100 @@
+package queries
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/shared/errors"
+)
+
+func TestGetYearRecapHandler(t *testing.T) {
+	exercise := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	exercise.ID = "habit-1"
+	exercise.CreatedAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Perfect record, but too recent to be the most consistent habit.
+	reading := entities.NewHabit("user-123", "Reading", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	reading.ID = "habit-2"
+	reading.CreatedAt = time.Date(2024, 12, 29, 0, 0, 0, 0, time.UTC)
+
+	var entries []*entities.HabitEntry
+	for day := 1; day <= 10; day++ {
+		entries = append(entries, entities.NewHabitEntry(exercise.ID, time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC), nil))
+	}
+	for day := 1; day <= 20; day++ {
+		entries = append(entries, entities.NewHabitEntry(exercise.ID, time.Date(2024, 3, day, 0, 0, 0, 0, time.UTC), nil))
+	}
+	for day := 29; day <= 31; day++ {
+		entries = append(entries, entities.NewHabitEntry(reading.ID, time.Date(2024, 12, day, 0, 0, 0, 0, time.UTC), nil))
+	}
+
+	handler := NewGetYearRecapHandler(
+		&mockHeatmapHabitRepo{mockHabitRepo{habits: []*entities.Habit{exercise, reading}}},
+		&mockHeatmapEntryRepo{mockEntryRepo{entries: entries}},
+	)
+
+	recap, err := handler.Handle(context.Background(), GetYearRecapQuery{
+		UserID: "user-123",
+		Year:   2024,
+		Today:  time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if recap.TotalCompletions != 33 || recap.ActiveDays != 33 {
+		t.Errorf("Expected 33 completions on 33 days, got %d on %d", recap.TotalCompletions, recap.ActiveDays)
+	}
+
+	if len(recap.LongestStreaks) != 2 || recap.LongestStreaks[0].HabitID != exercise.ID || recap.LongestStreaks[0].Streak != 20 || recap.LongestStreaks[1].Streak != 3 {
+		t.Errorf("Unexpected longest streaks: %+v", recap.LongestStreaks)
+	}
+
+	if recap.MostConsistentHabit == nil || recap.MostConsistentHabit.HabitID != exercise.ID {
+		t.Errorf("Expected %s to be the most consistent habit, got %+v", exercise.ID, recap.MostConsistentHabit)
+	}
+
+	if recap.BusiestMonth == nil || recap.BusiestMonth.Month != 3 || recap.BusiestMonth.Completions != 20 {
+		t.Errorf("Expected March to be the busiest month, got %+v", recap.BusiestMonth)
+	}
+
+	if len(recap.Months) != 12 || recap.Months[0].Completions != 10 || recap.Months[0].Scheduled != 31 {
+		t.Errorf("Unexpected January summary: %+v", recap.Months[0])
+	}
+
+	if recap.Heatmap == nil || len(recap.Heatmap.Days) != 366 {
+		t.Errorf("Expected a 366 day heatmap for a leap year")
+	}
+}
+
+func TestGetYearRecapHandler_CurrentYearEndsToday(t *testing.T) {
+	handler := NewGetYearRecapHandler(&mockHeatmapHabitRepo{}, &mockHeatmapEntryRepo{})
+
+	recap, err := handler.Handle(context.Background(), GetYearRecapQuery{
+		UserID: "user-123",
+		Year:   2025,
+		Today:  time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !recap.To.Equal(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the recap to end today, got %s", recap.To)
+	}
+	if recap.BusiestMonth != nil || recap.MostConsistentHabit != nil {
+		t.Errorf("Expected an empty recap, got %+v", recap)
+	}
+
+	_, err = handler.Handle(context.Background(), GetYearRecapQuery{
+		UserID: "user-123",
+		Year:   2026,
+		Today:  time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != errors.ErrInvalidInput {
+		t.Errorf("Expected ErrInvalidInput for a future year, got %v", err)
+	}
+}
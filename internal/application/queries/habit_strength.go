@@ -0,0 +1,121 @@
+package queries
+
+import (
+	"math"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/shared/utils"
+)
+
+// strengthHalfLife is the number of days after which a daily habit's past
+// behaviour weighs half as much in the strength score.
+const strengthHalfLife = 13.0
+
+type HabitStrengthPointDTO struct {
+	Date     time.Time `json:"date"`
+	Strength float64   `json:"strength"`
+}
+
+// strengthCheckpoint is the strength score, between 0 and 1, after every day
+// up to Date.
+type strengthCheckpoint struct {
+	Date  time.Time
+	Score float64
+}
+
+// calculateStrength returns an exponentially smoothed 0-100 consistency score
+// over the habit's scheduled occurrences up to today, together with the daily
+// value of the score from historyFrom on for charting. The score starts at the
+// habit's first occurrence, or carries on from seed so that only the entries
+// after it are needed. The checkpoint on the day before historyFrom is
+// returned for the next calculation to carry on from.
+func calculateStrength(habit *entities.Habit, entries []*entities.HabitEntry, seed *strengthCheckpoint, historyFrom, today time.Time) (float64, []HabitStrengthPointDTO, strengthCheckpoint) {
+	today = toDate(today)
+	historyFrom = toDate(historyFrom)
+
+	completion := make(map[string]float64)
+	start := toDate(habit.CreatedAt)
+	for _, entry := range entries {
+		date := toDate(entry.ScheduledDate)
+		completion[date.Format("2006-01-02")] = entryCompletion(habit, entry)
+		if date.Before(start) {
+			start = date
+		}
+	}
+
+	score := 0.0
+	if seed != nil {
+		start = seed.Date.AddDate(0, 0, 1)
+		score = seed.Score
+	}
+	checkpoint := strengthCheckpoint{Date: historyFrom.AddDate(0, 0, -1), Score: score}
+
+	if start.After(today) {
+		return score * 100, []HabitStrengthPointDTO{}, checkpoint
+	}
+
+	multiplier := strengthMultiplier(habit)
+	history := make([]HabitStrengthPointDTO, 0, int(today.Sub(maxDate(start, historyFrom)).Hours()/24)+1)
+
+	for date := start; !date.After(today); date = date.AddDate(0, 0, 1) {
+		value, completed := completion[date.Format("2006-01-02")]
+		scheduled := utils.ShouldAppearToday(string(habit.Frequency), habit.SpecificDays, habit.SpecificDates, date)
+
+		// Today is still in progress, so it only counts once it has been completed.
+		if (scheduled && !date.Equal(today)) || completed {
+			score = score*multiplier + value*(1-multiplier)
+		}
+
+		if date.Equal(checkpoint.Date) {
+			checkpoint.Score = score
+		}
+		if !date.Before(historyFrom) {
+			history = append(history, HabitStrengthPointDTO{
+				Date:     date,
+				Strength: score * 100,
+			})
+		}
+	}
+
+	return score * 100, history, checkpoint
+}
+
+// strengthMultiplier returns the decay applied per scheduled occurrence so that
+// habits with fewer occurrences per day decay more per occurrence.
+func strengthMultiplier(habit *entities.Habit) float64 {
+	frequency := 1.0
+	switch habit.Frequency {
+	case value_objects.FrequencyWeekly:
+		frequency = float64(len(habit.SpecificDays)) / 7
+	case value_objects.FrequencyMonthly:
+		frequency = float64(len(habit.SpecificDates)) / 30
+	}
+
+	if frequency <= 0 {
+		frequency = 1
+	}
+	if frequency > 1 {
+		frequency = 1
+	}
+
+	perDay := math.Pow(0.5, math.Sqrt(frequency)/strengthHalfLife)
+	return math.Pow(perDay, 1/frequency)
+}
+
+func entryCompletion(habit *entities.Habit, entry *entities.HabitEntry) float64 {
+	if habit.Type == value_objects.HabitTypeBoolean || habit.TargetValue == nil || *habit.TargetValue <= 0 {
+		return 1
+	}
+
+	if entry.Value == nil {
+		return 0
+	}
+
+	return math.Min(math.Max(*entry.Value / *habit.TargetValue, 0), 1)
+}
+
+func toDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
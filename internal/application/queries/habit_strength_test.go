@@ -0,0 +1,138 @@
+package queries
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+)
+
+func TestCalculateStrength_NoEntries(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	strength, history, _ := calculateStrength(habit, nil, nil, time.Time{}, today)
+
+	if strength != 0 {
+		t.Errorf("Expected strength 0, got %f", strength)
+	}
+
+	if len(history) != 10 {
+		t.Fatalf("Expected 10 history points, got %d", len(history))
+	}
+}
+
+func TestCalculateStrength_IncreasesWithCompletions(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	var entries []*entities.HabitEntry
+	for day := 1; day <= 10; day++ {
+		entries = append(entries, entities.NewHabitEntry("habit-1", time.Date(2025, 1, day, 0, 0, 0, 0, time.UTC), nil))
+	}
+
+	strength, history, _ := calculateStrength(habit, entries, nil, time.Time{}, today)
+
+	if strength <= 0 || strength >= 100 {
+		t.Errorf("Expected strength between 0 and 100, got %f", strength)
+	}
+
+	for i := 1; i < len(history); i++ {
+		if history[i].Strength <= history[i-1].Strength {
+			t.Errorf("Expected strength to increase on %s, got %f after %f", history[i].Date.Format("2006-01-02"), history[i].Strength, history[i-1].Strength)
+		}
+	}
+}
+
+func TestCalculateStrength_RecentBehaviourWeighsMore(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC)
+
+	var early, recent []*entities.HabitEntry
+	for day := 1; day <= 10; day++ {
+		early = append(early, entities.NewHabitEntry("habit-1", time.Date(2025, 1, day, 0, 0, 0, 0, time.UTC), nil))
+		recent = append(recent, entities.NewHabitEntry("habit-1", time.Date(2025, 1, day+10, 0, 0, 0, 0, time.UTC), nil))
+	}
+
+	earlyStrength, _, _ := calculateStrength(habit, early, nil, time.Time{}, today)
+	recentStrength, _, _ := calculateStrength(habit, recent, nil, time.Time{}, today)
+
+	if recentStrength <= earlyStrength {
+		t.Errorf("Expected recent completions (%f) to score higher than early ones (%f)", recentStrength, earlyStrength)
+	}
+}
+
+func TestCalculateStrength_IgnoresUnscheduledDays(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Gym", value_objects.HabitTypeBoolean, value_objects.FrequencyWeekly, false, false)
+	habit.SpecificDays = []int{1} // Monday
+	habit.CreatedAt = time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC)
+
+	entries := []*entities.HabitEntry{
+		entities.NewHabitEntry("habit-1", time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), nil),
+	}
+
+	strength, history, _ := calculateStrength(habit, entries, nil, time.Time{}, today)
+
+	if history[0].Strength != strength {
+		t.Errorf("Expected strength to stay at %f on unscheduled days, got %f", history[0].Strength, strength)
+	}
+}
+
+func TestCalculateStrength_PartialValueCompletion(t *testing.T) {
+	target := 10.0
+	habit := entities.NewHabit("user-123", "Reading", value_objects.HabitTypeValue, value_objects.FrequencyDaily, false, false)
+	habit.TargetValue = &target
+	habit.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	half := 5.0
+	full := 10.0
+
+	halfStrength, _, _ := calculateStrength(habit, []*entities.HabitEntry{
+		entities.NewHabitEntry("habit-1", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), &half),
+	}, nil, time.Time{}, today)
+	fullStrength, _, _ := calculateStrength(habit, []*entities.HabitEntry{
+		entities.NewHabitEntry("habit-1", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), &full),
+	}, nil, time.Time{}, today)
+
+	if halfStrength <= 0 || halfStrength >= fullStrength {
+		t.Errorf("Expected partial completion (%f) to score between 0 and full completion (%f)", halfStrength, fullStrength)
+	}
+}
+
+func TestCalculateStrength_CarriesOnFromCheckpoint(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	historyFrom := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2025, 2, 20, 0, 0, 0, 0, time.UTC)
+
+	var entries []*entities.HabitEntry
+	for date := habit.CreatedAt; date.Before(today); date = date.AddDate(0, 0, 2) {
+		entries = append(entries, entities.NewHabitEntry("habit-1", date, nil))
+	}
+
+	strength, history, checkpoint := calculateStrength(habit, entries, nil, historyFrom, today)
+	if len(history) != 20 || !history[0].Date.Equal(historyFrom) {
+		t.Fatalf("Expected history from %s, got %d points", historyFrom.Format("2006-01-02"), len(history))
+	}
+	if !checkpoint.Date.Equal(historyFrom.AddDate(0, 0, -1)) || checkpoint.Score <= 0 {
+		t.Fatalf("Expected the January score as checkpoint, got %+v", checkpoint)
+	}
+
+	var recent []*entities.HabitEntry
+	for _, entry := range entries {
+		if !entry.ScheduledDate.Before(historyFrom) {
+			recent = append(recent, entry)
+		}
+	}
+	carried, carriedHistory, _ := calculateStrength(habit, recent, &checkpoint, historyFrom, today)
+	if math.Abs(carried-strength) > 1e-9 || len(carriedHistory) != len(history) {
+		t.Errorf("Expected carrying on from the checkpoint to give %f, got %f", strength, carried)
+	}
+}
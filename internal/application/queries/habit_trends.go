@@ -0,0 +1,176 @@
+package queries
+
+import (
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/shared/utils"
+)
+
+const (
+	TrendImproving        = "improving"
+	TrendStable           = "stable"
+	TrendDeclining        = "declining"
+	TrendInsufficientData = "insufficient_data"
+)
+
+const (
+	PeriodWeekOverWeek   = "week_over_week"
+	PeriodMonthOverMonth = "month_over_month"
+	PeriodYearOverYear   = "year_over_year"
+)
+
+const (
+	trendWindowDays = 28
+	trendThreshold  = 10.0
+)
+
+type PeriodSummaryDTO struct {
+	From           time.Time `json:"from"`
+	To             time.Time `json:"to"`
+	Scheduled      int       `json:"scheduled"`
+	Completed      int       `json:"completed"`
+	CompletionRate float64   `json:"completion_rate"`
+	ValueSum       *float64  `json:"value_sum,omitempty"`
+	ValueAvg       *float64  `json:"value_avg,omitempty"`
+}
+
+type PeriodComparisonDTO struct {
+	Period               string           `json:"period"`
+	Current              PeriodSummaryDTO `json:"current"`
+	Previous             PeriodSummaryDTO `json:"previous"`
+	CompletionRateChange float64          `json:"completion_rate_change"`
+	ValueChangePercent   *float64         `json:"value_change_percent,omitempty"`
+}
+
+// comparePeriods compares the current week, month and month of the previous
+// year up to today against the same number of days of the previous period, so
+// a partially elapsed period is never compared against a complete one.
+func comparePeriods(habit *entities.Habit, entries []*entities.HabitEntry, today time.Time, weekStart time.Weekday) []PeriodComparisonDTO {
+	today = toDate(today)
+	byDate := indexEntriesByDate(entries)
+
+	weekFrom := utils.StartOfWeek(today, weekStart)
+	monthFrom := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	monthElapsed := today.Day() - 1
+
+	lastMonthFrom := monthFrom.AddDate(0, -1, 0)
+	lastYearMonthFrom := monthFrom.AddDate(-1, 0, 0)
+
+	return []PeriodComparisonDTO{
+		comparePeriod(habit, byDate, PeriodWeekOverWeek,
+			weekFrom, today,
+			weekFrom.AddDate(0, 0, -7), today.AddDate(0, 0, -7)),
+		comparePeriod(habit, byDate, PeriodMonthOverMonth,
+			monthFrom, today,
+			lastMonthFrom, minDate(lastMonthFrom.AddDate(0, 0, monthElapsed), monthFrom.AddDate(0, 0, -1))),
+		comparePeriod(habit, byDate, PeriodYearOverYear,
+			monthFrom, today,
+			lastYearMonthFrom, minDate(lastYearMonthFrom.AddDate(0, 0, monthElapsed), lastYearMonthFrom.AddDate(0, 1, -1))),
+	}
+}
+
+// classifyTrend compares the completion rate of the last four weeks with the
+// four weeks before them.
+func classifyTrend(habit *entities.Habit, entries []*entities.HabitEntry, today time.Time) string {
+	today = toDate(today)
+	byDate := indexEntriesByDate(entries)
+
+	currentFrom := today.AddDate(0, 0, -(trendWindowDays - 1))
+	previousTo := currentFrom.AddDate(0, 0, -1)
+	previousFrom := previousTo.AddDate(0, 0, -(trendWindowDays - 1))
+
+	current := summarizePeriod(habit, byDate, currentFrom, today)
+	previous := summarizePeriod(habit, byDate, previousFrom, previousTo)
+
+	if current.Scheduled == 0 || previous.Scheduled == 0 {
+		return TrendInsufficientData
+	}
+
+	change := current.CompletionRate - previous.CompletionRate
+	switch {
+	case change >= trendThreshold:
+		return TrendImproving
+	case change <= -trendThreshold:
+		return TrendDeclining
+	default:
+		return TrendStable
+	}
+}
+
+func comparePeriod(habit *entities.Habit, byDate map[string]*entities.HabitEntry, period string, currentFrom, currentTo, previousFrom, previousTo time.Time) PeriodComparisonDTO {
+	comparison := PeriodComparisonDTO{
+		Period:   period,
+		Current:  summarizePeriod(habit, byDate, currentFrom, currentTo),
+		Previous: summarizePeriod(habit, byDate, previousFrom, previousTo),
+	}
+
+	comparison.CompletionRateChange = comparison.Current.CompletionRate - comparison.Previous.CompletionRate
+
+	if comparison.Current.ValueSum != nil && comparison.Previous.ValueSum != nil && *comparison.Previous.ValueSum != 0 {
+		change := (*comparison.Current.ValueSum - *comparison.Previous.ValueSum) / *comparison.Previous.ValueSum * 100
+		comparison.ValueChangePercent = &change
+	}
+
+	return comparison
+}
+
+func summarizePeriod(habit *entities.Habit, byDate map[string]*entities.HabitEntry, from, to time.Time) PeriodSummaryDTO {
+	summary := PeriodSummaryDTO{
+		From: from,
+		To:   to,
+	}
+
+	createdDate := toDate(habit.CreatedAt)
+	tracksValues := habit.Type != value_objects.HabitTypeBoolean
+	valueSum := 0.0
+	valueCount := 0
+
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		entry := byDate[date.Format("2006-01-02")]
+
+		if entry != nil && tracksValues && entry.Value != nil {
+			valueSum += *entry.Value
+			valueCount++
+		}
+
+		if date.Before(createdDate) || !utils.ShouldAppearToday(string(habit.Frequency), habit.SpecificDays, habit.SpecificDates, date) {
+			continue
+		}
+
+		summary.Scheduled++
+		if entry != nil {
+			summary.Completed++
+		}
+	}
+
+	if summary.Scheduled > 0 {
+		summary.CompletionRate = float64(summary.Completed) / float64(summary.Scheduled) * 100
+	}
+
+	if tracksValues {
+		summary.ValueSum = &valueSum
+		if valueCount > 0 {
+			avg := valueSum / float64(valueCount)
+			summary.ValueAvg = &avg
+		}
+	}
+
+	return summary
+}
+
+func indexEntriesByDate(entries []*entities.HabitEntry) map[string]*entities.HabitEntry {
+	byDate := make(map[string]*entities.HabitEntry, len(entries))
+	for _, entry := range entries {
+		byDate[entry.ScheduledDate.Format("2006-01-02")] = entry
+	}
+	return byDate
+}
+
+func minDate(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,106 @@
+package queries
+
+import (
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+)
+
+func dailyEntries(habitID string, from, to time.Time, value *float64) []*entities.HabitEntry {
+	var entries []*entities.HabitEntry
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		entries = append(entries, entities.NewHabitEntry(habitID, date, value))
+	}
+	return entries
+}
+
+func TestClassifyTrend(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.CreatedAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	recentFrom := today.AddDate(0, 0, -27)
+	previousTo := recentFrom.AddDate(0, 0, -1)
+	previousFrom := previousTo.AddDate(0, 0, -27)
+
+	tests := []struct {
+		name     string
+		entries  []*entities.HabitEntry
+		expected string
+	}{
+		{"Improving", dailyEntries("habit-1", recentFrom, today, nil), TrendImproving},
+		{"Declining", dailyEntries("habit-1", previousFrom, previousTo, nil), TrendDeclining},
+		{"Stable", dailyEntries("habit-1", previousFrom, today, nil), TrendStable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := classifyTrend(habit, tt.entries, today)
+			if result != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestClassifyTrend_InsufficientData(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.CreatedAt = time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	result := classifyTrend(habit, dailyEntries("habit-1", habit.CreatedAt, today, nil), today)
+	if result != TrendInsufficientData {
+		t.Errorf("Expected %s, got %s", TrendInsufficientData, result)
+	}
+}
+
+func TestComparePeriods_ComparesSameElapsedDays(t *testing.T) {
+	habit := entities.NewHabit("user-123", "Push-ups", value_objects.HabitTypeCounter, value_objects.FrequencyDaily, false, false)
+	habit.CreatedAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2025, 3, 12, 0, 0, 0, 0, time.UTC) // Wednesday
+
+	ten := 10.0
+	twenty := 20.0
+	entries := append(
+		dailyEntries("habit-1", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), &ten),
+		dailyEntries("habit-1", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), today, &twenty)...,
+	)
+
+	comparisons := comparePeriods(habit, entries, today, time.Monday)
+
+	if len(comparisons) != 3 {
+		t.Fatalf("Expected 3 comparisons, got %d", len(comparisons))
+	}
+
+	week := comparisons[0]
+	if week.Period != PeriodWeekOverWeek {
+		t.Errorf("Expected %s, got %s", PeriodWeekOverWeek, week.Period)
+	}
+	if !week.Current.From.Equal(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)) || !week.Previous.To.Equal(time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected week ranges: %s-%s vs %s-%s", week.Current.From, week.Current.To, week.Previous.From, week.Previous.To)
+	}
+
+	month := comparisons[1]
+	if month.Current.Scheduled != 12 || month.Previous.Scheduled != 12 {
+		t.Errorf("Expected 12 scheduled days in both months, got %d and %d", month.Current.Scheduled, month.Previous.Scheduled)
+	}
+	if month.ValueChangePercent == nil || *month.ValueChangePercent != 100 {
+		t.Errorf("Expected value change of 100%%, got %v", month.ValueChangePercent)
+	}
+	if month.CompletionRateChange != 0 {
+		t.Errorf("Expected no completion rate change, got %f", month.CompletionRateChange)
+	}
+
+	yearOverYear := comparisons[2]
+	if !yearOverYear.Previous.From.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected previous period to start 2024-03-01, got %s", yearOverYear.Previous.From)
+	}
+	if yearOverYear.CompletionRateChange != 100 {
+		t.Errorf("Expected completion rate change of 100 points, got %f", yearOverYear.CompletionRateChange)
+	}
+	if yearOverYear.ValueChangePercent != nil {
+		t.Errorf("Expected no value change without previous values, got %f", *yearOverYear.ValueChangePercent)
+	}
+}
@@ -0,0 +1,67 @@
+package queries
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/services"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type EmailTemplatePreviewDTO struct {
+	Template string `json:"template"`
+	Language string `json:"language"`
+	Subject  string `json:"subject"`
+	HTML     string `json:"html"`
+	Text     string `json:"text"`
+}
+
+type PreviewEmailTemplateQuery struct {
+	Template string
+	Language string
+}
+
+type PreviewEmailTemplateHandler struct {
+	previewer services.EmailPreviewer
+}
+
+func NewPreviewEmailTemplateHandler(previewer services.EmailPreviewer) *PreviewEmailTemplateHandler {
+	return &PreviewEmailTemplateHandler{
+		previewer: previewer,
+	}
+}
+
+// Templates lists the names of the templates that can be previewed.
+func (h *PreviewEmailTemplateHandler) Templates(ctx context.Context) []string {
+	templates := h.previewer.Templates()
+	names := make([]string, 0, len(templates))
+	for _, template := range templates {
+		names = append(names, string(template))
+	}
+	return names
+}
+
+func (h *PreviewEmailTemplateHandler) Handle(ctx context.Context, query PreviewEmailTemplateQuery) (*EmailTemplatePreviewDTO, error) {
+	known := false
+	for _, template := range h.previewer.Templates() {
+		if string(template) == query.Template {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return nil, errors.ErrNotFound
+	}
+
+	message, err := h.previewer.Preview(services.EmailTemplate(query.Template), query.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EmailTemplatePreviewDTO{
+		Template: query.Template,
+		Language: query.Language,
+		Subject:  message.Subject,
+		HTML:     message.Body,
+		Text:     message.TextBody,
+	}, nil
+}
@@ -0,0 +1,57 @@
+package entities
+
+import "time"
+
+type AchievementCode string
+
+const (
+	AchievementFirstCompletion AchievementCode = "first_completion"
+	AchievementStreak7         AchievementCode = "streak_7"
+	AchievementStreak30        AchievementCode = "streak_30"
+	AchievementStreak100       AchievementCode = "streak_100"
+	AchievementStreak365       AchievementCode = "streak_365"
+	AchievementCompletions1000 AchievementCode = "completions_1000"
+	AchievementPerfectWeek     AchievementCode = "perfect_week"
+)
+
+// AchievementProgress is what the achievement rules are evaluated against
+// after a habit has been completed.
+type AchievementProgress struct {
+	TotalCompletions int
+	LongestStreak    int
+	PerfectWeek      bool
+}
+
+type AchievementRule struct {
+	Code AchievementCode
+	// HabitScoped achievements are attributed to the habit that unlocked them.
+	HabitScoped bool
+	IsUnlocked  func(progress AchievementProgress) bool
+}
+
+var AchievementCatalog = []AchievementRule{
+	{AchievementFirstCompletion, true, func(p AchievementProgress) bool { return p.TotalCompletions >= 1 }},
+	{AchievementStreak7, true, func(p AchievementProgress) bool { return p.LongestStreak >= 7 }},
+	{AchievementStreak30, true, func(p AchievementProgress) bool { return p.LongestStreak >= 30 }},
+	{AchievementStreak100, true, func(p AchievementProgress) bool { return p.LongestStreak >= 100 }},
+	{AchievementStreak365, true, func(p AchievementProgress) bool { return p.LongestStreak >= 365 }},
+	{AchievementCompletions1000, false, func(p AchievementProgress) bool { return p.TotalCompletions >= 1000 }},
+	{AchievementPerfectWeek, false, func(p AchievementProgress) bool { return p.PerfectWeek }},
+}
+
+type Achievement struct {
+	ID         string
+	UserID     string
+	Code       AchievementCode
+	HabitID    *string
+	UnlockedAt time.Time
+}
+
+func NewAchievement(userID string, code AchievementCode, habitID *string) *Achievement {
+	return &Achievement{
+		UserID:     userID,
+		Code:       code,
+		HabitID:    habitID,
+		UnlockedAt: time.Now(),
+	}
+}
@@ -0,0 +1,60 @@
+package entities
+
+import "time"
+
+// ChatLinkCodeTTL is how long a link code can be sent to the bot.
+const ChatLinkCodeTTL = 15 * time.Minute
+
+// ChatLink connects a user's account to the chat they talk to the bot from.
+// Timezone decides which day "today" is in the chat.
+type ChatLink struct {
+	UserID    string
+	ChatID    int64
+	Timezone  string
+	CreatedAt time.Time
+}
+
+func NewChatLink(userID string, chatID int64, timezone string) *ChatLink {
+	return &ChatLink{
+		UserID:    userID,
+		ChatID:    chatID,
+		Timezone:  timezone,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Today returns the current date in the link's timezone, as a UTC midnight
+// like the scheduled dates of habit entries.
+func (l *ChatLink) Today(now time.Time) time.Time {
+	loc, err := time.LoadLocation(l.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := now.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// ChatLinkCode is a one-time code the user sends to the bot to link the chat
+// to their account.
+type ChatLinkCode struct {
+	Code      string
+	UserID    string
+	Timezone  string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+func NewChatLinkCode(userID, code, timezone string) *ChatLinkCode {
+	now := time.Now()
+	return &ChatLinkCode{
+		Code:      code,
+		UserID:    userID,
+		Timezone:  timezone,
+		ExpiresAt: now.Add(ChatLinkCodeTTL),
+		CreatedAt: now,
+	}
+}
+
+func (c *ChatLinkCode) IsExpired() bool {
+	return time.Now().After(c.ExpiresAt)
+}
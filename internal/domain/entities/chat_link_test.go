@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChatLink_Today(t *testing.T) {
+	// 23:30 UTC on Jan 15 is already Jan 16 in Madrid and still Jan 15 in New York.
+	now := time.Date(2025, 1, 15, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		timezone string
+		expected time.Time
+	}{
+		{"Europe/Madrid", time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC)},
+		{"America/New_York", time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"Not/A_Zone", time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timezone, func(t *testing.T) {
+			link := NewChatLink("user-123", 42, tt.timezone)
+			if got := link.Today(now); !got.Equal(tt.expected) {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestChatLinkCode_IsExpired(t *testing.T) {
+	code := NewChatLinkCode("user-123", "ABCD2345", "UTC")
+	if code.IsExpired() {
+		t.Error("Expected a new code to be valid")
+	}
+
+	code.ExpiresAt = time.Now().Add(-time.Second)
+	if !code.IsExpired() {
+		t.Error("Expected the code to be expired")
+	}
+}
@@ -0,0 +1,110 @@
+package entities
+
+import (
+	"time"
+
+	"apocapoc-api/internal/shared/utils"
+)
+
+type DigestFrequency string
+
+const (
+	DigestWeekly  DigestFrequency = "WEEKLY"
+	DigestMonthly DigestFrequency = "MONTHLY"
+)
+
+// DigestSubscription holds a user's opt-in for periodic progress digests.
+// The last period fields store the start date of the last period a digest was
+// sent for, so a period is never sent twice.
+type DigestSubscription struct {
+	UserID            string
+	Weekly            bool
+	Monthly           bool
+	LastWeeklyPeriod  *time.Time
+	LastMonthlyPeriod *time.Time
+	UpdatedAt         time.Time
+}
+
+func NewDigestSubscription(userID string) *DigestSubscription {
+	return &DigestSubscription{
+		UserID:    userID,
+		UpdatedAt: time.Now(),
+	}
+}
+
+func (s *DigestSubscription) IsEnabled(frequency DigestFrequency) bool {
+	switch frequency {
+	case DigestWeekly:
+		return s.Weekly
+	case DigestMonthly:
+		return s.Monthly
+	}
+	return false
+}
+
+// SetEnabled opts in or out of a digest. Opting in starts over, so the first
+// digest is for the period the user opted in during.
+func (s *DigestSubscription) SetEnabled(frequency DigestFrequency, enabled bool) {
+	switch frequency {
+	case DigestWeekly:
+		if enabled && !s.Weekly {
+			s.LastWeeklyPeriod = nil
+		}
+		s.Weekly = enabled
+	case DigestMonthly:
+		if enabled && !s.Monthly {
+			s.LastMonthlyPeriod = nil
+		}
+		s.Monthly = enabled
+	}
+	s.UpdatedAt = time.Now()
+}
+
+// IsStarting reports whether the digest was opted in to and has no period
+// recorded yet. The period that has already ended is then marked as sent
+// without sending it, since it ended before the opt-in.
+func (s *DigestSubscription) IsStarting(frequency DigestFrequency) bool {
+	return s.IsEnabled(frequency) && s.lastPeriod(frequency) == nil
+}
+
+// IsDue reports whether the digest for the period starting on periodStart
+// still has to be sent.
+func (s *DigestSubscription) IsDue(frequency DigestFrequency, periodStart time.Time) bool {
+	if !s.IsEnabled(frequency) {
+		return false
+	}
+
+	last := s.lastPeriod(frequency)
+	return last != nil && last.Before(periodStart)
+}
+
+func (s *DigestSubscription) lastPeriod(frequency DigestFrequency) *time.Time {
+	if frequency == DigestMonthly {
+		return s.LastMonthlyPeriod
+	}
+	return s.LastWeeklyPeriod
+}
+
+func (s *DigestSubscription) MarkSent(frequency DigestFrequency, periodStart time.Time) {
+	switch frequency {
+	case DigestWeekly:
+		s.LastWeeklyPeriod = &periodStart
+	case DigestMonthly:
+		s.LastMonthlyPeriod = &periodStart
+	}
+	s.UpdatedAt = time.Now()
+}
+
+// PreviousDigestPeriod returns the first and last day of the most recent
+// complete week (Monday to Sunday) or calendar month before today.
+func PreviousDigestPeriod(frequency DigestFrequency, today time.Time) (time.Time, time.Time) {
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+
+	if frequency == DigestMonthly {
+		to := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+		return time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, time.UTC), to
+	}
+
+	to := utils.StartOfWeek(today, time.Monday).AddDate(0, 0, -1)
+	return to.AddDate(0, 0, -6), to
+}
@@ -0,0 +1,85 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPreviousDigestPeriod(t *testing.T) {
+	tests := []struct {
+		name      string
+		frequency DigestFrequency
+		today     time.Time
+		from      time.Time
+		to        time.Time
+	}{
+		{
+			name:      "Weekly on a Monday",
+			frequency: DigestWeekly,
+			today:     time.Date(2025, 1, 13, 8, 0, 0, 0, time.UTC),
+			from:      time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC),
+			to:        time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "Weekly on a Sunday",
+			frequency: DigestWeekly,
+			today:     time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC),
+			from:      time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC),
+			to:        time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "Monthly across a year boundary",
+			frequency: DigestMonthly,
+			today:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			from:      time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+			to:        time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "Monthly mid-month",
+			frequency: DigestMonthly,
+			today:     time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+			from:      time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			to:        time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := PreviousDigestPeriod(tt.frequency, tt.today)
+			if !from.Equal(tt.from) || !to.Equal(tt.to) {
+				t.Errorf("Expected %s - %s, got %s - %s", tt.from, tt.to, from, to)
+			}
+		})
+	}
+}
+
+func TestDigestSubscription_IsDue(t *testing.T) {
+	subscription := NewDigestSubscription("user-123")
+	periodStart := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+	if subscription.IsDue(DigestWeekly, periodStart) {
+		t.Error("Expected digest not to be due when the user has not opted in")
+	}
+
+	subscription.SetEnabled(DigestWeekly, true)
+	if subscription.IsDue(DigestWeekly, periodStart) || !subscription.IsStarting(DigestWeekly) {
+		t.Error("Expected the period before the opt-in to be skipped")
+	}
+	if subscription.IsStarting(DigestMonthly) {
+		t.Error("Expected monthly digest not to be starting")
+	}
+
+	subscription.MarkSent(DigestWeekly, periodStart)
+	if subscription.IsDue(DigestWeekly, periodStart) {
+		t.Error("Expected weekly digest not to be due once sent")
+	}
+	if !subscription.IsDue(DigestWeekly, periodStart.AddDate(0, 0, 7)) {
+		t.Error("Expected the next week to be due")
+	}
+
+	subscription.SetEnabled(DigestWeekly, false)
+	subscription.SetEnabled(DigestWeekly, true)
+	if subscription.IsDue(DigestWeekly, periodStart.AddDate(0, 0, 7)) {
+		t.Error("Expected opting in again to skip the periods before it")
+	}
+}
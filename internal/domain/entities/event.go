@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	EventUserRegistered      EventType = "user.registered"
+	EventUserEmailVerified   EventType = "user.email_verified"
+	EventHabitCreated        EventType = "habit.created"
+	EventHabitMarked         EventType = "habit.marked"
+	EventHabitUnmarked       EventType = "habit.unmarked"
+	EventHabitArchived       EventType = "habit.archived"
+	EventAchievementUnlocked EventType = "achievement.unlocked"
+	EventStreakBroken        EventType = "streak.broken"
+)
+
+// Event is something that happened to a user's data, emitted by the
+// application layer once the change is stored. ID is unique per event and is
+// used to handle each event at most once.
+type Event struct {
+	ID         string
+	Type       EventType
+	UserID     string
+	OccurredAt time.Time
+	Data       map[string]interface{}
+}
+
+func NewEvent(eventType EventType, userID string, data map[string]interface{}) *Event {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	return &Event{
+		ID:         uuid.NewString(),
+		Type:       eventType,
+		UserID:     userID,
+		OccurredAt: time.Now(),
+		Data:       data,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/shared/utils"
 )
 
 type Habit struct {
@@ -18,6 +19,7 @@ type Habit struct {
 	CarryOver     bool
 	IsNegative    bool
 	TargetValue   *float64
+	Difficulty    value_objects.HabitDifficulty
 	CreatedAt     time.Time
 	ArchivedAt    *time.Time
 }
@@ -37,6 +39,7 @@ func NewHabit(
 		Frequency:  frequency,
 		CarryOver:  carryOver,
 		IsNegative: isNegative,
+		Difficulty: value_objects.HabitDifficultyMedium,
 		CreatedAt:  time.Now(),
 	}
 }
@@ -49,3 +52,70 @@ func (h *Habit) Archive() {
 func (h *Habit) IsActive() bool {
 	return h.ArchivedAt == nil
 }
+
+func (h *Habit) IsScheduledOn(date time.Time) bool {
+	return utils.ShouldAppearToday(string(h.Frequency), h.SpecificDays, h.SpecificDates, date)
+}
+
+// OccurrencesBetween counts the days from from to to, both included, the
+// habit is scheduled on. It is worked out from the frequency rather than by
+// walking every day, so it stays cheap for habits kept for years.
+func (h *Habit) OccurrencesBetween(from, to time.Time) int {
+	from = dateOnly(from)
+	to = dateOnly(to)
+	if to.Before(from) {
+		return 0
+	}
+
+	switch h.Frequency {
+	case value_objects.FrequencyDaily:
+		return daysBetween(from, to) + 1
+	case value_objects.FrequencyWeekly:
+		days := daysBetween(from, to) + 1
+		count := days / 7 * len(distinct(h.SpecificDays, 0, 6))
+		// The days left over after the full weeks are fewer than a week.
+		for date := from.AddDate(0, 0, days/7*7); !date.After(to); date = date.AddDate(0, 0, 1) {
+			if h.IsScheduledOn(date) {
+				count++
+			}
+		}
+		return count
+	case value_objects.FrequencyMonthly:
+		dates := distinct(h.SpecificDates, 1, 31)
+		count := 0
+		for month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(to); month = month.AddDate(0, 1, 0) {
+			first, last := 1, month.AddDate(0, 1, -1).Day()
+			if month.Year() == from.Year() && month.Month() == from.Month() {
+				first = from.Day()
+			}
+			if month.Year() == to.Year() && month.Month() == to.Month() {
+				last = to.Day()
+			}
+			for _, day := range dates {
+				if day >= first && day <= last {
+					count++
+				}
+			}
+		}
+		return count
+	}
+
+	return 0
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// distinct returns the values from low to high, without repeats.
+func distinct(values []int, low, high int) []int {
+	seen := make(map[int]bool, len(values))
+	result := make([]int, 0, len(values))
+	for _, value := range values {
+		if value >= low && value <= high && !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
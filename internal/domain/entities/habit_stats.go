@@ -0,0 +1,256 @@
+package entities
+
+import (
+	"sort"
+	"time"
+
+	"apocapoc-api/internal/domain/value_objects"
+)
+
+// HabitStats is the materialized summary of a habit's completions. Streaks
+// only break on scheduled days without a completion, and CurrentStreak is the
+// run ending on LastCompletedDate.
+//
+// Strength is the strength score, between 0 and 1, after every day up to
+// StrengthDate. Reads carry it forward so they only load recent entries; it is
+// cleared when a completion on or before StrengthDate changes.
+type HabitStats struct {
+	HabitID              string
+	TotalCompletions     int
+	ScheduledCompletions int
+	CurrentStreak        int
+	LongestStreak        int
+	LastCompletedDate    *time.Time
+	Strength             float64
+	StrengthDate         *time.Time
+	UpdatedAt            time.Time
+}
+
+func NewHabitStats(habit *Habit, entries []*HabitEntry) *HabitStats {
+	stats := &HabitStats{
+		HabitID:   habit.ID,
+		UpdatedAt: time.Now(),
+	}
+
+	seen := make(map[time.Time]bool, len(entries))
+	dates := make([]time.Time, 0, len(entries))
+	for _, entry := range entries {
+		date := dateOnly(entry.ScheduledDate)
+		if !seen[date] {
+			seen[date] = true
+			dates = append(dates, date)
+		}
+	}
+
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+
+	for _, date := range dates {
+		stats.RecordCompletion(habit, date)
+	}
+
+	return stats
+}
+
+// RecordCompletion applies a new completion and reports whether it could be
+// applied incrementally. Completions on or before the last completed date
+// require recalculating the stats from all entries.
+func (s *HabitStats) RecordCompletion(habit *Habit, date time.Time) bool {
+	date = dateOnly(date)
+
+	if s.LastCompletedDate != nil && !date.After(*s.LastCompletedDate) {
+		return false
+	}
+	s.forgetStrengthFrom(date)
+
+	if s.LastCompletedDate != nil && !missedOccurrenceBetween(habit, *s.LastCompletedDate, date) {
+		s.CurrentStreak++
+	} else {
+		s.CurrentStreak = 1
+	}
+
+	if s.CurrentStreak > s.LongestStreak {
+		s.LongestStreak = s.CurrentStreak
+	}
+
+	s.TotalCompletions++
+	if habit.IsScheduledOn(date) {
+		s.ScheduledCompletions++
+	}
+
+	s.LastCompletedDate = &date
+	s.UpdatedAt = time.Now()
+
+	return true
+}
+
+// StreakWindow returns the days around date whose entries RemoveCompletion
+// needs. A run of completions is never longer than the longest streak, so the
+// window holds the whole run through date and the occurrence that bounds it.
+func (s *HabitStats) StreakWindow(habit *Habit, date time.Time) (time.Time, time.Time) {
+	date = dateOnly(date)
+	span := (s.LongestStreak + 1) * maxOccurrenceGap(habit)
+	return date.AddDate(0, 0, -span), date.AddDate(0, 0, span)
+}
+
+// RemoveCompletion takes back the completion on date given the entries left
+// within StreakWindow, and reports whether it could be applied incrementally.
+// Removing a completion from a run as long as the longest streak, or the last
+// completion when no earlier one is within the window, requires recalculating
+// the stats from all entries.
+func (s *HabitStats) RemoveCompletion(habit *Habit, date time.Time, entries []*HabitEntry) bool {
+	date = dateOnly(date)
+	if s.LastCompletedDate == nil || date.After(*s.LastCompletedDate) {
+		return false
+	}
+
+	from, to := s.StreakWindow(habit, date)
+	var dates []time.Time
+	for _, entry := range entries {
+		entryDate := dateOnly(entry.ScheduledDate)
+		if !entryDate.Equal(date) && !entryDate.Before(from) && !entryDate.After(to) {
+			dates = append(dates, entryDate)
+		}
+	}
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+
+	before := sort.Search(len(dates), func(i int) bool { return dates[i].After(date) })
+	withDate := make([]time.Time, 0, len(dates)+1)
+	withDate = append(append(append(withDate, dates[:before]...), date), dates[before:]...)
+
+	// The longest streak only changes when the run through date held it.
+	run, bounded := runThrough(habit, withDate, before, from, to)
+	if !bounded || run >= s.LongestStreak {
+		return false
+	}
+
+	last := *s.LastCompletedDate
+	current := s.CurrentStreak
+	switch {
+	case last.Equal(date) && before == 0:
+		return false
+	case last.Equal(date):
+		last = dates[before-1]
+		if current, bounded = runEnding(habit, dates, before-1, from); !bounded {
+			return false
+		}
+	case !last.After(to):
+		index := sort.Search(len(dates), func(i int) bool { return !dates[i].Before(last) })
+		if index == len(dates) || !dates[index].Equal(last) {
+			return false
+		}
+		if current, bounded = runEnding(habit, dates, index, from); !bounded {
+			return false
+		}
+	}
+
+	s.TotalCompletions--
+	if habit.IsScheduledOn(date) {
+		s.ScheduledCompletions--
+	}
+	s.CurrentStreak = current
+	s.LastCompletedDate = &last
+	s.forgetStrengthFrom(date)
+	s.UpdatedAt = time.Now()
+
+	return true
+}
+
+func (s *HabitStats) forgetStrengthFrom(date time.Time) {
+	if s.StrengthDate != nil && !date.After(*s.StrengthDate) {
+		s.Strength = 0
+		s.StrengthDate = nil
+	}
+}
+
+// runEnding returns the length of the run of completions ending on
+// dates[index], and whether the run is known to start within the window
+// beginning on from.
+func runEnding(habit *Habit, dates []time.Time, index int, from time.Time) (int, bool) {
+	length := 1
+	for ; index > 0 && !missedOccurrenceBetween(habit, dates[index-1], dates[index]); index-- {
+		length++
+	}
+	if index == 0 && !missedOccurrenceBetween(habit, from.AddDate(0, 0, -1), dates[0]) {
+		return length, false
+	}
+	return length, true
+}
+
+// runThrough returns the length of the run of completions through
+// dates[index], and whether the run is known to lie within from and to.
+func runThrough(habit *Habit, dates []time.Time, index int, from, to time.Time) (int, bool) {
+	length, bounded := runEnding(habit, dates, index, from)
+	for ; index < len(dates)-1 && !missedOccurrenceBetween(habit, dates[index], dates[index+1]); index++ {
+		length++
+	}
+	if index == len(dates)-1 && !missedOccurrenceBetween(habit, dates[index], to.AddDate(0, 0, 1)) {
+		bounded = false
+	}
+	return length, bounded
+}
+
+// maxOccurrenceGap returns the most days between two consecutive occurrences
+// of the habit.
+func maxOccurrenceGap(habit *Habit) int {
+	switch habit.Frequency {
+	case value_objects.FrequencyWeekly:
+		return 7
+	case value_objects.FrequencyMonthly:
+		// A date only some months have, like the 31st, can skip a month.
+		return 62
+	}
+	return 1
+}
+
+// CurrentStreakAt returns the streak as of today. An incomplete today does not
+// break the streak since the day is still in progress.
+func (s *HabitStats) CurrentStreakAt(habit *Habit, today time.Time) int {
+	if s.LastCompletedDate == nil {
+		return 0
+	}
+
+	today = dateOnly(today)
+	if today.After(*s.LastCompletedDate) && missedOccurrenceBetween(habit, *s.LastCompletedDate, today) {
+		return 0
+	}
+
+	return s.CurrentStreak
+}
+
+// StreakBrokenOn returns the first scheduled day after the last completion
+// that ended without one, i.e. the day a running streak was lost. Today is not
+// considered since it is still in progress.
+func (s *HabitStats) StreakBrokenOn(habit *Habit, today time.Time) (time.Time, bool) {
+	if s.LastCompletedDate == nil || s.CurrentStreak == 0 {
+		return time.Time{}, false
+	}
+
+	today = dateOnly(today)
+	for date := s.LastCompletedDate.AddDate(0, 0, 1); date.Before(today); date = date.AddDate(0, 0, 1) {
+		if habit.IsScheduledOn(date) {
+			return date, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func missedOccurrenceBetween(habit *Habit, from, to time.Time) bool {
+	first, last := from.AddDate(0, 0, 1), to.AddDate(0, 0, -1)
+	if last.Before(first) {
+		return false
+	}
+	if first.Before(dateOnly(habit.CreatedAt)) {
+		return true
+	}
+
+	return habit.OccurrencesBetween(first, last) > 0
+}
+
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
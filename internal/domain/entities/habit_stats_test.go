@@ -0,0 +1,170 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/value_objects"
+)
+
+func TestNewHabitStats_SkipsUnscheduledDays(t *testing.T) {
+	habit := NewHabit("user-123", "Gym", value_objects.HabitTypeBoolean, value_objects.FrequencyWeekly, false, false)
+	habit.ID = "habit-1"
+	habit.SpecificDays = []int{1, 3, 5}
+	habit.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Mondays, Wednesdays and Fridays from 2025-01-06, missing 2025-01-15.
+	var entries []*HabitEntry
+	for _, day := range []int{6, 8, 10, 13, 17, 20, 22} {
+		entries = append(entries, NewHabitEntry(habit.ID, time.Date(2025, 1, day, 0, 0, 0, 0, time.UTC), nil))
+	}
+
+	stats := NewHabitStats(habit, entries)
+
+	if stats.TotalCompletions != 7 || stats.ScheduledCompletions != 7 {
+		t.Errorf("Expected 7 completions, got %d total and %d scheduled", stats.TotalCompletions, stats.ScheduledCompletions)
+	}
+	if stats.LongestStreak != 4 {
+		t.Errorf("Expected longest streak of 4, got %d", stats.LongestStreak)
+	}
+	if stats.CurrentStreak != 3 {
+		t.Errorf("Expected current streak of 3, got %d", stats.CurrentStreak)
+	}
+	if stats.LastCompletedDate == nil || !stats.LastCompletedDate.Equal(time.Date(2025, 1, 22, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected last completion on 2025-01-22, got %v", stats.LastCompletedDate)
+	}
+}
+
+func TestHabitStats_RecordCompletion(t *testing.T) {
+	habit := NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	stats := NewHabitStats(habit, nil)
+
+	if !stats.RecordCompletion(habit, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatal("Expected first completion to be applied")
+	}
+	if !stats.RecordCompletion(habit, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Fatal("Expected consecutive completion to be applied")
+	}
+	if stats.CurrentStreak != 2 {
+		t.Errorf("Expected streak of 2, got %d", stats.CurrentStreak)
+	}
+
+	if !stats.RecordCompletion(habit, time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Fatal("Expected later completion to be applied")
+	}
+	if stats.CurrentStreak != 1 || stats.LongestStreak != 2 {
+		t.Errorf("Expected streak to restart after missed days, got current %d and longest %d", stats.CurrentStreak, stats.LongestStreak)
+	}
+
+	if stats.RecordCompletion(habit, time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Error("Expected an earlier completion to require a full recalculation")
+	}
+	if stats.TotalCompletions != 3 {
+		t.Errorf("Expected rejected completion to leave totals untouched, got %d", stats.TotalCompletions)
+	}
+}
+
+func TestHabitStats_RemoveCompletion(t *testing.T) {
+	habit := NewHabit("user-123", "Gym", value_objects.HabitTypeBoolean, value_objects.FrequencyWeekly, false, false)
+	habit.ID = "habit-1"
+	habit.SpecificDays = []int{1, 3, 5}
+	habit.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Mondays, Wednesdays and Fridays from 2025-01-06 to 2025-01-20, missing
+	// 2025-01-15, plus an extra Saturday on 2025-01-18.
+	days := []int{6, 8, 10, 13, 17, 18, 20}
+
+	applied := 0
+	for _, removed := range days {
+		var entries, remaining []*HabitEntry
+		for _, day := range days {
+			entry := NewHabitEntry(habit.ID, time.Date(2025, 1, day, 0, 0, 0, 0, time.UTC), nil)
+			entries = append(entries, entry)
+			if day != removed {
+				remaining = append(remaining, entry)
+			}
+		}
+
+		stats := NewHabitStats(habit, entries)
+		strengthDate := time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC)
+		stats.Strength, stats.StrengthDate = 0.5, &strengthDate
+
+		date := time.Date(2025, 1, removed, 0, 0, 0, 0, time.UTC)
+		if !stats.RemoveCompletion(habit, date, remaining) {
+			continue
+		}
+		applied++
+
+		want := NewHabitStats(habit, remaining)
+		if stats.TotalCompletions != want.TotalCompletions || stats.ScheduledCompletions != want.ScheduledCompletions ||
+			stats.CurrentStreak != want.CurrentStreak || stats.LongestStreak != want.LongestStreak ||
+			!stats.LastCompletedDate.Equal(*want.LastCompletedDate) {
+			t.Errorf("Removing 2025-01-%02d: expected %+v, got %+v", removed, want, stats)
+		}
+		if (stats.StrengthDate == nil) != !date.After(strengthDate) {
+			t.Errorf("Removing 2025-01-%02d: expected the strength checkpoint to be kept only for later days, got %v", removed, stats.StrengthDate)
+		}
+	}
+
+	// Only the completions of the longest run, 2025-01-06 to 2025-01-13,
+	// need a full recalculation.
+	if applied != 3 {
+		t.Errorf("Expected 3 removals to be applied incrementally, got %d", applied)
+	}
+}
+
+func TestHabitStats_CurrentStreakAt(t *testing.T) {
+	habit := NewHabit("user-123", "Exercise", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	lastCompleted := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	stats := &HabitStats{CurrentStreak: 5, LastCompletedDate: &lastCompleted}
+
+	tests := []struct {
+		name     string
+		today    time.Time
+		expected int
+	}{
+		{"Completed today", lastCompleted, 5},
+		{"Today still pending", lastCompleted.AddDate(0, 0, 1), 5},
+		{"Missed a day", lastCompleted.AddDate(0, 0, 2), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if streak := stats.CurrentStreakAt(habit, tt.today); streak != tt.expected {
+				t.Errorf("Expected streak of %d, got %d", tt.expected, streak)
+			}
+		})
+	}
+
+	if streak := NewHabitStats(habit, nil).CurrentStreakAt(habit, lastCompleted); streak != 0 {
+		t.Errorf("Expected no streak without completions, got %d", streak)
+	}
+}
+
+func TestHabitStats_StreakBrokenOn(t *testing.T) {
+	habit := NewHabit("user-123", "Gym", value_objects.HabitTypeBoolean, value_objects.FrequencyWeekly, false, false)
+	habit.SpecificDays = []int{1, 3, 5}
+	habit.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Wednesday 2025-01-08; the next occurrence is Friday 2025-01-10.
+	lastCompleted := time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)
+	stats := &HabitStats{CurrentStreak: 3, LastCompletedDate: &lastCompleted}
+
+	if _, broken := stats.StreakBrokenOn(habit, time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)); broken {
+		t.Error("Expected the streak to survive while Friday is in progress")
+	}
+
+	date, broken := stats.StreakBrokenOn(habit, time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC))
+	if !broken || !date.Equal(time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the streak to break on 2025-01-10, got %v %v", date, broken)
+	}
+
+	stats.CurrentStreak = 0
+	if _, broken := stats.StreakBrokenOn(habit, time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC)); broken {
+		t.Error("Expected no break without a running streak")
+	}
+}
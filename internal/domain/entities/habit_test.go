@@ -104,3 +104,42 @@ func TestHabit_WithTargetValue(t *testing.T) {
 		t.Errorf("Expected TargetValue 8.0, got %f", *habit.TargetValue)
 	}
 }
+
+func TestHabit_OccurrencesBetween(t *testing.T) {
+	tests := []struct {
+		name          string
+		frequency     value_objects.Frequency
+		specificDays  []int
+		specificDates []int
+	}{
+		{"Daily", value_objects.FrequencyDaily, nil, nil},
+		{"Weekly", value_objects.FrequencyWeekly, []int{1, 3, 5, 3}, nil},
+		{"Monthly", value_objects.FrequencyMonthly, nil, []int{1, 15, 29, 31}},
+	}
+
+	from := time.Date(2023, 1, 18, 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			habit := NewHabit("user-123", "Habit", value_objects.HabitTypeBoolean, tt.frequency, false, false)
+			habit.SpecificDays = tt.specificDays
+			habit.SpecificDates = tt.specificDates
+
+			// Compare with walking the days for ranges ending on every day
+			// of a couple of years, leap day included.
+			expected := 0
+			for to := from; to.Year() < 2025; to = to.AddDate(0, 0, 1) {
+				if habit.IsScheduledOn(to) {
+					expected++
+				}
+				if got := habit.OccurrencesBetween(from, to); got != expected {
+					t.Fatalf("Expected %d occurrences up to %s, got %d", expected, to.Format("2006-01-02"), got)
+				}
+			}
+		})
+	}
+
+	habit := NewHabit("user-123", "Habit", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	if got := habit.OccurrencesBetween(from, from.AddDate(0, 0, -1)); got != 0 {
+		t.Errorf("Expected no occurrences in an empty range, got %d", got)
+	}
+}
@@ -0,0 +1,85 @@
+package entities
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultMQTTTopicPrefix     = "apocapoc"
+	DefaultMQTTDiscoveryPrefix = "homeassistant"
+)
+
+// MQTTIntegration is a user's own MQTT broker, such as the one Home Assistant
+// listens on. Habit state is published there and habits can be marked by
+// publishing to their command topics. Timezone decides which day "today" is.
+type MQTTIntegration struct {
+	UserID          string
+	BrokerURL       string
+	Username        string
+	Password        string
+	TopicPrefix     string
+	DiscoveryPrefix string
+	Timezone        string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func NewMQTTIntegration(userID, brokerURL, username, password, topicPrefix, discoveryPrefix, timezone string) *MQTTIntegration {
+	now := time.Now()
+	return &MQTTIntegration{
+		UserID:          userID,
+		BrokerURL:       brokerURL,
+		Username:        username,
+		Password:        password,
+		TopicPrefix:     topicPrefix,
+		DiscoveryPrefix: discoveryPrefix,
+		Timezone:        timezone,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+}
+
+// IsValidMQTTBrokerURL accepts mqtt:// and tcp:// for plain connections and
+// mqtts:// and ssl:// for TLS. Credentials go in their own fields, not in the
+// URL.
+func IsValidMQTTBrokerURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Hostname() == "" || parsed.User != nil {
+		return false
+	}
+	switch parsed.Scheme {
+	case "mqtt", "tcp", "mqtts", "ssl":
+		return true
+	}
+	return false
+}
+
+// IsValidMQTTTopicPrefix accepts topic names without wildcards, empty levels
+// or a leading "$", which brokers reserve for their own topics.
+func IsValidMQTTTopicPrefix(prefix string) bool {
+	if prefix == "" || len(prefix) > 100 || strings.HasPrefix(prefix, "$") {
+		return false
+	}
+	if strings.ContainsAny(prefix, "+#\x00") {
+		return false
+	}
+	for _, level := range strings.Split(prefix, "/") {
+		if level == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Today returns the current date in the integration's timezone, as a UTC
+// midnight like the scheduled dates of habit entries.
+func (i *MQTTIntegration) Today(now time.Time) time.Time {
+	loc, err := time.LoadLocation(i.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := now.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
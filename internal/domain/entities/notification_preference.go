@@ -0,0 +1,92 @@
+package entities
+
+import "time"
+
+type NotificationCategory string
+
+const (
+	NotificationReminders    NotificationCategory = "reminders"
+	NotificationDigests      NotificationCategory = "digests"
+	NotificationAchievements NotificationCategory = "achievements"
+	// NotificationSecurity covers account mail such as verification and
+	// password reset links, which cannot be turned off.
+	NotificationSecurity NotificationCategory = "security"
+)
+
+type NotificationChannel string
+
+const (
+	NotificationEmail NotificationChannel = "email"
+	NotificationPush  NotificationChannel = "push"
+	// NotificationChat is a chat linked to the account through the bot.
+	NotificationChat NotificationChannel = "chat"
+)
+
+var NotificationCategories = []NotificationCategory{
+	NotificationReminders,
+	NotificationDigests,
+	NotificationAchievements,
+	NotificationSecurity,
+}
+
+var notificationChannels = map[NotificationCategory][]NotificationChannel{
+	NotificationReminders:    {NotificationEmail, NotificationPush, NotificationChat},
+	NotificationDigests:      {NotificationEmail},
+	NotificationAchievements: {NotificationEmail},
+	NotificationSecurity:     {NotificationEmail},
+}
+
+// Channels returns the channels notifications of the category are sent on.
+func (c NotificationCategory) Channels() []NotificationChannel {
+	return notificationChannels[c]
+}
+
+func (c NotificationCategory) Supports(channel NotificationChannel) bool {
+	for _, supported := range c.Channels() {
+		if supported == channel {
+			return true
+		}
+	}
+	return false
+}
+
+func (c NotificationCategory) IsMandatory() bool {
+	return c == NotificationSecurity
+}
+
+// NotificationPreference records whether a user receives a category of
+// notifications on a channel.
+type NotificationPreference struct {
+	UserID    string
+	Category  NotificationCategory
+	Channel   NotificationChannel
+	Enabled   bool
+	UpdatedAt time.Time
+}
+
+func NewNotificationPreference(userID string, category NotificationCategory, channel NotificationChannel, enabled bool) *NotificationPreference {
+	return &NotificationPreference{
+		UserID:    userID,
+		Category:  category,
+		Channel:   channel,
+		Enabled:   enabled,
+		UpdatedAt: time.Now(),
+	}
+}
+
+// NotificationPreferences are the preferences a user has stored. Every
+// category is enabled on every channel until the user turns it off.
+type NotificationPreferences []*NotificationPreference
+
+func (p NotificationPreferences) IsEnabled(category NotificationCategory, channel NotificationChannel) bool {
+	if category.IsMandatory() {
+		return true
+	}
+
+	for _, preference := range p {
+		if preference.Category == category && preference.Channel == channel {
+			return preference.Enabled
+		}
+	}
+	return true
+}
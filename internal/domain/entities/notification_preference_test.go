@@ -0,0 +1,44 @@
+package entities
+
+import "testing"
+
+func TestNotificationPreferences_IsEnabled(t *testing.T) {
+	preferences := NotificationPreferences{
+		NewNotificationPreference("user-123", NotificationReminders, NotificationPush, false),
+		NewNotificationPreference("user-123", NotificationDigests, NotificationEmail, true),
+		NewNotificationPreference("user-123", NotificationSecurity, NotificationEmail, false),
+	}
+
+	tests := []struct {
+		name     string
+		category NotificationCategory
+		channel  NotificationChannel
+		expected bool
+	}{
+		{"Turned off", NotificationReminders, NotificationPush, false},
+		{"Same category on another channel", NotificationReminders, NotificationEmail, true},
+		{"Turned on", NotificationDigests, NotificationEmail, true},
+		{"No stored preference", NotificationAchievements, NotificationEmail, true},
+		{"Security cannot be turned off", NotificationSecurity, NotificationEmail, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := preferences.IsEnabled(tt.category, tt.channel); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNotificationCategory_Supports(t *testing.T) {
+	if !NotificationReminders.Supports(NotificationPush) {
+		t.Error("Expected reminders to support push")
+	}
+	if NotificationDigests.Supports(NotificationPush) {
+		t.Error("Expected digests to be email only")
+	}
+	if NotificationCategory("marketing").Supports(NotificationEmail) {
+		t.Error("Expected an unknown category to support no channel")
+	}
+}
@@ -0,0 +1,85 @@
+package entities
+
+import "time"
+
+const DefaultNotificationTimezone = "UTC"
+
+// NotificationSchedule limits when a user is notified. No notifications are
+// delivered during quiet hours, from QuietHoursStart to QuietHoursEnd in
+// Timezone (possibly across midnight), and at most MaxPerHour are delivered
+// in any hour, where 0 means no limit. Notifications held back are delivered
+// later in a single batch per channel.
+type NotificationSchedule struct {
+	UserID          string
+	QuietHoursStart string
+	QuietHoursEnd   string
+	Timezone        string
+	MaxPerHour      int
+	UpdatedAt       time.Time
+}
+
+func NewNotificationSchedule(userID string) *NotificationSchedule {
+	return &NotificationSchedule{
+		UserID:    userID,
+		Timezone:  DefaultNotificationTimezone,
+		UpdatedAt: time.Now(),
+	}
+}
+
+func (s *NotificationSchedule) HasQuietHours() bool {
+	return s.QuietHoursStart != "" && s.QuietHoursEnd != "" && s.QuietHoursStart != s.QuietHoursEnd
+}
+
+func (s *NotificationSchedule) InQuietHours(now time.Time) bool {
+	if !s.HasQuietHours() {
+		return false
+	}
+
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return false
+	}
+	start, err := time.Parse(ReminderTimeLayout, s.QuietHoursStart)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse(ReminderTimeLayout, s.QuietHoursEnd)
+	if err != nil {
+		return false
+	}
+
+	local := now.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+
+	if startMinute < endMinute {
+		return minute >= startMinute && minute < endMinute
+	}
+	return minute >= startMinute || minute < endMinute
+}
+
+// HeldNotification is a notification kept back by quiet hours or the hourly
+// limit. Title is the line it is listed with in the batch. Content is the
+// notification as it would have been sent, encoded by its channel, for
+// channels that deliver held notifications in full rather than listing them;
+// it is empty otherwise.
+type HeldNotification struct {
+	ID        string
+	UserID    string
+	Category  NotificationCategory
+	Channel   NotificationChannel
+	Title     string
+	Content   string
+	CreatedAt time.Time
+}
+
+func NewHeldNotification(userID string, category NotificationCategory, channel NotificationChannel, title string) *HeldNotification {
+	return &HeldNotification{
+		UserID:    userID,
+		Category:  category,
+		Channel:   channel,
+		Title:     title,
+		CreatedAt: time.Now(),
+	}
+}
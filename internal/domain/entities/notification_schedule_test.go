@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNotificationSchedule_InQuietHours(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    string
+		end      string
+		timezone string
+		now      time.Time
+		expected bool
+	}{
+		{"Overnight, before midnight", "22:00", "07:00", "UTC", time.Date(2025, 1, 6, 23, 30, 0, 0, time.UTC), true},
+		{"Overnight, after midnight", "22:00", "07:00", "UTC", time.Date(2025, 1, 6, 2, 0, 0, 0, time.UTC), true},
+		{"Overnight, at the end", "22:00", "07:00", "UTC", time.Date(2025, 1, 6, 7, 0, 0, 0, time.UTC), false},
+		{"Overnight, during the day", "22:00", "07:00", "UTC", time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC), false},
+		{"Same day", "13:00", "15:00", "UTC", time.Date(2025, 1, 6, 14, 0, 0, 0, time.UTC), true},
+		{"In the user's timezone", "22:00", "07:00", "Europe/Madrid", time.Date(2025, 1, 6, 21, 30, 0, 0, time.UTC), true},
+		{"Off", "", "", "UTC", time.Date(2025, 1, 6, 2, 0, 0, 0, time.UTC), false},
+		{"Same start and end", "22:00", "22:00", "UTC", time.Date(2025, 1, 6, 22, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := NewNotificationSchedule("user-123")
+			schedule.QuietHoursStart = tt.start
+			schedule.QuietHoursEnd = tt.end
+			schedule.Timezone = tt.timezone
+
+			if got := schedule.InQuietHours(tt.now); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
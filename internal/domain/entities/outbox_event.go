@@ -0,0 +1,90 @@
+package entities
+
+import "time"
+
+type OutboxEventStatus string
+
+const (
+	OutboxEventPending   OutboxEventStatus = "PENDING"
+	OutboxEventProcessed OutboxEventStatus = "PROCESSED"
+	OutboxEventFailed    OutboxEventStatus = "FAILED"
+)
+
+const (
+	OutboxMaxAttempts = 10
+
+	outboxRetryBaseDelay = 10 * time.Second
+	outboxRetryMaxDelay  = time.Hour
+)
+
+// OutboxEvent is an event stored alongside the change that emitted it, so
+// asynchronous subscribers receive it even if the process stops before they
+// run. HandledBy lists the subscribers that already handled the event, so a
+// retry only runs the ones that failed.
+type OutboxEvent struct {
+	Event
+	Status        OutboxEventStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	HandledBy     []string
+	ProcessedAt   *time.Time
+}
+
+func NewOutboxEvent(event *Event) *OutboxEvent {
+	return &OutboxEvent{
+		Event:         *event,
+		Status:        OutboxEventPending,
+		NextAttemptAt: event.OccurredAt,
+		HandledBy:     []string{},
+	}
+}
+
+// OutboxRetryDelay returns how long to wait after the given failed attempt,
+// doubling from 10 seconds up to an hour.
+func OutboxRetryDelay(attempt int) time.Duration {
+	delay := outboxRetryBaseDelay
+	for i := 1; i < attempt && delay < outboxRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > outboxRetryMaxDelay {
+		return outboxRetryMaxDelay
+	}
+	return delay
+}
+
+func (e *OutboxEvent) IsHandledBy(subscriber string) bool {
+	for _, name := range e.HandledBy {
+		if name == subscriber {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *OutboxEvent) MarkHandledBy(subscriber string) {
+	if !e.IsHandledBy(subscriber) {
+		e.HandledBy = append(e.HandledBy, subscriber)
+	}
+}
+
+func (e *OutboxEvent) MarkProcessed(now time.Time) {
+	e.Attempts++
+	e.Status = OutboxEventProcessed
+	e.LastError = ""
+	e.ProcessedAt = &now
+}
+
+// RecordFailure stores a failed attempt. The event stays pending with a
+// backoff until it runs out of attempts.
+func (e *OutboxEvent) RecordFailure(message string, now time.Time) {
+	e.Attempts++
+	e.LastError = message
+
+	if e.Attempts >= OutboxMaxAttempts {
+		e.Status = OutboxEventFailed
+		return
+	}
+
+	e.NextAttemptAt = now.Add(OutboxRetryDelay(e.Attempts))
+}
@@ -0,0 +1,93 @@
+package entities
+
+import "time"
+
+type OutgoingEmailStatus string
+
+const (
+	OutgoingEmailPending OutgoingEmailStatus = "PENDING"
+	OutgoingEmailSent    OutgoingEmailStatus = "SENT"
+	OutgoingEmailFailed  OutgoingEmailStatus = "FAILED"
+)
+
+const (
+	OutgoingEmailMaxAttempts = 10
+
+	emailRetryBaseDelay = 30 * time.Second
+	emailRetryMaxDelay  = time.Hour
+)
+
+// OutgoingEmail is a message waiting in the email outbox. Pending emails are
+// sent at NextAttemptAt and a failed attempt is retried with exponential
+// backoff. Once OutgoingEmailMaxAttempts is reached the email is kept as
+// failed until an admin retries it.
+type OutgoingEmail struct {
+	ID      string
+	To      string
+	Subject string
+	Body    string
+	// TextBody is the plain-text alternative of an HTML Body.
+	TextBody      string
+	IsHTML        bool
+	Headers       map[string]string
+	Status        OutgoingEmailStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
+
+func NewOutgoingEmail(to, subject, body string, isHTML bool) *OutgoingEmail {
+	now := time.Now()
+	return &OutgoingEmail{
+		To:            to,
+		Subject:       subject,
+		Body:          body,
+		IsHTML:        isHTML,
+		Status:        OutgoingEmailPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+}
+
+// EmailRetryDelay returns how long to wait after the given failed attempt,
+// doubling from 30 seconds up to an hour.
+func EmailRetryDelay(attempt int) time.Duration {
+	delay := emailRetryBaseDelay
+	for i := 1; i < attempt && delay < emailRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > emailRetryMaxDelay {
+		return emailRetryMaxDelay
+	}
+	return delay
+}
+
+func (e *OutgoingEmail) RecordSuccess(now time.Time) {
+	e.Attempts++
+	e.Status = OutgoingEmailSent
+	e.LastError = ""
+	e.SentAt = &now
+}
+
+// RecordFailure stores a failed attempt. The email stays pending with a
+// backoff until it runs out of attempts.
+func (e *OutgoingEmail) RecordFailure(message string, now time.Time) {
+	e.Attempts++
+	e.LastError = message
+
+	if e.Attempts >= OutgoingEmailMaxAttempts {
+		e.Status = OutgoingEmailFailed
+		return
+	}
+
+	e.NextAttemptAt = now.Add(EmailRetryDelay(e.Attempts))
+}
+
+// Retry queues a failed email again with a fresh set of attempts.
+func (e *OutgoingEmail) Retry(now time.Time) {
+	e.Status = OutgoingEmailPending
+	e.Attempts = 0
+	e.NextAttemptAt = now
+}
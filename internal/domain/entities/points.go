@@ -0,0 +1,105 @@
+package entities
+
+import "time"
+
+type PointTransactionType string
+
+const (
+	PointTransactionCompletion PointTransactionType = "COMPLETION"
+	PointTransactionReversal   PointTransactionType = "REVERSAL"
+	PointTransactionRedemption PointTransactionType = "REDEMPTION"
+)
+
+// PointTransaction is an entry of the user's points ledger. Credits are
+// positive and debits negative, so the balance is the sum of all points.
+type PointTransaction struct {
+	ID        string
+	UserID    string
+	Type      PointTransactionType
+	Points    int
+	HabitID   *string
+	EntryID   *string
+	RewardID  *string
+	CreatedAt time.Time
+}
+
+func NewCompletionCredit(habit *Habit, entry *HabitEntry) *PointTransaction {
+	return &PointTransaction{
+		UserID:    habit.UserID,
+		Type:      PointTransactionCompletion,
+		Points:    habit.Difficulty.Points(),
+		HabitID:   &habit.ID,
+		EntryID:   &entry.ID,
+		CreatedAt: time.Now(),
+	}
+}
+
+// NewCompletionReversal cancels the points credited for an entry.
+func NewCompletionReversal(habit *Habit, entryID string, credited int) *PointTransaction {
+	return &PointTransaction{
+		UserID:    habit.UserID,
+		Type:      PointTransactionReversal,
+		Points:    -credited,
+		HabitID:   &habit.ID,
+		EntryID:   &entryID,
+		CreatedAt: time.Now(),
+	}
+}
+
+func NewRedemption(reward *Reward) *PointTransaction {
+	return &PointTransaction{
+		UserID:    reward.UserID,
+		Type:      PointTransactionRedemption,
+		Points:    -reward.Cost,
+		RewardID:  &reward.ID,
+		CreatedAt: time.Now(),
+	}
+}
+
+// PointsBalance summarizes the ledger. XP only counts completions and their
+// reversals, so redeeming rewards never costs levels.
+type PointsBalance struct {
+	XP      int
+	Balance int
+}
+
+func (b PointsBalance) Level() int {
+	level := 1
+	for XPForLevel(level+1) <= b.XP {
+		level++
+	}
+	return level
+}
+
+// XPForLevel returns the XP needed to reach level. Each level takes 100 XP
+// more than the previous one.
+func XPForLevel(level int) int {
+	return 50 * level * (level - 1)
+}
+
+type Reward struct {
+	ID         string
+	UserID     string
+	Name       string
+	Cost       int
+	CreatedAt  time.Time
+	ArchivedAt *time.Time
+}
+
+func NewReward(userID, name string, cost int) *Reward {
+	return &Reward{
+		UserID:    userID,
+		Name:      name,
+		Cost:      cost,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (r *Reward) Archive() {
+	now := time.Now()
+	r.ArchivedAt = &now
+}
+
+func (r *Reward) IsActive() bool {
+	return r.ArchivedAt == nil
+}
@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"testing"
+
+	"apocapoc-api/internal/domain/value_objects"
+)
+
+func TestPointsBalance_Level(t *testing.T) {
+	tests := []struct {
+		xp       int
+		expected int
+	}{
+		{0, 1},
+		{99, 1},
+		{100, 2},
+		{299, 2},
+		{300, 3},
+		{1000, 5},
+	}
+
+	for _, tt := range tests {
+		if got := (PointsBalance{XP: tt.xp}).Level(); got != tt.expected {
+			t.Errorf("Level for %d XP = %d, want %d", tt.xp, got, tt.expected)
+		}
+	}
+}
+
+func TestNewCompletionCredit_WeightedByDifficulty(t *testing.T) {
+	habit := NewHabit("user-123", "Running", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+	habit.Difficulty = value_objects.HabitDifficultyHard
+
+	entry := &HabitEntry{ID: "entry-1", HabitID: habit.ID}
+	credit := NewCompletionCredit(habit, entry)
+
+	if credit.Points != value_objects.HabitDifficultyHard.Points() {
+		t.Errorf("Expected %d points, got %d", value_objects.HabitDifficultyHard.Points(), credit.Points)
+	}
+	if credit.EntryID == nil || *credit.EntryID != entry.ID {
+		t.Errorf("Expected credit to reference entry %s", entry.ID)
+	}
+
+	reversal := NewCompletionReversal(habit, entry.ID, credit.Points)
+	if reversal.Points != -credit.Points || reversal.Type != PointTransactionReversal {
+		t.Errorf("Expected reversal of %d points, got %+v", credit.Points, reversal)
+	}
+}
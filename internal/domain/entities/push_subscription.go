@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"crypto/ecdh"
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const pushAuthSecretLength = 16
+
+// PushSubscription is a browser's Web Push subscription, one per device. P256dh
+// and Auth are the base64url keys the browser hands out to encrypt payloads.
+type PushSubscription struct {
+	ID        string
+	UserID    string
+	Endpoint  string
+	P256dh    string
+	Auth      string
+	UserAgent string
+	CreatedAt time.Time
+}
+
+func NewPushSubscription(userID, endpoint, p256dh, auth, userAgent string) *PushSubscription {
+	return &PushSubscription{
+		UserID:    userID,
+		Endpoint:  endpoint,
+		P256dh:    p256dh,
+		Auth:      auth,
+		UserAgent: userAgent,
+		CreatedAt: time.Now(),
+	}
+}
+
+// IsValid checks that the endpoint is an HTTPS URL and that the keys are a
+// P-256 public key and a 16 byte authentication secret.
+func (s *PushSubscription) IsValid() bool {
+	endpoint, err := url.Parse(s.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		return false
+	}
+
+	p256dh, err := DecodePushKey(s.P256dh)
+	if err != nil {
+		return false
+	}
+	if _, err := ecdh.P256().NewPublicKey(p256dh); err != nil {
+		return false
+	}
+
+	auth, err := DecodePushKey(s.Auth)
+	return err == nil && len(auth) == pushAuthSecretLength
+}
+
+// DecodePushKey decodes a base64url key, with or without padding, as browsers
+// and push libraries use both.
+func DecodePushKey(key string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(key, "="))
+}
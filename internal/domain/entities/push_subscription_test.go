@@ -0,0 +1,33 @@
+package entities
+
+import "testing"
+
+const (
+	testPushP256dh = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	testPushAuth   = "BTBZMqHH6r4Tts7J_aSIgg"
+)
+
+func TestPushSubscription_IsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		p256dh   string
+		auth     string
+		valid    bool
+	}{
+		{"valid", "https://push.example.com/send/abc", testPushP256dh, testPushAuth, true},
+		{"padded keys", "https://push.example.com/send/abc", testPushP256dh + "=", testPushAuth + "==", true},
+		{"plain http endpoint", "http://push.example.com/send/abc", testPushP256dh, testPushAuth, false},
+		{"not a curve point", "https://push.example.com/send/abc", "BAAA", testPushAuth, false},
+		{"short auth secret", "https://push.example.com/send/abc", testPushP256dh, "BTBZMqHH6r4", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription := NewPushSubscription("user-1", tt.endpoint, tt.p256dh, tt.auth, "")
+			if subscription.IsValid() != tt.valid {
+				t.Errorf("Expected IsValid() = %v", tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecapShare is a public link to a user's year recap card. The token is the
+// only thing the link exposes about the account.
+type RecapShare struct {
+	ID        string
+	UserID    string
+	Year      int
+	Token     string
+	CreatedAt time.Time
+}
+
+func NewRecapShare(userID string, year int, token string) *RecapShare {
+	return &RecapShare{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Year:      year,
+		Token:     token,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,76 @@
+package entities
+
+import "time"
+
+const ReminderTimeLayout = "15:04"
+
+// ReminderGracePeriod bounds how late a reminder may still be delivered, so a
+// server that was down at reminder time does not send stale reminders, and a
+// reminder created after its time does not fire straight away.
+const ReminderGracePeriod = time.Hour
+
+// HabitReminder fires once a day at TimeOfDay in Timezone on the days the habit
+// is scheduled, as long as the habit has not been completed yet. LastSentOn
+// holds the local date of the last delivery so a day is never reminded twice.
+type HabitReminder struct {
+	ID         string
+	HabitID    string
+	UserID     string
+	TimeOfDay  string
+	Timezone   string
+	Enabled    bool
+	LastSentOn *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func NewHabitReminder(habitID, userID, timeOfDay, timezone string) *HabitReminder {
+	now := time.Now()
+	return &HabitReminder{
+		HabitID:   habitID,
+		UserID:    userID,
+		TimeOfDay: timeOfDay,
+		Timezone:  timezone,
+		Enabled:   true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func IsValidReminderTime(timeOfDay string) bool {
+	_, err := time.Parse(ReminderTimeLayout, timeOfDay)
+	return err == nil
+}
+
+// DueDate returns the local date the reminder is due for at now. It reports
+// false outside the grace period after the reminder time and once that day's
+// reminder has been sent.
+func (r *HabitReminder) DueDate(now time.Time) (time.Time, bool) {
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	at, err := time.Parse(ReminderTimeLayout, r.TimeOfDay)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	local := now.In(loc)
+	remindAt := time.Date(local.Year(), local.Month(), local.Day(), at.Hour(), at.Minute(), 0, 0, loc)
+	if local.Before(remindAt) || !local.Before(remindAt.Add(ReminderGracePeriod)) {
+		return time.Time{}, false
+	}
+
+	date := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	if r.LastSentOn != nil && !r.LastSentOn.Before(date) {
+		return time.Time{}, false
+	}
+
+	return date, true
+}
+
+func (r *HabitReminder) MarkSent(date time.Time) {
+	r.LastSentOn = &date
+	r.UpdatedAt = time.Now()
+}
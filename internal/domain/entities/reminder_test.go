@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHabitReminder_DueDate(t *testing.T) {
+	reminder := NewHabitReminder("habit-1", "user-1", "08:30", "Europe/Madrid")
+	madridDay := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		due  bool
+	}{
+		{"before reminder time", time.Date(2025, 3, 10, 7, 29, 0, 0, time.UTC), false},
+		{"at reminder time", time.Date(2025, 3, 10, 7, 30, 0, 0, time.UTC), true},
+		{"within grace period", time.Date(2025, 3, 10, 8, 15, 0, 0, time.UTC), true},
+		{"after grace period", time.Date(2025, 3, 10, 8, 30, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, due := reminder.DueDate(tt.now)
+			if due != tt.due {
+				t.Fatalf("Expected due %v, got %v", tt.due, due)
+			}
+			if due && !date.Equal(madridDay) {
+				t.Errorf("Expected local date %s, got %s", madridDay, date)
+			}
+		})
+	}
+
+	reminder.MarkSent(madridDay)
+	if _, due := reminder.DueDate(time.Date(2025, 3, 10, 7, 45, 0, 0, time.UTC)); due {
+		t.Error("Expected the reminder not to be due again on the same day")
+	}
+	if _, due := reminder.DueDate(time.Date(2025, 3, 11, 7, 45, 0, 0, time.UTC)); !due {
+		t.Error("Expected the reminder to be due the next day")
+	}
+}
+
+func TestHabitReminder_DueDateInvalidRule(t *testing.T) {
+	reminder := NewHabitReminder("habit-1", "user-1", "8am", "UTC")
+	if _, due := reminder.DueDate(time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)); due {
+		t.Error("Expected an invalid time of day never to be due")
+	}
+
+	if IsValidReminderTime("24:00") || !IsValidReminderTime("23:59") {
+		t.Error("Unexpected time of day validation")
+	}
+}
@@ -2,6 +2,10 @@ package entities
 
 import "time"
 
+// DefaultUserLanguage is the language emails are sent in until the user picks
+// another one.
+const DefaultUserLanguage = "en"
+
 type User struct {
 	ID                      string
 	Email                   string
@@ -9,6 +13,7 @@ type User struct {
 	EmailVerified           bool
 	EmailVerificationToken  *string
 	EmailVerificationExpiry *time.Time
+	Language                string
 	CreatedAt               time.Time
 	UpdatedAt               time.Time
 }
@@ -18,6 +23,7 @@ func NewUser(email, passwordHash string) *User {
 	return &User{
 		Email:        email,
 		PasswordHash: passwordHash,
+		Language:     DefaultUserLanguage,
 		CreatedAt:    now,
 		UpdatedAt:    now,
 	}
@@ -0,0 +1,137 @@
+package entities
+
+import (
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// WebhookEventTypes are the events webhooks can subscribe to.
+var WebhookEventTypes = []EventType{
+	EventHabitCreated,
+	EventHabitMarked,
+	EventHabitUnmarked,
+	EventHabitArchived,
+	EventStreakBroken,
+}
+
+func IsWebhookEventType(eventType EventType) bool {
+	for _, t := range WebhookEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookMaxConsecutiveFailures is the number of failed delivery attempts in a
+// row after which a webhook is disabled until its owner re-enables it.
+const WebhookMaxConsecutiveFailures = 15
+
+// Webhook is an endpoint registered by a user to receive the events it is
+// subscribed to. Secret signs every delivery so the receiver can verify it.
+type Webhook struct {
+	ID                  string
+	UserID              string
+	URL                 string
+	Secret              string
+	Events              []EventType
+	Enabled             bool
+	ConsecutiveFailures int
+	DisabledAt          *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+func NewWebhook(userID, url, secret string, events []EventType) *Webhook {
+	now := time.Now()
+	return &Webhook{
+		UserID:    userID,
+		URL:       url,
+		Secret:    secret,
+		Events:    events,
+		Enabled:   true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// IsValidWebhookURL accepts absolute http and https URLs. Plain http is allowed
+// since receivers are often home automation servers on the local network.
+// Hosts given as an IP address must pass IsAllowedWebhookIP; host names are
+// checked once resolved, when the delivery is sent.
+func IsValidWebhookURL(rawURL string, allowPrivateNetworks bool) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" || parsed.User != nil {
+		return false
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return false
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return IsAllowedWebhookIP(ip, allowPrivateNetworks)
+	}
+	return true
+}
+
+// IsAllowedWebhookIP keeps webhooks away from the server itself and from
+// link-local addresses such as cloud metadata endpoints. Private network
+// addresses are only allowed when the server opts in to LAN receivers.
+func IsAllowedWebhookIP(ip net.IP, allowPrivateNetworks bool) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified() || ip.IsMulticast() {
+		return false
+	}
+	if ip.IsPrivate() {
+		return allowPrivateNetworks
+	}
+	return true
+}
+
+func (w *Webhook) IsSubscribedTo(eventType EventType) bool {
+	for _, event := range w.Events {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Enable turns delivery back on and forgets past failures.
+func (w *Webhook) Enable() {
+	w.Enabled = true
+	w.ConsecutiveFailures = 0
+	w.DisabledAt = nil
+	w.UpdatedAt = time.Now()
+}
+
+func (w *Webhook) Disable() {
+	now := time.Now()
+	w.Enabled = false
+	w.DisabledAt = &now
+	w.UpdatedAt = now
+}
+
+func (w *Webhook) RecordSuccess() {
+	w.ConsecutiveFailures = 0
+	w.UpdatedAt = time.Now()
+}
+
+// RecordFailure counts a failed delivery attempt and reports whether it
+// disabled the webhook.
+func (w *Webhook) RecordFailure() bool {
+	w.ConsecutiveFailures++
+	w.UpdatedAt = time.Now()
+
+	if w.Enabled && w.ConsecutiveFailures >= WebhookMaxConsecutiveFailures {
+		w.Disable()
+		return true
+	}
+	return false
+}
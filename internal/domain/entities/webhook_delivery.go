@@ -0,0 +1,101 @@
+package entities
+
+import "time"
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "FAILED"
+)
+
+const (
+	WebhookMaxAttempts = 8
+
+	webhookRetryBaseDelay = 30 * time.Second
+	webhookRetryMaxDelay  = time.Hour
+)
+
+// WebhookDelivery is one event sent to one webhook, kept as the delivery log.
+// Pending deliveries are attempted at NextAttemptAt, and a failed attempt is
+// retried with exponential backoff until WebhookMaxAttempts is reached.
+type WebhookDelivery struct {
+	ID             string
+	WebhookID      string
+	EventID        string
+	EventType      EventType
+	Payload        string
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode *int
+	LastError      string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+func NewWebhookDelivery(webhookID string, event *Event, payload string) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		WebhookID:     webhookID,
+		EventID:       event.ID,
+		EventType:     event.Type,
+		Payload:       payload,
+		Status:        WebhookDeliveryPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+}
+
+// WebhookRetryDelay returns how long to wait after the given failed attempt,
+// doubling from 30 seconds up to an hour.
+func WebhookRetryDelay(attempt int) time.Duration {
+	delay := webhookRetryBaseDelay
+	for i := 1; i < attempt && delay < webhookRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > webhookRetryMaxDelay {
+		return webhookRetryMaxDelay
+	}
+	return delay
+}
+
+func (d *WebhookDelivery) RecordSuccess(statusCode int, now time.Time) {
+	d.Attempts++
+	d.Status = WebhookDeliverySucceeded
+	d.LastStatusCode = &statusCode
+	d.LastError = ""
+	d.DeliveredAt = &now
+}
+
+// RecordFailure stores a failed attempt. statusCode is nil when no response was
+// received. The delivery stays pending with a backoff until it runs out of
+// attempts.
+func (d *WebhookDelivery) RecordFailure(statusCode *int, message string, now time.Time) {
+	d.Attempts++
+	d.LastStatusCode = statusCode
+	d.LastError = message
+
+	if d.Attempts >= WebhookMaxAttempts {
+		d.Status = WebhookDeliveryFailed
+		return
+	}
+
+	d.NextAttemptAt = now.Add(WebhookRetryDelay(d.Attempts))
+}
+
+// Abandon fails the delivery without attempting it, e.g. because its webhook
+// was disabled.
+func (d *WebhookDelivery) Abandon(message string) {
+	d.Status = WebhookDeliveryFailed
+	d.LastError = message
+}
+
+// Redeliver queues the delivery again with a fresh set of attempts.
+func (d *WebhookDelivery) Redeliver(now time.Time) {
+	d.Status = WebhookDeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = now
+	d.DeliveredAt = nil
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+type AchievementRepository interface {
+	Create(ctx context.Context, achievement *entities.Achievement) error
+	FindByUserID(ctx context.Context, userID string) ([]*entities.Achievement, error)
+}
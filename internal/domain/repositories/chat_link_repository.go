@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+// ChatLinkRepository holds at most one chat per user and one user per chat.
+type ChatLinkRepository interface {
+	// Save replaces any link of the same user or chat.
+	Save(ctx context.Context, link *entities.ChatLink) error
+	FindByUserID(ctx context.Context, userID string) (*entities.ChatLink, error)
+	FindByChatID(ctx context.Context, chatID int64) (*entities.ChatLink, error)
+	DeleteByUserID(ctx context.Context, userID string) error
+}
+
+type ChatLinkCodeRepository interface {
+	// Create replaces the user's previous code.
+	Create(ctx context.Context, code *entities.ChatLinkCode) error
+	FindByCode(ctx context.Context, code string) (*entities.ChatLinkCode, error)
+	// Delete returns errors.ErrNotFound when the code was already deleted.
+	Delete(ctx context.Context, code string) error
+}
@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+type DigestSubscriptionRepository interface {
+	FindByUserID(ctx context.Context, userID string) (*entities.DigestSubscription, error)
+	FindEnabled(ctx context.Context) ([]*entities.DigestSubscription, error)
+	Save(ctx context.Context, subscription *entities.DigestSubscription) error
+}
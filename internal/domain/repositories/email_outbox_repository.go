@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+type EmailOutboxRepository interface {
+	Create(ctx context.Context, email *entities.OutgoingEmail) error
+	FindByID(ctx context.Context, id string) (*entities.OutgoingEmail, error)
+	FindByStatus(ctx context.Context, status entities.OutgoingEmailStatus, limit int) ([]*entities.OutgoingEmail, error)
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.OutgoingEmail, error)
+	CountByStatus(ctx context.Context) (map[entities.OutgoingEmailStatus]int, error)
+	Update(ctx context.Context, email *entities.OutgoingEmail) error
+	DeleteSentBefore(ctx context.Context, before time.Time) (int, error)
+}
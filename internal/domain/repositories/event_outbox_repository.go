@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+type EventOutboxRepository interface {
+	// Create stores a new event and returns errors.ErrAlreadyExists when an
+	// event with the same ID was already stored.
+	Create(ctx context.Context, event *entities.OutboxEvent) error
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.OutboxEvent, error)
+	Update(ctx context.Context, event *entities.OutboxEvent) error
+	DeleteProcessedBefore(ctx context.Context, before time.Time) (int, error)
+}
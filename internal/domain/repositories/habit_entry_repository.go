@@ -13,6 +13,7 @@ type HabitEntryRepository interface {
 	FindByHabitID(ctx context.Context, habitID string) ([]*entities.HabitEntry, error)
 	FindByHabitIDAndDateRange(ctx context.Context, habitID string, from, to time.Time) ([]*entities.HabitEntry, error)
 	FindByUserID(ctx context.Context, userID string) ([]*entities.HabitEntry, error)
+	FindByUserIDAndDateRange(ctx context.Context, userID string, from, to time.Time) ([]*entities.HabitEntry, error)
 	FindPendingByHabitID(ctx context.Context, habitID string, beforeDate time.Time) ([]*entities.HabitEntry, error)
 	Update(ctx context.Context, entry *entities.HabitEntry) error
 	Delete(ctx context.Context, id string) error
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+type HabitReminderRepository interface {
+	Create(ctx context.Context, reminder *entities.HabitReminder) error
+	FindByID(ctx context.Context, id string) (*entities.HabitReminder, error)
+	FindByHabitID(ctx context.Context, habitID string) ([]*entities.HabitReminder, error)
+	// FindEnabled returns the enabled reminders of active habits.
+	FindEnabled(ctx context.Context) ([]*entities.HabitReminder, error)
+	Update(ctx context.Context, reminder *entities.HabitReminder) error
+	Delete(ctx context.Context, id string) error
+}
@@ -18,6 +18,7 @@ type HabitFilter struct {
 type HabitRepository interface {
 	Create(ctx context.Context, habit *entities.Habit) error
 	FindByID(ctx context.Context, id string) (*entities.Habit, error)
+	FindAll(ctx context.Context) ([]*entities.Habit, error)
 	FindByUserID(ctx context.Context, userID string) ([]*entities.Habit, error)
 	FindActiveByUserID(ctx context.Context, userID string) ([]*entities.Habit, error)
 	FindActiveByUserIDWithPagination(ctx context.Context, userID string, params pagination.Params) ([]*entities.Habit, error)
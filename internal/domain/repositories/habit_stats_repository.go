@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+type HabitStatsRepository interface {
+	FindByHabitID(ctx context.Context, habitID string) (*entities.HabitStats, error)
+	FindByUserID(ctx context.Context, userID string) ([]*entities.HabitStats, error)
+	Save(ctx context.Context, stats *entities.HabitStats) error
+	// SaveStrength stores only the strength checkpoint, leaving the counters
+	// to the writes that maintain them.
+	SaveStrength(ctx context.Context, habitID string, date time.Time, strength float64) error
+}
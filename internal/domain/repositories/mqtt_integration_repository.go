@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+type MQTTIntegrationRepository interface {
+	// Save creates the user's integration or replaces it.
+	Save(ctx context.Context, integration *entities.MQTTIntegration) error
+	FindByUserID(ctx context.Context, userID string) (*entities.MQTTIntegration, error)
+	FindAll(ctx context.Context) ([]*entities.MQTTIntegration, error)
+	DeleteByUserID(ctx context.Context, userID string) error
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+type NotificationPreferenceRepository interface {
+	FindByUserID(ctx context.Context, userID string) (entities.NotificationPreferences, error)
+	Save(ctx context.Context, preference *entities.NotificationPreference) error
+}
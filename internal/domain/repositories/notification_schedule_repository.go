@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+type NotificationScheduleRepository interface {
+	FindByUserID(ctx context.Context, userID string) (*entities.NotificationSchedule, error)
+	Save(ctx context.Context, schedule *entities.NotificationSchedule) error
+}
+
+type HeldNotificationRepository interface {
+	Create(ctx context.Context, notification *entities.HeldNotification) error
+	FindByUserID(ctx context.Context, userID string) ([]*entities.HeldNotification, error)
+	FindUserIDs(ctx context.Context) ([]string, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// NotificationLogRepository records when notifications were delivered, so the
+// hourly limit can be enforced.
+type NotificationLogRepository interface {
+	Record(ctx context.Context, userID string, channel entities.NotificationChannel, sentAt time.Time) error
+	CountSince(ctx context.Context, userID string, since time.Time) (int, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int, error)
+}
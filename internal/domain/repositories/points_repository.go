@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/pagination"
+)
+
+type PointsRepository interface {
+	Create(ctx context.Context, transaction *entities.PointTransaction) error
+	FindByUserID(ctx context.Context, userID string, params pagination.Params) ([]*entities.PointTransaction, error)
+	CountByUserID(ctx context.Context, userID string) (int, error)
+	// SumByEntryID returns the net points currently credited for an entry.
+	SumByEntryID(ctx context.Context, entryID string) (int, error)
+	GetBalance(ctx context.Context, userID string) (entities.PointsBalance, error)
+}
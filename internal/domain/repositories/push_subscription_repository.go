@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+type PushSubscriptionRepository interface {
+	// Save registers the subscription, replacing any existing one with the same
+	// endpoint.
+	Save(ctx context.Context, subscription *entities.PushSubscription) error
+	FindByUserID(ctx context.Context, userID string) ([]*entities.PushSubscription, error)
+	FindByEndpoint(ctx context.Context, endpoint string) (*entities.PushSubscription, error)
+	Delete(ctx context.Context, id string) error
+}
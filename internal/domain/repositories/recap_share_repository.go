@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+type RecapShareRepository interface {
+	Create(ctx context.Context, share *entities.RecapShare) error
+	FindByToken(ctx context.Context, token string) (*entities.RecapShare, error)
+	FindByUserIDAndYear(ctx context.Context, userID string, year int) (*entities.RecapShare, error)
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+type RewardRepository interface {
+	Create(ctx context.Context, reward *entities.Reward) error
+	FindByID(ctx context.Context, id string) (*entities.Reward, error)
+	FindActiveByUserID(ctx context.Context, userID string) ([]*entities.Reward, error)
+	Update(ctx context.Context, reward *entities.Reward) error
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *entities.Webhook) error
+	FindByID(ctx context.Context, id string) (*entities.Webhook, error)
+	FindByUserID(ctx context.Context, userID string) ([]*entities.Webhook, error)
+	FindEnabled(ctx context.Context) ([]*entities.Webhook, error)
+	CountByUserID(ctx context.Context, userID string) (int, error)
+	Update(ctx context.Context, webhook *entities.Webhook) error
+	Delete(ctx context.Context, id string) error
+}
+
+type WebhookDeliveryRepository interface {
+	// Create stores a new delivery and returns errors.ErrAlreadyExists when the
+	// event was already queued for the webhook.
+	Create(ctx context.Context, delivery *entities.WebhookDelivery) error
+	FindByID(ctx context.Context, id string) (*entities.WebhookDelivery, error)
+	FindByWebhookID(ctx context.Context, webhookID string, limit int) ([]*entities.WebhookDelivery, error)
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.WebhookDelivery, error)
+	Update(ctx context.Context, delivery *entities.WebhookDelivery) error
+}
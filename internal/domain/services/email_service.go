@@ -5,6 +5,11 @@ type EmailMessage struct {
 	Subject string
 	Body    string
 	IsHTML  bool
+	// TextBody is the plain-text alternative of an HTML Body. When set, the
+	// message is sent as multipart.
+	TextBody string
+	// Headers are extra headers such as List-Unsubscribe.
+	Headers map[string]string
 }
 
 type EmailService interface {
@@ -0,0 +1,15 @@
+package services
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+// EventPublisher stores an event and runs its synchronous subscribers. When ctx
+// carries a transaction, the event is stored within it, so it is only kept if
+// the change that emitted it is committed. Asynchronous subscribers receive
+// the event in the background afterwards.
+type EventPublisher interface {
+	Publish(ctx context.Context, event *entities.Event) error
+}
@@ -0,0 +1,42 @@
+package services
+
+import "context"
+
+type EmailTemplate string
+
+const (
+	EmailTemplateVerifyEmail         EmailTemplate = "verify_email"
+	EmailTemplateResendVerification  EmailTemplate = "resend_verification"
+	EmailTemplatePasswordReset       EmailTemplate = "password_reset"
+	EmailTemplateWelcome             EmailTemplate = "welcome"
+	EmailTemplateAchievementUnlocked EmailTemplate = "achievement_unlocked"
+	EmailTemplateReminder            EmailTemplate = "reminder"
+	EmailTemplateDigest              EmailTemplate = "digest"
+	EmailTemplateNotificationBatch   EmailTemplate = "notification_batch"
+	EmailTemplateReplyConfirmation   EmailTemplate = "reply_confirmation"
+)
+
+// TemplatedEmail is a transactional email rendered from a template in the
+// recipient's language. Data holds the values the template fills in, such as
+// links. UserID identifies the recipient's account, so that their
+// notification preferences apply. ReplyTo is set on emails that can be
+// answered by email.
+type TemplatedEmail struct {
+	UserID   string
+	To       string
+	Language string
+	Template EmailTemplate
+	Data     map[string]interface{}
+	ReplyTo  string
+}
+
+type Mailer interface {
+	SendTemplate(ctx context.Context, email TemplatedEmail) error
+}
+
+// EmailPreviewer renders templates with sample data, so they can be reviewed
+// without sending anything.
+type EmailPreviewer interface {
+	Templates() []EmailTemplate
+	Preview(template EmailTemplate, language string) (*EmailMessage, error)
+}
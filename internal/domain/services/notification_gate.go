@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+type NotificationDecision string
+
+const (
+	NotificationSend NotificationDecision = "send"
+	// NotificationSkip means the user turned the category off on the channel.
+	NotificationSkip NotificationDecision = "skip"
+	// NotificationHold means the notification was kept back by quiet hours or
+	// the hourly limit, and will be delivered later in a batch.
+	NotificationHold NotificationDecision = "hold"
+)
+
+// NotificationGate is asked by every channel before it delivers a
+// notification, so the user's preferences, quiet hours and hourly limit apply
+// everywhere. Security email does not go through it. Content is kept with the
+// notification when it is held, for channels that deliver it in full later;
+// channels that only list held notifications by title pass it empty.
+type NotificationGate interface {
+	Admit(ctx context.Context, userID string, category entities.NotificationCategory, channel entities.NotificationChannel, title, content string) (NotificationDecision, error)
+}
+
+// NotificationBatchSender delivers the notifications held for a user on its
+// channel, as a single message listing them or, when they were held with
+// their content, as they were.
+type NotificationBatchSender interface {
+	Channel() entities.NotificationChannel
+	SendBatch(ctx context.Context, user *entities.User, notifications []*entities.HeldNotification) error
+}
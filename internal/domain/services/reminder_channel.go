@@ -0,0 +1,13 @@
+package services
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+// ReminderChannel delivers a habit reminder to a user, e.g. by email.
+type ReminderChannel interface {
+	Name() string
+	SendReminder(ctx context.Context, user *entities.User, habit *entities.Habit) error
+}
@@ -0,0 +1,11 @@
+package services
+
+// ReplyAddresses signs the Reply-To addresses of reminders and digests, so
+// users can check in habits by replying to them. The addresses are empty when
+// replies are not accepted.
+type ReplyAddresses interface {
+	// HabitAddress is for replies about a single habit, like "done".
+	HabitAddress(habitID string) string
+	// UserAddress is for replies that name the habits, like "done Reading".
+	UserAddress(userID string) string
+}
@@ -0,0 +1,10 @@
+package services
+
+import "apocapoc-api/internal/domain/entities"
+
+// UnsubscribeTokens signs the tokens in unsubscribe links, so a user can turn
+// off a category of email without logging in.
+type UnsubscribeTokens interface {
+	Sign(userID string, category entities.NotificationCategory) string
+	Verify(token string) (userID string, category entities.NotificationCategory, err error)
+}
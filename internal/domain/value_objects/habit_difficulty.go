@@ -0,0 +1,52 @@
+package value_objects
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type HabitDifficulty string
+
+const (
+	HabitDifficultyEasy   HabitDifficulty = "EASY"
+	HabitDifficultyMedium HabitDifficulty = "MEDIUM"
+	HabitDifficultyHard   HabitDifficulty = "HARD"
+)
+
+func (hd HabitDifficulty) IsValid() bool {
+	switch hd {
+	case HabitDifficultyEasy, HabitDifficultyMedium, HabitDifficultyHard:
+		return true
+	}
+	return false
+}
+
+// Points is the number of points credited for one completion of a habit with
+// this difficulty.
+func (hd HabitDifficulty) Points() int {
+	switch hd {
+	case HabitDifficultyEasy:
+		return 5
+	case HabitDifficultyHard:
+		return 20
+	}
+	return 10
+}
+
+func (hd HabitDifficulty) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(hd))
+}
+
+func (hd *HabitDifficulty) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	*hd = HabitDifficulty(s)
+	if !hd.IsValid() {
+		return fmt.Errorf("invalid habit difficulty: %s (must be EASY, MEDIUM, or HARD)", s)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package value_objects
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestHabitDifficulty_IsValid(t *testing.T) {
+	tests := []struct {
+		name       string
+		difficulty HabitDifficulty
+		expected   bool
+	}{
+		{"Easy is valid", HabitDifficultyEasy, true},
+		{"Medium is valid", HabitDifficultyMedium, true},
+		{"Hard is valid", HabitDifficultyHard, true},
+		{"Empty string is invalid", HabitDifficulty(""), false},
+		{"Lowercase is invalid", HabitDifficulty("hard"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.difficulty.IsValid(); got != tt.expected {
+				t.Errorf("HabitDifficulty.IsValid() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHabitDifficulty_Points(t *testing.T) {
+	if HabitDifficultyEasy.Points() >= HabitDifficultyMedium.Points() || HabitDifficultyMedium.Points() >= HabitDifficultyHard.Points() {
+		t.Errorf("Expected points to grow with difficulty, got %d, %d and %d",
+			HabitDifficultyEasy.Points(), HabitDifficultyMedium.Points(), HabitDifficultyHard.Points())
+	}
+}
+
+func TestHabitDifficulty_UnmarshalJSON(t *testing.T) {
+	var difficulty HabitDifficulty
+	if err := json.Unmarshal([]byte(`"HARD"`), &difficulty); err != nil || difficulty != HabitDifficultyHard {
+		t.Errorf("Expected HARD, got %s (err: %v)", difficulty, err)
+	}
+
+	if err := json.Unmarshal([]byte(`"EXTREME"`), &difficulty); err == nil {
+		t.Error("Expected error for invalid difficulty")
+	}
+}
@@ -20,6 +20,7 @@ type Translations struct {
 	Success    map[string]string `json:"success"`
 	Validation map[string]string `json:"validation"`
 	Emails     map[string]string `json:"emails"`
+	Chat       map[string]string `json:"chat"`
 }
 
 type Translator struct {
@@ -89,6 +90,8 @@ func (t *Translator) Translate(lang language.Tag, category, key string) string {
 		categoryMap = trans.Validation
 	case "emails":
 		categoryMap = trans.Emails
+	case "chat":
+		categoryMap = trans.Chat
 	default:
 		return key
 	}
@@ -116,6 +119,10 @@ func (t *Translator) Email(lang language.Tag, key string) string {
 	return t.Translate(lang, "emails", key)
 }
 
+func (t *Translator) Chat(lang language.Tag, key string) string {
+	return t.Translate(lang, "chat", key)
+}
+
 func (t *Translator) TranslateValidationError(lang language.Tag, field, validationKey string) string {
 	message := t.Validation(lang, validationKey)
 	return strings.ReplaceAll(message, field, field)
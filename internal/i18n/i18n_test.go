@@ -208,6 +208,17 @@ func TestEmail(t *testing.T) {
 	}
 }
 
+func TestChat(t *testing.T) {
+	translator, _ := NewTranslator()
+
+	if got := translator.Chat(language.English, "unlinked"); got != "This chat is no longer linked to your account." {
+		t.Errorf("Unexpected English chat message: %q", got)
+	}
+	if got := translator.Chat(language.Spanish, "unlinked"); got != "Este chat ya no está vinculado a tu cuenta." {
+		t.Errorf("Unexpected Spanish chat message: %q", got)
+	}
+}
+
 func TestTranslate(t *testing.T) {
 	translator, _ := NewTranslator()
 
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidReplyToken = errors.New("invalid reply token")
+
+// ReplyTokenKind tells what a reply token identifies: the habit of a reminder
+// or the recipient of a digest.
+type ReplyTokenKind byte
+
+const (
+	ReplyTokenHabit ReplyTokenKind = 'h'
+	ReplyTokenUser  ReplyTokenKind = 'u'
+)
+
+const (
+	replySignatureSize = 10
+	replyTokenSize     = 1 + 16 + 4 + replySignatureSize
+)
+
+// replyEncoding is lowercase base32, because the tokens travel in the local
+// part of email addresses, which mail servers may change the case of.
+var replyEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+type ReplyToken struct {
+	Kind ReplyTokenKind
+	// ID is the habit or user ID, which must be a UUID.
+	ID string
+	// IssuedAt is kept to the minute.
+	IssuedAt time.Time
+}
+
+// ReplyTokens signs the Reply-To addresses of emails, so a reply can be
+// traced back to what the email was about without the sender logging in.
+// The tokens are compact binary rather than readable, because the local part
+// of an address holds at most 64 characters, and the signature is truncated
+// for the same reason.
+type ReplyTokens struct {
+	key []byte
+}
+
+// NewReplyTokens derives its key from secret, so the tokens cannot be used as
+// any other kind of token signed with the same secret.
+func NewReplyTokens(secret string) *ReplyTokens {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("reply"))
+	return &ReplyTokens{key: mac.Sum(nil)}
+}
+
+func (t *ReplyTokens) Sign(token ReplyToken) (string, error) {
+	id, err := uuid.Parse(token.ID)
+	if err != nil {
+		return "", err
+	}
+
+	payload := make([]byte, 0, replyTokenSize)
+	payload = append(payload, byte(token.Kind))
+	payload = append(payload, id[:]...)
+	payload = binary.BigEndian.AppendUint32(payload, uint32(token.IssuedAt.Unix()/60))
+	payload = append(payload, t.sign(payload)...)
+
+	return replyEncoding.EncodeToString(payload), nil
+}
+
+func (t *ReplyTokens) Verify(token string) (*ReplyToken, error) {
+	decoded, err := replyEncoding.DecodeString(strings.ToLower(token))
+	if err != nil || len(decoded) != replyTokenSize {
+		return nil, ErrInvalidReplyToken
+	}
+
+	payload, signature := decoded[:replyTokenSize-replySignatureSize], decoded[replyTokenSize-replySignatureSize:]
+	if !hmac.Equal(signature, t.sign(payload)) {
+		return nil, ErrInvalidReplyToken
+	}
+
+	kind := ReplyTokenKind(payload[0])
+	if kind != ReplyTokenHabit && kind != ReplyTokenUser {
+		return nil, ErrInvalidReplyToken
+	}
+
+	id, err := uuid.FromBytes(payload[1:17])
+	if err != nil {
+		return nil, ErrInvalidReplyToken
+	}
+
+	return &ReplyToken{
+		Kind:     kind,
+		ID:       id.String(),
+		IssuedAt: time.Unix(int64(binary.BigEndian.Uint32(payload[17:]))*60, 0),
+	}, nil
+}
+
+func (t *ReplyTokens) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, t.key)
+	mac.Write(payload)
+	return mac.Sum(nil)[:replySignatureSize]
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const testHabitID = "0f8fad5b-d9cb-469f-a165-70867728950e"
+
+func TestReplyTokensRoundTrip(t *testing.T) {
+	tokens := NewReplyTokens("test-secret")
+	issuedAt := time.Date(2026, 3, 10, 8, 30, 45, 0, time.UTC)
+
+	token, err := tokens.Sign(ReplyToken{Kind: ReplyTokenHabit, ID: testHabitID, IssuedAt: issuedAt})
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if len("reply+"+token) > 64 {
+		t.Errorf("Expected the token to fit in the local part of an address, got %d characters", len(token))
+	}
+
+	verified, err := tokens.Verify(strings.ToUpper(token))
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if verified.Kind != ReplyTokenHabit || verified.ID != testHabitID {
+		t.Errorf("Unexpected token: %+v", verified)
+	}
+	if !verified.IssuedAt.Equal(issuedAt.Truncate(time.Minute)) {
+		t.Errorf("Expected the issue time to the minute, got %v", verified.IssuedAt)
+	}
+}
+
+func TestReplyTokensRejectInvalidTokens(t *testing.T) {
+	tokens := NewReplyTokens("test-secret")
+	now := time.Now()
+	token, _ := tokens.Sign(ReplyToken{Kind: ReplyTokenHabit, ID: testHabitID, IssuedAt: now})
+	other, _ := tokens.Sign(ReplyToken{Kind: ReplyTokenUser, ID: testHabitID, IssuedAt: now})
+	foreign, _ := NewReplyTokens("other-secret").Sign(ReplyToken{Kind: ReplyTokenHabit, ID: testHabitID, IssuedAt: now})
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"truncated", token[:len(token)-4]},
+		{"tampered kind", other[:2] + token[2:]},
+		{"signed with another secret", foreign},
+		{"garbage", "not-a-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tokens.Verify(tt.token); err != ErrInvalidReplyToken {
+				t.Errorf("Expected ErrInvalidReplyToken, got %v", err)
+			}
+		})
+	}
+
+	if _, err := tokens.Sign(ReplyToken{Kind: ReplyTokenHabit, ID: "habit-1", IssuedAt: now}); err == nil {
+		t.Error("Expected an ID that is not a UUID to fail")
+	}
+}
@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+var ErrInvalidUnsubscribeToken = errors.New("invalid unsubscribe token")
+
+// UnsubscribeTokens signs unsubscribe links with HMAC-SHA256. The tokens do
+// not expire, because unsubscribe links in old emails must keep working.
+type UnsubscribeTokens struct {
+	key []byte
+}
+
+// NewUnsubscribeTokens derives its key from secret, so the tokens cannot be
+// used as any other kind of token signed with the same secret.
+func NewUnsubscribeTokens(secret string) *UnsubscribeTokens {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("unsubscribe"))
+	return &UnsubscribeTokens{key: mac.Sum(nil)}
+}
+
+func (t *UnsubscribeTokens) Sign(userID string, category entities.NotificationCategory) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(userID + ":" + string(category)))
+	return payload + "." + base64.RawURLEncoding.EncodeToString(t.sign(payload))
+}
+
+func (t *UnsubscribeTokens) Verify(token string) (string, entities.NotificationCategory, error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+
+	decodedSignature, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(decodedSignature, t.sign(payload)) {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+
+	userID, category, ok := strings.Cut(string(decoded), ":")
+	if !ok || userID == "" || category == "" {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+
+	return userID, entities.NotificationCategory(category), nil
+}
+
+func (t *UnsubscribeTokens) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, t.key)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
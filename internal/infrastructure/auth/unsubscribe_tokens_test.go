@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"testing"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+func TestUnsubscribeTokensRoundTrip(t *testing.T) {
+	tokens := NewUnsubscribeTokens("test-secret")
+
+	token := tokens.Sign("user-123", entities.NotificationDigests)
+
+	userID, category, err := tokens.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if userID != "user-123" || category != entities.NotificationDigests {
+		t.Errorf("Expected user-123/digests, got %s/%s", userID, category)
+	}
+}
+
+func TestUnsubscribeTokensRejectInvalidTokens(t *testing.T) {
+	tokens := NewUnsubscribeTokens("test-secret")
+	token := tokens.Sign("user-123", entities.NotificationDigests)
+	other := tokens.Sign("user-456", entities.NotificationReminders)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"no signature", "dXNlci0xMjM6ZGlnZXN0cw"},
+		{"tampered payload", other[:len(other)/2] + token[len(token)/2:]},
+		{"signed with another secret", NewUnsubscribeTokens("other-secret").Sign("user-123", entities.NotificationDigests)},
+		{"garbage", "not.a-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := tokens.Verify(tt.token); err != ErrInvalidUnsubscribeToken {
+				t.Errorf("Expected ErrInvalidUnsubscribeToken, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package card
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"text/template"
+	"time"
+
+	"apocapoc-api/internal/application/queries"
+)
+
+//go:embed templates/year_recap.svg
+var yearRecapTemplate string
+
+const (
+	cardWidth    = 800
+	cardHeight   = 340
+	cellSize     = 10
+	cellGap      = 2
+	heatmapTop   = 236
+	heatmapLeft  = 40
+	tileWidth    = 168
+	tileSpacing  = 12
+	maxNameRunes = 22
+)
+
+var heatmapColors = map[string]string{
+	queries.HeatmapLevelNone:    "#1e293b",
+	queries.HeatmapLevelPartial: "#4ade80",
+	queries.HeatmapLevelFull:    "#16a34a",
+}
+
+type tile struct {
+	X, TextX             int
+	Value, Label, Detail string
+}
+
+type cell struct {
+	X, Y  int
+	Color string
+}
+
+var yearRecapCard = template.Must(template.New("year_recap").Parse(yearRecapTemplate))
+
+// RenderYearRecap draws a shareable SVG summary card of a year recap. It only
+// shows aggregated numbers and habit names, nothing that identifies the account.
+func RenderYearRecap(appName string, recap *queries.YearRecapDTO) ([]byte, error) {
+	data := struct {
+		Width, Height, CellSize int
+		Title, Subtitle         string
+		Tiles                   []tile
+		Cells                   []cell
+	}{
+		Width:    cardWidth,
+		Height:   cardHeight,
+		CellSize: cellSize,
+		Title:    fmt.Sprintf("%d in habits", recap.Year),
+		Subtitle: fmt.Sprintf("%s · %.0f%% of scheduled habits completed", appName, recap.CompletionRate),
+		Tiles:    recapTiles(recap),
+		Cells:    heatmapCells(recap.Heatmap),
+	}
+
+	var buf bytes.Buffer
+	if err := yearRecapCard.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render year recap card: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func recapTiles(recap *queries.YearRecapDTO) []tile {
+	streak, streakHabit := "0", "No streaks yet"
+	if len(recap.LongestStreaks) > 0 {
+		streak = fmt.Sprintf("%d days", recap.LongestStreaks[0].Streak)
+		streakHabit = truncate(recap.LongestStreaks[0].HabitName)
+	}
+
+	busiest, busiestDetail := "-", ""
+	if recap.BusiestMonth != nil {
+		busiest = time.Month(recap.BusiestMonth.Month).String()
+		busiestDetail = fmt.Sprintf("%d completions", recap.BusiestMonth.Completions)
+	}
+
+	consistent, consistentDetail := "-", ""
+	if recap.MostConsistentHabit != nil {
+		consistent = fmt.Sprintf("%.0f%%", recap.MostConsistentHabit.CompletionRate)
+		consistentDetail = truncate(recap.MostConsistentHabit.HabitName)
+	}
+
+	tiles := []tile{
+		{Value: fmt.Sprintf("%d", recap.TotalCompletions), Label: "Completions", Detail: fmt.Sprintf("on %d days", recap.ActiveDays)},
+		{Value: streak, Label: "Longest streak", Detail: streakHabit},
+		{Value: consistent, Label: "Most consistent", Detail: consistentDetail},
+		{Value: busiest, Label: "Busiest month", Detail: busiestDetail},
+	}
+
+	for i := range tiles {
+		tiles[i].X = heatmapLeft + i*(tileWidth+tileSpacing)
+		tiles[i].TextX = tiles[i].X + 16
+	}
+
+	return tiles
+}
+
+// heatmapCells lays the days out in Monday-based week columns.
+func heatmapCells(heatmap *queries.HeatmapDTO) []cell {
+	if heatmap == nil || len(heatmap.Days) == 0 {
+		return nil
+	}
+
+	offset := (int(heatmap.From.Weekday()) + 6) % 7
+	cells := make([]cell, 0, len(heatmap.Days))
+
+	for i, day := range heatmap.Days {
+		position := i + offset
+		color := heatmapColors[day.Level]
+		if day.Scheduled == 0 {
+			color = "#111827"
+		}
+
+		cells = append(cells, cell{
+			X:     heatmapLeft + (position/7)*(cellSize+cellGap),
+			Y:     heatmapTop + (position%7)*(cellSize+cellGap),
+			Color: color,
+		})
+	}
+
+	return cells
+}
+
+func truncate(name string) string {
+	runes := []rune(name)
+	if len(runes) <= maxNameRunes {
+		return name
+	}
+	return string(runes[:maxNameRunes-1]) + "…"
+}
@@ -0,0 +1,53 @@
+package card
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/application/queries"
+)
+
+func TestRenderYearRecap(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	days := make([]queries.HeatmapDayDTO, 366)
+	for i := range days {
+		days[i] = queries.HeatmapDayDTO{Date: from.AddDate(0, 0, i), Level: queries.HeatmapLevelFull, Scheduled: 1, Completed: 1}
+	}
+
+	recap := &queries.YearRecapDTO{
+		Year:                2024,
+		TotalCompletions:    366,
+		ActiveDays:          366,
+		CompletionRate:      100,
+		LongestStreaks:      []queries.HabitStreakDTO{{HabitID: "habit-1", HabitName: "Read <poems> & more", Streak: 366}},
+		MostConsistentHabit: &queries.HabitRankDTO{HabitID: "habit-1", HabitName: "Read <poems> & more", CompletionRate: 100},
+		BusiestMonth:        &queries.RecapMonthDTO{Month: 3, Completions: 31},
+		Heatmap:             &queries.HeatmapDTO{From: from, To: from.AddDate(0, 0, 365), Days: days},
+	}
+
+	svg, err := RenderYearRecap("Apocapoc", recap)
+	if err != nil {
+		t.Fatalf("RenderYearRecap failed: %v", err)
+	}
+
+	var doc struct {
+		XMLName xml.Name
+		Rects   []struct{} `xml:"rect"`
+	}
+	if err := xml.Unmarshal(svg, &doc); err != nil {
+		t.Fatalf("Expected valid SVG, got %v", err)
+	}
+
+	// Background, four tiles and one cell per day.
+	if len(doc.Rects) != 1+4+366 {
+		t.Errorf("Expected %d rects, got %d", 1+4+366, len(doc.Rects))
+	}
+
+	for _, expected := range []string{"2024 in habits", "366 days", "March", "Read &lt;poems&gt; &amp; more"} {
+		if !strings.Contains(string(svg), expected) {
+			t.Errorf("Expected card to contain %q", expected)
+		}
+	}
+}
@@ -8,56 +8,122 @@ import (
 )
 
 type Config struct {
-	DBPath              string
-	Port                string
-	AppURL              string
-	JWTSecret           string
-	JWTExpiry           string
-	RefreshTokenExpiry  string
-	DefaultTimezone     string
-	SMTPHost            string
-	SMTPPort            string
-	SMTPUser            string
-	SMTPPassword        string
-	SMTPFrom            string
-	SupportEmail        string
-	SendWelcomeEmail    string
-	RegistrationMode    string
-	LogLevel            string
-	Environment         string
-	BackupEnabled       string
-	BackupInterval      string
-	BackupRetentionDays string
-	BackupPath          string
-	BackupCompress      string
+	DBPath                    string
+	Port                      string
+	AppURL                    string
+	APIURL                    string
+	JWTSecret                 string
+	JWTExpiry                 string
+	RefreshTokenExpiry        string
+	DefaultTimezone           string
+	SMTPHost                  string
+	SMTPPort                  string
+	SMTPUser                  string
+	SMTPPassword              string
+	SMTPFrom                  string
+	SupportEmail              string
+	SendWelcomeEmail          string
+	RegistrationMode          string
+	LogLevel                  string
+	Environment               string
+	BackupEnabled             string
+	BackupInterval            string
+	BackupRetentionDays       string
+	BackupPath                string
+	BackupCompress            string
+	DigestEnabled             string
+	DigestInterval            string
+	RemindersEnabled          string
+	ReminderInterval          string
+	NotificationBatchInterval string
+	VAPIDPublicKey            string
+	VAPIDPrivateKey           string
+	VAPIDSubject              string
+	WebhooksEnabled           string
+	WebhookInterval           string
+	WebhookPrivateNetworks    string
+	EventRetryInterval        string
+	EventRetention            string
+	EmailOutboxInterval       string
+	EmailRetention            string
+	AdminEmails               string
+	EmailTransport            string
+	EmailFrom                 string
+	EmailFileDir              string
+	EmailHTTPURL              string
+	EmailHTTPFormat           string
+	EmailHTTPAPIKey           string
+	EmailHTTPAuthHeader       string
+	EmailHTTPHealthURL        string
+	TelegramBotToken          string
+	TelegramAPIURL            string
+	TelegramBotUsername       string
+	TelegramPollTimeout       string
+	MQTTEnabled               string
+	MQTTInterval              string
+	InboundEmailAddress       string
+	InboundSMTPAddr           string
 }
 
 func Load() (*Config, error) {
 	godotenv.Load()
 
 	cfg := &Config{
-		DBPath:              os.Getenv("DB_PATH"),
-		Port:                getEnvOrDefault("PORT", "8080"),
-		AppURL:              getEnvOrDefault("APP_URL", "http://localhost:8080"),
-		JWTSecret:           os.Getenv("JWT_SECRET"),
-		JWTExpiry:           os.Getenv("JWT_EXPIRY"),
-		RefreshTokenExpiry:  os.Getenv("REFRESH_TOKEN_EXPIRY"),
-		DefaultTimezone:     os.Getenv("DEFAULT_TIMEZONE"),
-		SMTPHost:            os.Getenv("SMTP_HOST"),
-		SMTPPort:            getEnvOrDefault("SMTP_PORT", "587"),
-		SMTPUser:            os.Getenv("SMTP_USER"),
-		SMTPPassword:        os.Getenv("SMTP_PASSWORD"),
-		SMTPFrom:            os.Getenv("SMTP_FROM"),
-		SupportEmail:        getEnvOrDefault("SUPPORT_EMAIL", "contact@apocapoc.app"),
-		SendWelcomeEmail:    getEnvOrDefault("SEND_WELCOME_EMAIL", "false"),
-		RegistrationMode:    getEnvOrDefault("REGISTRATION_MODE", "open"),
-		LogLevel:            getEnvOrDefault("LOG_LEVEL", "info"),
-		Environment:         getEnvOrDefault("ENVIRONMENT", "production"),
-		BackupEnabled:       getEnvOrDefault("BACKUP_ENABLED", "false"),
-		BackupInterval:      getEnvOrDefault("BACKUP_INTERVAL", "24h"),
-		BackupRetentionDays: getEnvOrDefault("BACKUP_RETENTION_DAYS", "7"),
-		BackupPath:          getEnvOrDefault("BACKUP_PATH", "./data/backups"),
-		BackupCompress:      getEnvOrDefault("BACKUP_COMPRESS", "true"),
+		DBPath:                    os.Getenv("DB_PATH"),
+		Port:                      getEnvOrDefault("PORT", "8080"),
+		AppURL:                    getEnvOrDefault("APP_URL", "http://localhost:8080"),
+		APIURL:                    os.Getenv("API_URL"),
+		JWTSecret:                 os.Getenv("JWT_SECRET"),
+		JWTExpiry:                 os.Getenv("JWT_EXPIRY"),
+		RefreshTokenExpiry:        os.Getenv("REFRESH_TOKEN_EXPIRY"),
+		DefaultTimezone:           os.Getenv("DEFAULT_TIMEZONE"),
+		SMTPHost:                  os.Getenv("SMTP_HOST"),
+		SMTPPort:                  getEnvOrDefault("SMTP_PORT", "587"),
+		SMTPUser:                  os.Getenv("SMTP_USER"),
+		SMTPPassword:              os.Getenv("SMTP_PASSWORD"),
+		SMTPFrom:                  os.Getenv("SMTP_FROM"),
+		SupportEmail:              getEnvOrDefault("SUPPORT_EMAIL", "contact@apocapoc.app"),
+		SendWelcomeEmail:          getEnvOrDefault("SEND_WELCOME_EMAIL", "false"),
+		RegistrationMode:          getEnvOrDefault("REGISTRATION_MODE", "open"),
+		LogLevel:                  getEnvOrDefault("LOG_LEVEL", "info"),
+		Environment:               getEnvOrDefault("ENVIRONMENT", "production"),
+		BackupEnabled:             getEnvOrDefault("BACKUP_ENABLED", "false"),
+		BackupInterval:            getEnvOrDefault("BACKUP_INTERVAL", "24h"),
+		BackupRetentionDays:       getEnvOrDefault("BACKUP_RETENTION_DAYS", "7"),
+		BackupPath:                getEnvOrDefault("BACKUP_PATH", "./data/backups"),
+		BackupCompress:            getEnvOrDefault("BACKUP_COMPRESS", "true"),
+		DigestEnabled:             getEnvOrDefault("DIGEST_ENABLED", "true"),
+		DigestInterval:            getEnvOrDefault("DIGEST_INTERVAL", "1h"),
+		RemindersEnabled:          getEnvOrDefault("REMINDERS_ENABLED", "true"),
+		ReminderInterval:          getEnvOrDefault("REMINDER_INTERVAL", "1m"),
+		NotificationBatchInterval: getEnvOrDefault("NOTIFICATION_BATCH_INTERVAL", "1m"),
+		VAPIDPublicKey:            os.Getenv("VAPID_PUBLIC_KEY"),
+		VAPIDPrivateKey:           os.Getenv("VAPID_PRIVATE_KEY"),
+		VAPIDSubject:              os.Getenv("VAPID_SUBJECT"),
+		WebhooksEnabled:           getEnvOrDefault("WEBHOOKS_ENABLED", "true"),
+		WebhookInterval:           getEnvOrDefault("WEBHOOK_INTERVAL", "1m"),
+		WebhookPrivateNetworks:    getEnvOrDefault("WEBHOOK_ALLOW_PRIVATE_NETWORKS", "false"),
+		EventRetryInterval:        getEnvOrDefault("EVENT_RETRY_INTERVAL", "1m"),
+		EventRetention:            getEnvOrDefault("EVENT_RETENTION", "168h"),
+		EmailOutboxInterval:       getEnvOrDefault("EMAIL_OUTBOX_INTERVAL", "30s"),
+		EmailRetention:            getEnvOrDefault("EMAIL_RETENTION", "24h"),
+		AdminEmails:               os.Getenv("ADMIN_EMAILS"),
+		EmailTransport:            os.Getenv("EMAIL_TRANSPORT"),
+		EmailFrom:                 os.Getenv("EMAIL_FROM"),
+		EmailFileDir:              getEnvOrDefault("EMAIL_FILE_DIR", "./data/mail"),
+		EmailHTTPURL:              os.Getenv("EMAIL_HTTP_URL"),
+		EmailHTTPFormat:           getEnvOrDefault("EMAIL_HTTP_FORMAT", "json"),
+		EmailHTTPAPIKey:           os.Getenv("EMAIL_HTTP_API_KEY"),
+		EmailHTTPAuthHeader:       getEnvOrDefault("EMAIL_HTTP_AUTH_HEADER", "Authorization"),
+		EmailHTTPHealthURL:        os.Getenv("EMAIL_HTTP_HEALTH_URL"),
+		TelegramBotToken:          os.Getenv("TELEGRAM_BOT_TOKEN"),
+		TelegramAPIURL:            getEnvOrDefault("TELEGRAM_API_URL", "https://api.telegram.org"),
+		TelegramBotUsername:       os.Getenv("TELEGRAM_BOT_USERNAME"),
+		TelegramPollTimeout:       getEnvOrDefault("TELEGRAM_POLL_TIMEOUT", "30s"),
+		MQTTEnabled:               getEnvOrDefault("MQTT_ENABLED", "true"),
+		MQTTInterval:              getEnvOrDefault("MQTT_INTERVAL", "1m"),
+		InboundEmailAddress:       os.Getenv("INBOUND_EMAIL_ADDRESS"),
+		InboundSMTPAddr:           getEnvOrDefault("INBOUND_SMTP_ADDR", ":2525"),
 	}
 
 	if cfg.DBPath == "" {
@@ -78,6 +144,18 @@ func Load() (*Config, error) {
 	if cfg.DefaultTimezone == "" {
 		return nil, fmt.Errorf("DEFAULT_TIMEZONE is required")
 	}
+	if (cfg.VAPIDPublicKey == "") != (cfg.VAPIDPrivateKey == "") {
+		return nil, fmt.Errorf("VAPID_PUBLIC_KEY and VAPID_PRIVATE_KEY must be set together")
+	}
+	if cfg.APIURL == "" {
+		cfg.APIURL = cfg.AppURL
+	}
+	if cfg.EmailFrom == "" {
+		cfg.EmailFrom = cfg.SMTPFrom
+	}
+	if cfg.VAPIDSubject == "" {
+		cfg.VAPIDSubject = "mailto:" + cfg.SupportEmail
+	}
 
 	return cfg, nil
 }
@@ -25,6 +25,9 @@ func TestLoad_Success(t *testing.T) {
 	if cfg.AppURL != "http://test.com" {
 		t.Errorf("AppURL = %v, want %v", cfg.AppURL, "http://test.com")
 	}
+	if cfg.APIURL != "http://test.com" {
+		t.Errorf("APIURL = %v, want it to fall back to APP_URL", cfg.APIURL)
+	}
 	if cfg.JWTSecret != "test-secret" {
 		t.Errorf("JWTSecret = %v, want %v", cfg.JWTSecret, "test-secret")
 	}
@@ -248,6 +251,43 @@ func TestLoad_WithSMTPConfig(t *testing.T) {
 	if cfg.SMTPFrom != "noreply@test.com" {
 		t.Errorf("SMTPFrom = %v, want %v", cfg.SMTPFrom, "noreply@test.com")
 	}
+	if cfg.EmailFrom != "noreply@test.com" {
+		t.Errorf("EmailFrom = %v, want SMTP_FROM %v", cfg.EmailFrom, "noreply@test.com")
+	}
+}
+
+func TestLoad_WithEmailTransportConfig(t *testing.T) {
+	os.Setenv("DB_PATH", "/test/db.sqlite")
+	os.Setenv("APP_URL", "http://test.com")
+	os.Setenv("JWT_SECRET", "test-secret")
+	os.Setenv("JWT_EXPIRY", "1h")
+	os.Setenv("REFRESH_TOKEN_EXPIRY", "7d")
+	os.Setenv("DEFAULT_TIMEZONE", "UTC")
+	os.Setenv("EMAIL_TRANSPORT", "http")
+	os.Setenv("EMAIL_FROM", "hello@test.com")
+	os.Setenv("EMAIL_HTTP_URL", "http://localhost:9000/send")
+	defer clearEnv()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.EmailTransport != "http" {
+		t.Errorf("EmailTransport = %v, want %v", cfg.EmailTransport, "http")
+	}
+	if cfg.EmailFrom != "hello@test.com" {
+		t.Errorf("EmailFrom = %v, want %v", cfg.EmailFrom, "hello@test.com")
+	}
+	if cfg.EmailHTTPURL != "http://localhost:9000/send" {
+		t.Errorf("EmailHTTPURL = %v, want %v", cfg.EmailHTTPURL, "http://localhost:9000/send")
+	}
+	if cfg.EmailHTTPFormat != "json" {
+		t.Errorf("EmailHTTPFormat = %v, want default %v", cfg.EmailHTTPFormat, "json")
+	}
+	if cfg.EmailFileDir != "./data/mail" {
+		t.Errorf("EmailFileDir = %v, want default %v", cfg.EmailFileDir, "./data/mail")
+	}
 }
 
 func TestGetEnvOrDefault(t *testing.T) {
@@ -300,6 +340,7 @@ func clearEnv() {
 	os.Unsetenv("DB_PATH")
 	os.Unsetenv("PORT")
 	os.Unsetenv("APP_URL")
+	os.Unsetenv("API_URL")
 	os.Unsetenv("JWT_SECRET")
 	os.Unsetenv("JWT_EXPIRY")
 	os.Unsetenv("REFRESH_TOKEN_EXPIRY")
@@ -312,4 +353,7 @@ func clearEnv() {
 	os.Unsetenv("SUPPORT_EMAIL")
 	os.Unsetenv("SEND_WELCOME_EMAIL")
 	os.Unsetenv("REGISTRATION_MODE")
+	os.Unsetenv("EMAIL_TRANSPORT")
+	os.Unsetenv("EMAIL_FROM")
+	os.Unsetenv("EMAIL_HTTP_URL")
 }
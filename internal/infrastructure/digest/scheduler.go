@@ -0,0 +1,183 @@
+package digest
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/infrastructure/logger"
+)
+
+// Config's Location is the time zone periods are worked out in for users
+// without a notification timezone.
+type Config struct {
+	Enabled  bool
+	Interval time.Duration
+	Location *time.Location
+}
+
+type Mailer interface {
+	SendDigest(ctx context.Context, user *entities.User, frequency entities.DigestFrequency, digest *queries.ProgressDigestDTO) error
+}
+
+// Scheduler periodically sends the weekly and monthly progress digests of the
+// previous week or month, in each user's notification timezone, to the users
+// who opted in.
+type Scheduler struct {
+	subscriptionRepo repositories.DigestSubscriptionRepository
+	userRepo         repositories.UserRepository
+	scheduleRepo     repositories.NotificationScheduleRepository
+	digestHandler    *queries.GetProgressDigestHandler
+	mailer           Mailer
+	config           Config
+	stopCh           chan struct{}
+}
+
+func NewScheduler(
+	subscriptionRepo repositories.DigestSubscriptionRepository,
+	userRepo repositories.UserRepository,
+	scheduleRepo repositories.NotificationScheduleRepository,
+	digestHandler *queries.GetProgressDigestHandler,
+	mailer Mailer,
+	config Config,
+) *Scheduler {
+	if config.Location == nil {
+		config.Location = time.UTC
+	}
+
+	return &Scheduler{
+		subscriptionRepo: subscriptionRepo,
+		userRepo:         userRepo,
+		scheduleRepo:     scheduleRepo,
+		digestHandler:    digestHandler,
+		mailer:           mailer,
+		config:           config,
+		stopCh:           make(chan struct{}),
+	}
+}
+
+func (s *Scheduler) Start() {
+	if !s.config.Enabled {
+		logger.Info().Msg("Digest scheduler is disabled")
+		return
+	}
+
+	logger.Info().
+		Dur("interval", s.config.Interval).
+		Str("timezone", s.config.Location.String()).
+		Msg("Starting digest scheduler")
+
+	go s.run()
+}
+
+func (s *Scheduler) run() {
+	s.SendDue(context.Background(), time.Now())
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			logger.Debug().Msg("Checking for due digests")
+			s.SendDue(context.Background(), time.Now())
+
+		case <-s.stopCh:
+			logger.Info().Msg("Digest scheduler stopped")
+			return
+		}
+	}
+}
+
+func (s *Scheduler) Stop() {
+	if s.config.Enabled {
+		close(s.stopCh)
+	}
+}
+
+// SendDue sends every digest whose period has ended by now and has not been
+// sent yet, and returns how many were sent. Failed digests are retried on the
+// next run.
+func (s *Scheduler) SendDue(ctx context.Context, now time.Time) int {
+	subscriptions, err := s.subscriptionRepo.FindEnabled(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to load digest subscriptions")
+		return 0
+	}
+
+	sent := 0
+
+	for _, subscription := range subscriptions {
+		today := now.In(s.userLocation(ctx, subscription.UserID))
+
+		for _, frequency := range []entities.DigestFrequency{entities.DigestWeekly, entities.DigestMonthly} {
+			from, to := entities.PreviousDigestPeriod(frequency, today)
+			if subscription.IsStarting(frequency) {
+				subscription.MarkSent(frequency, from)
+				if err := s.subscriptionRepo.Save(ctx, subscription); err != nil {
+					logger.Error().Err(err).
+						Str("user_id", subscription.UserID).
+						Msg("Failed to start digest subscription")
+				}
+				continue
+			}
+			if !subscription.IsDue(frequency, from) {
+				continue
+			}
+
+			delivered, err := s.send(ctx, subscription, frequency, from, to)
+			if err != nil {
+				logger.Error().Err(err).
+					Str("user_id", subscription.UserID).
+					Str("frequency", string(frequency)).
+					Msg("Failed to send digest")
+				continue
+			}
+
+			if delivered {
+				sent++
+			}
+		}
+	}
+
+	return sent
+}
+
+func (s *Scheduler) userLocation(ctx context.Context, userID string) *time.Location {
+	schedule, err := s.scheduleRepo.FindByUserID(ctx, userID)
+	if err == nil {
+		if loc, err := time.LoadLocation(schedule.Timezone); err == nil {
+			return loc
+		}
+	}
+	return s.config.Location
+}
+
+// send delivers one digest and records the period as sent. Users with an
+// unverified email are skipped for the period without being emailed.
+func (s *Scheduler) send(ctx context.Context, subscription *entities.DigestSubscription, frequency entities.DigestFrequency, from, to time.Time) (bool, error) {
+	user, err := s.userRepo.FindByID(ctx, subscription.UserID)
+	if err != nil {
+		return false, err
+	}
+
+	if user.EmailVerified {
+		digest, err := s.digestHandler.Handle(ctx, queries.GetProgressDigestQuery{
+			UserID: user.ID,
+			From:   from,
+			To:     to,
+		})
+		if err != nil {
+			return false, err
+		}
+
+		if err := s.mailer.SendDigest(ctx, user, frequency, digest); err != nil {
+			return false, err
+		}
+	}
+
+	subscription.MarkSent(frequency, from)
+	return user.EmailVerified, s.subscriptionRepo.Save(ctx, subscription)
+}
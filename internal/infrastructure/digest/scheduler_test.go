@@ -0,0 +1,189 @@
+package digest
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/infrastructure/persistence/sqlite"
+)
+
+type sentDigest struct {
+	to        string
+	language  string
+	frequency entities.DigestFrequency
+	digest    *queries.ProgressDigestDTO
+}
+
+type recordingMailer struct {
+	sent []sentDigest
+}
+
+func (m *recordingMailer) SendDigest(ctx context.Context, user *entities.User, frequency entities.DigestFrequency, digest *queries.ProgressDigestDTO) error {
+	m.sent = append(m.sent, sentDigest{user.Email, user.Language, frequency, digest})
+	return nil
+}
+
+func TestScheduler_SendDue(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := sqlite.RunMigrations(db); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	userRepo := sqlite.NewUserRepository(db)
+	subscriptionRepo := sqlite.NewDigestSubscriptionRepository(db)
+
+	verified := entities.NewUser("verified@example.com", "hash")
+	verified.EmailVerified = true
+	verified.Language = "es"
+	unverified := entities.NewUser("unverified@example.com", "hash")
+	userRepo.Create(ctx, verified)
+	userRepo.Create(ctx, unverified)
+
+	// Both users opted in before the periods below.
+	lastWeek := time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)
+	lastMonth := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	subscription := entities.NewDigestSubscription(verified.ID)
+	subscription.Weekly = true
+	subscription.Monthly = true
+	subscription.LastWeeklyPeriod = &lastWeek
+	subscription.LastMonthlyPeriod = &lastMonth
+	subscriptionRepo.Save(ctx, subscription)
+
+	pending := entities.NewDigestSubscription(unverified.ID)
+	pending.Weekly = true
+	pending.LastWeeklyPeriod = &lastWeek
+	subscriptionRepo.Save(ctx, pending)
+
+	mailer := &recordingMailer{}
+	scheduler := newTestScheduler(db, mailer)
+
+	monday := time.Date(2025, 2, 3, 9, 0, 0, 0, time.UTC)
+
+	if sent := scheduler.SendDue(ctx, monday); sent != 2 {
+		t.Fatalf("Expected 2 digests sent, got %d", sent)
+	}
+
+	weekly, monthly := mailer.sent[0], mailer.sent[1]
+	if weekly.to != verified.Email || weekly.language != "es" || weekly.frequency != entities.DigestWeekly {
+		t.Errorf("Unexpected weekly digest: %+v", weekly)
+	}
+	if !weekly.digest.From.Equal(time.Date(2025, 1, 27, 0, 0, 0, 0, time.UTC)) || !weekly.digest.To.Equal(time.Date(2025, 2, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the week of January 27th, got %s - %s", weekly.digest.From, weekly.digest.To)
+	}
+	if monthly.frequency != entities.DigestMonthly || !monthly.digest.From.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the January monthly digest, got %+v", monthly)
+	}
+
+	if sent := scheduler.SendDue(ctx, monday.Add(time.Hour)); sent != 0 {
+		t.Errorf("Expected no digests on the next run, got %d", sent)
+	}
+
+	if sent := scheduler.SendDue(ctx, monday.AddDate(0, 0, 7)); sent != 1 {
+		t.Errorf("Expected only the next weekly digest, got %d", sent)
+	}
+
+	stored, err := subscriptionRepo.FindByUserID(ctx, unverified.ID)
+	if err != nil {
+		t.Fatalf("FindByUserID failed: %v", err)
+	}
+	if !stored.LastWeeklyPeriod.After(lastWeek) {
+		t.Error("Expected the unverified user's period to be skipped")
+	}
+}
+
+func newTestScheduler(db *sql.DB, mailer Mailer) *Scheduler {
+	return NewScheduler(
+		sqlite.NewDigestSubscriptionRepository(db),
+		sqlite.NewUserRepository(db),
+		sqlite.NewNotificationScheduleRepository(db),
+		queries.NewGetProgressDigestHandler(sqlite.NewHabitRepository(db), sqlite.NewHabitEntryRepository(db)),
+		mailer,
+		Config{Enabled: true, Interval: time.Hour},
+	)
+}
+
+func TestScheduler_StartsWithThePeriodOfTheOptIn(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := sqlite.RunMigrations(db); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	user := entities.NewUser("new@example.com", "hash")
+	user.EmailVerified = true
+	sqlite.NewUserRepository(db).Create(ctx, user)
+
+	subscription := entities.NewDigestSubscription(user.ID)
+	subscription.SetEnabled(entities.DigestWeekly, true)
+	sqlite.NewDigestSubscriptionRepository(db).Save(ctx, subscription)
+
+	mailer := &recordingMailer{}
+	scheduler := newTestScheduler(db, mailer)
+
+	// Opted in on Wednesday 2025-02-05: the week before is not sent.
+	wednesday := time.Date(2025, 2, 5, 9, 0, 0, 0, time.UTC)
+	if sent := scheduler.SendDue(ctx, wednesday); sent != 0 {
+		t.Fatalf("Expected no digest for the week before the opt-in, got %d", sent)
+	}
+
+	if sent := scheduler.SendDue(ctx, wednesday.AddDate(0, 0, 5)); sent != 1 {
+		t.Fatalf("Expected the digest of the week of the opt-in, got %d", sent)
+	}
+	if from := mailer.sent[0].digest.From; !from.Equal(time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the week of February 3rd, got %s", from)
+	}
+}
+
+func TestScheduler_UsesTheUserTimezone(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := sqlite.RunMigrations(db); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	user := entities.NewUser("auckland@example.com", "hash")
+	user.EmailVerified = true
+	sqlite.NewUserRepository(db).Create(ctx, user)
+
+	schedule := entities.NewNotificationSchedule(user.ID)
+	schedule.Timezone = "Pacific/Auckland"
+	sqlite.NewNotificationScheduleRepository(db).Save(ctx, schedule)
+
+	lastWeek := time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)
+	subscription := entities.NewDigestSubscription(user.ID)
+	subscription.Weekly = true
+	subscription.LastWeeklyPeriod = &lastWeek
+	sqlite.NewDigestSubscriptionRepository(db).Save(ctx, subscription)
+
+	mailer := &recordingMailer{}
+	scheduler := newTestScheduler(db, mailer)
+
+	// Sunday evening in UTC is already Monday morning in Auckland.
+	if sent := scheduler.SendDue(ctx, time.Date(2025, 2, 2, 14, 0, 0, 0, time.UTC)); sent != 1 {
+		t.Fatalf("Expected the week to have ended in Auckland, got %d digests", sent)
+	}
+	if to := mailer.sent[0].digest.To; !to.Equal(time.Date(2025, 2, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the week ending on February 2nd, got %s", to)
+	}
+}
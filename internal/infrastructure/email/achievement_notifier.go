@@ -0,0 +1,51 @@
+package email
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/domain/services"
+)
+
+// AchievementNotifier emails users when they unlock an achievement. It
+// subscribes to achievement.unlocked events.
+type AchievementNotifier struct {
+	mailer   services.Mailer
+	userRepo repositories.UserRepository
+}
+
+func NewAchievementNotifier(mailer services.Mailer, userRepo repositories.UserRepository) *AchievementNotifier {
+	return &AchievementNotifier{
+		mailer:   mailer,
+		userRepo: userRepo,
+	}
+}
+
+func (n *AchievementNotifier) Handle(ctx context.Context, event *entities.Event) error {
+	data, _ := event.Data["achievement"].(map[string]interface{})
+	code, _ := data["code"].(string)
+	if code == "" {
+		return nil
+	}
+
+	return n.NotifyAchievementUnlocked(ctx, &entities.Achievement{
+		UserID: event.UserID,
+		Code:   entities.AchievementCode(code),
+	})
+}
+
+func (n *AchievementNotifier) NotifyAchievementUnlocked(ctx context.Context, achievement *entities.Achievement) error {
+	user, err := n.userRepo.FindByID(ctx, achievement.UserID)
+	if err != nil {
+		return err
+	}
+
+	return n.mailer.SendTemplate(ctx, services.TemplatedEmail{
+		UserID:   user.ID,
+		To:       user.Email,
+		Language: user.Language,
+		Template: services.EmailTemplateAchievementUnlocked,
+		Data:     map[string]interface{}{"Code": string(achievement.Code)},
+	})
+}
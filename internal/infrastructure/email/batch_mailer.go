@@ -0,0 +1,56 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/services"
+)
+
+// BatchItem is one held notification listed in the batch email. Body holds
+// the text of a held email, such as a digest, so it is not lost.
+type BatchItem struct {
+	Title string
+	Body  string
+}
+
+// BatchMailer delivers the emails held back by quiet hours or the hourly
+// limit as a single message, so the user gets one email however many were
+// held. It implements services.NotificationBatchSender.
+type BatchMailer struct {
+	mailer services.Mailer
+}
+
+func NewBatchMailer(mailer services.Mailer) *BatchMailer {
+	return &BatchMailer{mailer: mailer}
+}
+
+func (m *BatchMailer) Channel() entities.NotificationChannel {
+	return entities.NotificationEmail
+}
+
+func (m *BatchMailer) SendBatch(ctx context.Context, user *entities.User, notifications []*entities.HeldNotification) error {
+	items := make([]BatchItem, 0, len(notifications))
+	for _, notification := range notifications {
+		item := BatchItem{Title: notification.Title}
+		if notification.Content != "" {
+			var message services.EmailMessage
+			if err := json.Unmarshal([]byte(notification.Content), &message); err != nil {
+				return fmt.Errorf("failed to decode held email %s: %w", notification.ID, err)
+			}
+			item.Body = strings.TrimSpace(message.TextBody)
+		}
+		items = append(items, item)
+	}
+
+	return m.mailer.SendTemplate(ctx, services.TemplatedEmail{
+		UserID:   user.ID,
+		To:       user.Email,
+		Language: user.Language,
+		Template: services.EmailTemplateNotificationBatch,
+		Data:     map[string]interface{}{"Items": items},
+	})
+}
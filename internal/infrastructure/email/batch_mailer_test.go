@@ -0,0 +1,140 @@
+package email
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/services"
+	"apocapoc-api/internal/i18n"
+	"apocapoc-api/internal/infrastructure/auth"
+	"apocapoc-api/internal/infrastructure/notifications"
+	"apocapoc-api/internal/infrastructure/persistence/sqlite"
+)
+
+// setupQuietHours returns a user in quiet hours at now, a mailer that holds
+// their email, and a batcher that delivers it through transport.
+func setupQuietHours(t *testing.T, now time.Time) (*entities.User, *Mailer, *notifications.Batcher, *flakyTransport) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := sqlite.RunMigrations(db); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	userRepo := sqlite.NewUserRepository(db)
+	scheduleRepo := sqlite.NewNotificationScheduleRepository(db)
+	heldRepo := sqlite.NewHeldNotificationRepository(db)
+	logRepo := sqlite.NewNotificationLogRepository(db)
+
+	user := entities.NewUser("quiet@example.com", "hash")
+	user.Language = "es"
+	userRepo.Create(ctx, user)
+
+	schedule := entities.NewNotificationSchedule(user.ID)
+	schedule.QuietHoursStart = now.Add(-time.Hour).Format("15:04")
+	schedule.QuietHoursEnd = now.Add(time.Hour).Format("15:04")
+	scheduleRepo.Save(ctx, schedule)
+
+	translator, err := i18n.NewTranslator()
+	if err != nil {
+		t.Fatalf("Failed to create translator: %v", err)
+	}
+
+	transport := &flakyTransport{}
+	mailer := NewMailer(
+		transport,
+		NewTemplateRenderer("Apocapoc", "https://apocapoc.app", "help@apocapoc.app"),
+		translator,
+		notifications.NewGate(sqlite.NewNotificationPreferenceRepository(db), scheduleRepo, heldRepo, logRepo),
+		auth.NewUnsubscribeTokens("test-secret"),
+		"https://api.apocapoc.app/api/v1/unsubscribe",
+	)
+
+	batcher := notifications.NewBatcher(heldRepo, scheduleRepo, logRepo, userRepo,
+		[]services.NotificationBatchSender{NewBatchMailer(mailer)},
+		notifications.Config{Enabled: true, Interval: time.Minute},
+	)
+
+	return user, mailer, batcher, transport
+}
+
+func sendTestDigest(t *testing.T, mailer *Mailer, user *entities.User, frequency entities.DigestFrequency, completed int) {
+	digest := &queries.ProgressDigestDTO{
+		From:           time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC),
+		To:             time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC),
+		Scheduled:      14,
+		Completed:      completed,
+		CompletionRate: float64(completed) / 14.0 * 100,
+	}
+	if err := NewDigestMailer(mailer, nil).SendDigest(context.Background(), user, frequency, digest); err != nil {
+		t.Fatalf("SendDigest failed: %v", err)
+	}
+}
+
+func TestBatchMailer_DeliversHeldDigestAfterQuietHours(t *testing.T) {
+	now := time.Now().UTC()
+	user, mailer, batcher, transport := setupQuietHours(t, now)
+
+	sendTestDigest(t, mailer, user, entities.DigestWeekly, 13)
+	if len(transport.sent) != 0 {
+		t.Fatalf("Expected the digest to be held during quiet hours, got %d emails", len(transport.sent))
+	}
+
+	if sent := batcher.DeliverDue(context.Background(), now.Add(2*time.Hour)); sent != 1 {
+		t.Fatalf("Expected the held digest to be delivered after quiet hours, got %d batches", sent)
+	}
+
+	if len(transport.sent) != 1 {
+		t.Fatalf("Expected 1 email, got %d", len(transport.sent))
+	}
+	message := transport.sent[0]
+	if message.To != user.Email || message.Subject != "1 notificaciones mientras no estabas" {
+		t.Errorf("Unexpected message: %+v", message)
+	}
+	if !strings.Contains(message.TextBody, "- Tu resumen semanal de progreso") || !strings.Contains(message.TextBody, "Completaste 13 de 14 hábitos programados (93%).") {
+		t.Errorf("Expected the digest in the batch, got %s", message.TextBody)
+	}
+	if !strings.Contains(message.Body, "Completaste 13 de 14 hábitos programados (93%).") {
+		t.Errorf("Expected the digest in the HTML batch, got %s", message.Body)
+	}
+}
+
+func TestBatchMailer_RetriesFailedBatchAsOneEmail(t *testing.T) {
+	now := time.Now().UTC()
+	user, mailer, batcher, transport := setupQuietHours(t, now)
+	ctx := context.Background()
+
+	sendTestDigest(t, mailer, user, entities.DigestWeekly, 13)
+	sendTestDigest(t, mailer, user, entities.DigestMonthly, 11)
+
+	transport.err = fmt.Errorf("connection refused")
+	if sent := batcher.DeliverDue(ctx, now.Add(2*time.Hour)); sent != 0 {
+		t.Fatalf("Expected the failed batch not to count as sent, got %d", sent)
+	}
+
+	transport.err = nil
+	if sent := batcher.DeliverDue(ctx, now.Add(2*time.Hour)); sent != 1 {
+		t.Fatalf("Expected the batch to be retried, got %d batches", sent)
+	}
+	if sent := batcher.DeliverDue(ctx, now.Add(3*time.Hour)); sent != 0 {
+		t.Fatalf("Expected nothing left to deliver, got %d batches", sent)
+	}
+
+	if len(transport.sent) != 1 {
+		t.Fatalf("Expected both digests in a single email, got %d emails", len(transport.sent))
+	}
+	body := transport.sent[0].TextBody
+	if !strings.Contains(body, "Completaste 13 de 14") || !strings.Contains(body, "Completaste 11 de 14") {
+		t.Errorf("Expected both digests in the batch, got %s", body)
+	}
+}
@@ -0,0 +1,44 @@
+package email
+
+import (
+	"context"
+
+	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/services"
+)
+
+// DigestMailer sends progress digests in the user's language. When
+// replies are set up, the digest can be answered to check in habits by name.
+type DigestMailer struct {
+	mailer  services.Mailer
+	replies services.ReplyAddresses
+}
+
+// NewDigestMailer takes nil replies when replies are not accepted.
+func NewDigestMailer(mailer services.Mailer, replies services.ReplyAddresses) *DigestMailer {
+	return &DigestMailer{
+		mailer:  mailer,
+		replies: replies,
+	}
+}
+
+func (m *DigestMailer) SendDigest(ctx context.Context, user *entities.User, frequency entities.DigestFrequency, digest *queries.ProgressDigestDTO) error {
+	var replyTo string
+	if m.replies != nil {
+		replyTo = m.replies.UserAddress(user.ID)
+	}
+
+	return m.mailer.SendTemplate(ctx, services.TemplatedEmail{
+		UserID:   user.ID,
+		To:       user.Email,
+		Language: user.Language,
+		Template: services.EmailTemplateDigest,
+		Data: map[string]interface{}{
+			"Frequency": string(frequency),
+			"Digest":    digest,
+			"CanReply":  replyTo != "",
+		},
+		ReplyTo: replyTo,
+	})
+}
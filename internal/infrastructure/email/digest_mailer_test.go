@@ -0,0 +1,112 @@
+package email
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/services"
+	"apocapoc-api/internal/i18n"
+	"apocapoc-api/internal/infrastructure/auth"
+)
+
+type recordingEmailService struct {
+	sent []services.EmailMessage
+}
+
+func (s *recordingEmailService) Send(message services.EmailMessage) error {
+	s.sent = append(s.sent, message)
+	return nil
+}
+
+func (s *recordingEmailService) HealthCheck() error {
+	return nil
+}
+
+// stubGate returns decision for every notification and records the titles
+// it was asked about.
+type stubGate struct {
+	decision services.NotificationDecision
+	titles   []string
+}
+
+func (g *stubGate) Admit(ctx context.Context, userID string, category entities.NotificationCategory, channel entities.NotificationChannel, title, content string) (services.NotificationDecision, error) {
+	g.titles = append(g.titles, title)
+	return g.decision, nil
+}
+
+func newTestMailer(t *testing.T, emailService services.EmailService) *Mailer {
+	return newTestMailerWithGate(t, emailService, &stubGate{decision: services.NotificationSend})
+}
+
+func newTestMailerWithGate(t *testing.T, emailService services.EmailService, gate *stubGate) *Mailer {
+	translator, err := i18n.NewTranslator()
+	if err != nil {
+		t.Fatalf("Failed to create translator: %v", err)
+	}
+	return NewMailer(
+		emailService,
+		NewTemplateRenderer("Apocapoc", "https://apocapoc.app", "help@apocapoc.app"),
+		translator,
+		gate,
+		auth.NewUnsubscribeTokens("test-secret"),
+		"https://api.apocapoc.app/api/v1/unsubscribe",
+	)
+}
+
+func TestDigestMailer_SendDigestLocalized(t *testing.T) {
+	emailService := &recordingEmailService{}
+	mailer := NewDigestMailer(newTestMailer(t, emailService), nil)
+
+	digest := &queries.ProgressDigestDTO{
+		From:           time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC),
+		To:             time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC),
+		Scheduled:      14,
+		Completed:      13,
+		CompletionRate: 13.0 / 14.0 * 100,
+		StreaksGained:  []queries.HabitStreakDTO{{HabitID: "habit-1", HabitName: "Exercise", Streak: 12}},
+		StreaksLost:    []queries.HabitStreakDTO{{HabitID: "habit-2", HabitName: "Reading", Streak: 7}},
+		BestHabit:      &queries.HabitRankDTO{HabitID: "habit-1", HabitName: "Exercise", CompletionRate: 100},
+		WorstHabit:     &queries.HabitRankDTO{HabitID: "habit-2", HabitName: "Reading", CompletionRate: 85.7},
+	}
+
+	if err := mailer.SendDigest(context.Background(), &entities.User{ID: "user-1", Email: "user@example.com", Language: "es"}, entities.DigestWeekly, digest); err != nil {
+		t.Fatalf("SendDigest failed: %v", err)
+	}
+
+	if len(emailService.sent) != 1 {
+		t.Fatalf("Expected 1 email, got %d", len(emailService.sent))
+	}
+
+	message := emailService.sent[0]
+	if message.To != "user@example.com" || !message.IsHTML {
+		t.Errorf("Unexpected message: %+v", message)
+	}
+	if message.Subject != "Tu resumen semanal de progreso" {
+		t.Errorf("Expected Spanish subject, got %q", message.Subject)
+	}
+
+	expected := []string{
+		`<html lang="es">`,
+		"Del 2025-01-06 al 2025-01-12",
+		"Completaste 13 de 14 hábitos programados (93%).",
+		"Exercise (100%)",
+		"Reading (86%)",
+		"Exercise: 12 días",
+		"Reading: 7 días",
+		"Todos los derechos reservados.",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(message.Body, exp) {
+			t.Errorf("Expected body to contain %q", exp)
+		}
+	}
+	for _, exp := range expected[1:] {
+		if !strings.Contains(message.TextBody, exp) {
+			t.Errorf("Expected text body to contain %q", exp)
+		}
+	}
+}
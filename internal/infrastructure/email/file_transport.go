@@ -0,0 +1,64 @@
+package email
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"apocapoc-api/internal/domain/services"
+)
+
+// FileTransport writes each message as an .eml file to a directory instead of
+// sending it, for development and CI. The files open in any mail client.
+type FileTransport struct {
+	dir  string
+	from string
+}
+
+func NewFileTransport(dir, from string) *FileTransport {
+	return &FileTransport{
+		dir:  dir,
+		from: from,
+	}
+}
+
+func (t *FileTransport) Send(message services.EmailMessage) error {
+	if err := os.MkdirAll(t.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create mail directory: %w", err)
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), hex.EncodeToString(suffix))
+
+	file, err := os.OpenFile(filepath.Join(t.dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := newMailMessage(t.from, message).WriteTo(file); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+
+	return nil
+}
+
+// HealthCheck makes sure the directory can be written to.
+func (t *FileTransport) HealthCheck() error {
+	if err := os.MkdirAll(t.dir, 0o755); err != nil {
+		return fmt.Errorf("mail directory is not writable: %w", err)
+	}
+
+	probe, err := os.CreateTemp(t.dir, ".healthcheck-*")
+	if err != nil {
+		return fmt.Errorf("mail directory is not writable: %w", err)
+	}
+	probe.Close()
+	return os.Remove(probe.Name())
+}
@@ -0,0 +1,180 @@
+package email
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"apocapoc-api/internal/domain/services"
+)
+
+const (
+	HTTPFormatJSON = "json"
+	HTTPFormatForm = "form"
+
+	httpTransportTimeout = 10 * time.Second
+	maxResponseExcerpt   = 200
+)
+
+// HTTPConfig describes a transactional email provider's HTTP API. Messages are
+// posted to URL with the fields from, to, subject and either html or text,
+// encoded as JSON or as a form. Extra headers are sent in a headers field, as
+// an object in JSON and as a JSON-encoded string in a form.
+type HTTPConfig struct {
+	URL    string
+	Format string
+	// APIKey is sent in the AuthHeader, as a bearer token when the header is
+	// Authorization.
+	APIKey     string
+	AuthHeader string
+	// HealthURL is requested by HealthCheck. Without it, HealthCheck only
+	// checks that the provider's host accepts connections.
+	HealthURL string
+	From      string
+}
+
+type HTTPTransport struct {
+	config HTTPConfig
+	client *http.Client
+}
+
+func NewHTTPTransport(config HTTPConfig) *HTTPTransport {
+	if config.Format == "" {
+		config.Format = HTTPFormatJSON
+	}
+	if config.AuthHeader == "" {
+		config.AuthHeader = "Authorization"
+	}
+
+	return &HTTPTransport{
+		config: config,
+		client: &http.Client{Timeout: httpTransportTimeout},
+	}
+}
+
+func (t *HTTPTransport) Send(message services.EmailMessage) error {
+	fields := map[string]string{
+		"from":    t.config.From,
+		"to":      message.To,
+		"subject": message.Subject,
+	}
+	if message.IsHTML {
+		fields["html"] = message.Body
+		if message.TextBody != "" {
+			fields["text"] = message.TextBody
+		}
+	} else {
+		fields["text"] = message.Body
+	}
+
+	var body []byte
+	var contentType string
+	switch t.config.Format {
+	case HTTPFormatJSON:
+		payload := make(map[string]interface{}, len(fields))
+		for key, value := range fields {
+			payload[key] = value
+		}
+		if len(message.Headers) > 0 {
+			payload["headers"] = message.Headers
+		}
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body, contentType = encoded, "application/json"
+	case HTTPFormatForm:
+		form := url.Values{}
+		for key, value := range fields {
+			form.Set(key, value)
+		}
+		if len(message.Headers) > 0 {
+			headers, err := json.Marshal(message.Headers)
+			if err != nil {
+				return err
+			}
+			form.Set("headers", string(headers))
+		}
+		body, contentType = []byte(form.Encode()), "application/x-www-form-urlencoded"
+	default:
+		return fmt.Errorf("unsupported email HTTP format %q", t.config.Format)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, t.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	t.authorize(req)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseExcerpt))
+		return fmt.Errorf("email provider responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(excerpt)))
+	}
+
+	return nil
+}
+
+func (t *HTTPTransport) authorize(req *http.Request) {
+	if t.config.APIKey == "" {
+		return
+	}
+	if strings.EqualFold(t.config.AuthHeader, "Authorization") {
+		req.Header.Set("Authorization", "Bearer "+t.config.APIKey)
+		return
+	}
+	req.Header.Set(t.config.AuthHeader, t.config.APIKey)
+}
+
+func (t *HTTPTransport) HealthCheck() error {
+	if t.config.HealthURL != "" {
+		req, err := http.NewRequest(http.MethodGet, t.config.HealthURL, nil)
+		if err != nil {
+			return err
+		}
+		t.authorize(req)
+
+		resp, err := t.client.Do(req)
+		if err != nil {
+			return fmt.Errorf("email provider is unreachable: %w", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("email provider health check responded with status %d", resp.StatusCode)
+		}
+		return nil
+	}
+
+	target, err := url.Parse(t.config.URL)
+	if err != nil || target.Host == "" {
+		return fmt.Errorf("invalid email provider URL %q", t.config.URL)
+	}
+
+	address := target.Host
+	if target.Port() == "" {
+		port := "443"
+		if target.Scheme == "http" {
+			port = "80"
+		}
+		address = net.JoinHostPort(target.Hostname(), port)
+	}
+
+	conn, err := net.DialTimeout("tcp", address, httpTransportTimeout)
+	if err != nil {
+		return fmt.Errorf("email provider is unreachable: %w", err)
+	}
+	return conn.Close()
+}
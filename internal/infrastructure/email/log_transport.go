@@ -0,0 +1,33 @@
+package email
+
+import (
+	"apocapoc-api/internal/domain/services"
+	"apocapoc-api/internal/infrastructure/logger"
+)
+
+// LogTransport writes each message to the application log instead of sending
+// it, for development and CI. Bodies are logged at debug level.
+type LogTransport struct{}
+
+func NewLogTransport() *LogTransport {
+	return &LogTransport{}
+}
+
+func (t *LogTransport) Send(message services.EmailMessage) error {
+	logger.Info().
+		Str("to", message.To).
+		Str("subject", message.Subject).
+		Msg("Email written to log")
+
+	logger.Debug().
+		Str("to", message.To).
+		Bool("html", message.IsHTML).
+		Str("body", message.Body).
+		Msg("Email body")
+
+	return nil
+}
+
+func (t *LogTransport) HealthCheck() error {
+	return nil
+}
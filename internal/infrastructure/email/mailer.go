@@ -0,0 +1,292 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/services"
+	"apocapoc-api/internal/i18n"
+	"apocapoc-api/internal/infrastructure/logger"
+)
+
+type emailTemplate struct {
+	// subject is a text template rendered with the same data as the body.
+	subject string
+	// category is empty for emails that are not notifications themselves,
+	// like the batch of notifications held back by quiet hours.
+	category entities.NotificationCategory
+	// autoReply marks emails sent in answer to an email from the user, so
+	// their auto-responders do not answer back.
+	autoReply bool
+	sample    func(appURL string) map[string]interface{}
+}
+
+func (t emailTemplate) unsubscribable() bool {
+	return t.category != "" && !t.category.IsMandatory()
+}
+
+var emailTemplates = map[services.EmailTemplate]emailTemplate{
+	services.EmailTemplateVerifyEmail: {
+		subject:  `{{t "verify_email_subject"}}`,
+		category: entities.NotificationSecurity,
+		sample: func(appURL string) map[string]interface{} {
+			return map[string]interface{}{"URL": appURL + "/verify-email?token=sample-token"}
+		},
+	},
+	services.EmailTemplateResendVerification: {
+		subject:  `{{t "resend_verification_subject"}}`,
+		category: entities.NotificationSecurity,
+		sample: func(appURL string) map[string]interface{} {
+			return map[string]interface{}{"URL": appURL + "/verify-email?token=sample-token"}
+		},
+	},
+	services.EmailTemplatePasswordReset: {
+		subject:  `{{t "password_reset_subject"}}`,
+		category: entities.NotificationSecurity,
+		sample: func(appURL string) map[string]interface{} {
+			return map[string]interface{}{"URL": appURL + "/reset-password?token=sample-token"}
+		},
+	},
+	services.EmailTemplateWelcome: {
+		subject:  `{{t "welcome_subject"}}`,
+		category: entities.NotificationSecurity,
+		sample:   func(appURL string) map[string]interface{} { return map[string]interface{}{} },
+	},
+	services.EmailTemplateAchievementUnlocked: {
+		subject:  `{{t "achievement_subject" (t (printf "achievement_name_%s" .Data.Code))}}`,
+		category: entities.NotificationAchievements,
+		sample: func(appURL string) map[string]interface{} {
+			return map[string]interface{}{"Code": string(entities.AchievementStreak7)}
+		},
+	},
+	services.EmailTemplateReminder: {
+		subject:  `{{t "reminder_subject" .Data.HabitName}}`,
+		category: entities.NotificationReminders,
+		sample: func(appURL string) map[string]interface{} {
+			return map[string]interface{}{"HabitName": "Read 20 pages", "CanReply": true}
+		},
+	},
+	services.EmailTemplateDigest: {
+		subject:  `{{if eq .Data.Frequency "MONTHLY"}}{{t "digest_monthly_subject"}}{{else}}{{t "digest_weekly_subject"}}{{end}}`,
+		category: entities.NotificationDigests,
+		sample: func(appURL string) map[string]interface{} {
+			to := time.Date(2026, 1, 11, 0, 0, 0, 0, time.UTC)
+			return map[string]interface{}{
+				"Frequency": string(entities.DigestWeekly),
+				"CanReply":  true,
+				"Digest": &queries.ProgressDigestDTO{
+					From:           to.AddDate(0, 0, -6),
+					To:             to,
+					Scheduled:      14,
+					Completed:      11,
+					CompletionRate: 78.6,
+					StreaksGained:  []queries.HabitStreakDTO{{HabitName: "Read 20 pages", Streak: 12}},
+					StreaksLost:    []queries.HabitStreakDTO{{HabitName: "Meditate", Streak: 5}},
+					BestHabit:      &queries.HabitRankDTO{HabitName: "Read 20 pages", CompletionRate: 100},
+					WorstHabit:     &queries.HabitRankDTO{HabitName: "Meditate", CompletionRate: 43},
+				},
+			}
+		},
+	},
+	services.EmailTemplateNotificationBatch: {
+		subject: `{{t "notification_batch_subject" (len .Data.Items)}}`,
+		sample: func(appURL string) map[string]interface{} {
+			return map[string]interface{}{"Items": []BatchItem{
+				{Title: "Time for: Read 20 pages"},
+				{Title: "Your weekly progress digest", Body: "You completed 11 of 14 scheduled habits (79%)."},
+			}}
+		},
+	},
+	services.EmailTemplateReplyConfirmation: {
+		subject:   `{{t "reply_confirmation_subject"}}`,
+		autoReply: true,
+		sample: func(appURL string) map[string]interface{} {
+			return map[string]interface{}{
+				"Results": []map[string]string{
+					{"Outcome": "marked", "Habit": "Read 20 pages"},
+					{"Outcome": "recorded", "Habit": "Drink water", "Value": "3"},
+					{"Outcome": "not_found", "Habit": "Meditate"},
+				},
+			}
+		},
+	},
+}
+
+// Mailer renders transactional emails from their templates in the
+// recipient's language and sends them as multipart text and HTML. It
+// implements services.Mailer and services.EmailPreviewer.
+//
+// Emails addressed to a user go through the notification gate, which skips
+// them when the user turned off their category and holds them back during
+// quiet hours or over the hourly limit. They carry a one-click unsubscribe
+// link (RFC 8058) unless the category cannot be turned off.
+type Mailer struct {
+	emailService      services.EmailService
+	renderer          *TemplateRenderer
+	translator        *i18n.Translator
+	gate              services.NotificationGate
+	unsubscribeTokens services.UnsubscribeTokens
+	// unsubscribeURL is the API's one-click unsubscribe endpoint.
+	unsubscribeURL string
+}
+
+func NewMailer(
+	emailService services.EmailService,
+	renderer *TemplateRenderer,
+	translator *i18n.Translator,
+	gate services.NotificationGate,
+	unsubscribeTokens services.UnsubscribeTokens,
+	unsubscribeURL string,
+) *Mailer {
+	return &Mailer{
+		emailService:      emailService,
+		renderer:          renderer,
+		translator:        translator,
+		gate:              gate,
+		unsubscribeTokens: unsubscribeTokens,
+		unsubscribeURL:    unsubscribeURL,
+	}
+}
+
+func (m *Mailer) SendTemplate(ctx context.Context, email services.TemplatedEmail) error {
+	tmpl, ok := emailTemplates[email.Template]
+	if !ok {
+		return fmt.Errorf("unknown email template %q", email.Template)
+	}
+
+	optional := email.UserID != "" && tmpl.unsubscribable()
+
+	data := email.Data
+	var token string
+	if optional {
+		token = url.QueryEscape(m.unsubscribeTokens.Sign(email.UserID, tmpl.category))
+		data = map[string]interface{}{"UnsubscribeURL": m.unsubscribePage(token)}
+		for key, value := range email.Data {
+			data[key] = value
+		}
+	}
+
+	message, err := m.Render(email.Template, email.Language, data)
+	if err != nil {
+		return err
+	}
+	message.To = email.To
+
+	if optional {
+		setHeader(message, "List-Unsubscribe", "<"+m.unsubscribeURL+"?token="+token+">")
+		setHeader(message, "List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+	}
+	if email.ReplyTo != "" {
+		setHeader(message, "Reply-To", email.ReplyTo)
+	}
+	if tmpl.autoReply {
+		setHeader(message, "Auto-Submitted", "auto-replied")
+	}
+
+	if optional {
+		// The whole message is kept if it is held, so it arrives as it was
+		// written once the hold ends.
+		content, err := json.Marshal(message)
+		if err != nil {
+			return fmt.Errorf("failed to encode email: %w", err)
+		}
+
+		decision, err := m.gate.Admit(ctx, email.UserID, tmpl.category, entities.NotificationEmail, message.Subject, string(content))
+		if err != nil {
+			return err
+		}
+		if decision != services.NotificationSend {
+			logger.Debug().
+				Str("user_id", email.UserID).
+				Str("template", string(email.Template)).
+				Str("decision", string(decision)).
+				Msg("Email not sent now")
+			return nil
+		}
+	}
+
+	return m.emailService.Send(*message)
+}
+
+func setHeader(message *services.EmailMessage, name, value string) {
+	if message.Headers == nil {
+		message.Headers = make(map[string]string)
+	}
+	message.Headers[name] = value
+}
+
+// unsubscribePage is the app page linked from the email footer. The
+// List-Unsubscribe header points at the API instead, so mail clients can
+// unsubscribe with a single POST.
+func (m *Mailer) unsubscribePage(token string) string {
+	return m.renderer.appURL + "/unsubscribe?token=" + token
+}
+
+// Render renders a template without sending it. Unsupported languages fall
+// back to English.
+func (m *Mailer) Render(name services.EmailTemplate, language string, data map[string]interface{}) (*services.EmailMessage, error) {
+	tmpl, ok := emailTemplates[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown email template %q", name)
+	}
+
+	lang := m.translator.GetLanguage(language)
+	translate := func(key string) string { return m.translator.Email(lang, key) }
+
+	templateData := map[string]interface{}{
+		"Lang":              lang.String(),
+		"FooterHelp":        translate("footer_help"),
+		"FooterRights":      translate("footer_rights"),
+		"FooterUnsubscribe": translate("footer_unsubscribe"),
+	}
+	for key, value := range data {
+		templateData[key] = value
+	}
+
+	subject, err := m.renderer.RenderText(tmpl.subject, translate, templateData)
+	if err != nil {
+		return nil, err
+	}
+
+	html, text, err := m.renderer.RenderEmail(string(name), translate, templateData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &services.EmailMessage{
+		Subject:  subject,
+		Body:     html,
+		IsHTML:   true,
+		TextBody: text,
+	}, nil
+}
+
+func (m *Mailer) Templates() []services.EmailTemplate {
+	names := make([]services.EmailTemplate, 0, len(emailTemplates))
+	for name := range emailTemplates {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+// Preview renders a template with sample data.
+func (m *Mailer) Preview(name services.EmailTemplate, language string) (*services.EmailMessage, error) {
+	tmpl, ok := emailTemplates[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown email template %q", name)
+	}
+
+	data := tmpl.sample(m.renderer.appURL)
+	if tmpl.unsubscribable() {
+		data["UnsubscribeURL"] = m.unsubscribePage("sample-token")
+	}
+
+	return m.Render(name, language, data)
+}
@@ -0,0 +1,201 @@
+package email
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/services"
+	"apocapoc-api/internal/infrastructure/auth"
+)
+
+func TestMailer_SendTemplateIsMultipartAndLocalized(t *testing.T) {
+	emailService := &recordingEmailService{}
+	mailer := newTestMailer(t, emailService)
+
+	err := mailer.SendTemplate(context.Background(), services.TemplatedEmail{
+		To:       "user@example.com",
+		Language: "es",
+		Template: services.EmailTemplateVerifyEmail,
+		Data:     map[string]interface{}{"URL": "https://apocapoc.app/verify-email?token=abc&x=1"},
+	})
+	if err != nil {
+		t.Fatalf("SendTemplate failed: %v", err)
+	}
+
+	message := emailService.sent[0]
+	if message.To != "user@example.com" || !message.IsHTML || message.TextBody == "" {
+		t.Fatalf("Expected a multipart message, got %+v", message)
+	}
+	if message.Subject != "Verifica tu dirección de correo electrónico" {
+		t.Errorf("Expected a Spanish subject, got %q", message.Subject)
+	}
+	if !strings.Contains(message.Body, `href="https://apocapoc.app/verify-email?token=abc&amp;x=1"`) {
+		t.Errorf("Expected the link in the HTML body, got %s", message.Body)
+	}
+	if !strings.Contains(message.TextBody, "https://apocapoc.app/verify-email?token=abc&x=1") {
+		t.Errorf("Expected the raw link in the text body, got %s", message.TextBody)
+	}
+	if strings.Contains(message.TextBody, "<") {
+		t.Errorf("Expected no markup in the text body, got %s", message.TextBody)
+	}
+}
+
+func TestMailer_PreviewRendersEveryTemplate(t *testing.T) {
+	mailer := newTestMailer(t, nil)
+
+	for _, name := range mailer.Templates() {
+		for _, language := range []string{"en", "es"} {
+			message, err := mailer.Preview(name, language)
+			if err != nil {
+				t.Errorf("Preview(%s, %s) failed: %v", name, language, err)
+				continue
+			}
+
+			for _, part := range []string{message.Subject, message.Body, message.TextBody} {
+				if part == "" || strings.Contains(part, "<no value>") || strings.Contains(part, "%!") {
+					t.Errorf("Preview(%s, %s) rendered an incomplete email: %q", name, language, part)
+				}
+			}
+		}
+	}
+
+	if _, err := mailer.Preview("missing", "en"); err == nil {
+		t.Error("Expected an unknown template to fail")
+	}
+}
+
+func TestMailer_SendTemplateAddsOneClickUnsubscribe(t *testing.T) {
+	emailService := &recordingEmailService{}
+	mailer := newTestMailer(t, emailService)
+
+	err := mailer.SendTemplate(context.Background(), services.TemplatedEmail{
+		UserID:   "user-1",
+		To:       "user@example.com",
+		Language: "en",
+		Template: services.EmailTemplateReminder,
+		Data:     map[string]interface{}{"HabitName": "Stretch"},
+	})
+	if err != nil {
+		t.Fatalf("SendTemplate failed: %v", err)
+	}
+
+	message := emailService.sent[0]
+	link := message.Headers["List-Unsubscribe"]
+	prefix := "<https://api.apocapoc.app/api/v1/unsubscribe?token="
+	if !strings.HasPrefix(link, prefix) || !strings.HasSuffix(link, ">") {
+		t.Fatalf("Unexpected List-Unsubscribe header: %q", link)
+	}
+	if message.Headers["List-Unsubscribe-Post"] != "List-Unsubscribe=One-Click" {
+		t.Errorf("Unexpected List-Unsubscribe-Post header: %q", message.Headers["List-Unsubscribe-Post"])
+	}
+
+	token, _ := url.QueryUnescape(strings.TrimSuffix(strings.TrimPrefix(link, prefix), ">"))
+	userID, category, err := auth.NewUnsubscribeTokens("test-secret").Verify(token)
+	if err != nil || userID != "user-1" || category != entities.NotificationReminders {
+		t.Errorf("Expected a reminders token for user-1, got %s/%s (%v)", userID, category, err)
+	}
+
+	if !strings.Contains(message.TextBody, "Unsubscribe from these emails: https://apocapoc.app/unsubscribe?token=") {
+		t.Errorf("Expected the unsubscribe link in the footer, got %s", message.TextBody)
+	}
+}
+
+func TestMailer_SendTemplateGoesThroughTheGate(t *testing.T) {
+	for _, decision := range []services.NotificationDecision{services.NotificationSkip, services.NotificationHold} {
+		emailService := &recordingEmailService{}
+		gate := &stubGate{decision: decision}
+		mailer := newTestMailerWithGate(t, emailService, gate)
+
+		err := mailer.SendTemplate(context.Background(), services.TemplatedEmail{
+			UserID:   "user-1",
+			To:       "user@example.com",
+			Template: services.EmailTemplateDigest,
+			Data:     map[string]interface{}{"Frequency": "WEEKLY", "Digest": &queries.ProgressDigestDTO{}},
+		})
+		if err != nil {
+			t.Fatalf("SendTemplate failed: %v", err)
+		}
+		if len(emailService.sent) != 0 {
+			t.Fatalf("Expected the digest not to be sent on %s, got %d emails", decision, len(emailService.sent))
+		}
+		if len(gate.titles) != 1 || gate.titles[0] != "Your weekly progress digest" {
+			t.Errorf("Expected the gate to be asked with the subject, got %v", gate.titles)
+		}
+
+		err = mailer.SendTemplate(context.Background(), services.TemplatedEmail{
+			UserID:   "user-1",
+			To:       "user@example.com",
+			Template: services.EmailTemplatePasswordReset,
+			Data:     map[string]interface{}{"URL": "https://apocapoc.app/reset-password?token=abc"},
+		})
+		if err != nil {
+			t.Fatalf("SendTemplate failed: %v", err)
+		}
+		if len(emailService.sent) != 1 {
+			t.Fatalf("Expected security email to be sent regardless, got %d emails", len(emailService.sent))
+		}
+		if len(gate.titles) != 1 {
+			t.Errorf("Expected security email to bypass the gate, got %v", gate.titles)
+		}
+		if len(emailService.sent[0].Headers) != 0 {
+			t.Errorf("Expected no unsubscribe headers on security email, got %v", emailService.sent[0].Headers)
+		}
+	}
+}
+
+func TestBatchMailer_SendBatchListsHeldNotifications(t *testing.T) {
+	emailService := &recordingEmailService{}
+	gate := &stubGate{decision: services.NotificationHold}
+	mailer := NewBatchMailer(newTestMailerWithGate(t, emailService, gate))
+
+	user := entities.NewUser("user@example.com", "hash")
+	user.Language = "es"
+	err := mailer.SendBatch(context.Background(), user, []*entities.HeldNotification{
+		entities.NewHeldNotification(user.ID, entities.NotificationReminders, entities.NotificationEmail, "Es hora de Leer"),
+		entities.NewHeldNotification(user.ID, entities.NotificationAchievements, entities.NotificationEmail, "Logro desbloqueado"),
+	})
+	if err != nil {
+		t.Fatalf("SendBatch failed: %v", err)
+	}
+
+	if len(emailService.sent) != 1 || len(gate.titles) != 0 {
+		t.Fatalf("Expected the batch to be sent without going through the gate, got %d emails", len(emailService.sent))
+	}
+	message := emailService.sent[0]
+	if message.Subject != "2 notificaciones mientras no estabas" {
+		t.Errorf("Unexpected subject: %q", message.Subject)
+	}
+	if !strings.Contains(message.TextBody, "- Es hora de Leer\n- Logro desbloqueado") {
+		t.Errorf("Expected the held notifications listed, got %s", message.TextBody)
+	}
+}
+
+func TestMailer_SendTemplateMarksReplyConfirmationsAsAutomatic(t *testing.T) {
+	emailService := &recordingEmailService{}
+	mailer := newTestMailer(t, emailService)
+
+	err := mailer.SendTemplate(context.Background(), services.TemplatedEmail{
+		UserID:   "user-1",
+		To:       "user@example.com",
+		Language: "en",
+		Template: services.EmailTemplateReplyConfirmation,
+		Data: map[string]interface{}{
+			"Results": []map[string]string{{"Outcome": "recorded", "Habit": "Water", "Value": "3"}, {"Outcome": "unknown"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SendTemplate failed: %v", err)
+	}
+
+	message := emailService.sent[0]
+	if message.Headers["Auto-Submitted"] != "auto-replied" || message.Headers["List-Unsubscribe"] != "" {
+		t.Errorf("Unexpected headers: %v", message.Headers)
+	}
+	if !strings.Contains(message.TextBody, "- Water: recorded 3.") || !strings.Contains(message.TextBody, "- We could not understand your reply.") {
+		t.Errorf("Expected every result in the text body, got %s", message.TextBody)
+	}
+}
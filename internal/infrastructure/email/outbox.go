@@ -0,0 +1,163 @@
+package email
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/domain/services"
+	"apocapoc-api/internal/infrastructure/logger"
+	"apocapoc-api/internal/shared/utils"
+)
+
+const (
+	outboxBatchSize       = 50
+	outboxCleanupInterval = time.Hour
+	maxEmailErrorLength   = 500
+)
+
+type OutboxConfig struct {
+	// Interval is how often the outbox is checked for emails to retry. Newly
+	// queued emails are sent right away.
+	Interval time.Duration
+	// Retention is how long sent emails are kept. They often hold
+	// verification and reset links, so they are not kept for long.
+	Retention time.Duration
+}
+
+// Outbox is an EmailService that stores messages in the email outbox instead
+// of sending them, so a message is not lost when the mail server is down. It
+// sends the queued messages through the transport in the background.
+type Outbox struct {
+	repo        repositories.EmailOutboxRepository
+	transport   services.EmailService
+	config      OutboxConfig
+	lastCleanup time.Time
+	notifyCh    chan struct{}
+	stopCh      chan struct{}
+}
+
+func NewOutbox(repo repositories.EmailOutboxRepository, transport services.EmailService, config OutboxConfig) *Outbox {
+	if config.Interval <= 0 {
+		config.Interval = 30 * time.Second
+	}
+	if config.Retention <= 0 {
+		config.Retention = 24 * time.Hour
+	}
+
+	return &Outbox{
+		repo:      repo,
+		transport: transport,
+		config:    config,
+		notifyCh:  make(chan struct{}, 1),
+		stopCh:    make(chan struct{}),
+	}
+}
+
+func (o *Outbox) Send(message services.EmailMessage) error {
+	email := entities.NewOutgoingEmail(message.To, message.Subject, message.Body, message.IsHTML)
+	email.TextBody = message.TextBody
+	email.Headers = message.Headers
+	if err := o.repo.Create(context.Background(), email); err != nil {
+		return err
+	}
+
+	select {
+	case o.notifyCh <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// HealthCheck reports on the transport, since queueing only needs the
+// database.
+func (o *Outbox) HealthCheck() error {
+	return o.transport.HealthCheck()
+}
+
+func (o *Outbox) Start() {
+	logger.Info().
+		Dur("interval", o.config.Interval).
+		Msg("Starting email outbox")
+
+	go o.run()
+}
+
+func (o *Outbox) run() {
+	o.tick(context.Background(), time.Now())
+
+	ticker := time.NewTicker(o.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			logger.Debug().Msg("Checking for due emails")
+			o.tick(context.Background(), time.Now())
+
+		case <-o.notifyCh:
+			o.tick(context.Background(), time.Now())
+
+		case <-o.stopCh:
+			logger.Info().Msg("Email outbox stopped")
+			return
+		}
+	}
+}
+
+func (o *Outbox) Stop() {
+	close(o.stopCh)
+}
+
+func (o *Outbox) tick(ctx context.Context, now time.Time) {
+	if now.Sub(o.lastCleanup) >= outboxCleanupInterval {
+		if _, err := o.repo.DeleteSentBefore(ctx, now.Add(-o.config.Retention)); err != nil {
+			logger.Error().Err(err).Msg("Failed to clean up the email outbox")
+		}
+		o.lastCleanup = now
+	}
+	o.SendDue(ctx, now)
+}
+
+// SendDue attempts every pending email whose next attempt is due and returns
+// how many were sent.
+func (o *Outbox) SendDue(ctx context.Context, now time.Time) int {
+	emails, err := o.repo.FindDue(ctx, now, outboxBatchSize)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to load due emails")
+		return 0
+	}
+
+	sent := 0
+	for _, email := range emails {
+		err := o.transport.Send(services.EmailMessage{
+			To:       email.To,
+			Subject:  email.Subject,
+			Body:     email.Body,
+			IsHTML:   email.IsHTML,
+			TextBody: email.TextBody,
+			Headers:  email.Headers,
+		})
+		if err == nil {
+			email.RecordSuccess(now)
+			sent++
+		} else {
+			email.RecordFailure(utils.Truncate(err.Error(), maxEmailErrorLength), now)
+
+			if email.Status == entities.OutgoingEmailFailed {
+				logger.Error().
+					Err(err).
+					Str("email_id", email.ID).
+					Int("attempts", email.Attempts).
+					Msg("Giving up on email")
+			}
+		}
+
+		if err := o.repo.Update(ctx, email); err != nil {
+			logger.Error().Err(err).Str("email_id", email.ID).Msg("Failed to update email")
+		}
+	}
+
+	return sent
+}
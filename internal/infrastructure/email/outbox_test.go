@@ -0,0 +1,110 @@
+package email
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/services"
+	"apocapoc-api/internal/infrastructure/persistence/sqlite"
+
+	_ "modernc.org/sqlite"
+)
+
+type flakyTransport struct {
+	recordingEmailService
+	err error
+}
+
+func (t *flakyTransport) Send(message services.EmailMessage) error {
+	if t.err != nil {
+		return t.err
+	}
+	return t.recordingEmailService.Send(message)
+}
+
+func setupOutbox(t *testing.T) (*Outbox, *sqlite.EmailOutboxRepository, *flakyTransport) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := sqlite.RunMigrations(db); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := sqlite.NewEmailOutboxRepository(db)
+	transport := &flakyTransport{}
+	return NewOutbox(repo, transport, OutboxConfig{}), repo, transport
+}
+
+func TestOutbox_QueuesWhileTransportIsDown(t *testing.T) {
+	outbox, repo, transport := setupOutbox(t)
+	ctx := context.Background()
+	transport.err = fmt.Errorf("connection refused")
+
+	err := outbox.Send(services.EmailMessage{
+		To:       "user@example.com",
+		Subject:  "Verify your email address",
+		Body:     "<p>Hi</p>",
+		IsHTML:   true,
+		TextBody: "Hi",
+		Headers:  map[string]string{"List-Unsubscribe": "<https://example.com/u>"},
+	})
+	if err != nil {
+		t.Fatalf("Expected the email to be queued, got %v", err)
+	}
+
+	now := time.Now()
+	if sent := outbox.SendDue(ctx, now); sent != 0 {
+		t.Fatalf("Expected nothing to be sent, got %d", sent)
+	}
+
+	pending, _ := repo.FindByStatus(ctx, entities.OutgoingEmailPending, 10)
+	if len(pending) != 1 || pending[0].Attempts != 1 || pending[0].LastError != "connection refused" {
+		t.Fatalf("Expected the failed attempt to be recorded, got %+v", pending)
+	}
+
+	transport.err = nil
+	if sent := outbox.SendDue(ctx, now); sent != 0 {
+		t.Errorf("Expected the email to wait for its retry, got %d sent", sent)
+	}
+	if sent := outbox.SendDue(ctx, now.Add(entities.EmailRetryDelay(1))); sent != 1 {
+		t.Fatalf("Expected the email to be sent on retry, got %d", sent)
+	}
+
+	if len(transport.sent) != 1 || transport.sent[0].To != "user@example.com" || !transport.sent[0].IsHTML {
+		t.Fatalf("Unexpected messages: %+v", transport.sent)
+	}
+	if transport.sent[0].TextBody != "Hi" || transport.sent[0].Headers["List-Unsubscribe"] != "<https://example.com/u>" {
+		t.Errorf("Expected the text body and headers to survive the outbox, got %+v", transport.sent[0])
+	}
+}
+
+func TestOutbox_DeadLettersAfterMaxAttempts(t *testing.T) {
+	outbox, repo, transport := setupOutbox(t)
+	ctx := context.Background()
+	transport.err = fmt.Errorf("mailbox unavailable")
+
+	outbox.Send(services.EmailMessage{To: "user@example.com", Subject: "Reminder", Body: "Body"})
+
+	now := time.Now()
+	for i := 0; i < entities.OutgoingEmailMaxAttempts; i++ {
+		outbox.SendDue(ctx, now)
+		now = now.Add(time.Hour)
+	}
+
+	failed, _ := repo.FindByStatus(ctx, entities.OutgoingEmailFailed, 10)
+	if len(failed) != 1 || failed[0].Attempts != entities.OutgoingEmailMaxAttempts {
+		t.Fatalf("Expected the email to be dead-lettered, got %+v", failed)
+	}
+
+	transport.err = nil
+	if sent := outbox.SendDue(ctx, now.Add(24*time.Hour)); sent != 0 {
+		t.Errorf("Expected failed emails not to be retried automatically, got %d sent", sent)
+	}
+}
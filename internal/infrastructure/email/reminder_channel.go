@@ -0,0 +1,48 @@
+package email
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/services"
+)
+
+// ReminderChannel delivers habit reminders by email. Users who have not
+// verified their address are skipped. When replies are set up, the reminder
+// can be answered to check in the habit.
+type ReminderChannel struct {
+	mailer  services.Mailer
+	replies services.ReplyAddresses
+}
+
+// NewReminderChannel takes nil replies when replies are not accepted.
+func NewReminderChannel(mailer services.Mailer, replies services.ReplyAddresses) *ReminderChannel {
+	return &ReminderChannel{
+		mailer:  mailer,
+		replies: replies,
+	}
+}
+
+func (c *ReminderChannel) Name() string {
+	return "email"
+}
+
+func (c *ReminderChannel) SendReminder(ctx context.Context, user *entities.User, habit *entities.Habit) error {
+	if !user.EmailVerified {
+		return nil
+	}
+
+	var replyTo string
+	if c.replies != nil {
+		replyTo = c.replies.HabitAddress(habit.ID)
+	}
+
+	return c.mailer.SendTemplate(ctx, services.TemplatedEmail{
+		UserID:   user.ID,
+		To:       user.Email,
+		Language: user.Language,
+		Template: services.EmailTemplateReminder,
+		Data:     map[string]interface{}{"HabitName": habit.Name, "CanReply": replyTo != ""},
+		ReplyTo:  replyTo,
+	})
+}
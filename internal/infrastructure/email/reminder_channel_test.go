@@ -0,0 +1,86 @@
+package email
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+)
+
+func TestReminderChannel_SendReminder(t *testing.T) {
+	emailService := &recordingEmailService{}
+	channel := NewReminderChannel(newTestMailer(t, emailService), nil)
+
+	user := entities.NewUser("user@example.com", "hash")
+	habit := entities.NewHabit(user.ID, "Drink <water>", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+
+	if err := channel.SendReminder(context.Background(), user, habit); err != nil {
+		t.Fatalf("SendReminder failed: %v", err)
+	}
+	if len(emailService.sent) != 0 {
+		t.Fatal("Expected no email for an unverified address")
+	}
+
+	user.EmailVerified = true
+	if err := channel.SendReminder(context.Background(), user, habit); err != nil {
+		t.Fatalf("SendReminder failed: %v", err)
+	}
+
+	if len(emailService.sent) != 1 {
+		t.Fatalf("Expected 1 email, got %d", len(emailService.sent))
+	}
+
+	message := emailService.sent[0]
+	if message.To != user.Email || message.Subject != "Reminder: Drink <water>" {
+		t.Errorf("Unexpected message: %s / %s", message.To, message.Subject)
+	}
+	if !strings.Contains(message.Body, "Time for Drink &lt;water&gt;") {
+		t.Errorf("Expected the escaped habit name in the body, got %s", message.Body)
+	}
+	if !strings.Contains(message.TextBody, "Time for Drink <water>") {
+		t.Errorf("Expected the habit name in the text body, got %s", message.TextBody)
+	}
+
+	user.Language = "es"
+	channel.SendReminder(context.Background(), user, habit)
+	if subject := emailService.sent[1].Subject; subject != "Recordatorio: Drink <water>" {
+		t.Errorf("Expected a Spanish subject for a Spanish user, got %q", subject)
+	}
+}
+
+type stubReplyAddresses struct{}
+
+func (stubReplyAddresses) HabitAddress(habitID string) string {
+	return "reply+" + habitID + "@apocapoc.app"
+}
+
+func (stubReplyAddresses) UserAddress(userID string) string {
+	return "reply+" + userID + "@apocapoc.app"
+}
+
+func TestReminderChannel_SendReminderCanBeRepliedTo(t *testing.T) {
+	emailService := &recordingEmailService{}
+	channel := NewReminderChannel(newTestMailer(t, emailService), stubReplyAddresses{})
+
+	user := entities.NewUser("user@example.com", "hash")
+	user.ID = "user-1"
+	user.EmailVerified = true
+	habit := entities.NewHabit(user.ID, "Stretch", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+
+	if err := channel.SendReminder(context.Background(), user, habit); err != nil {
+		t.Fatalf("SendReminder failed: %v", err)
+	}
+
+	message := emailService.sent[0]
+	if replyTo := message.Headers["Reply-To"]; replyTo != "reply+"+habit.ID+"@apocapoc.app" {
+		t.Errorf("Expected the habit's reply address, got %q", replyTo)
+	}
+	if !strings.Contains(message.TextBody, `Reply "done" to this email`) {
+		t.Errorf("Expected the reply hint in the text body, got %s", message.TextBody)
+	}
+	if message.Headers["List-Unsubscribe"] == "" {
+		t.Error("Expected the unsubscribe header to be kept")
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"log"
 	"strings"
-	"time"
 
 	"apocapoc-api/internal/domain/services"
 
@@ -32,16 +31,7 @@ func NewSMTPService(config SMTPConfig) *SMTPService {
 }
 
 func (s *SMTPService) Send(message services.EmailMessage) error {
-	m := mail.NewMessage()
-	m.SetHeader("From", s.config.From)
-	m.SetHeader("To", message.To)
-	m.SetHeader("Subject", message.Subject)
-
-	if message.IsHTML {
-		m.SetBody("text/html", message.Body)
-	} else {
-		m.SetBody("text/plain", message.Body)
-	}
+	m := newMailMessage(s.config.From, message)
 
 	dialer := mail.NewDialer(s.config.Host, s.config.Port, s.config.Username, s.config.Password)
 	dialer.TLSConfig = &tls.Config{
@@ -53,7 +43,7 @@ func (s *SMTPService) Send(message services.EmailMessage) error {
 		dialer.SSL = true
 	}
 
-	if err := s.sendWithRetry(dialer, m); err != nil {
+	if err := s.send(dialer, m); err != nil {
 		log.Printf("[EMAIL] status=failed to=%s subject=%q error=%q", message.To, message.Subject, err.Error())
 		return fmt.Errorf("failed to send email: %w", err)
 	}
@@ -62,31 +52,21 @@ func (s *SMTPService) Send(message services.EmailMessage) error {
 	return nil
 }
 
-func (s *SMTPService) sendWithRetry(dialer *mail.Dialer, message *mail.Message) error {
-	maxRetries := 3
-	var lastErr error
-
-	for i := 0; i < maxRetries; i++ {
-		if err := dialer.DialAndSend(message); err == nil {
-			return nil
-		} else {
-			lastErr = err
-
-			if isAuthError(err) {
-				return fmt.Errorf("SMTP authentication failed. Please check your SMTP credentials (username, password, and from address)")
-			}
-
-			if isConfigError(err) {
-				return fmt.Errorf("SMTP configuration error: %w", err)
-			}
-
-			if i < maxRetries-1 {
-				time.Sleep(time.Second * time.Duration(i+1))
-			}
-		}
+// send makes a single attempt. Failed messages are retried by the email
+// outbox rather than by blocking the caller.
+func (s *SMTPService) send(dialer *mail.Dialer, message *mail.Message) error {
+	err := dialer.DialAndSend(message)
+	if err == nil {
+		return nil
 	}
 
-	return fmt.Errorf("failed to send email after %d attempts: %w", maxRetries, lastErr)
+	if isAuthError(err) {
+		return fmt.Errorf("SMTP authentication failed. Please check your SMTP credentials (username, password, and from address)")
+	}
+	if isConfigError(err) {
+		return fmt.Errorf("SMTP configuration error: %w", err)
+	}
+	return err
 }
 
 func isAuthError(err error) bool {
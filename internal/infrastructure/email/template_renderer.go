@@ -2,14 +2,24 @@ package email
 
 import (
 	"bytes"
+	"embed"
 	"fmt"
 	"html/template"
+	"strings"
+	textTemplate "text/template"
 )
 
+//go:embed templates/base.html
+var baseTemplate string
+
+//go:embed templates
+var templateFiles embed.FS
+
 type TemplateData struct {
 	AppName      string
 	AppURL       string
 	SupportEmail string
+	Content      template.HTML
 	Data         map[string]interface{}
 }
 
@@ -28,17 +38,97 @@ func NewTemplateRenderer(appName, appURL, supportEmail string) *TemplateRenderer
 }
 
 func (r *TemplateRenderer) Render(templateContent string, data map[string]interface{}) (string, error) {
+	return r.execute(templateContent, r.templateData("", data))
+}
+
+// RenderWithLayout renders templateContent and wraps the result in base.html.
+// The layout receives the same data, so it can pick up localized strings.
+func (r *TemplateRenderer) RenderWithLayout(templateContent string, data map[string]interface{}) (string, error) {
+	content, err := r.Render(templateContent, data)
+	if err != nil {
+		return "", err
+	}
+
+	return r.execute(baseTemplate, r.templateData(template.HTML(content), data))
+}
+
+func (r *TemplateRenderer) execute(templateContent string, templateData TemplateData) (string, error) {
 	tmpl, err := template.New("email").Parse(templateContent)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse template: %w", err)
 	}
 
-	templateData := TemplateData{
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, templateData); err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// RenderEmail renders templates/<name>.html wrapped in base.html and
+// templates/<name>.txt wrapped in base.txt. Both can call t to look up a
+// string with translate, formatting it with any further arguments.
+func (r *TemplateRenderer) RenderEmail(name string, translate func(key string) string, data map[string]interface{}) (string, string, error) {
+	htmlContent, err := templateFiles.ReadFile("templates/" + name + ".html")
+	if err != nil {
+		return "", "", fmt.Errorf("unknown email template %q", name)
+	}
+	textContent, err := templateFiles.ReadFile("templates/" + name + ".txt")
+	if err != nil {
+		return "", "", fmt.Errorf("unknown email template %q", name)
+	}
+	textLayout, err := templateFiles.ReadFile("templates/base.txt")
+	if err != nil {
+		return "", "", err
+	}
+
+	tmpl, err := template.New("email").Funcs(template.FuncMap(translateFuncs(translate))).Parse(string(htmlContent))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse template: %w", err)
+	}
+	var content bytes.Buffer
+	if err := tmpl.Execute(&content, r.templateData("", data)); err != nil {
+		return "", "", fmt.Errorf("failed to execute template: %w", err)
+	}
+	html, err := r.execute(baseTemplate, r.templateData(template.HTML(content.String()), data))
+	if err != nil {
+		return "", "", err
+	}
+
+	text, err := r.RenderText(string(textContent), translate, data)
+	if err != nil {
+		return "", "", err
+	}
+	text, err = r.executeText(string(textLayout), nil, r.templateData(template.HTML(strings.TrimSpace(text)), data))
+	if err != nil {
+		return "", "", err
+	}
+
+	return html, text, nil
+}
+
+// RenderText renders a plain-text template such as a subject line. It can call
+// t like the templates rendered by RenderEmail.
+func (r *TemplateRenderer) RenderText(templateContent string, translate func(key string) string, data map[string]interface{}) (string, error) {
+	return r.executeText(templateContent, translateFuncs(translate), r.templateData("", data))
+}
+
+func (r *TemplateRenderer) templateData(content template.HTML, data map[string]interface{}) TemplateData {
+	return TemplateData{
 		AppName:      r.appName,
 		AppURL:       r.appURL,
 		SupportEmail: r.supportEmail,
+		Content:      content,
 		Data:         data,
 	}
+}
+
+func (r *TemplateRenderer) executeText(templateContent string, funcs map[string]interface{}, templateData TemplateData) (string, error) {
+	tmpl, err := textTemplate.New("email").Funcs(textTemplate.FuncMap(funcs)).Parse(templateContent)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
 
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, templateData); err != nil {
@@ -47,3 +137,14 @@ func (r *TemplateRenderer) Render(templateContent string, data map[string]interf
 
 	return buf.String(), nil
 }
+
+func translateFuncs(translate func(key string) string) map[string]interface{} {
+	return map[string]interface{}{
+		"t": func(key string, args ...interface{}) string {
+			if len(args) == 0 {
+				return translate(key)
+			}
+			return fmt.Sprintf(translate(key), args...)
+		},
+	}
+}
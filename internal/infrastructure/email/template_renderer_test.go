@@ -95,3 +95,29 @@ func TestTemplateRenderer_RenderSyntaxError(t *testing.T) {
 		t.Error("Expected error for invalid template syntax")
 	}
 }
+
+func TestTemplateRenderer_RenderWithLayout(t *testing.T) {
+	renderer := NewTemplateRenderer("My App", "https://myapp.com", "help@myapp.com")
+
+	result, err := renderer.RenderWithLayout(`<p>Hi {{.Data.Name}}</p>`, map[string]interface{}{
+		"Name":       "<Jane>",
+		"Lang":       "es",
+		"FooterHelp": "¿Necesitas ayuda?",
+	})
+	if err != nil {
+		t.Fatalf("Failed to render template: %v", err)
+	}
+
+	expected := []string{
+		`<html lang="es">`,
+		"<h1>My App</h1>",
+		"<p>Hi &lt;Jane&gt;</p>",
+		"¿Necesitas ayuda?",
+		"All rights reserved.",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(result, exp) {
+			t.Errorf("Expected result to contain '%s'", exp)
+		}
+	}
+}
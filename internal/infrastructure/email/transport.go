@@ -0,0 +1,91 @@
+package email
+
+import (
+	"fmt"
+	"time"
+
+	"apocapoc-api/internal/domain/services"
+
+	"gopkg.in/mail.v2"
+)
+
+const (
+	TransportSMTP = "smtp"
+	TransportFile = "file"
+	TransportLog  = "log"
+	TransportHTTP = "http"
+)
+
+type TransportConfig struct {
+	// Name selects the transport. It defaults to smtp when an SMTP host is
+	// configured, and no transport at all otherwise.
+	Name    string
+	From    string
+	SMTP    SMTPConfig
+	FileDir string
+	HTTP    HTTPConfig
+}
+
+// NewTransport returns the transport selected by the config, or nil when
+// email is not configured.
+func NewTransport(config TransportConfig) (services.EmailService, error) {
+	name := config.Name
+	if name == "" && config.SMTP.Host != "" {
+		name = TransportSMTP
+	}
+
+	switch name {
+	case "":
+		return nil, nil
+	case TransportSMTP:
+		if config.SMTP.Host == "" {
+			return nil, fmt.Errorf("the smtp email transport requires SMTP_HOST")
+		}
+		smtp := config.SMTP
+		smtp.From = config.From
+		return NewSMTPService(smtp), nil
+	case TransportFile:
+		if config.FileDir == "" {
+			return nil, fmt.Errorf("the file email transport requires EMAIL_FILE_DIR")
+		}
+		return NewFileTransport(config.FileDir, config.From), nil
+	case TransportLog:
+		return NewLogTransport(), nil
+	case TransportHTTP:
+		if config.HTTP.URL == "" {
+			return nil, fmt.Errorf("the http email transport requires EMAIL_HTTP_URL")
+		}
+		if config.HTTP.Format != "" && config.HTTP.Format != HTTPFormatJSON && config.HTTP.Format != HTTPFormatForm {
+			return nil, fmt.Errorf("unsupported EMAIL_HTTP_FORMAT %q", config.HTTP.Format)
+		}
+		http := config.HTTP
+		http.From = config.From
+		return NewHTTPTransport(http), nil
+	default:
+		return nil, fmt.Errorf("unknown email transport %q", name)
+	}
+}
+
+// newMailMessage builds the RFC 5322 message shared by the SMTP and file
+// transports.
+func newMailMessage(from string, message services.EmailMessage) *mail.Message {
+	m := mail.NewMessage()
+	m.SetHeader("From", from)
+	m.SetHeader("To", message.To)
+	m.SetHeader("Subject", message.Subject)
+	m.SetDateHeader("Date", time.Now())
+	for name, value := range message.Headers {
+		m.SetHeader(name, value)
+	}
+
+	if message.IsHTML && message.TextBody != "" {
+		m.SetBody("text/plain", message.TextBody)
+		m.AddAlternative("text/html", message.Body)
+	} else if message.IsHTML {
+		m.SetBody("text/html", message.Body)
+	} else {
+		m.SetBody("text/plain", message.Body)
+	}
+
+	return m
+}
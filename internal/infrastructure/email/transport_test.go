@@ -0,0 +1,219 @@
+package email
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"apocapoc-api/internal/domain/services"
+)
+
+func TestFileTransport_WritesEmlFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	transport := NewFileTransport(dir, "noreply@example.com")
+
+	if err := transport.HealthCheck(); err != nil {
+		t.Fatalf("Expected the directory to be writable: %v", err)
+	}
+
+	for _, subject := range []string{"First", "Second"} {
+		err := transport.Send(services.EmailMessage{
+			To:      "user@example.com",
+			Subject: subject,
+			Body:    "<p>Hello</p>",
+			IsHTML:  true,
+		})
+		if err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 .eml files, got %d", len(files))
+	}
+
+	content, _ := os.ReadFile(files[0])
+	for _, want := range []string{"From: noreply@example.com", "To: user@example.com", "Subject: First", "Content-Type: text/html"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Expected the file to contain %q, got:\n%s", want, content)
+		}
+	}
+}
+
+func TestFileTransport_WritesMultipartWhenTextIsGiven(t *testing.T) {
+	dir := t.TempDir()
+	transport := NewFileTransport(dir, "noreply@example.com")
+
+	err := transport.Send(services.EmailMessage{
+		To:       "user@example.com",
+		Subject:  "Hi",
+		Body:     "<p>Hello</p>",
+		IsHTML:   true,
+		TextBody: "Hello",
+		Headers:  map[string]string{"List-Unsubscribe-Post": "List-Unsubscribe=One-Click"},
+	})
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	content, _ := os.ReadFile(files[0])
+	for _, want := range []string{"multipart/alternative", "Content-Type: text/plain", "Content-Type: text/html", "List-Unsubscribe-Post: List-Unsubscribe=One-Click"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Expected the file to contain %q, got:\n%s", want, content)
+		}
+	}
+}
+
+func TestFileTransport_HealthCheckFailsWhenNotWritable(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "not-a-directory")
+	os.WriteFile(file, []byte("x"), 0o644)
+
+	if err := NewFileTransport(file, "noreply@example.com").HealthCheck(); err == nil {
+		t.Error("Expected the health check to fail")
+	}
+}
+
+func TestHTTPTransport_PostsJSON(t *testing.T) {
+	var received map[string]string
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected a JSON body, got %s", r.Header.Get("Content-Type"))
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	transport := NewHTTPTransport(HTTPConfig{URL: server.URL, APIKey: "secret", From: "noreply@example.com"})
+	err := transport.Send(services.EmailMessage{To: "user@example.com", Subject: "Hi", Body: "Hello"})
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	if auth != "Bearer secret" {
+		t.Errorf("Expected a bearer token, got %q", auth)
+	}
+	if received["from"] != "noreply@example.com" || received["to"] != "user@example.com" || received["subject"] != "Hi" || received["text"] != "Hello" {
+		t.Errorf("Unexpected payload: %+v", received)
+	}
+	if _, ok := received["html"]; ok {
+		t.Error("Expected no html field for a plain text email")
+	}
+}
+
+func TestHTTPTransport_PostsFormWithCustomAuthHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "secret" {
+			t.Errorf("Expected the API key header, got %q", r.Header.Get("X-Api-Key"))
+		}
+		if r.FormValue("html") != "<p>Hello</p>" || r.FormValue("to") != "user@example.com" {
+			t.Errorf("Unexpected form: %v", r.Form)
+		}
+		var headers map[string]string
+		json.Unmarshal([]byte(r.FormValue("headers")), &headers)
+		if headers["List-Unsubscribe"] != "<https://example.com/u>" {
+			t.Errorf("Expected JSON-encoded headers, got %q", r.FormValue("headers"))
+		}
+	}))
+	defer server.Close()
+
+	transport := NewHTTPTransport(HTTPConfig{
+		URL:        server.URL,
+		Format:     HTTPFormatForm,
+		APIKey:     "secret",
+		AuthHeader: "X-Api-Key",
+	})
+	err := transport.Send(services.EmailMessage{
+		To:      "user@example.com",
+		Subject: "Hi",
+		Body:    "<p>Hello</p>",
+		IsHTML:  true,
+		Headers: map[string]string{"List-Unsubscribe": "<https://example.com/u>"},
+	})
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+}
+
+func TestHTTPTransport_FailsOnErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid recipient", http.StatusUnprocessableEntity)
+	}))
+	defer server.Close()
+
+	err := NewHTTPTransport(HTTPConfig{URL: server.URL}).Send(services.EmailMessage{To: "user@example.com"})
+	if err == nil || !strings.Contains(err.Error(), "422") || !strings.Contains(err.Error(), "invalid recipient") {
+		t.Errorf("Expected the status and response in the error, got %v", err)
+	}
+}
+
+func TestHTTPTransport_HealthCheck(t *testing.T) {
+	healthy := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+
+	if err := NewHTTPTransport(HTTPConfig{URL: server.URL}).HealthCheck(); err != nil {
+		t.Errorf("Expected the host to be reachable: %v", err)
+	}
+
+	withHealthURL := NewHTTPTransport(HTTPConfig{URL: server.URL, HealthURL: server.URL + "/health"})
+	if err := withHealthURL.HealthCheck(); err != nil {
+		t.Errorf("Expected the health URL to be healthy: %v", err)
+	}
+	healthy = false
+	if err := withHealthURL.HealthCheck(); err == nil {
+		t.Error("Expected an unhealthy provider to fail the health check")
+	}
+
+	server.Close()
+	if err := NewHTTPTransport(HTTPConfig{URL: server.URL}).HealthCheck(); err == nil {
+		t.Error("Expected an unreachable provider to fail the health check")
+	}
+}
+
+func TestNewTransport(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  TransportConfig
+		want    string
+		wantErr bool
+	}{
+		{name: "disabled", config: TransportConfig{}, want: "<nil>"},
+		{name: "smtp by default", config: TransportConfig{SMTP: SMTPConfig{Host: "smtp.example.com"}}, want: "*email.SMTPService"},
+		{name: "smtp without host", config: TransportConfig{Name: TransportSMTP}, wantErr: true},
+		{name: "file", config: TransportConfig{Name: TransportFile, FileDir: "mail"}, want: "*email.FileTransport"},
+		{name: "log", config: TransportConfig{Name: TransportLog}, want: "*email.LogTransport"},
+		{name: "http", config: TransportConfig{Name: TransportHTTP, HTTP: HTTPConfig{URL: "https://mail.example.com"}}, want: "*email.HTTPTransport"},
+		{name: "http without url", config: TransportConfig{Name: TransportHTTP}, wantErr: true},
+		{name: "http with bad format", config: TransportConfig{Name: TransportHTTP, HTTP: HTTPConfig{URL: "https://mail.example.com", Format: "xml"}}, wantErr: true},
+		{name: "unknown", config: TransportConfig{Name: "carrier-pigeon"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport, err := NewTransport(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewTransport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got := fmt.Sprintf("%T", transport); got != tt.want {
+				t.Errorf("NewTransport() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
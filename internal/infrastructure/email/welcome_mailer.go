@@ -0,0 +1,37 @@
+package email
+
+import (
+	"context"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/domain/services"
+)
+
+// WelcomeMailer emails users once they have verified their email address. It
+// subscribes to user.email_verified events.
+type WelcomeMailer struct {
+	mailer   services.Mailer
+	userRepo repositories.UserRepository
+}
+
+func NewWelcomeMailer(mailer services.Mailer, userRepo repositories.UserRepository) *WelcomeMailer {
+	return &WelcomeMailer{
+		mailer:   mailer,
+		userRepo: userRepo,
+	}
+}
+
+func (m *WelcomeMailer) Handle(ctx context.Context, event *entities.Event) error {
+	user, err := m.userRepo.FindByID(ctx, event.UserID)
+	if err != nil {
+		return err
+	}
+
+	return m.mailer.SendTemplate(ctx, services.TemplatedEmail{
+		UserID:   user.ID,
+		To:       user.Email,
+		Language: user.Language,
+		Template: services.EmailTemplateWelcome,
+	})
+}
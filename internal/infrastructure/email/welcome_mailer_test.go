@@ -0,0 +1,81 @@
+package email
+
+import (
+	"context"
+	"testing"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type stubUserRepo struct {
+	user *entities.User
+}
+
+func (r *stubUserRepo) Create(ctx context.Context, user *entities.User) error { return nil }
+
+func (r *stubUserRepo) FindByID(ctx context.Context, id string) (*entities.User, error) {
+	if r.user == nil || r.user.ID != id {
+		return nil, errors.ErrNotFound
+	}
+	return r.user, nil
+}
+
+func (r *stubUserRepo) FindByEmail(ctx context.Context, email string) (*entities.User, error) {
+	return nil, errors.ErrNotFound
+}
+
+func (r *stubUserRepo) FindByVerificationToken(ctx context.Context, token string) (*entities.User, error) {
+	return nil, errors.ErrNotFound
+}
+
+func (r *stubUserRepo) Update(ctx context.Context, user *entities.User) error { return nil }
+
+func (r *stubUserRepo) Delete(ctx context.Context, id string) error { return nil }
+
+func TestWelcomeMailer_Handle(t *testing.T) {
+	user := entities.NewUser("test@example.com", "hash")
+	user.ID = "user-123"
+
+	emailService := &recordingEmailService{}
+	mailer := NewWelcomeMailer(newTestMailer(t, emailService), &stubUserRepo{user: user})
+
+	event := entities.NewEvent(entities.EventUserEmailVerified, user.ID, nil)
+	if err := mailer.Handle(context.Background(), event); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+
+	if len(emailService.sent) != 1 {
+		t.Fatalf("Expected 1 email sent, got %d", len(emailService.sent))
+	}
+
+	message := emailService.sent[0]
+	if message.To != "test@example.com" || message.Subject != "Welcome to Apocapoc!" || !message.IsHTML {
+		t.Errorf("Unexpected message: %+v", message)
+	}
+}
+
+func TestAchievementNotifier_Handle(t *testing.T) {
+	user := entities.NewUser("test@example.com", "hash")
+	user.ID = "user-123"
+
+	emailService := &recordingEmailService{}
+	notifier := NewAchievementNotifier(newTestMailer(t, emailService), &stubUserRepo{user: user})
+
+	event := entities.NewEvent(entities.EventAchievementUnlocked, user.ID, map[string]interface{}{
+		"achievement": map[string]interface{}{"code": "streak_7"},
+	})
+	if err := notifier.Handle(context.Background(), event); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+
+	if len(emailService.sent) != 1 || emailService.sent[0].Subject != "Achievement unlocked: 7-day streak" {
+		t.Errorf("Unexpected emails: %+v", emailService.sent)
+	}
+
+	user.Language = "es"
+	notifier.Handle(context.Background(), event)
+	if len(emailService.sent) != 2 || emailService.sent[1].Subject != "Logro desbloqueado: Racha de 7 días" {
+		t.Errorf("Expected a Spanish email for a Spanish user, got %+v", emailService.sent)
+	}
+}
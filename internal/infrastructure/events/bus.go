@@ -0,0 +1,268 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/infrastructure/logger"
+	"apocapoc-api/internal/shared/errors"
+	"apocapoc-api/internal/shared/utils"
+)
+
+const (
+	dispatchBatchSize = 100
+	cleanupInterval   = time.Hour
+	maxErrorLength    = 500
+)
+
+// Handler reacts to an event. A synchronous handler runs inside Publish and an
+// error fails the change that emitted the event. An asynchronous handler runs
+// in the background and is retried with a backoff when it returns an error,
+// so it must be safe to run more than once for the same event.
+type Handler func(ctx context.Context, event *entities.Event) error
+
+type Config struct {
+	// Interval is how often the outbox is checked for events to retry.
+	// Newly published events are dispatched right away.
+	Interval time.Duration
+	// Retention is how long processed events are kept in the outbox.
+	Retention time.Duration
+}
+
+type subscriber struct {
+	name    string
+	handler Handler
+	types   []entities.EventType
+}
+
+func (s subscriber) handles(eventType entities.EventType) bool {
+	if len(s.types) == 0 {
+		return true
+	}
+	for _, t := range s.types {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Bus stores published events in the outbox and hands them to the subscribers
+// registered for their type. It implements services.EventPublisher.
+type Bus struct {
+	outboxRepo  repositories.EventOutboxRepository
+	config      Config
+	mu          sync.RWMutex
+	sync        []subscriber
+	async       []subscriber
+	lastCleanup time.Time
+	notifyCh    chan struct{}
+	stopCh      chan struct{}
+}
+
+func NewBus(outboxRepo repositories.EventOutboxRepository, config Config) *Bus {
+	if config.Interval <= 0 {
+		config.Interval = time.Minute
+	}
+	if config.Retention <= 0 {
+		config.Retention = 7 * 24 * time.Hour
+	}
+
+	return &Bus{
+		outboxRepo: outboxRepo,
+		config:     config,
+		notifyCh:   make(chan struct{}, 1),
+		stopCh:     make(chan struct{}),
+	}
+}
+
+// Subscribe registers a handler that runs synchronously within Publish for the
+// given event types, or for every event when none are given.
+func (b *Bus) Subscribe(name string, handler Handler, types ...entities.EventType) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sync = append(b.sync, subscriber{name: name, handler: handler, types: types})
+}
+
+// SubscribeAsync registers a handler that runs in the background for the given
+// event types, or for every event when none are given. The name identifies the
+// subscriber in the outbox, so it must stay the same across restarts.
+func (b *Bus) SubscribeAsync(name string, handler Handler, types ...entities.EventType) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.async = append(b.async, subscriber{name: name, handler: handler, types: types})
+}
+
+// Publish stores the event in the outbox and runs the synchronous subscribers.
+// An event whose ID was already published is ignored.
+func (b *Bus) Publish(ctx context.Context, event *entities.Event) error {
+	err := b.outboxRepo.Create(ctx, entities.NewOutboxEvent(event))
+	if err == errors.ErrAlreadyExists {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range b.subscribers(false, event.Type) {
+		if err := sub.handler(ctx, event); err != nil {
+			return fmt.Errorf("event subscriber %s: %w", sub.name, err)
+		}
+	}
+
+	b.notify()
+	return nil
+}
+
+func (b *Bus) subscribers(async bool, eventType entities.EventType) []subscriber {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	all := b.sync
+	if async {
+		all = b.async
+	}
+
+	var matching []subscriber
+	for _, sub := range all {
+		if sub.handles(eventType) {
+			matching = append(matching, sub)
+		}
+	}
+	return matching
+}
+
+func (b *Bus) notify() {
+	select {
+	case b.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+func (b *Bus) Start() {
+	logger.Info().
+		Dur("interval", b.config.Interval).
+		Dur("retention", b.config.Retention).
+		Msg("Starting event dispatcher")
+
+	go b.run()
+}
+
+func (b *Bus) run() {
+	b.tick(context.Background(), time.Now())
+
+	ticker := time.NewTicker(b.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			logger.Debug().Msg("Checking for due events")
+			b.tick(context.Background(), time.Now())
+
+		case <-b.notifyCh:
+			b.tick(context.Background(), time.Now())
+
+		case <-b.stopCh:
+			logger.Info().Msg("Event dispatcher stopped")
+			return
+		}
+	}
+}
+
+func (b *Bus) Stop() {
+	close(b.stopCh)
+}
+
+func (b *Bus) tick(ctx context.Context, now time.Time) {
+	if now.Sub(b.lastCleanup) >= cleanupInterval {
+		b.Cleanup(ctx, now)
+		b.lastCleanup = now
+	}
+
+	// A full batch means more events may be waiting.
+	if b.DispatchDue(ctx, now) == dispatchBatchSize {
+		b.notify()
+	}
+}
+
+// Cleanup deletes the events processed longer ago than the retention period.
+func (b *Bus) Cleanup(ctx context.Context, now time.Time) int {
+	deleted, err := b.outboxRepo.DeleteProcessedBefore(ctx, now.Add(-b.config.Retention))
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to clean up the event outbox")
+	}
+	return deleted
+}
+
+// DispatchDue hands every due event in the outbox to its asynchronous
+// subscribers and returns how many events were attempted.
+func (b *Bus) DispatchDue(ctx context.Context, now time.Time) int {
+	events, err := b.outboxRepo.FindDue(ctx, now, dispatchBatchSize)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to load due events")
+		return 0
+	}
+
+	for _, event := range events {
+		b.dispatch(ctx, event, now)
+
+		if err := b.outboxRepo.Update(ctx, event); err != nil {
+			logger.Error().Err(err).Str("event_id", event.ID).Msg("Failed to update outbox event")
+		}
+	}
+
+	return len(events)
+}
+
+func (b *Bus) dispatch(ctx context.Context, event *entities.OutboxEvent, now time.Time) {
+	var failures []string
+
+	for _, sub := range b.subscribers(true, event.Type) {
+		if event.IsHandledBy(sub.name) {
+			continue
+		}
+
+		if err := runHandler(ctx, sub.handler, &event.Event); err != nil {
+			logger.Warn().
+				Err(err).
+				Str("event_id", event.ID).
+				Str("event_type", string(event.Type)).
+				Str("subscriber", sub.name).
+				Msg("Event subscriber failed")
+			failures = append(failures, sub.name+": "+err.Error())
+			continue
+		}
+		event.MarkHandledBy(sub.name)
+	}
+
+	if len(failures) == 0 {
+		event.MarkProcessed(now)
+		return
+	}
+
+	event.RecordFailure(utils.Truncate(strings.Join(failures, "; "), maxErrorLength), now)
+
+	if event.Status == entities.OutboxEventFailed {
+		logger.Error().
+			Str("event_id", event.ID).
+			Str("event_type", string(event.Type)).
+			Int("attempts", event.Attempts).
+			Msg("Giving up on event")
+	}
+}
+
+// runHandler keeps a panicking subscriber from stopping the dispatcher.
+func runHandler(ctx context.Context, handler Handler, event *entities.Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return handler(ctx, event)
+}
@@ -0,0 +1,179 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/infrastructure/persistence/sqlite"
+)
+
+type testEnv struct {
+	ctx        context.Context
+	db         *sql.DB
+	user       *entities.User
+	outboxRepo *sqlite.EventOutboxRepository
+	bus        *Bus
+}
+
+func setupTestEnv(t *testing.T) *testEnv {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := sqlite.RunMigrations(db); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	env := &testEnv{
+		ctx:        context.Background(),
+		db:         db,
+		user:       entities.NewUser("events@example.com", "hash"),
+		outboxRepo: sqlite.NewEventOutboxRepository(db),
+	}
+	env.bus = NewBus(env.outboxRepo, Config{Interval: time.Minute, Retention: time.Hour})
+	sqlite.NewUserRepository(db).Create(env.ctx, env.user)
+
+	return env
+}
+
+type recorder struct {
+	received []*entities.Event
+	err      error
+}
+
+func (r *recorder) handle(ctx context.Context, event *entities.Event) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.received = append(r.received, event)
+	return nil
+}
+
+func TestBus_SyncSubscribersRunWithinPublish(t *testing.T) {
+	env := setupTestEnv(t)
+
+	marked := &recorder{}
+	all := &recorder{}
+	env.bus.Subscribe("marked", marked.handle, entities.EventHabitMarked)
+	env.bus.Subscribe("all", all.handle)
+
+	env.bus.Publish(env.ctx, entities.NewEvent(entities.EventHabitMarked, env.user.ID, nil))
+	env.bus.Publish(env.ctx, entities.NewEvent(entities.EventHabitCreated, env.user.ID, nil))
+
+	if len(marked.received) != 1 || marked.received[0].Type != entities.EventHabitMarked {
+		t.Errorf("Expected only the habit.marked event, got %+v", marked.received)
+	}
+	if len(all.received) != 2 {
+		t.Errorf("Expected both events, got %d", len(all.received))
+	}
+
+	failing := &recorder{err: fmt.Errorf("boom")}
+	env.bus.Subscribe("failing", failing.handle, entities.EventHabitArchived)
+	if err := env.bus.Publish(env.ctx, entities.NewEvent(entities.EventHabitArchived, env.user.ID, nil)); err == nil {
+		t.Error("Expected a failing sync subscriber to fail Publish")
+	}
+}
+
+func TestBus_PublishIgnoresDuplicateEvents(t *testing.T) {
+	env := setupTestEnv(t)
+
+	sync := &recorder{}
+	async := &recorder{}
+	env.bus.Subscribe("sync", sync.handle)
+	env.bus.SubscribeAsync("async", async.handle)
+
+	event := entities.NewEvent(entities.EventStreakBroken, env.user.ID, nil)
+	for i := 0; i < 2; i++ {
+		if err := env.bus.Publish(env.ctx, event); err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+	env.bus.DispatchDue(env.ctx, time.Now())
+
+	if len(sync.received) != 1 || len(async.received) != 1 {
+		t.Errorf("Expected the event to be handled once, got %d sync and %d async", len(sync.received), len(async.received))
+	}
+}
+
+func TestBus_DispatchDueRetriesOnlyFailedSubscribers(t *testing.T) {
+	env := setupTestEnv(t)
+
+	ok := &recorder{}
+	flaky := &recorder{err: fmt.Errorf("smtp down")}
+	other := &recorder{}
+	env.bus.SubscribeAsync("ok", ok.handle, entities.EventAchievementUnlocked)
+	env.bus.SubscribeAsync("flaky", flaky.handle, entities.EventAchievementUnlocked)
+	env.bus.SubscribeAsync("other", other.handle, entities.EventUserRegistered)
+
+	event := entities.NewEvent(entities.EventAchievementUnlocked, env.user.ID, map[string]interface{}{
+		"achievement": map[string]interface{}{"code": "first_completion"},
+	})
+	env.bus.Publish(env.ctx, event)
+
+	now := time.Now()
+	if dispatched := env.bus.DispatchDue(env.ctx, now); dispatched != 1 {
+		t.Fatalf("Expected 1 event to be dispatched, got %d", dispatched)
+	}
+	if len(ok.received) != 1 || len(other.received) != 0 {
+		t.Fatalf("Expected only the subscribed handlers to run, got %d and %d", len(ok.received), len(other.received))
+	}
+	achievement, _ := ok.received[0].Data["achievement"].(map[string]interface{})
+	if ok.received[0].ID != event.ID || achievement["code"] != "first_completion" {
+		t.Errorf("Expected the stored event to be handed over, got %+v", ok.received[0])
+	}
+
+	if dispatched := env.bus.DispatchDue(env.ctx, now); dispatched != 0 {
+		t.Errorf("Expected the failed event to wait for its retry, got %d dispatched", dispatched)
+	}
+
+	flaky.err = nil
+	env.bus.DispatchDue(env.ctx, now.Add(entities.OutboxRetryDelay(1)))
+
+	if len(ok.received) != 1 || len(flaky.received) != 1 {
+		t.Errorf("Expected only the failed subscriber to be retried, got %d and %d", len(ok.received), len(flaky.received))
+	}
+	if due, _ := env.outboxRepo.FindDue(env.ctx, now.Add(24*time.Hour), 10); len(due) != 0 {
+		t.Errorf("Expected the event to be processed, got %d still due", len(due))
+	}
+}
+
+func TestBus_DispatchDueGivesUpAfterMaxAttempts(t *testing.T) {
+	env := setupTestEnv(t)
+
+	env.bus.SubscribeAsync("panicking", func(ctx context.Context, event *entities.Event) error {
+		panic("unexpected")
+	})
+	env.bus.Publish(env.ctx, entities.NewEvent(entities.EventHabitCreated, env.user.ID, nil))
+
+	now := time.Now()
+	for i := 0; i < entities.OutboxMaxAttempts; i++ {
+		env.bus.DispatchDue(env.ctx, now)
+		now = now.Add(time.Hour)
+	}
+
+	if due, _ := env.outboxRepo.FindDue(env.ctx, now.Add(24*time.Hour), 10); len(due) != 0 {
+		t.Errorf("Expected the event to be given up on, got %d still due", len(due))
+	}
+}
+
+func TestBus_CleanupDeletesOldProcessedEvents(t *testing.T) {
+	env := setupTestEnv(t)
+
+	env.bus.Publish(env.ctx, entities.NewEvent(entities.EventHabitCreated, env.user.ID, nil))
+
+	now := time.Now()
+	env.bus.DispatchDue(env.ctx, now)
+
+	if deleted := env.bus.Cleanup(env.ctx, now); deleted != 0 {
+		t.Errorf("Expected recent events to be kept, deleted %d", deleted)
+	}
+	if deleted := env.bus.Cleanup(env.ctx, now.Add(2*time.Hour)); deleted != 1 {
+		t.Errorf("Expected the processed event to be deleted, deleted %d", deleted)
+	}
+}
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"net/http"
+
+	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/i18n"
+)
+
+type AchievementHandlers struct {
+	getAchievementsHandler *queries.GetUserAchievementsHandler
+	translator             *i18n.Translator
+}
+
+func NewAchievementHandlers(getAchievementsHandler *queries.GetUserAchievementsHandler, translator *i18n.Translator) *AchievementHandlers {
+	return &AchievementHandlers{
+		getAchievementsHandler: getAchievementsHandler,
+		translator:             translator,
+	}
+}
+
+// GetAchievements godoc
+// @Summary List achievements
+// @Description Get the full achievement catalog with the unlock status and date of each badge for the authenticated user
+// @Tags achievements
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} queries.AchievementDTO
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /achievements [get]
+func (h *AchievementHandlers) GetAchievements(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	achievements, err := h.getAchievementsHandler.Handle(r.Context(), queries.GetUserAchievementsQuery{
+		UserID: userID,
+	})
+	if err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_get_achievements")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, achievements)
+}
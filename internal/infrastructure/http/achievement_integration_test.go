@@ -0,0 +1,79 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/application/queries"
+)
+
+func TestAchievementsFlow(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.Close()
+
+	token := registerAndLogin(t, *ts.Router, "achievements@example.com", "Password123!")
+
+	t.Run("Lists the catalog locked before any completion", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/achievements", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		var achievements []queries.AchievementDTO
+		decodeResponse(t, rr, &achievements)
+
+		if len(achievements) != 7 {
+			t.Fatalf("Expected 7 achievements in the catalog, got %d", len(achievements))
+		}
+		for _, achievement := range achievements {
+			if achievement.Unlocked {
+				t.Errorf("Expected %s to be locked", achievement.Code)
+			}
+		}
+	})
+
+	habitBody := CreateHabitRequest{
+		Name:      "Meditation",
+		Type:      "BOOLEAN",
+		Frequency: "DAILY",
+	}
+	rr := makeRequest(t, *ts.Router, "POST", "/api/v1/habits", habitBody, token)
+	var habitResp map[string]string
+	decodeResponse(t, rr, &habitResp)
+	habitID := habitResp["id"]
+
+	today := time.Now().UTC().Format("2006-01-02")
+	rr = makeRequest(t, *ts.Router, "POST", "/api/v1/habits/"+habitID+"/mark", MarkHabitRequest{ScheduledDate: today}, token)
+	if rr.Code != http.StatusOK && rr.Code != http.StatusCreated {
+		t.Fatalf("Expected habit to be marked, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+
+	t.Run("Unlocks the first completion", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/achievements", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		var achievements []queries.AchievementDTO
+		decodeResponse(t, rr, &achievements)
+
+		first := achievements[0]
+		if first.Code != "first_completion" || !first.Unlocked || first.UnlockedAt == nil {
+			t.Fatalf("Expected first_completion to be unlocked, got %+v", first)
+		}
+		if first.HabitID == nil || *first.HabitID != habitID {
+			t.Errorf("Expected first_completion to reference habit %s, got %v", habitID, first.HabitID)
+		}
+		if achievements[1].Unlocked {
+			t.Error("Expected streak_7 to stay locked")
+		}
+	})
+
+	t.Run("Requires authentication", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/achievements", nil, "")
+		if rr.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status 401, got %d", rr.Code)
+		}
+	})
+}
@@ -0,0 +1,159 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"apocapoc-api/internal/application/commands"
+	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/i18n"
+	"apocapoc-api/internal/shared/errors"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type AdminHandlers struct {
+	getEmailOutboxHandler       *queries.GetEmailOutboxHandler
+	retryOutgoingEmailHandler   *commands.RetryOutgoingEmailHandler
+	previewEmailTemplateHandler *queries.PreviewEmailTemplateHandler
+	translator                  *i18n.Translator
+}
+
+func NewAdminHandlers(
+	getEmailOutboxHandler *queries.GetEmailOutboxHandler,
+	retryOutgoingEmailHandler *commands.RetryOutgoingEmailHandler,
+	previewEmailTemplateHandler *queries.PreviewEmailTemplateHandler,
+	translator *i18n.Translator,
+) *AdminHandlers {
+	return &AdminHandlers{
+		getEmailOutboxHandler:       getEmailOutboxHandler,
+		retryOutgoingEmailHandler:   retryOutgoingEmailHandler,
+		previewEmailTemplateHandler: previewEmailTemplateHandler,
+		translator:                  translator,
+	}
+}
+
+// GetEmailOutbox godoc
+// @Summary Get the email outbox
+// @Description Get the number of pending, failed and sent emails and the most recent pending and failed ones, newest first. Admins only.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "PENDING or FAILED (default both)"
+// @Param limit query int false "Number of emails per status (default 50, max 200)"
+// @Success 200 {object} queries.EmailOutboxDTO
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/emails [get]
+func (h *AdminHandlers) GetEmailOutbox(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	outbox, err := h.getEmailOutboxHandler.Handle(r.Context(), queries.GetEmailOutboxQuery{
+		Status: r.URL.Query().Get("status"),
+		Limit:  limit,
+	})
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_email_status")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_get_email_outbox")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, outbox)
+}
+
+// RetryOutgoingEmail godoc
+// @Summary Retry an email
+// @Description Queue a pending or failed email to be sent right away with a fresh set of retries. Admins only.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Email ID"
+// @Success 202 {object} queries.OutgoingEmailDTO
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/emails/{id}/retry [post]
+func (h *AdminHandlers) RetryOutgoingEmail(w http.ResponseWriter, r *http.Request) {
+	email, err := h.retryOutgoingEmailHandler.Handle(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		switch err {
+		case errors.ErrInvalidInput:
+			respondErrorI18n(w, r, h.translator, http.StatusConflict, "email_already_sent")
+		case errors.ErrNotFound:
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "email_not_found")
+		default:
+			respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_retry_email")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusAccepted, queries.NewOutgoingEmailDTO(email))
+}
+
+// ListEmailTemplates godoc
+// @Summary List email templates
+// @Description List the transactional email templates that can be previewed. Admins only.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string][]string
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /admin/email-templates [get]
+func (h *AdminHandlers) ListEmailTemplates(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, map[string][]string{
+		"templates": h.previewEmailTemplateHandler.Templates(r.Context()),
+	})
+}
+
+// PreviewEmailTemplate godoc
+// @Summary Preview an email template
+// @Description Render a transactional email template with sample data, without sending it. Returns the subject, HTML and plain-text versions, or only the HTML or text version when format is html or text. Admins only.
+// @Tags admin
+// @Produce json
+// @Produce html
+// @Produce plain
+// @Security BearerAuth
+// @Param name path string true "Template name"
+// @Param lang query string false "Language (default English)"
+// @Param format query string false "html or text to return only that version"
+// @Success 200 {object} queries.EmailTemplatePreviewDTO
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/email-templates/{name}/preview [get]
+func (h *AdminHandlers) PreviewEmailTemplate(w http.ResponseWriter, r *http.Request) {
+	preview, err := h.previewEmailTemplateHandler.Handle(r.Context(), queries.PreviewEmailTemplateQuery{
+		Template: chi.URLParam(r, "name"),
+		Language: h.translator.GetLanguage(r.URL.Query().Get("lang")).String(),
+	})
+	if err != nil {
+		if err == errors.ErrNotFound {
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "email_template_not_found")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_preview_email_template")
+		return
+	}
+
+	switch r.URL.Query().Get("format") {
+	case "html":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(preview.HTML))
+	case "text":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(preview.Text))
+	default:
+		respondJSON(w, http.StatusOK, preview)
+	}
+}
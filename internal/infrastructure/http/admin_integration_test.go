@@ -0,0 +1,144 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/infrastructure/persistence/sqlite"
+)
+
+func TestAdminEmailOutboxIntegration(t *testing.T) {
+	ts := setupTestServer(t)
+	ctx := context.Background()
+
+	repo := sqlite.NewEmailOutboxRepository(ts.DB)
+	pending := entities.NewOutgoingEmail("user@example.com", "Verify your email address", "secret link", true)
+	repo.Create(ctx, pending)
+
+	failed := entities.NewOutgoingEmail("user@example.com", "Reset your password", "secret link", true)
+	repo.Create(ctx, failed)
+	for i := 0; i < entities.OutgoingEmailMaxAttempts; i++ {
+		failed.RecordFailure("connection refused", time.Now())
+	}
+	repo.Update(ctx, failed)
+
+	adminToken := registerAndLogin(t, *ts.Router, testAdminEmail, "Password123!")
+	userToken := registerAndLogin(t, *ts.Router, "regular@example.com", "Password123!")
+
+	t.Run("Requires an admin", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/admin/emails", nil, userToken)
+		if rr.Code != http.StatusForbidden {
+			t.Errorf("Expected status 403, got %d", rr.Code)
+		}
+
+		rr = makeRequest(t, *ts.Router, "GET", "/api/v1/admin/emails", nil, "")
+		if rr.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status 401, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Lists pending and failed emails without bodies", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/admin/emails", nil, adminToken)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+		if strings.Contains(rr.Body.String(), "secret link") {
+			t.Error("Expected email bodies to be left out")
+		}
+
+		var outbox queries.EmailOutboxDTO
+		decodeResponse(t, rr, &outbox)
+		if outbox.Pending != 1 || outbox.Failed != 1 || len(outbox.Emails) != 2 {
+			t.Errorf("Unexpected outbox: %+v", outbox)
+		}
+
+		rr = makeRequest(t, *ts.Router, "GET", "/api/v1/admin/emails?status=FAILED", nil, adminToken)
+		decodeResponse(t, rr, &outbox)
+		if len(outbox.Emails) != 1 || outbox.Emails[0].ID != failed.ID || outbox.Emails[0].LastError != "connection refused" {
+			t.Errorf("Expected only the failed email, got %+v", outbox.Emails)
+		}
+
+		rr = makeRequest(t, *ts.Router, "GET", "/api/v1/admin/emails?status=SENT", nil, adminToken)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for an unsupported status, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Retries a failed email", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "POST", "/api/v1/admin/emails/"+failed.ID+"/retry", nil, adminToken)
+		if rr.Code != http.StatusAccepted {
+			t.Fatalf("Expected status 202, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		var email queries.OutgoingEmailDTO
+		decodeResponse(t, rr, &email)
+		if email.Status != "PENDING" || email.Attempts != 0 {
+			t.Errorf("Expected the email to be queued again, got %+v", email)
+		}
+
+		rr = makeRequest(t, *ts.Router, "POST", "/api/v1/admin/emails/missing/retry", nil, adminToken)
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", rr.Code)
+		}
+	})
+}
+
+func TestAdminEmailTemplatePreviewIntegration(t *testing.T) {
+	ts := setupTestServer(t)
+
+	adminToken := registerAndLogin(t, *ts.Router, testAdminEmail, "Password123!")
+	userToken := registerAndLogin(t, *ts.Router, "regular@example.com", "Password123!")
+
+	t.Run("Requires an admin", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/admin/email-templates", nil, userToken)
+		if rr.Code != http.StatusForbidden {
+			t.Errorf("Expected status 403, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Lists the templates", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/admin/email-templates", nil, adminToken)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", rr.Code)
+		}
+
+		var resp map[string][]string
+		decodeResponse(t, rr, &resp)
+		if !strings.Contains(strings.Join(resp["templates"], ","), "password_reset") {
+			t.Errorf("Expected password_reset to be listed, got %v", resp["templates"])
+		}
+	})
+
+	t.Run("Renders a template with sample data", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/admin/email-templates/password_reset/preview?lang=es", nil, adminToken)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		var preview queries.EmailTemplatePreviewDTO
+		decodeResponse(t, rr, &preview)
+		if preview.Language != "es" || preview.Subject != "Solicitud de restablecimiento de contraseña" {
+			t.Errorf("Expected a Spanish preview, got %+v", preview)
+		}
+		if !strings.Contains(preview.HTML, "reset-password?token=sample-token") || !strings.Contains(preview.Text, "reset-password?token=sample-token") {
+			t.Errorf("Expected the sample link in both versions, got %+v", preview)
+		}
+
+		rr = makeRequest(t, *ts.Router, "GET", "/api/v1/admin/email-templates/password_reset/preview?format=html", nil, adminToken)
+		if !strings.HasPrefix(rr.Header().Get("Content-Type"), "text/html") || !strings.Contains(rr.Body.String(), "<!DOCTYPE html>") {
+			t.Errorf("Expected the raw HTML, got %s: %s", rr.Header().Get("Content-Type"), rr.Body.String())
+		}
+	})
+
+	t.Run("Unknown template", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/admin/email-templates/missing/preview", nil, adminToken)
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", rr.Code)
+		}
+	})
+}
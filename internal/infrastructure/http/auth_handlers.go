@@ -93,7 +93,7 @@ type LogoutRequest struct {
 
 // Register godoc
 // @Summary Register a new user
-// @Description Create a new user account. If email verification is enabled, you will receive a verification email. Otherwise, you can login immediately.
+// @Description Create a new user account. If email verification is enabled, you will receive a verification email. Otherwise, you can login immediately. Emails are sent in the language of the Accept-Language header, which can be changed later.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -114,6 +114,7 @@ func (h *AuthHandlers) Register(w http.ResponseWriter, r *http.Request) {
 	cmd := commands.RegisterUserCommand{
 		Email:    req.Email,
 		Password: req.Password,
+		Language: i18n.GetLanguageFromContext(r.Context()).String(),
 	}
 
 	result, err := h.registerHandler.Handle(r.Context(), cmd)
@@ -0,0 +1,169 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"apocapoc-api/internal/application/commands"
+	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/i18n"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type ChatHandlers struct {
+	getChatLinkHandler        *queries.GetChatLinkHandler
+	createChatLinkCodeHandler *commands.CreateChatLinkCodeHandler
+	unlinkChatHandler         *commands.UnlinkChatHandler
+	enabled                   bool
+	botUsername               string
+	translator                *i18n.Translator
+}
+
+// NewChatHandlers takes enabled false when no chat bot is configured.
+// botUsername is optional and only used to build links that open the chat
+// with the code filled in.
+func NewChatHandlers(
+	getChatLinkHandler *queries.GetChatLinkHandler,
+	createChatLinkCodeHandler *commands.CreateChatLinkCodeHandler,
+	unlinkChatHandler *commands.UnlinkChatHandler,
+	enabled bool,
+	botUsername string,
+	translator *i18n.Translator,
+) *ChatHandlers {
+	return &ChatHandlers{
+		getChatLinkHandler:        getChatLinkHandler,
+		createChatLinkCodeHandler: createChatLinkCodeHandler,
+		unlinkChatHandler:         unlinkChatHandler,
+		enabled:                   enabled,
+		botUsername:               botUsername,
+		translator:                translator,
+	}
+}
+
+// GetChatLink godoc
+// @Summary Get the linked chat
+// @Description Tell whether a chat is linked to the authenticated user's account through the chat bot
+// @Tags chat
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} queries.ChatLinkDTO
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse "Chat bot is not configured"
+// @Failure 500 {object} ErrorResponse
+// @Router /chat/link [get]
+func (h *ChatHandlers) GetChatLink(w http.ResponseWriter, r *http.Request) {
+	if !h.enabled {
+		respondErrorI18n(w, r, h.translator, http.StatusNotFound, "chat_bot_not_configured")
+		return
+	}
+
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	link, err := h.getChatLinkHandler.Handle(r.Context(), queries.GetChatLinkQuery{UserID: userID})
+	if err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_get_chat_link")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, link)
+}
+
+// CreateChatLinkCode godoc
+// @Summary Create a chat link code
+// @Description Create a one-time code, valid for 15 minutes, to send to the chat bot as "/start <code>" to link the chat to the authenticated user's account. The timezone decides which day "today" is when checking in from the chat (default UTC). A new code replaces the previous one, and linking another chat replaces the linked one.
+// @Tags chat
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateChatLinkCodeRequest false "Timezone"
+// @Success 201 {object} ChatLinkCodeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse "Chat bot is not configured"
+// @Failure 500 {object} ErrorResponse
+// @Router /chat/link-code [post]
+func (h *ChatHandlers) CreateChatLinkCode(w http.ResponseWriter, r *http.Request) {
+	if !h.enabled {
+		respondErrorI18n(w, r, h.translator, http.StatusNotFound, "chat_bot_not_configured")
+		return
+	}
+
+	var req CreateChatLinkCodeRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_request_body")
+			return
+		}
+	}
+
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	code, err := h.createChatLinkCodeHandler.Handle(r.Context(), commands.CreateChatLinkCodeCommand{
+		UserID:   userID,
+		Timezone: req.Timezone,
+	})
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_timezone")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_create_chat_link_code")
+		return
+	}
+
+	response := ChatLinkCodeResponse{
+		Code:      code.Code,
+		ExpiresAt: code.ExpiresAt,
+	}
+	if h.botUsername != "" {
+		response.URL = "https://t.me/" + h.botUsername + "?start=" + code.Code
+	}
+
+	respondJSON(w, http.StatusCreated, response)
+}
+
+// UnlinkChat godoc
+// @Summary Unlink the chat
+// @Description Disconnect the chat linked to the authenticated user's account. The chat no longer receives reminders and can no longer check in.
+// @Tags chat
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse "No chat is linked, or the chat bot is not configured"
+// @Failure 500 {object} ErrorResponse
+// @Router /chat/link [delete]
+func (h *ChatHandlers) UnlinkChat(w http.ResponseWriter, r *http.Request) {
+	if !h.enabled {
+		respondErrorI18n(w, r, h.translator, http.StatusNotFound, "chat_bot_not_configured")
+		return
+	}
+
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	if err := h.unlinkChatHandler.Handle(r.Context(), commands.UnlinkChatCommand{UserID: userID}); err != nil {
+		if err == errors.ErrNotFound {
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "chat_not_linked")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_unlink_chat")
+		return
+	}
+
+	lang := i18n.GetLanguageFromContext(r.Context())
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": h.translator.Success(lang, "chat_unlinked"),
+	})
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"apocapoc-api/internal/application/commands"
+	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/infrastructure/persistence/sqlite"
+)
+
+func TestChatLinkIntegration(t *testing.T) {
+	ts := setupTestServer(t)
+	token := registerAndLogin(t, *ts.Router, "chat@example.com", "Password123!")
+
+	getLink := func(t *testing.T) queries.ChatLinkDTO {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/chat/link", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+		var link queries.ChatLinkDTO
+		decodeResponse(t, rr, &link)
+		return link
+	}
+
+	t.Run("Is not linked by default", func(t *testing.T) {
+		if link := getLink(t); link.Linked {
+			t.Errorf("Expected no linked chat, got %+v", link)
+		}
+	})
+
+	t.Run("Links a chat with a one-time code", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "POST", "/api/v1/chat/link-code", CreateChatLinkCodeRequest{Timezone: "Europe/Madrid"}, token)
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+		var code ChatLinkCodeResponse
+		decodeResponse(t, rr, &code)
+		if code.Code == "" || code.URL != "https://t.me/apocapoc_bot?start="+code.Code {
+			t.Fatalf("Unexpected code response: %+v", code)
+		}
+
+		linkChat := commands.NewLinkChatHandler(sqlite.NewChatLinkCodeRepository(ts.DB), sqlite.NewChatLinkRepository(ts.DB), sqlite.NewUserRepository(ts.DB), sqlite.NewTransactor(ts.DB))
+		if _, err := linkChat.Handle(context.Background(), commands.LinkChatCommand{Code: code.Code, ChatID: 4242}); err != nil {
+			t.Fatalf("Failed to link chat: %v", err)
+		}
+
+		link := getLink(t)
+		if !link.Linked || link.Timezone != "Europe/Madrid" || link.LinkedAt == nil {
+			t.Errorf("Unexpected link: %+v", link)
+		}
+	})
+
+	t.Run("Rejects an invalid timezone", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "POST", "/api/v1/chat/link-code", CreateChatLinkCodeRequest{Timezone: "Nowhere/Special"}, token)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Unlinks the chat", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "DELETE", "/api/v1/chat/link", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+		if link := getLink(t); link.Linked {
+			t.Errorf("Expected the chat to be unlinked, got %+v", link)
+		}
+
+		rr = makeRequest(t, *ts.Router, "DELETE", "/api/v1/chat/link", nil, token)
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 when nothing is linked, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Requires authentication", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/chat/link", nil, "")
+		if rr.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status 401, got %d", rr.Code)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"apocapoc-api/internal/application/queries"
+)
+
+func TestDigestSubscriptionFlow(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.Close()
+
+	token := registerAndLogin(t, *ts.Router, "digest@example.com", "Password123!")
+
+	t.Run("Opted out by default", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/users/me/digests", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		var subscription queries.DigestSubscriptionDTO
+		decodeResponse(t, rr, &subscription)
+
+		if subscription.Weekly || subscription.Monthly {
+			t.Errorf("Unexpected default subscription: %+v", subscription)
+		}
+	})
+
+	t.Run("Opts in to the weekly digest", func(t *testing.T) {
+		weekly := true
+		rr := makeRequest(t, *ts.Router, "PUT", "/api/v1/users/me/digests", UpdateDigestSubscriptionRequest{
+			Weekly: &weekly,
+		}, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		var subscription queries.DigestSubscriptionDTO
+		decodeResponse(t, rr, &subscription)
+
+		if !subscription.Weekly || subscription.Monthly {
+			t.Errorf("Unexpected subscription: %+v", subscription)
+		}
+	})
+
+	t.Run("Leaves omitted settings unchanged", func(t *testing.T) {
+		monthly := true
+		rr := makeRequest(t, *ts.Router, "PUT", "/api/v1/users/me/digests", UpdateDigestSubscriptionRequest{
+			Monthly: &monthly,
+		}, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		rr = makeRequest(t, *ts.Router, "GET", "/api/v1/users/me/digests", nil, token)
+
+		var subscription queries.DigestSubscriptionDTO
+		decodeResponse(t, rr, &subscription)
+
+		if !subscription.Weekly || !subscription.Monthly {
+			t.Errorf("Unexpected subscription: %+v", subscription)
+		}
+	})
+
+	t.Run("Requires authentication", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/users/me/digests", nil, "")
+		if rr.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status 401, got %d", rr.Code)
+		}
+	})
+}
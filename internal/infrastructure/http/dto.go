@@ -3,29 +3,33 @@ package http
 import (
 	"time"
 
+	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/domain/entities"
 	"apocapoc-api/internal/domain/value_objects"
 	"apocapoc-api/internal/shared/pagination"
 )
 
 type CreateHabitRequest struct {
-	Name          string                  `json:"name"`
-	Description   string                  `json:"description"`
-	Type          value_objects.HabitType `json:"type"`
-	Frequency     value_objects.Frequency `json:"frequency"`
-	SpecificDays  []int                   `json:"specific_days,omitempty"`
-	SpecificDates []int                   `json:"specific_dates,omitempty"`
-	CarryOver     bool                    `json:"carry_over"`
-	IsNegative    bool                    `json:"is_negative"`
-	TargetValue   *float64                `json:"target_value,omitempty"`
+	Name          string                        `json:"name"`
+	Description   string                        `json:"description"`
+	Type          value_objects.HabitType       `json:"type"`
+	Frequency     value_objects.Frequency       `json:"frequency"`
+	SpecificDays  []int                         `json:"specific_days,omitempty"`
+	SpecificDates []int                         `json:"specific_dates,omitempty"`
+	CarryOver     bool                          `json:"carry_over"`
+	IsNegative    bool                          `json:"is_negative"`
+	TargetValue   *float64                      `json:"target_value,omitempty"`
+	Difficulty    value_objects.HabitDifficulty `json:"difficulty,omitempty"`
 }
 
 type UpdateHabitRequest struct {
-	Name          string   `json:"name"`
-	Description   string   `json:"description"`
-	SpecificDays  []int    `json:"specific_days,omitempty"`
-	SpecificDates []int    `json:"specific_dates,omitempty"`
-	CarryOver     bool     `json:"carry_over"`
-	TargetValue   *float64 `json:"target_value,omitempty"`
+	Name          string                        `json:"name"`
+	Description   string                        `json:"description"`
+	SpecificDays  []int                         `json:"specific_days,omitempty"`
+	SpecificDates []int                         `json:"specific_dates,omitempty"`
+	CarryOver     bool                          `json:"carry_over"`
+	TargetValue   *float64                      `json:"target_value,omitempty"`
+	Difficulty    value_objects.HabitDifficulty `json:"difficulty,omitempty"`
 }
 
 type HabitResponse struct {
@@ -44,6 +48,115 @@ type HabitResponse struct {
 	ArchivedAt    *time.Time              `json:"archived_at,omitempty"`
 }
 
+type CreateRewardRequest struct {
+	Name string `json:"name"`
+	Cost int    `json:"cost"`
+}
+
+type RecapShareResponse struct {
+	Year    int    `json:"year"`
+	Token   string `json:"token"`
+	CardURL string `json:"card_url"`
+}
+
+type UpdateDigestSubscriptionRequest struct {
+	Weekly  *bool `json:"weekly,omitempty"`
+	Monthly *bool `json:"monthly,omitempty"`
+}
+
+type UpdateUserLanguageRequest struct {
+	Language string `json:"language"`
+}
+
+type UserLanguageResponse struct {
+	Language string `json:"language"`
+}
+
+// UpdateNotificationPreferencesRequest maps categories to the channels to
+// turn on or off, e.g. {"digests": {"email": false}}.
+type UpdateNotificationPreferencesRequest map[entities.NotificationCategory]map[entities.NotificationChannel]bool
+
+// UpdateNotificationScheduleRequest replaces the quiet hours, as HH:MM in
+// Timezone, and the hourly limit. Leave both quiet hours empty for none.
+type UpdateNotificationScheduleRequest struct {
+	QuietHoursStart string `json:"quiet_hours_start"`
+	QuietHoursEnd   string `json:"quiet_hours_end"`
+	Timezone        string `json:"timezone"`
+	MaxPerHour      int    `json:"max_per_hour"`
+}
+
+type CreateChatLinkCodeRequest struct {
+	Timezone string `json:"timezone"`
+}
+
+// ChatLinkCodeResponse holds the code to send to the chat bot. URL opens the
+// chat with the code filled in when the bot's username is configured.
+type ChatLinkCodeResponse struct {
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expires_at"`
+	URL       string    `json:"url,omitempty"`
+}
+
+// UpdateMQTTIntegrationRequest leaves out Password to keep the stored one.
+type UpdateMQTTIntegrationRequest struct {
+	BrokerURL       string  `json:"broker_url"`
+	Username        string  `json:"username"`
+	Password        *string `json:"password,omitempty"`
+	TopicPrefix     string  `json:"topic_prefix"`
+	DiscoveryPrefix string  `json:"discovery_prefix"`
+	Timezone        string  `json:"timezone"`
+}
+
+type UnsubscribeResponse struct {
+	Message  string `json:"message"`
+	Category string `json:"category"`
+}
+
+type CreateHabitReminderRequest struct {
+	Time     string `json:"time"`
+	Timezone string `json:"timezone"`
+}
+
+type UpdateHabitReminderRequest struct {
+	Time     string `json:"time,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
+	Enabled  *bool  `json:"enabled,omitempty"`
+}
+
+// PushSubscriptionRequest mirrors the browser's PushSubscription.toJSON().
+type PushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+type UnregisterPushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint"`
+}
+
+type VAPIDPublicKeyResponse struct {
+	PublicKey string `json:"public_key"`
+}
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+type UpdateWebhookRequest struct {
+	URL     string   `json:"url,omitempty"`
+	Events  []string `json:"events,omitempty"`
+	Enabled *bool    `json:"enabled,omitempty"`
+}
+
+// CreateWebhookResponse is the only response that includes the signing secret.
+type CreateWebhookResponse struct {
+	queries.WebhookDTO
+	Secret string `json:"secret"`
+}
+
 type MarkHabitRequest struct {
 	ScheduledDate string   `json:"scheduled_date"`
 	Value         *float64 `json:"value,omitempty"`
@@ -67,14 +180,15 @@ type TodaysHabitResponse struct {
 }
 
 type UserHabitResponse struct {
-	ID           string                  `json:"id"`
-	Name         string                  `json:"name"`
-	Type         value_objects.HabitType `json:"type"`
-	Frequency    value_objects.Frequency `json:"frequency"`
-	SpecificDays []int                   `json:"specific_days,omitempty"`
-	TargetValue  *float64                `json:"target_value,omitempty"`
-	CarryOver    bool                    `json:"carry_over"`
-	IsNegative   bool                    `json:"is_negative"`
+	ID           string                        `json:"id"`
+	Name         string                        `json:"name"`
+	Type         value_objects.HabitType       `json:"type"`
+	Frequency    value_objects.Frequency       `json:"frequency"`
+	SpecificDays []int                         `json:"specific_days,omitempty"`
+	TargetValue  *float64                      `json:"target_value,omitempty"`
+	CarryOver    bool                          `json:"carry_over"`
+	IsNegative   bool                          `json:"is_negative"`
+	Difficulty   value_objects.HabitDifficulty `json:"difficulty"`
 }
 
 type GetUserHabitsResponse struct {
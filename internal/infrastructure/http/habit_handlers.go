@@ -93,6 +93,7 @@ func (h *HabitHandlers) CreateHabit(w http.ResponseWriter, r *http.Request) {
 		CarryOver:     req.CarryOver,
 		IsNegative:    req.IsNegative,
 		TargetValue:   req.TargetValue,
+		Difficulty:    req.Difficulty,
 	}
 
 	habitID, err := h.createHandler.Handle(r.Context(), cmd)
@@ -208,6 +209,7 @@ func (h *HabitHandlers) GetUserHabits(w http.ResponseWriter, r *http.Request) {
 			TargetValue:  habit.TargetValue,
 			CarryOver:    habit.CarryOver,
 			IsNegative:   habit.IsNegative,
+			Difficulty:   habit.Difficulty,
 		}
 	}
 
@@ -272,6 +274,7 @@ func (h *HabitHandlers) GetHabitByID(w http.ResponseWriter, r *http.Request) {
 		TargetValue:  habit.TargetValue,
 		CarryOver:    habit.CarryOver,
 		IsNegative:   habit.IsNegative,
+		Difficulty:   habit.Difficulty,
 	}
 
 	respondJSON(w, http.StatusOK, response)
@@ -317,6 +320,7 @@ func (h *HabitHandlers) UpdateHabit(w http.ResponseWriter, r *http.Request) {
 		TargetValue:   req.TargetValue,
 		SpecificDays:  req.SpecificDays,
 		SpecificDates: req.SpecificDates,
+		Difficulty:    req.Difficulty,
 	}
 
 	if err := h.updateHandler.Handle(r.Context(), cmd); err != nil {
@@ -11,17 +11,25 @@ import (
 
 	"apocapoc-api/internal/application/commands"
 	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/domain/entities"
 	"apocapoc-api/internal/i18n"
 	"apocapoc-api/internal/infrastructure/auth"
 	"apocapoc-api/internal/infrastructure/crypto"
+	"apocapoc-api/internal/infrastructure/email"
+	"apocapoc-api/internal/infrastructure/events"
+	"apocapoc-api/internal/infrastructure/notifications"
 	"apocapoc-api/internal/infrastructure/persistence/sqlite"
 
 	_ "modernc.org/sqlite"
 )
 
+const testAdminEmail = "admin@example.com"
+
 type TestServer struct {
-	Router *http.Handler
-	DB     *sql.DB
+	Router            *http.Handler
+	DB                *sql.DB
+	Events            *events.Bus
+	UnsubscribeTokens *auth.UnsubscribeTokens
 }
 
 func setupTestServer(t *testing.T) *TestServer {
@@ -40,49 +48,132 @@ func setupTestServer(t *testing.T) *TestServer {
 	userRepo := sqlite.NewUserRepository(db)
 	habitRepo := sqlite.NewHabitRepository(db)
 	entryRepo := sqlite.NewHabitEntryRepository(db)
+	habitStatsRepo := sqlite.NewHabitStatsRepository(db)
+	transactor := sqlite.NewTransactor(db)
+	achievementRepo := sqlite.NewAchievementRepository(db)
+	pointsRepo := sqlite.NewPointsRepository(db)
+	rewardRepo := sqlite.NewRewardRepository(db)
 	refreshTokenRepo := sqlite.NewRefreshTokenRepository(db)
 	passwordResetTokenRepo := sqlite.NewPasswordResetTokenRepository(db)
+	webhookRepo := sqlite.NewWebhookRepository(db)
+	webhookDeliveryRepo := sqlite.NewWebhookDeliveryRepository(db)
+	eventBus := events.NewBus(sqlite.NewEventOutboxRepository(db), events.Config{})
+	eventBus.SubscribeAsync("webhooks", commands.NewWebhookEventHandler(webhookRepo, webhookDeliveryRepo).Handle, entities.WebhookEventTypes...)
 
-	registerHandler := commands.NewRegisterUserHandler(userRepo, passwordHasher, nil, "", "open", false)
+	registerHandler := commands.NewRegisterUserHandler(userRepo, passwordHasher, nil, "", "open", false, eventBus, nil)
 	loginHandler := queries.NewLoginUserHandler(userRepo, passwordHasher)
 	refreshTokenHandler := queries.NewRefreshTokenHandler(refreshTokenRepo, userRepo)
 	revokeTokenHandler := commands.NewRevokeTokenHandler(refreshTokenRepo)
 	revokeAllTokensHandler := commands.NewRevokeAllTokensHandler(refreshTokenRepo)
-	verifyEmailHandler := commands.NewVerifyEmailHandler(userRepo, nil, false)
+	verifyEmailHandler := commands.NewVerifyEmailHandler(userRepo, eventBus)
 	resendVerificationEmailHandler := commands.NewResendVerificationEmailHandler(userRepo, nil, "")
 	requestPasswordResetHandler := commands.NewRequestPasswordResetHandler(userRepo, passwordResetTokenRepo, nil, "")
 	resetPasswordHandler := commands.NewResetPasswordHandler(userRepo, passwordResetTokenRepo, passwordHasher)
-	createHandler := commands.NewCreateHabitHandler(habitRepo)
+	createHandler := commands.NewCreateHabitHandler(habitRepo, eventBus)
 	getTodaysHandler := queries.NewGetTodaysHabitsHandler(habitRepo, entryRepo)
 	getUserHabitsHandler := queries.NewGetUserHabitsHandler(habitRepo)
 	getHabitByIDHandler := queries.NewGetHabitByIDHandler(habitRepo)
 	getHabitEntriesHandler := queries.NewGetHabitEntriesHandler(habitRepo, entryRepo)
-	getHabitStatsHandler := queries.NewGetHabitStatsHandler(habitRepo, entryRepo)
+	getHabitStatsHandler := queries.NewGetHabitStatsHandler(habitRepo, entryRepo, habitStatsRepo)
+	getDashboardStatsHandler := queries.NewGetDashboardStatsHandler(habitRepo, entryRepo)
+	getHeatmapHandler := queries.NewGetHeatmapHandler(habitRepo, entryRepo)
+	getHabitSeriesHandler := queries.NewGetHabitSeriesHandler(habitRepo, entryRepo)
+	getCorrelationInsightsHandler := queries.NewGetCorrelationInsightsHandler(habitRepo, entryRepo)
+	getBehaviourInsightsHandler := queries.NewGetBehaviourInsightsHandler(habitRepo, entryRepo)
 	exportUserDataHandler := queries.NewExportUserDataHandler(habitRepo, entryRepo)
-	updateHandler := commands.NewUpdateHabitHandler(habitRepo)
-	archiveHandler := commands.NewArchiveHabitHandler(habitRepo)
-	markHandler := commands.NewMarkHabitHandler(entryRepo, habitRepo)
-	unmarkHandler := commands.NewUnmarkHabitHandler(habitRepo, entryRepo)
+	updateHandler := commands.NewUpdateHabitHandler(habitRepo, entryRepo, habitStatsRepo, transactor)
+	archiveHandler := commands.NewArchiveHabitHandler(habitRepo, eventBus)
+	achievementEvaluator := commands.NewAchievementEvaluator(habitRepo, entryRepo, habitStatsRepo, achievementRepo)
+	getUserAchievementsHandler := queries.NewGetUserAchievementsHandler(achievementRepo)
+	markHandler := commands.NewMarkHabitHandler(entryRepo, habitRepo, habitStatsRepo, pointsRepo, transactor, achievementEvaluator, eventBus)
+	unmarkHandler := commands.NewUnmarkHabitHandler(habitRepo, entryRepo, habitStatsRepo, pointsRepo, transactor, eventBus)
+	createRewardHandler := commands.NewCreateRewardHandler(rewardRepo)
+	archiveRewardHandler := commands.NewArchiveRewardHandler(rewardRepo)
+	redeemRewardHandler := commands.NewRedeemRewardHandler(rewardRepo, pointsRepo, transactor)
+	getPointsSummaryHandler := queries.NewGetPointsSummaryHandler(pointsRepo)
+	getPointTransactionsHandler := queries.NewGetPointTransactionsHandler(pointsRepo)
+	getRewardsHandler := queries.NewGetRewardsHandler(rewardRepo, pointsRepo)
+	recapShareRepo := sqlite.NewRecapShareRepository(db)
+	getYearRecapHandler := queries.NewGetYearRecapHandler(habitRepo, entryRepo)
+	getSharedYearRecapHandler := queries.NewGetSharedYearRecapHandler(recapShareRepo, getYearRecapHandler)
+	shareYearRecapHandler := commands.NewShareYearRecapHandler(recapShareRepo)
+	revokeYearRecapShareHandler := commands.NewRevokeYearRecapShareHandler(recapShareRepo)
+	reminderRepo := sqlite.NewHabitReminderRepository(db)
+	getHabitRemindersHandler := queries.NewGetHabitRemindersHandler(habitRepo, reminderRepo)
+	createHabitReminderHandler := commands.NewCreateHabitReminderHandler(habitRepo, reminderRepo)
+	updateHabitReminderHandler := commands.NewUpdateHabitReminderHandler(reminderRepo)
+	deleteHabitReminderHandler := commands.NewDeleteHabitReminderHandler(reminderRepo)
+	pushSubscriptionRepo := sqlite.NewPushSubscriptionRepository(db)
+	registerPushSubscriptionHandler := commands.NewRegisterPushSubscriptionHandler(pushSubscriptionRepo)
+	unregisterPushSubscriptionHandler := commands.NewUnregisterPushSubscriptionHandler(pushSubscriptionRepo)
+	getWebhooksHandler := queries.NewGetWebhooksHandler(webhookRepo)
+	getWebhookDeliveriesHandler := queries.NewGetWebhookDeliveriesHandler(webhookRepo, webhookDeliveryRepo)
+	createWebhookHandler := commands.NewCreateWebhookHandler(webhookRepo, false)
+	updateWebhookHandler := commands.NewUpdateWebhookHandler(webhookRepo, false)
+	deleteWebhookHandler := commands.NewDeleteWebhookHandler(webhookRepo)
+	redeliverWebhookDeliveryHandler := commands.NewRedeliverWebhookDeliveryHandler(webhookRepo, webhookDeliveryRepo)
+	emailOutboxRepo := sqlite.NewEmailOutboxRepository(db)
+	getEmailOutboxHandler := queries.NewGetEmailOutboxHandler(emailOutboxRepo)
+	retryOutgoingEmailHandler := commands.NewRetryOutgoingEmailHandler(emailOutboxRepo)
 
 	refreshTokenExpiry := 7 * 24 * time.Hour
 
 	deleteUserHandler := commands.NewDeleteUserHandler(userRepo)
+	digestSubscriptionRepo := sqlite.NewDigestSubscriptionRepository(db)
+	getDigestSubscriptionHandler := queries.NewGetDigestSubscriptionHandler(digestSubscriptionRepo)
+	updateDigestSubscriptionHandler := commands.NewUpdateDigestSubscriptionHandler(digestSubscriptionRepo)
+	updateUserLanguageHandler := commands.NewUpdateUserLanguageHandler(userRepo)
+	notificationPreferenceRepo := sqlite.NewNotificationPreferenceRepository(db)
+	unsubscribeTokens := auth.NewUnsubscribeTokens("test-secret")
+	getNotificationPreferencesHandler := queries.NewGetNotificationPreferencesHandler(notificationPreferenceRepo)
+	updateNotificationPreferencesHandler := commands.NewUpdateNotificationPreferencesHandler(notificationPreferenceRepo, transactor)
+	notificationScheduleRepo := sqlite.NewNotificationScheduleRepository(db)
+	getNotificationScheduleHandler := queries.NewGetNotificationScheduleHandler(notificationScheduleRepo)
+	updateNotificationScheduleHandler := commands.NewUpdateNotificationScheduleHandler(notificationScheduleRepo)
+	notificationGate := notifications.NewGate(notificationPreferenceRepo, notificationScheduleRepo, sqlite.NewHeldNotificationRepository(db), sqlite.NewNotificationLogRepository(db))
+	unsubscribeHandler := commands.NewUnsubscribeHandler(userRepo, notificationPreferenceRepo, unsubscribeTokens)
+
+	chatLinkRepo := sqlite.NewChatLinkRepository(db)
+	getChatLinkHandler := queries.NewGetChatLinkHandler(chatLinkRepo)
+	createChatLinkCodeHandler := commands.NewCreateChatLinkCodeHandler(sqlite.NewChatLinkCodeRepository(db))
+	unlinkChatHandler := commands.NewUnlinkChatHandler(chatLinkRepo)
+
+	mqttIntegrationRepo := sqlite.NewMQTTIntegrationRepository(db)
+	getMQTTIntegrationHandler := queries.NewGetMQTTIntegrationHandler(mqttIntegrationRepo)
+	saveMQTTIntegrationHandler := commands.NewSaveMQTTIntegrationHandler(mqttIntegrationRepo)
+	deleteMQTTIntegrationHandler := commands.NewDeleteMQTTIntegrationHandler(mqttIntegrationRepo)
 
 	translator, _ := i18n.NewTranslator()
 
+	templateMailer := email.NewMailer(nil, email.NewTemplateRenderer("Apocapoc", "http://localhost:3000", "help@example.com"), translator, notificationGate, unsubscribeTokens, "http://localhost:8080/api/v1/unsubscribe")
+	previewEmailTemplateHandler := queries.NewPreviewEmailTemplateHandler(templateMailer)
+
 	authHandlers := NewAuthHandlers(registerHandler, loginHandler, refreshTokenHandler, revokeTokenHandler, revokeAllTokensHandler, verifyEmailHandler, resendVerificationEmailHandler, requestPasswordResetHandler, resetPasswordHandler, jwtService, refreshTokenRepo, refreshTokenExpiry, translator)
 	habitHandlers := NewHabitHandlers(createHandler, getTodaysHandler, getUserHabitsHandler, getHabitByIDHandler, getHabitEntriesHandler, updateHandler, archiveHandler, markHandler, unmarkHandler, translator)
-	statsHandlers := NewStatsHandlers(getHabitStatsHandler, translator)
+	statsHandlers := NewStatsHandlers(getHabitStatsHandler, getDashboardStatsHandler, getHeatmapHandler, getHabitSeriesHandler, getCorrelationInsightsHandler, getBehaviourInsightsHandler, translator)
 	healthHandlers := NewHealthHandlers(db, nil)
-	userHandlers := NewUserHandlers(deleteUserHandler, translator)
+	userHandlers := NewUserHandlers(deleteUserHandler, getDigestSubscriptionHandler, updateDigestSubscriptionHandler, updateUserLanguageHandler, getNotificationPreferencesHandler, updateNotificationPreferencesHandler, getNotificationScheduleHandler, updateNotificationScheduleHandler, translator)
 	exportHandlers := NewExportHandlers(exportUserDataHandler, translator)
+	achievementHandlers := NewAchievementHandlers(getUserAchievementsHandler, translator)
+	pointsHandlers := NewPointsHandlers(getPointsSummaryHandler, getPointTransactionsHandler, getRewardsHandler, createRewardHandler, archiveRewardHandler, redeemRewardHandler, translator)
+	recapHandlers := NewRecapHandlers(getYearRecapHandler, getSharedYearRecapHandler, shareYearRecapHandler, revokeYearRecapShareHandler, translator)
+	reminderHandlers := NewReminderHandlers(getHabitRemindersHandler, createHabitReminderHandler, updateHabitReminderHandler, deleteHabitReminderHandler, translator)
+	pushHandlers := NewPushHandlers(registerPushSubscriptionHandler, unregisterPushSubscriptionHandler, testVAPIDPublicKey, translator)
+	webhookHandlers := NewWebhookHandlers(getWebhooksHandler, getWebhookDeliveriesHandler, createWebhookHandler, updateWebhookHandler, deleteWebhookHandler, redeliverWebhookDeliveryHandler, translator)
+
+	adminHandlers := NewAdminHandlers(getEmailOutboxHandler, retryOutgoingEmailHandler, previewEmailTemplateHandler, translator)
+	unsubscribeHandlers := NewUnsubscribeHandlers(unsubscribeHandler, "http://localhost:3000", translator)
+	chatHandlers := NewChatHandlers(getChatLinkHandler, createChatLinkCodeHandler, unlinkChatHandler, true, "apocapoc_bot", translator)
+	mqttHandlers := NewMQTTHandlers(getMQTTIntegrationHandler, saveMQTTIntegrationHandler, deleteMQTTIntegrationHandler, true, translator)
 
-	router := NewRouter("http://localhost:3000", habitHandlers, authHandlers, statsHandlers, healthHandlers, userHandlers, exportHandlers, jwtService, translator)
+	router := NewRouter("http://localhost:3000", habitHandlers, authHandlers, statsHandlers, healthHandlers, userHandlers, exportHandlers, achievementHandlers, pointsHandlers, recapHandlers, reminderHandlers, pushHandlers, webhookHandlers, adminHandlers, unsubscribeHandlers, chatHandlers, mqttHandlers, jwtService, []string{testAdminEmail}, translator)
 
 	handler := http.Handler(router)
 	return &TestServer{
-		Router: &handler,
-		DB:     db,
+		Router:            &handler,
+		DB:                db,
+		Events:            eventBus,
+		UnsubscribeTokens: unsubscribeTokens,
 	}
 }
 
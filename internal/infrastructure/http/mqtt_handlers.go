@@ -0,0 +1,164 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"apocapoc-api/internal/application/commands"
+	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/i18n"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type MQTTHandlers struct {
+	getMQTTIntegrationHandler    *queries.GetMQTTIntegrationHandler
+	saveMQTTIntegrationHandler   *commands.SaveMQTTIntegrationHandler
+	deleteMQTTIntegrationHandler *commands.DeleteMQTTIntegrationHandler
+	enabled                      bool
+	translator                   *i18n.Translator
+}
+
+// NewMQTTHandlers takes enabled false when the MQTT bridge is turned off on
+// this server.
+func NewMQTTHandlers(
+	getMQTTIntegrationHandler *queries.GetMQTTIntegrationHandler,
+	saveMQTTIntegrationHandler *commands.SaveMQTTIntegrationHandler,
+	deleteMQTTIntegrationHandler *commands.DeleteMQTTIntegrationHandler,
+	enabled bool,
+	translator *i18n.Translator,
+) *MQTTHandlers {
+	return &MQTTHandlers{
+		getMQTTIntegrationHandler:    getMQTTIntegrationHandler,
+		saveMQTTIntegrationHandler:   saveMQTTIntegrationHandler,
+		deleteMQTTIntegrationHandler: deleteMQTTIntegrationHandler,
+		enabled:                      enabled,
+		translator:                   translator,
+	}
+}
+
+// GetMQTTIntegration godoc
+// @Summary Get the MQTT integration
+// @Description Get the authenticated user's MQTT broker settings. The password is never returned.
+// @Tags mqtt
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} queries.MQTTIntegrationDTO
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse "No MQTT integration, or MQTT is disabled"
+// @Failure 500 {object} ErrorResponse
+// @Router /mqtt [get]
+func (h *MQTTHandlers) GetMQTTIntegration(w http.ResponseWriter, r *http.Request) {
+	if !h.enabled {
+		respondErrorI18n(w, r, h.translator, http.StatusNotFound, "mqtt_disabled")
+		return
+	}
+
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	integration, err := h.getMQTTIntegrationHandler.Handle(r.Context(), queries.GetMQTTIntegrationQuery{UserID: userID})
+	if err != nil {
+		if err == errors.ErrNotFound {
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "mqtt_integration_not_found")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_get_mqtt_integration")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, integration)
+}
+
+// SaveMQTTIntegration godoc
+// @Summary Set up the MQTT integration
+// @Description Connect the authenticated user's habits to their MQTT broker, such as the one Home Assistant uses. Habit state is published to retained topics under topic_prefix (default "apocapoc") with Home Assistant discovery configs under discovery_prefix (default "homeassistant"), and habits are marked from their command topics. Leave out password to keep the stored one. The timezone decides which day "today" is (default UTC). Changes are picked up by the bridge within a minute.
+// @Tags mqtt
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body UpdateMQTTIntegrationRequest true "Broker settings"
+// @Success 200 {object} queries.MQTTIntegrationDTO
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse "MQTT is disabled"
+// @Failure 500 {object} ErrorResponse
+// @Router /mqtt [put]
+func (h *MQTTHandlers) SaveMQTTIntegration(w http.ResponseWriter, r *http.Request) {
+	if !h.enabled {
+		respondErrorI18n(w, r, h.translator, http.StatusNotFound, "mqtt_disabled")
+		return
+	}
+
+	var req UpdateMQTTIntegrationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	integration, err := h.saveMQTTIntegrationHandler.Handle(r.Context(), commands.SaveMQTTIntegrationCommand{
+		UserID:          userID,
+		BrokerURL:       req.BrokerURL,
+		Username:        req.Username,
+		Password:        req.Password,
+		TopicPrefix:     req.TopicPrefix,
+		DiscoveryPrefix: req.DiscoveryPrefix,
+		Timezone:        req.Timezone,
+	})
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_mqtt_integration")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_save_mqtt_integration")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, queries.NewMQTTIntegrationDTO(integration))
+}
+
+// DeleteMQTTIntegration godoc
+// @Summary Remove the MQTT integration
+// @Description Disconnect the authenticated user's habits from their MQTT broker. The retained states and discovery configs are cleared, which removes the entities from Home Assistant.
+// @Tags mqtt
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse "No MQTT integration, or MQTT is disabled"
+// @Failure 500 {object} ErrorResponse
+// @Router /mqtt [delete]
+func (h *MQTTHandlers) DeleteMQTTIntegration(w http.ResponseWriter, r *http.Request) {
+	if !h.enabled {
+		respondErrorI18n(w, r, h.translator, http.StatusNotFound, "mqtt_disabled")
+		return
+	}
+
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	if err := h.deleteMQTTIntegrationHandler.Handle(r.Context(), commands.DeleteMQTTIntegrationCommand{UserID: userID}); err != nil {
+		if err == errors.ErrNotFound {
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "mqtt_integration_not_found")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_delete_mqtt_integration")
+		return
+	}
+
+	lang := i18n.GetLanguageFromContext(r.Context())
+	respondJSON(w, http.StatusOK, map[string]string{
+		"message": h.translator.Success(lang, "mqtt_integration_deleted"),
+	})
+}
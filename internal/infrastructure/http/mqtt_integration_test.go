@@ -0,0 +1,97 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"apocapoc-api/internal/application/queries"
+)
+
+func TestMQTTIntegrationIntegration(t *testing.T) {
+	ts := setupTestServer(t)
+	token := registerAndLogin(t, *ts.Router, "mqtt@example.com", "Password123!")
+
+	getIntegration := func(t *testing.T) queries.MQTTIntegrationDTO {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/mqtt", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+		var integration queries.MQTTIntegrationDTO
+		decodeResponse(t, rr, &integration)
+		return integration
+	}
+
+	t.Run("Is not set up by default", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/mqtt", nil, token)
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Saves the broker settings without returning the password", func(t *testing.T) {
+		password := "secret"
+		rr := makeRequest(t, *ts.Router, "PUT", "/api/v1/mqtt", UpdateMQTTIntegrationRequest{
+			BrokerURL: "mqtt://homeassistant.local:1883",
+			Username:  "apocapoc",
+			Password:  &password,
+			Timezone:  "Europe/Madrid",
+		}, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		integration := getIntegration(t)
+		if integration.BrokerURL != "mqtt://homeassistant.local:1883" || !integration.HasPassword ||
+			integration.TopicPrefix != "apocapoc" || integration.DiscoveryPrefix != "homeassistant" || integration.Timezone != "Europe/Madrid" {
+			t.Errorf("Unexpected integration: %+v", integration)
+		}
+	})
+
+	t.Run("Keeps the password when it is left out", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "PUT", "/api/v1/mqtt", UpdateMQTTIntegrationRequest{
+			BrokerURL:   "mqtts://homeassistant.local",
+			Username:    "apocapoc",
+			TopicPrefix: "home/habits",
+		}, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		integration := getIntegration(t)
+		if !integration.HasPassword || integration.TopicPrefix != "home/habits" {
+			t.Errorf("Unexpected integration: %+v", integration)
+		}
+	})
+
+	t.Run("Rejects invalid settings", func(t *testing.T) {
+		for _, body := range []UpdateMQTTIntegrationRequest{
+			{BrokerURL: "http://homeassistant.local"},
+			{BrokerURL: "mqtt://homeassistant.local", TopicPrefix: "habits/#"},
+			{BrokerURL: "mqtt://homeassistant.local", Timezone: "Nowhere/Special"},
+		} {
+			rr := makeRequest(t, *ts.Router, "PUT", "/api/v1/mqtt", body, token)
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("Expected status 400 for %+v, got %d", body, rr.Code)
+			}
+		}
+	})
+
+	t.Run("Removes the integration", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "DELETE", "/api/v1/mqtt", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		rr = makeRequest(t, *ts.Router, "DELETE", "/api/v1/mqtt", nil, token)
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 when nothing is set up, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Requires authentication", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/mqtt", nil, "")
+		if rr.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status 401, got %d", rr.Code)
+		}
+	})
+}
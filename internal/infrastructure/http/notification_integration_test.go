@@ -0,0 +1,193 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/domain/entities"
+)
+
+func TestNotificationPreferencesIntegration(t *testing.T) {
+	ts := setupTestServer(t)
+	token := registerAndLogin(t, *ts.Router, "prefs@example.com", "Password123!")
+
+	getPreferences := func(t *testing.T) queries.NotificationPreferencesDTO {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/users/me/notifications", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+		var preferences queries.NotificationPreferencesDTO
+		decodeResponse(t, rr, &preferences)
+		return preferences
+	}
+
+	t.Run("Everything is on by default", func(t *testing.T) {
+		preferences := getPreferences(t)
+		if !preferences[entities.NotificationReminders][entities.NotificationEmail] ||
+			!preferences[entities.NotificationReminders][entities.NotificationPush] ||
+			!preferences[entities.NotificationDigests][entities.NotificationEmail] ||
+			!preferences[entities.NotificationSecurity][entities.NotificationEmail] {
+			t.Errorf("Unexpected defaults: %+v", preferences)
+		}
+		if _, ok := preferences[entities.NotificationDigests][entities.NotificationPush]; ok {
+			t.Error("Expected digests to be listed for email only")
+		}
+	})
+
+	t.Run("Turns categories off per channel", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "PUT", "/api/v1/users/me/notifications", map[string]map[string]bool{
+			"reminders":    {"push": false},
+			"achievements": {"email": false},
+		}, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		preferences := getPreferences(t)
+		if preferences[entities.NotificationReminders][entities.NotificationPush] ||
+			!preferences[entities.NotificationReminders][entities.NotificationEmail] ||
+			preferences[entities.NotificationAchievements][entities.NotificationEmail] {
+			t.Errorf("Unexpected preferences: %+v", preferences)
+		}
+	})
+
+	t.Run("Rejects invalid preferences", func(t *testing.T) {
+		for _, body := range []map[string]map[string]bool{
+			{"security": {"email": false}},
+			{"marketing": {"email": false}},
+			{"digests": {"sms": false}},
+		} {
+			rr := makeRequest(t, *ts.Router, "PUT", "/api/v1/users/me/notifications", body, token)
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("Expected status 400 for %v, got %d", body, rr.Code)
+			}
+		}
+	})
+
+	t.Run("Requires authentication", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/users/me/notifications", nil, "")
+		if rr.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status 401, got %d", rr.Code)
+		}
+	})
+}
+
+func TestNotificationScheduleIntegration(t *testing.T) {
+	ts := setupTestServer(t)
+	token := registerAndLogin(t, *ts.Router, "schedule@example.com", "Password123!")
+
+	t.Run("Has no quiet hours or limit by default", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/users/me/notifications/schedule", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		var schedule queries.NotificationScheduleDTO
+		decodeResponse(t, rr, &schedule)
+		if schedule != (queries.NotificationScheduleDTO{Timezone: "UTC"}) {
+			t.Errorf("Unexpected default schedule: %+v", schedule)
+		}
+	})
+
+	t.Run("Sets quiet hours and an hourly limit", func(t *testing.T) {
+		want := queries.NotificationScheduleDTO{
+			QuietHoursStart: "22:00",
+			QuietHoursEnd:   "07:00",
+			Timezone:        "Europe/Madrid",
+			MaxPerHour:      3,
+		}
+		rr := makeRequest(t, *ts.Router, "PUT", "/api/v1/users/me/notifications/schedule", UpdateNotificationScheduleRequest{
+			QuietHoursStart: want.QuietHoursStart,
+			QuietHoursEnd:   want.QuietHoursEnd,
+			Timezone:        want.Timezone,
+			MaxPerHour:      want.MaxPerHour,
+		}, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		rr = makeRequest(t, *ts.Router, "GET", "/api/v1/users/me/notifications/schedule", nil, token)
+		var schedule queries.NotificationScheduleDTO
+		decodeResponse(t, rr, &schedule)
+		if schedule != want {
+			t.Errorf("Expected %+v, got %+v", want, schedule)
+		}
+	})
+
+	t.Run("Rejects invalid schedules", func(t *testing.T) {
+		for _, body := range []UpdateNotificationScheduleRequest{
+			{QuietHoursStart: "22:00"},
+			{QuietHoursStart: "22:00", QuietHoursEnd: "7am"},
+			{Timezone: "Nowhere/Special"},
+			{MaxPerHour: -2},
+		} {
+			rr := makeRequest(t, *ts.Router, "PUT", "/api/v1/users/me/notifications/schedule", body, token)
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("Expected status 400 for %+v, got %d", body, rr.Code)
+			}
+		}
+	})
+}
+
+func TestUnsubscribeIntegration(t *testing.T) {
+	ts := setupTestServer(t)
+	token := registerAndLogin(t, *ts.Router, "unsub@example.com", "Password123!")
+
+	var userID string
+	ts.DB.QueryRow("SELECT id FROM users WHERE email = ?", "unsub@example.com").Scan(&userID)
+	unsubscribeToken := url.QueryEscape(ts.UnsubscribeTokens.Sign(userID, entities.NotificationDigests))
+
+	t.Run("One-click POST turns the category off without login", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/v1/unsubscribe?token="+unsubscribeToken, strings.NewReader("List-Unsubscribe=One-Click"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+		(*ts.Router).ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		var resp UnsubscribeResponse
+		decodeResponse(t, rr, &resp)
+		if resp.Category != "digests" {
+			t.Errorf("Expected the digests category, got %q", resp.Category)
+		}
+
+		rr = makeRequest(t, *ts.Router, "GET", "/api/v1/users/me/notifications", nil, token)
+		var preferences queries.NotificationPreferencesDTO
+		decodeResponse(t, rr, &preferences)
+		if preferences[entities.NotificationDigests][entities.NotificationEmail] {
+			t.Error("Expected digest emails to be off")
+		}
+	})
+
+	t.Run("GET redirects to the app without unsubscribing", func(t *testing.T) {
+		achievementsToken := url.QueryEscape(ts.UnsubscribeTokens.Sign(userID, entities.NotificationAchievements))
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/unsubscribe?token="+achievementsToken, nil, "")
+		if rr.Code != http.StatusFound {
+			t.Fatalf("Expected status 302, got %d", rr.Code)
+		}
+		if location := rr.Header().Get("Location"); location != "http://localhost:3000/unsubscribe?token="+achievementsToken {
+			t.Errorf("Unexpected redirect: %s", location)
+		}
+
+		var count int
+		ts.DB.QueryRow("SELECT COUNT(*) FROM notification_preferences WHERE user_id = ? AND category = 'achievements'", userID).Scan(&count)
+		if count != 0 {
+			t.Error("Expected GET not to change preferences")
+		}
+	})
+
+	t.Run("Rejects tampered and security tokens", func(t *testing.T) {
+		securityToken := url.QueryEscape(ts.UnsubscribeTokens.Sign(userID, entities.NotificationSecurity))
+		for _, token := range []string{"tampered." + unsubscribeToken, securityToken, ""} {
+			rr := makeRequest(t, *ts.Router, "POST", "/api/v1/unsubscribe?token="+token, nil, "")
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("Expected status 400, got %d", rr.Code)
+			}
+		}
+	})
+}
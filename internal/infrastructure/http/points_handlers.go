@@ -0,0 +1,263 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"apocapoc-api/internal/application/commands"
+	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/i18n"
+	"apocapoc-api/internal/shared/errors"
+	"apocapoc-api/internal/shared/pagination"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type PointsHandlers struct {
+	getPointsSummaryHandler     *queries.GetPointsSummaryHandler
+	getPointTransactionsHandler *queries.GetPointTransactionsHandler
+	getRewardsHandler           *queries.GetRewardsHandler
+	createRewardHandler         *commands.CreateRewardHandler
+	archiveRewardHandler        *commands.ArchiveRewardHandler
+	redeemRewardHandler         *commands.RedeemRewardHandler
+	translator                  *i18n.Translator
+}
+
+func NewPointsHandlers(
+	getPointsSummaryHandler *queries.GetPointsSummaryHandler,
+	getPointTransactionsHandler *queries.GetPointTransactionsHandler,
+	getRewardsHandler *queries.GetRewardsHandler,
+	createRewardHandler *commands.CreateRewardHandler,
+	archiveRewardHandler *commands.ArchiveRewardHandler,
+	redeemRewardHandler *commands.RedeemRewardHandler,
+	translator *i18n.Translator,
+) *PointsHandlers {
+	return &PointsHandlers{
+		getPointsSummaryHandler:     getPointsSummaryHandler,
+		getPointTransactionsHandler: getPointTransactionsHandler,
+		getRewardsHandler:           getRewardsHandler,
+		createRewardHandler:         createRewardHandler,
+		archiveRewardHandler:        archiveRewardHandler,
+		redeemRewardHandler:         redeemRewardHandler,
+		translator:                  translator,
+	}
+}
+
+// GetPoints godoc
+// @Summary Get points summary
+// @Description Get the authenticated user's XP, spendable balance and level. XP only counts completions, so redeeming rewards never lowers the level.
+// @Tags points
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} queries.PointsSummaryDTO
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /points [get]
+func (h *PointsHandlers) GetPoints(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	summary, err := h.getPointsSummaryHandler.Handle(r.Context(), queries.GetPointsSummaryQuery{UserID: userID})
+	if err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_get_points")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, summary)
+}
+
+// GetPointTransactions godoc
+// @Summary Get points history
+// @Description Get the authenticated user's points ledger, newest first: completion credits, their reversals and reward redemptions
+// @Tags points
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 50, max: 100)"
+// @Success 200 {object} queries.GetPointTransactionsResult
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /points/transactions [get]
+func (h *PointsHandlers) GetPointTransactions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	page := 1
+	pageSize := 50
+
+	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && p > 0 {
+		page = p
+	}
+	if ps, err := strconv.Atoi(r.URL.Query().Get("page_size")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	result, err := h.getPointTransactionsHandler.Handle(r.Context(), queries.GetPointTransactionsQuery{
+		UserID:           userID,
+		PaginationParams: pagination.NewParams(page, pageSize),
+	})
+	if err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_get_points")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// GetRewards godoc
+// @Summary Get rewards
+// @Description Get the authenticated user's rewards, cheapest first, flagging the ones affordable with the current balance
+// @Tags rewards
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} queries.RewardDTO
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /rewards [get]
+func (h *PointsHandlers) GetRewards(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	rewards, err := h.getRewardsHandler.Handle(r.Context(), queries.GetRewardsQuery{UserID: userID})
+	if err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_get_rewards")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, rewards)
+}
+
+// CreateReward godoc
+// @Summary Create a reward
+// @Description Create a reward that can be redeemed with points
+// @Tags rewards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateRewardRequest true "Reward data"
+// @Success 201 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /rewards [post]
+func (h *PointsHandlers) CreateReward(w http.ResponseWriter, r *http.Request) {
+	var req CreateRewardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	rewardID, err := h.createRewardHandler.Handle(r.Context(), commands.CreateRewardCommand{
+		UserID: userID,
+		Name:   req.Name,
+		Cost:   req.Cost,
+	})
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_reward")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_create_reward")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, map[string]string{"id": rewardID})
+}
+
+// ArchiveReward godoc
+// @Summary Archive a reward
+// @Description Remove a reward from the shop. Past redemptions stay in the points history.
+// @Tags rewards
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Reward ID"
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /rewards/{id} [delete]
+func (h *PointsHandlers) ArchiveReward(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	err := h.archiveRewardHandler.Handle(r.Context(), commands.ArchiveRewardCommand{
+		RewardID: chi.URLParam(r, "id"),
+		UserID:   userID,
+	})
+	if err != nil {
+		if err == errors.ErrNotFound {
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "reward_not_found")
+			return
+		}
+		if err == errors.ErrUnauthorized {
+			respondErrorI18n(w, r, h.translator, http.StatusForbidden, "access_denied")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_archive_reward")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"status": "archived"})
+}
+
+// RedeemReward godoc
+// @Summary Redeem a reward
+// @Description Spend points on a reward. The cost is debited from the balance and recorded in the points history.
+// @Tags rewards
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Reward ID"
+// @Success 200 {object} queries.PointsSummaryDTO
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "Not enough points"
+// @Failure 500 {object} ErrorResponse
+// @Router /rewards/{id}/redeem [post]
+func (h *PointsHandlers) RedeemReward(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	balance, err := h.redeemRewardHandler.Handle(r.Context(), commands.RedeemRewardCommand{
+		RewardID: chi.URLParam(r, "id"),
+		UserID:   userID,
+	})
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "reward_not_found")
+		case errors.ErrUnauthorized:
+			respondErrorI18n(w, r, h.translator, http.StatusForbidden, "access_denied")
+		case errors.ErrInsufficientPoints:
+			respondErrorI18n(w, r, h.translator, http.StatusConflict, "insufficient_points")
+		default:
+			respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_redeem_reward")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, queries.NewPointsSummaryDTO(balance))
+}
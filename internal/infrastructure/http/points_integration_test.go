@@ -0,0 +1,131 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/application/queries"
+)
+
+func TestPointsAndRewardsFlow(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.Close()
+
+	token := registerAndLogin(t, *ts.Router, "points@example.com", "Password123!")
+
+	habitBody := CreateHabitRequest{
+		Name:       "Running",
+		Type:       "BOOLEAN",
+		Frequency:  "DAILY",
+		Difficulty: "HARD",
+	}
+	rr := makeRequest(t, *ts.Router, "POST", "/api/v1/habits", habitBody, token)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	var habitResp map[string]string
+	decodeResponse(t, rr, &habitResp)
+	habitID := habitResp["id"]
+
+	today := time.Now().UTC().Format("2006-01-02")
+	makeRequest(t, *ts.Router, "POST", "/api/v1/habits/"+habitID+"/mark", MarkHabitRequest{ScheduledDate: today}, token)
+
+	t.Run("Credits points weighted by difficulty", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/points", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		var summary queries.PointsSummaryDTO
+		decodeResponse(t, rr, &summary)
+
+		if summary.XP != 20 || summary.Balance != 20 || summary.Level != 1 || summary.XPToNextLevel != 80 {
+			t.Errorf("Expected 20 XP at level 1 with 80 to go, got %+v", summary)
+		}
+	})
+
+	var rewardID string
+	t.Run("Creates a reward", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "POST", "/api/v1/rewards", CreateRewardRequest{Name: "Coffee", Cost: 15}, token)
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+		var resp map[string]string
+		decodeResponse(t, rr, &resp)
+		rewardID = resp["id"]
+
+		rr = makeRequest(t, *ts.Router, "POST", "/api/v1/rewards", CreateRewardRequest{Name: "Free", Cost: 0}, token)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for a zero cost, got %d", rr.Code)
+		}
+
+		rr = makeRequest(t, *ts.Router, "GET", "/api/v1/rewards", nil, token)
+		var rewards []queries.RewardDTO
+		decodeResponse(t, rr, &rewards)
+		if len(rewards) != 1 || !rewards[0].Affordable {
+			t.Errorf("Expected one affordable reward, got %+v", rewards)
+		}
+	})
+
+	t.Run("Redeems a reward", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "POST", "/api/v1/rewards/"+rewardID+"/redeem", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		var summary queries.PointsSummaryDTO
+		decodeResponse(t, rr, &summary)
+		if summary.Balance != 5 || summary.XP != 20 {
+			t.Errorf("Expected balance 5 with XP unchanged, got %+v", summary)
+		}
+
+		rr = makeRequest(t, *ts.Router, "POST", "/api/v1/rewards/"+rewardID+"/redeem", nil, token)
+		if rr.Code != http.StatusConflict {
+			t.Errorf("Expected status 409 without enough points, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Unmarking reverses the credit", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "DELETE", "/api/v1/habits/"+habitID+"/entries/"+today, nil, token)
+		if rr.Code != http.StatusOK && rr.Code != http.StatusNoContent {
+			t.Fatalf("Expected habit to be unmarked, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		rr = makeRequest(t, *ts.Router, "GET", "/api/v1/points", nil, token)
+		var summary queries.PointsSummaryDTO
+		decodeResponse(t, rr, &summary)
+		if summary.XP != 0 || summary.Balance != -15 {
+			t.Errorf("Expected XP 0 and balance -15 after the reversal, got %+v", summary)
+		}
+	})
+
+	t.Run("Lists the transaction history", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/points/transactions", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		var result queries.GetPointTransactionsResult
+		decodeResponse(t, rr, &result)
+
+		if result.Pagination.TotalItems != 3 || len(result.Transactions) != 3 {
+			t.Fatalf("Expected 3 transactions, got %+v", result)
+		}
+		if result.Transactions[0].Type != "REVERSAL" || result.Transactions[0].Points != -20 {
+			t.Errorf("Expected the reversal first, got %+v", result.Transactions[0])
+		}
+	})
+
+	t.Run("Archives a reward", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "DELETE", "/api/v1/rewards/"+rewardID, nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", rr.Code)
+		}
+
+		rr = makeRequest(t, *ts.Router, "POST", "/api/v1/rewards/"+rewardID+"/redeem", nil, token)
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 for an archived reward, got %d", rr.Code)
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"apocapoc-api/internal/application/commands"
+	"apocapoc-api/internal/i18n"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type PushHandlers struct {
+	registerPushSubscriptionHandler   *commands.RegisterPushSubscriptionHandler
+	unregisterPushSubscriptionHandler *commands.UnregisterPushSubscriptionHandler
+	vapidPublicKey                    string
+	translator                        *i18n.Translator
+}
+
+// NewPushHandlers takes an empty vapidPublicKey when Web Push is not
+// configured.
+func NewPushHandlers(
+	registerPushSubscriptionHandler *commands.RegisterPushSubscriptionHandler,
+	unregisterPushSubscriptionHandler *commands.UnregisterPushSubscriptionHandler,
+	vapidPublicKey string,
+	translator *i18n.Translator,
+) *PushHandlers {
+	return &PushHandlers{
+		registerPushSubscriptionHandler:   registerPushSubscriptionHandler,
+		unregisterPushSubscriptionHandler: unregisterPushSubscriptionHandler,
+		vapidPublicKey:                    vapidPublicKey,
+		translator:                        translator,
+	}
+}
+
+// GetVAPIDPublicKey godoc
+// @Summary Get the VAPID public key
+// @Description Get the application server key browsers need to create a push subscription
+// @Tags push
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} VAPIDPublicKeyResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse "Push notifications are not configured"
+// @Router /push/vapid-public-key [get]
+func (h *PushHandlers) GetVAPIDPublicKey(w http.ResponseWriter, r *http.Request) {
+	if h.vapidPublicKey == "" {
+		respondErrorI18n(w, r, h.translator, http.StatusNotFound, "push_not_configured")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, VAPIDPublicKeyResponse{PublicKey: h.vapidPublicKey})
+}
+
+// RegisterPushSubscription godoc
+// @Summary Register a push subscription
+// @Description Register the current device's browser push subscription so it receives reminders. Registering the same endpoint again updates it.
+// @Tags push
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body PushSubscriptionRequest true "Browser push subscription"
+// @Success 201 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse "Push notifications are not configured"
+// @Failure 500 {object} ErrorResponse
+// @Router /push/subscriptions [post]
+func (h *PushHandlers) RegisterPushSubscription(w http.ResponseWriter, r *http.Request) {
+	if h.vapidPublicKey == "" {
+		respondErrorI18n(w, r, h.translator, http.StatusNotFound, "push_not_configured")
+		return
+	}
+
+	var req PushSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	err := h.registerPushSubscriptionHandler.Handle(r.Context(), commands.RegisterPushSubscriptionCommand{
+		UserID:    userID,
+		Endpoint:  req.Endpoint,
+		P256dh:    req.Keys.P256dh,
+		Auth:      req.Keys.Auth,
+		UserAgent: r.UserAgent(),
+	})
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_push_subscription")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_register_push_subscription")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, map[string]string{"status": "registered"})
+}
+
+// UnregisterPushSubscription godoc
+// @Summary Unregister a push subscription
+// @Description Stop sending push notifications to a device
+// @Tags push
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body UnregisterPushSubscriptionRequest true "Subscription endpoint"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /push/subscriptions [delete]
+func (h *PushHandlers) UnregisterPushSubscription(w http.ResponseWriter, r *http.Request) {
+	var req UnregisterPushSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	err := h.unregisterPushSubscriptionHandler.Handle(r.Context(), commands.UnregisterPushSubscriptionCommand{
+		UserID:   userID,
+		Endpoint: req.Endpoint,
+	})
+	if err != nil {
+		if err == errors.ErrNotFound {
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "push_subscription_not_found")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_unregister_push_subscription")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"status": "unregistered"})
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+)
+
+const testVAPIDPublicKey = "BP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A8"
+
+func TestPushSubscriptionsFlow(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.Close()
+
+	token := registerAndLogin(t, *ts.Router, "push@example.com", "Password123!")
+	otherToken := registerAndLogin(t, *ts.Router, "other-push@example.com", "Password123!")
+
+	var subscription PushSubscriptionRequest
+	subscription.Endpoint = "https://push.example.com/send/device-1"
+	subscription.Keys.P256dh = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	subscription.Keys.Auth = "BTBZMqHH6r4Tts7J_aSIgg"
+
+	t.Run("Exposes the VAPID public key", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/push/vapid-public-key", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		var resp VAPIDPublicKeyResponse
+		decodeResponse(t, rr, &resp)
+		if resp.PublicKey != testVAPIDPublicKey {
+			t.Errorf("Unexpected public key %q", resp.PublicKey)
+		}
+	})
+
+	t.Run("Registers a device", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "POST", "/api/v1/push/subscriptions", subscription, token)
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		rr = makeRequest(t, *ts.Router, "POST", "/api/v1/push/subscriptions", subscription, token)
+		if rr.Code != http.StatusCreated {
+			t.Errorf("Expected registering the same device again to succeed, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Rejects invalid subscriptions", func(t *testing.T) {
+		invalid := subscription
+		invalid.Endpoint = "http://push.example.com/send/device-1"
+		rr := makeRequest(t, *ts.Router, "POST", "/api/v1/push/subscriptions", invalid, token)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for a plain HTTP endpoint, got %d", rr.Code)
+		}
+
+		invalid = subscription
+		invalid.Keys.Auth = ""
+		rr = makeRequest(t, *ts.Router, "POST", "/api/v1/push/subscriptions", invalid, token)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 without an auth secret, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Unregisters only the owner's device", func(t *testing.T) {
+		body := UnregisterPushSubscriptionRequest{Endpoint: subscription.Endpoint}
+
+		rr := makeRequest(t, *ts.Router, "DELETE", "/api/v1/push/subscriptions", body, otherToken)
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 for another user's device, got %d", rr.Code)
+		}
+
+		rr = makeRequest(t, *ts.Router, "DELETE", "/api/v1/push/subscriptions", body, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		rr = makeRequest(t, *ts.Router, "DELETE", "/api/v1/push/subscriptions", body, token)
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 after unregistering, got %d", rr.Code)
+		}
+	})
+}
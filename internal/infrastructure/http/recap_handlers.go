@@ -0,0 +1,257 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"apocapoc-api/internal/application/commands"
+	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/i18n"
+	"apocapoc-api/internal/infrastructure/card"
+	"apocapoc-api/internal/shared/constants"
+	"apocapoc-api/internal/shared/errors"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type RecapHandlers struct {
+	getYearRecapHandler         *queries.GetYearRecapHandler
+	getSharedYearRecapHandler   *queries.GetSharedYearRecapHandler
+	shareYearRecapHandler       *commands.ShareYearRecapHandler
+	revokeYearRecapShareHandler *commands.RevokeYearRecapShareHandler
+	translator                  *i18n.Translator
+}
+
+func NewRecapHandlers(
+	getYearRecapHandler *queries.GetYearRecapHandler,
+	getSharedYearRecapHandler *queries.GetSharedYearRecapHandler,
+	shareYearRecapHandler *commands.ShareYearRecapHandler,
+	revokeYearRecapShareHandler *commands.RevokeYearRecapShareHandler,
+	translator *i18n.Translator,
+) *RecapHandlers {
+	return &RecapHandlers{
+		getYearRecapHandler:         getYearRecapHandler,
+		getSharedYearRecapHandler:   getSharedYearRecapHandler,
+		shareYearRecapHandler:       shareYearRecapHandler,
+		revokeYearRecapShareHandler: revokeYearRecapShareHandler,
+		translator:                  translator,
+	}
+}
+
+// GetYearRecap godoc
+// @Summary Get year in review
+// @Description Get the recap of a calendar year: total completions, active days, longest streaks, most consistent habit, busiest month, monthly breakdown and a heatmap. The current year is summarised up to today.
+// @Tags recap
+// @Produce json
+// @Security BearerAuth
+// @Param year path int true "Year (e.g., 2025)"
+// @Param timezone query string false "IANA timezone used to determine today (default: UTC)"
+// @Success 200 {object} queries.YearRecapDTO
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /recap/{year} [get]
+func (h *RecapHandlers) GetYearRecap(w http.ResponseWriter, r *http.Request) {
+	recap, ok := h.loadYearRecap(w, r)
+	if !ok {
+		return
+	}
+
+	respondJSON(w, http.StatusOK, recap)
+}
+
+// GetYearRecapCard godoc
+// @Summary Get year in review card
+// @Description Get the year recap rendered as an SVG summary card
+// @Tags recap
+// @Produce image/svg+xml
+// @Security BearerAuth
+// @Param year path int true "Year (e.g., 2025)"
+// @Param timezone query string false "IANA timezone used to determine today (default: UTC)"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /recap/{year}/card [get]
+func (h *RecapHandlers) GetYearRecapCard(w http.ResponseWriter, r *http.Request) {
+	recap, ok := h.loadYearRecap(w, r)
+	if !ok {
+		return
+	}
+
+	h.respondCard(w, r, recap, "private, max-age=3600")
+}
+
+// ShareYearRecap godoc
+// @Summary Share year in review card
+// @Description Create a public link to the SVG recap card of a year, or return the existing one. The link does not require authentication and does not reveal the account.
+// @Tags recap
+// @Produce json
+// @Security BearerAuth
+// @Param year path int true "Year (e.g., 2025)"
+// @Success 200 {object} RecapShareResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /recap/{year}/share [post]
+func (h *RecapHandlers) ShareYearRecap(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	year, err := strconv.Atoi(chi.URLParam(r, "year"))
+	if err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_year")
+		return
+	}
+
+	share, err := h.shareYearRecapHandler.Handle(r.Context(), commands.ShareYearRecapCommand{
+		UserID: userID,
+		Year:   year,
+		Today:  time.Now().UTC(),
+	})
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_year")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_share_recap")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, RecapShareResponse{
+		Year:    share.Year,
+		Token:   share.Token,
+		CardURL: "/api/v1/shared/recaps/" + share.Token,
+	})
+}
+
+// RevokeYearRecapShare godoc
+// @Summary Stop sharing year in review card
+// @Description Disable the public link to the recap card of a year
+// @Tags recap
+// @Produce json
+// @Security BearerAuth
+// @Param year path int true "Year (e.g., 2025)"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /recap/{year}/share [delete]
+func (h *RecapHandlers) RevokeYearRecapShare(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	year, err := strconv.Atoi(chi.URLParam(r, "year"))
+	if err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_year")
+		return
+	}
+
+	err = h.revokeYearRecapShareHandler.Handle(r.Context(), commands.RevokeYearRecapShareCommand{
+		UserID: userID,
+		Year:   year,
+	})
+	if err != nil {
+		if err == errors.ErrNotFound {
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "recap_share_not_found")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_share_recap")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"status": "revoked"})
+}
+
+// GetSharedYearRecapCard godoc
+// @Summary Get shared year in review card
+// @Description Public endpoint serving the SVG recap card behind a share link
+// @Tags recap
+// @Produce image/svg+xml
+// @Param token path string true "Share token"
+// @Success 200 {file} file
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /shared/recaps/{token} [get]
+func (h *RecapHandlers) GetSharedYearRecapCard(w http.ResponseWriter, r *http.Request) {
+	recap, err := h.getSharedYearRecapHandler.Handle(r.Context(), queries.GetSharedYearRecapQuery{
+		Token: chi.URLParam(r, "token"),
+		Today: time.Now().UTC(),
+	})
+	if err != nil {
+		if err == errors.ErrNotFound {
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "recap_share_not_found")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_get_recap")
+		return
+	}
+
+	// Shared cards are revalidated on every use, so a revoked share stops
+	// being served at once instead of lingering in caches.
+	h.respondCard(w, r, recap, "private, no-cache")
+}
+
+func (h *RecapHandlers) loadYearRecap(w http.ResponseWriter, r *http.Request) (*queries.YearRecapDTO, bool) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return nil, false
+	}
+
+	year, err := strconv.Atoi(chi.URLParam(r, "year"))
+	if err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_year")
+		return nil, false
+	}
+
+	timezone := r.URL.Query().Get("timezone")
+	if timezone == "" {
+		timezone = "UTC"
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_timezone")
+		return nil, false
+	}
+
+	today := time.Now().In(loc)
+
+	recap, err := h.getYearRecapHandler.Handle(r.Context(), queries.GetYearRecapQuery{
+		UserID: userID,
+		Year:   year,
+		Today:  time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_year")
+			return nil, false
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_get_recap")
+		return nil, false
+	}
+
+	return recap, true
+}
+
+func (h *RecapHandlers) respondCard(w http.ResponseWriter, r *http.Request, recap *queries.YearRecapDTO, cacheControl string) {
+	svg, err := card.RenderYearRecap(constants.AppName, recap)
+	if err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_get_recap")
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Cache-Control", cacheControl)
+	w.WriteHeader(http.StatusOK)
+	w.Write(svg)
+}
@@ -0,0 +1,113 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/application/queries"
+)
+
+func TestYearRecapFlow(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.Close()
+
+	token := registerAndLogin(t, *ts.Router, "recap@example.com", "Password123!")
+
+	rr := makeRequest(t, *ts.Router, "POST", "/api/v1/habits", CreateHabitRequest{
+		Name:      "Journaling",
+		Type:      "BOOLEAN",
+		Frequency: "DAILY",
+	}, token)
+	var habitResp map[string]string
+	decodeResponse(t, rr, &habitResp)
+
+	today := time.Now().UTC()
+	rr = makeRequest(t, *ts.Router, "POST", "/api/v1/habits/"+habitResp["id"]+"/mark", MarkHabitRequest{ScheduledDate: today.Format("2006-01-02")}, token)
+	if rr.Code != http.StatusOK && rr.Code != http.StatusCreated {
+		t.Fatalf("Expected habit to be marked, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+
+	year := strconv.Itoa(today.Year())
+
+	t.Run("Returns the recap of the current year", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/recap/"+year, nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		var recap queries.YearRecapDTO
+		decodeResponse(t, rr, &recap)
+
+		if recap.TotalCompletions != 1 || recap.ActiveDays != 1 {
+			t.Errorf("Expected 1 completion on 1 day, got %+v", recap)
+		}
+		if len(recap.LongestStreaks) != 1 || recap.LongestStreaks[0].HabitName != "Journaling" {
+			t.Errorf("Unexpected longest streaks: %+v", recap.LongestStreaks)
+		}
+		if recap.BusiestMonth == nil || recap.BusiestMonth.Month != int(today.Month()) {
+			t.Errorf("Expected the current month to be the busiest, got %+v", recap.BusiestMonth)
+		}
+	})
+
+	t.Run("Rejects a future year", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/recap/"+strconv.Itoa(today.Year()+1), nil, token)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Renders the card", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/recap/"+year+"/card", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+		if rr.Header().Get("Content-Type") != "image/svg+xml" {
+			t.Errorf("Expected an SVG, got %s", rr.Header().Get("Content-Type"))
+		}
+		if !strings.Contains(rr.Body.String(), "Journaling") {
+			t.Error("Expected the card to mention the habit")
+		}
+	})
+
+	var share RecapShareResponse
+	t.Run("Shares the card publicly", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "POST", "/api/v1/recap/"+year+"/share", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+		decodeResponse(t, rr, &share)
+
+		rr = makeRequest(t, *ts.Router, "POST", "/api/v1/recap/"+year+"/share", nil, token)
+		var again RecapShareResponse
+		decodeResponse(t, rr, &again)
+		if again.Token != share.Token {
+			t.Errorf("Expected sharing twice to return the same link")
+		}
+
+		rr = makeRequest(t, *ts.Router, "GET", share.CardURL, nil, "")
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected the public card to be served, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+		if strings.Contains(rr.Body.String(), "recap@example.com") {
+			t.Error("Expected the public card not to expose the account")
+		}
+		if cacheControl := rr.Header().Get("Cache-Control"); cacheControl != "private, no-cache" {
+			t.Errorf("Expected the public card to be revalidated, got %q", cacheControl)
+		}
+	})
+
+	t.Run("Revokes the public link", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "DELETE", "/api/v1/recap/"+year+"/share", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		rr = makeRequest(t, *ts.Router, "GET", share.CardURL, nil, "")
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 after revoking, got %d", rr.Code)
+		}
+	})
+}
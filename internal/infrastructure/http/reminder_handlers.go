@@ -0,0 +1,225 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"apocapoc-api/internal/application/commands"
+	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/i18n"
+	"apocapoc-api/internal/shared/errors"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ReminderHandlers struct {
+	getHabitRemindersHandler   *queries.GetHabitRemindersHandler
+	createHabitReminderHandler *commands.CreateHabitReminderHandler
+	updateHabitReminderHandler *commands.UpdateHabitReminderHandler
+	deleteHabitReminderHandler *commands.DeleteHabitReminderHandler
+	translator                 *i18n.Translator
+}
+
+func NewReminderHandlers(
+	getHabitRemindersHandler *queries.GetHabitRemindersHandler,
+	createHabitReminderHandler *commands.CreateHabitReminderHandler,
+	updateHabitReminderHandler *commands.UpdateHabitReminderHandler,
+	deleteHabitReminderHandler *commands.DeleteHabitReminderHandler,
+	translator *i18n.Translator,
+) *ReminderHandlers {
+	return &ReminderHandlers{
+		getHabitRemindersHandler:   getHabitRemindersHandler,
+		createHabitReminderHandler: createHabitReminderHandler,
+		updateHabitReminderHandler: updateHabitReminderHandler,
+		deleteHabitReminderHandler: deleteHabitReminderHandler,
+		translator:                 translator,
+	}
+}
+
+// GetHabitReminders godoc
+// @Summary Get habit reminders
+// @Description Get the reminder rules of a habit
+// @Tags reminders
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Habit ID"
+// @Success 200 {array} queries.HabitReminderDTO
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /habits/{id}/reminders [get]
+func (h *ReminderHandlers) GetHabitReminders(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	reminders, err := h.getHabitRemindersHandler.Handle(r.Context(), queries.GetHabitRemindersQuery{
+		HabitID: chi.URLParam(r, "id"),
+		UserID:  userID,
+	})
+	if err != nil {
+		if err == errors.ErrNotFound {
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "habit_not_found")
+			return
+		}
+		if err == errors.ErrUnauthorized {
+			respondErrorI18n(w, r, h.translator, http.StatusForbidden, "access_denied")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_get_reminders")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, reminders)
+}
+
+// CreateHabitReminder godoc
+// @Summary Create a habit reminder
+// @Description Remind the user at a time of day in their timezone, only on the days the habit is scheduled and only while it has not been completed
+// @Tags reminders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Habit ID"
+// @Param request body CreateHabitReminderRequest true "Reminder rule (time as HH:MM)"
+// @Success 201 {object} queries.HabitReminderDTO
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /habits/{id}/reminders [post]
+func (h *ReminderHandlers) CreateHabitReminder(w http.ResponseWriter, r *http.Request) {
+	var req CreateHabitReminderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	reminder, err := h.createHabitReminderHandler.Handle(r.Context(), commands.CreateHabitReminderCommand{
+		HabitID:   chi.URLParam(r, "id"),
+		UserID:    userID,
+		TimeOfDay: req.Time,
+		Timezone:  req.Timezone,
+	})
+	if err != nil {
+		switch err {
+		case errors.ErrInvalidInput:
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_reminder")
+		case errors.ErrNotFound:
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "habit_not_found")
+		case errors.ErrUnauthorized:
+			respondErrorI18n(w, r, h.translator, http.StatusForbidden, "access_denied")
+		default:
+			respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_create_reminder")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, queries.NewHabitReminderDTO(reminder))
+}
+
+// UpdateHabitReminder godoc
+// @Summary Update a habit reminder
+// @Description Change the time or timezone of a reminder, or enable and disable it. Omitted fields are left unchanged.
+// @Tags reminders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Habit ID"
+// @Param reminderId path string true "Reminder ID"
+// @Param request body UpdateHabitReminderRequest true "Reminder changes"
+// @Success 200 {object} queries.HabitReminderDTO
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /habits/{id}/reminders/{reminderId} [put]
+func (h *ReminderHandlers) UpdateHabitReminder(w http.ResponseWriter, r *http.Request) {
+	var req UpdateHabitReminderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	reminder, err := h.updateHabitReminderHandler.Handle(r.Context(), commands.UpdateHabitReminderCommand{
+		ReminderID: chi.URLParam(r, "reminderId"),
+		HabitID:    chi.URLParam(r, "id"),
+		UserID:     userID,
+		TimeOfDay:  req.Time,
+		Timezone:   req.Timezone,
+		Enabled:    req.Enabled,
+	})
+	if err != nil {
+		switch err {
+		case errors.ErrInvalidInput:
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_reminder")
+		case errors.ErrNotFound:
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "reminder_not_found")
+		case errors.ErrUnauthorized:
+			respondErrorI18n(w, r, h.translator, http.StatusForbidden, "access_denied")
+		default:
+			respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_update_reminder")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, queries.NewHabitReminderDTO(reminder))
+}
+
+// DeleteHabitReminder godoc
+// @Summary Delete a habit reminder
+// @Description Delete a reminder rule of a habit
+// @Tags reminders
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Habit ID"
+// @Param reminderId path string true "Reminder ID"
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /habits/{id}/reminders/{reminderId} [delete]
+func (h *ReminderHandlers) DeleteHabitReminder(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	err := h.deleteHabitReminderHandler.Handle(r.Context(), commands.DeleteHabitReminderCommand{
+		ReminderID: chi.URLParam(r, "reminderId"),
+		HabitID:    chi.URLParam(r, "id"),
+		UserID:     userID,
+	})
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "reminder_not_found")
+		case errors.ErrUnauthorized:
+			respondErrorI18n(w, r, h.translator, http.StatusForbidden, "access_denied")
+		default:
+			respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_delete_reminder")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"apocapoc-api/internal/application/queries"
+)
+
+func TestHabitRemindersFlow(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.Close()
+
+	token := registerAndLogin(t, *ts.Router, "reminders@example.com", "Password123!")
+	otherToken := registerAndLogin(t, *ts.Router, "other-reminders@example.com", "Password123!")
+
+	rr := makeRequest(t, *ts.Router, "POST", "/api/v1/habits", CreateHabitRequest{Name: "Stretch", Type: "BOOLEAN", Frequency: "DAILY"}, token)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	var habitResp map[string]string
+	decodeResponse(t, rr, &habitResp)
+	remindersPath := "/api/v1/habits/" + habitResp["id"] + "/reminders"
+
+	var reminder queries.HabitReminderDTO
+	t.Run("Creates a reminder", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "POST", remindersPath, CreateHabitReminderRequest{Time: "07:45", Timezone: "Europe/Madrid"}, token)
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+		decodeResponse(t, rr, &reminder)
+
+		if reminder.ID == "" || reminder.Time != "07:45" || reminder.Timezone != "Europe/Madrid" || !reminder.Enabled {
+			t.Errorf("Unexpected reminder: %+v", reminder)
+		}
+	})
+
+	t.Run("Rejects invalid rules", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "POST", remindersPath, CreateHabitReminderRequest{Time: "25:00", Timezone: "UTC"}, token)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for an invalid time, got %d", rr.Code)
+		}
+
+		rr = makeRequest(t, *ts.Router, "POST", remindersPath, CreateHabitReminderRequest{Time: "08:00"}, token)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 without a timezone, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Hides reminders from other users", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", remindersPath, nil, otherToken)
+		if rr.Code != http.StatusForbidden {
+			t.Errorf("Expected status 403, got %d", rr.Code)
+		}
+
+		rr = makeRequest(t, *ts.Router, "DELETE", remindersPath+"/"+reminder.ID, nil, otherToken)
+		if rr.Code != http.StatusForbidden {
+			t.Errorf("Expected status 403, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Disables a reminder", func(t *testing.T) {
+		disabled := false
+		rr := makeRequest(t, *ts.Router, "PUT", remindersPath+"/"+reminder.ID, UpdateHabitReminderRequest{Enabled: &disabled}, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		rr = makeRequest(t, *ts.Router, "GET", remindersPath, nil, token)
+		var reminders []queries.HabitReminderDTO
+		decodeResponse(t, rr, &reminders)
+		if len(reminders) != 1 || reminders[0].Enabled || reminders[0].Time != "07:45" {
+			t.Errorf("Expected the disabled reminder to keep its time, got %+v", reminders)
+		}
+	})
+
+	t.Run("Deletes a reminder", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "DELETE", remindersPath+"/"+reminder.ID, nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		rr = makeRequest(t, *ts.Router, "DELETE", remindersPath+"/"+reminder.ID, nil, token)
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 for a deleted reminder, got %d", rr.Code)
+		}
+	})
+}
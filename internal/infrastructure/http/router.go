@@ -17,7 +17,7 @@ import (
 	_ "apocapoc-api/docs"
 )
 
-func NewRouter(appURL string, habitHandlers *HabitHandlers, authHandlers *AuthHandlers, statsHandlers *StatsHandlers, healthHandlers *HealthHandlers, userHandlers *UserHandlers, exportHandlers *ExportHandlers, jwtService *auth.JWTService, translator *i18n.Translator) *chi.Mux {
+func NewRouter(appURL string, habitHandlers *HabitHandlers, authHandlers *AuthHandlers, statsHandlers *StatsHandlers, healthHandlers *HealthHandlers, userHandlers *UserHandlers, exportHandlers *ExportHandlers, achievementHandlers *AchievementHandlers, pointsHandlers *PointsHandlers, recapHandlers *RecapHandlers, reminderHandlers *ReminderHandlers, pushHandlers *PushHandlers, webhookHandlers *WebhookHandlers, adminHandlers *AdminHandlers, unsubscribeHandlers *UnsubscribeHandlers, chatHandlers *ChatHandlers, mqttHandlers *MQTTHandlers, jwtService *auth.JWTService, adminEmails []string, translator *i18n.Translator) *chi.Mux {
 	r := chi.NewRouter()
 
 	r.Use(logger.Middleware)
@@ -66,18 +66,123 @@ func NewRouter(appURL string, habitHandlers *HabitHandlers, authHandlers *AuthHa
 		r.Get("/{id}/entries", habitHandlers.GetHabitEntries)
 		r.Post("/{id}/mark", habitHandlers.MarkHabit)
 		r.Delete("/{id}/entries/{date}", habitHandlers.UnmarkHabit)
+		r.Get("/{id}/reminders", reminderHandlers.GetHabitReminders)
+		r.Post("/{id}/reminders", reminderHandlers.CreateHabitReminder)
+		r.Put("/{id}/reminders/{reminderId}", reminderHandlers.UpdateHabitReminder)
+		r.Delete("/{id}/reminders/{reminderId}", reminderHandlers.DeleteHabitReminder)
 	})
 
 	r.Route("/api/v1/stats", func(r chi.Router) {
 		r.Use(AuthMiddleware(jwtService))
 		r.Use(RateLimitByUser(jwtService, 100, 1*time.Minute))
+		r.Get("/overview", statsHandlers.GetDashboardStats)
+		r.Get("/heatmap", statsHandlers.GetHeatmap)
+		r.Get("/correlations", statsHandlers.GetCorrelations)
+		r.Get("/behaviour", statsHandlers.GetBehaviourInsights)
 		r.Get("/habits/{id}", statsHandlers.GetHabitStats)
+		r.Get("/habits/{id}/heatmap", statsHandlers.GetHabitHeatmap)
+		r.Get("/habits/{id}/series", statsHandlers.GetHabitSeries)
+		r.Get("/habits/{id}/behaviour", statsHandlers.GetHabitBehaviourInsights)
 	})
 
 	r.Route("/api/v1/users", func(r chi.Router) {
 		r.Use(AuthMiddleware(jwtService))
 		r.Use(RateLimitByUser(jwtService, 100, 1*time.Minute))
 		r.Delete("/me", userHandlers.DeleteAccount)
+		r.Get("/me/digests", userHandlers.GetDigestSubscription)
+		r.Put("/me/digests", userHandlers.UpdateDigestSubscription)
+		r.Put("/me/language", userHandlers.UpdateLanguage)
+		r.Get("/me/notifications", userHandlers.GetNotificationPreferences)
+		r.Put("/me/notifications", userHandlers.UpdateNotificationPreferences)
+		r.Get("/me/notifications/schedule", userHandlers.GetNotificationSchedule)
+		r.Put("/me/notifications/schedule", userHandlers.UpdateNotificationSchedule)
+	})
+
+	r.Route("/api/v1/chat", func(r chi.Router) {
+		r.Use(AuthMiddleware(jwtService))
+		r.Use(RateLimitByUser(jwtService, 100, 1*time.Minute))
+		r.Get("/link", chatHandlers.GetChatLink)
+		r.Delete("/link", chatHandlers.UnlinkChat)
+		r.Post("/link-code", chatHandlers.CreateChatLinkCode)
+	})
+
+	r.Route("/api/v1/mqtt", func(r chi.Router) {
+		r.Use(AuthMiddleware(jwtService))
+		r.Use(RateLimitByUser(jwtService, 100, 1*time.Minute))
+		r.Get("/", mqttHandlers.GetMQTTIntegration)
+		r.Put("/", mqttHandlers.SaveMQTTIntegration)
+		r.Delete("/", mqttHandlers.DeleteMQTTIntegration)
+	})
+
+	r.Route("/api/v1/push", func(r chi.Router) {
+		r.Use(AuthMiddleware(jwtService))
+		r.Use(RateLimitByUser(jwtService, 100, 1*time.Minute))
+		r.Get("/vapid-public-key", pushHandlers.GetVAPIDPublicKey)
+		r.Post("/subscriptions", pushHandlers.RegisterPushSubscription)
+		r.Delete("/subscriptions", pushHandlers.UnregisterPushSubscription)
+	})
+
+	r.Route("/api/v1/webhooks", func(r chi.Router) {
+		r.Use(AuthMiddleware(jwtService))
+		r.Use(RateLimitByUser(jwtService, 100, 1*time.Minute))
+		r.Get("/", webhookHandlers.GetWebhooks)
+		r.Post("/", webhookHandlers.CreateWebhook)
+		r.Put("/{id}", webhookHandlers.UpdateWebhook)
+		r.Delete("/{id}", webhookHandlers.DeleteWebhook)
+		r.Get("/{id}/deliveries", webhookHandlers.GetWebhookDeliveries)
+		r.Post("/{id}/deliveries/{deliveryId}/redeliver", webhookHandlers.RedeliverWebhookDelivery)
+	})
+
+	r.Route("/api/v1/achievements", func(r chi.Router) {
+		r.Use(AuthMiddleware(jwtService))
+		r.Use(RateLimitByUser(jwtService, 100, 1*time.Minute))
+		r.Get("/", achievementHandlers.GetAchievements)
+	})
+
+	r.Route("/api/v1/points", func(r chi.Router) {
+		r.Use(AuthMiddleware(jwtService))
+		r.Use(RateLimitByUser(jwtService, 100, 1*time.Minute))
+		r.Get("/", pointsHandlers.GetPoints)
+		r.Get("/transactions", pointsHandlers.GetPointTransactions)
+	})
+
+	r.Route("/api/v1/rewards", func(r chi.Router) {
+		r.Use(AuthMiddleware(jwtService))
+		r.Use(RateLimitByUser(jwtService, 100, 1*time.Minute))
+		r.Post("/", pointsHandlers.CreateReward)
+		r.Get("/", pointsHandlers.GetRewards)
+		r.Delete("/{id}", pointsHandlers.ArchiveReward)
+		r.Post("/{id}/redeem", pointsHandlers.RedeemReward)
+	})
+
+	r.Route("/api/v1/recap", func(r chi.Router) {
+		r.Use(AuthMiddleware(jwtService))
+		r.Use(RateLimitByUser(jwtService, 100, 1*time.Minute))
+		r.Get("/{year}", recapHandlers.GetYearRecap)
+		r.Get("/{year}/card", recapHandlers.GetYearRecapCard)
+		r.Post("/{year}/share", recapHandlers.ShareYearRecap)
+		r.Delete("/{year}/share", recapHandlers.RevokeYearRecapShare)
+	})
+
+	r.Route("/api/v1/shared", func(r chi.Router) {
+		r.Use(httprate.LimitByIP(60, 1*time.Minute))
+		r.Get("/recaps/{token}", recapHandlers.GetSharedYearRecapCard)
+	})
+
+	r.Route("/api/v1/unsubscribe", func(r chi.Router) {
+		r.Use(httprate.LimitByIP(30, 1*time.Minute))
+		r.Get("/", unsubscribeHandlers.UnsubscribePage)
+		r.Post("/", unsubscribeHandlers.Unsubscribe)
+	})
+
+	r.Route("/api/v1/admin", func(r chi.Router) {
+		r.Use(AuthMiddleware(jwtService))
+		r.Use(AdminMiddleware(adminEmails))
+		r.Use(RateLimitByUser(jwtService, 100, 1*time.Minute))
+		r.Get("/emails", adminHandlers.GetEmailOutbox)
+		r.Post("/emails/{id}/retry", adminHandlers.RetryOutgoingEmail)
+		r.Get("/email-templates", adminHandlers.ListEmailTemplates)
+		r.Get("/email-templates/{name}/preview", adminHandlers.PreviewEmailTemplate)
 	})
 
 	r.Route("/api/v1/export", func(r chi.Router) {
@@ -2,37 +2,61 @@ package http
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"apocapoc-api/internal/application/queries"
 	"apocapoc-api/internal/shared/errors"
+	"apocapoc-api/internal/shared/utils"
 
 	"apocapoc-api/internal/i18n"
 	"github.com/go-chi/chi/v5"
 )
 
 type StatsHandlers struct {
-	getHabitStatsHandler *queries.GetHabitStatsHandler
-	translator           *i18n.Translator
+	getHabitStatsHandler     *queries.GetHabitStatsHandler
+	getDashboardStatsHandler *queries.GetDashboardStatsHandler
+	getHeatmapHandler        *queries.GetHeatmapHandler
+	getHabitSeriesHandler    *queries.GetHabitSeriesHandler
+	getCorrelationsHandler   *queries.GetCorrelationInsightsHandler
+	getBehaviourHandler      *queries.GetBehaviourInsightsHandler
+	translator               *i18n.Translator
 }
 
 func NewStatsHandlers(
 	getHabitStatsHandler *queries.GetHabitStatsHandler,
+	getDashboardStatsHandler *queries.GetDashboardStatsHandler,
+	getHeatmapHandler *queries.GetHeatmapHandler,
+	getHabitSeriesHandler *queries.GetHabitSeriesHandler,
+	getCorrelationsHandler *queries.GetCorrelationInsightsHandler,
+	getBehaviourHandler *queries.GetBehaviourInsightsHandler,
 	translator *i18n.Translator,
 ) *StatsHandlers {
 	return &StatsHandlers{
-		getHabitStatsHandler: getHabitStatsHandler,
-		translator:           translator,
+		getHabitStatsHandler:     getHabitStatsHandler,
+		getDashboardStatsHandler: getDashboardStatsHandler,
+		getHeatmapHandler:        getHeatmapHandler,
+		getHabitSeriesHandler:    getHabitSeriesHandler,
+		getCorrelationsHandler:   getCorrelationsHandler,
+		getBehaviourHandler:      getBehaviourHandler,
+		translator:               translator,
 	}
 }
 
 // GetHabitStats godoc
 // @Summary Get habit statistics
-// @Description Get statistics for a specific habit including streaks and completion rates
+// @Description Get statistics for a specific habit including streaks and completion rates. Weeks and months are calendar periods in the given timezone.
 // @Tags stats
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Habit ID"
+// @Param timezone query string false "IANA timezone used to resolve today (default UTC)"
+// @Param week_start query string false "First day of the week (default monday)"
+// @Param from query string false "Start date (YYYY-MM-DD), defaults to the habit creation date"
+// @Param to query string false "End date (YYYY-MM-DD), defaults to today"
 // @Success 200 {object} queries.HabitStatsDTO
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
@@ -47,13 +71,51 @@ func (h *StatsHandlers) GetHabitStats(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	timezone := r.URL.Query().Get("timezone")
+	if timezone == "" {
+		timezone = "UTC"
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_timezone")
+		return
+	}
+
+	weekStart, ok := parseWeekStart(w, r, h.translator)
+	if !ok {
+		return
+	}
+
 	query := queries.GetHabitStatsQuery{
-		HabitID: habitID,
-		UserID:  userID,
+		HabitID:   habitID,
+		UserID:    userID,
+		Date:      time.Now().In(loc),
+		WeekStart: weekStart,
+	}
+
+	if fromStr := r.URL.Query().Get("from"); fromStr != "" {
+		query.From, err = time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_from_date_format")
+			return
+		}
+	}
+
+	if toStr := r.URL.Query().Get("to"); toStr != "" {
+		query.To, err = time.Parse("2006-01-02", toStr)
+		if err != nil {
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_to_date_format")
+			return
+		}
 	}
 
 	stats, err := h.getHabitStatsHandler.Handle(r.Context(), query)
 	if err != nil {
+		if err == errors.ErrInvalidInput {
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_date_range")
+			return
+		}
 		if err == errors.ErrNotFound {
 			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "habit_not_found")
 			return
@@ -68,3 +130,440 @@ func (h *StatsHandlers) GetHabitStats(w http.ResponseWriter, r *http.Request) {
 
 	respondJSON(w, http.StatusOK, stats)
 }
+
+// GetDashboardStats godoc
+// @Summary Get dashboard statistics
+// @Description Get account-wide statistics for the authenticated user: completion rates for today, this week and this month, active streaks, best and worst habits, and a daily completion series. Requires timezone as query parameter.
+// @Tags stats
+// @Produce json
+// @Security BearerAuth
+// @Param timezone query string true "IANA timezone (e.g., 'America/New_York', 'Europe/Madrid', 'UTC')"
+// @Param days query int false "Number of days in the daily series (default: 30, max: 365)"
+// @Success 200 {object} queries.DashboardStatsDTO
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /stats/overview [get]
+func (h *StatsHandlers) GetDashboardStats(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	timezone := r.URL.Query().Get("timezone")
+	if timezone == "" {
+		respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "timezone_required")
+		return
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_timezone")
+		return
+	}
+
+	days := 30
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		days, err = strconv.Atoi(daysStr)
+		if err != nil || days < 1 || days > 365 {
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_days_parameter")
+			return
+		}
+	}
+
+	today := time.Now().In(loc)
+
+	query := queries.GetDashboardStatsQuery{
+		UserID: userID,
+		Date:   time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC),
+		Days:   days,
+	}
+
+	stats, err := h.getDashboardStatsHandler.Handle(r.Context(), query)
+	if err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_get_stats")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, stats)
+}
+
+// GetHeatmap godoc
+// @Summary Get calendar heatmap across all habits
+// @Description Get per-day completion intensity across all habits of the authenticated user. Defaults to the last 365 days ending today in the given timezone. The range may not exceed 366 days.
+// @Tags stats
+// @Produce json
+// @Security BearerAuth
+// @Param timezone query string true "IANA timezone (e.g., 'America/New_York', 'Europe/Madrid', 'UTC')"
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Success 200 {object} queries.HeatmapDTO
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /stats/heatmap [get]
+func (h *StatsHandlers) GetHeatmap(w http.ResponseWriter, r *http.Request) {
+	h.getHeatmap(w, r, "")
+}
+
+// GetHabitHeatmap godoc
+// @Summary Get calendar heatmap for a habit
+// @Description Get per-day completion intensity for a habit. VALUE habits without a target are normalized against the highest value in the range. Defaults to the last 365 days ending today in the given timezone. The range may not exceed 366 days.
+// @Tags stats
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Habit ID"
+// @Param timezone query string true "IANA timezone (e.g., 'America/New_York', 'Europe/Madrid', 'UTC')"
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Success 200 {object} queries.HeatmapDTO
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /stats/habits/{id}/heatmap [get]
+func (h *StatsHandlers) GetHabitHeatmap(w http.ResponseWriter, r *http.Request) {
+	h.getHeatmap(w, r, chi.URLParam(r, "id"))
+}
+
+func (h *StatsHandlers) getHeatmap(w http.ResponseWriter, r *http.Request, habitID string) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	from, to, ok := parseDateRange(w, r, h.translator, 365)
+	if !ok {
+		return
+	}
+
+	query := queries.GetHeatmapQuery{
+		UserID:  userID,
+		HabitID: habitID,
+		From:    from,
+		To:      to,
+	}
+
+	heatmap, err := h.getHeatmapHandler.Handle(r.Context(), query)
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_date_range")
+			return
+		}
+		if err == errors.ErrNotFound {
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "habit_not_found")
+			return
+		}
+		if err == errors.ErrUnauthorized {
+			respondErrorI18n(w, r, h.translator, http.StatusForbidden, "access_denied")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_get_stats")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, heatmap)
+}
+
+// GetHabitSeries godoc
+// @Summary Get habit value series
+// @Description Get a habit's values bucketed by day, week, month or year with sum, avg, min, max and count, plus optional trailing moving averages. Buckets are aligned to the given timezone and week start day.
+// @Tags stats
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Habit ID"
+// @Param timezone query string true "IANA timezone (e.g., 'America/New_York', 'Europe/Madrid', 'UTC')"
+// @Param granularity query string false "Bucket size: day, week, month or year (default: day)"
+// @Param week_start query string false "First day of the week for weekly buckets (default: monday)"
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD, default: today)"
+// @Param moving_average query string false "Comma-separated moving average windows in buckets, at most 5 (e.g., '7,30')"
+// @Success 200 {object} queries.HabitSeriesDTO
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /stats/habits/{id}/series [get]
+func (h *StatsHandlers) GetHabitSeries(w http.ResponseWriter, r *http.Request) {
+	habitID := chi.URLParam(r, "id")
+
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	granularity := queries.SeriesGranularityDay
+	if granularityStr := r.URL.Query().Get("granularity"); granularityStr != "" {
+		granularity = queries.SeriesGranularity(granularityStr)
+		if !granularity.IsValid() {
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_granularity")
+			return
+		}
+	}
+
+	weekStart, ok := parseWeekStart(w, r, h.translator)
+	if !ok {
+		return
+	}
+
+	defaultDays := map[queries.SeriesGranularity]int{
+		queries.SeriesGranularityDay:   30,
+		queries.SeriesGranularityWeek:  12 * 7,
+		queries.SeriesGranularityMonth: 365,
+		queries.SeriesGranularityYear:  5 * 365,
+	}
+
+	from, to, ok := parseDateRange(w, r, h.translator, defaultDays[granularity])
+	if !ok {
+		return
+	}
+
+	query := queries.GetHabitSeriesQuery{
+		HabitID:     habitID,
+		UserID:      userID,
+		Granularity: granularity,
+		WeekStart:   weekStart,
+		From:        from,
+		To:          to,
+	}
+
+	if movingAverageStr := r.URL.Query().Get("moving_average"); movingAverageStr != "" {
+		windowStrs := strings.Split(movingAverageStr, ",")
+		if len(windowStrs) > queries.MaxMovingAverages {
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_moving_average")
+			return
+		}
+		for _, windowStr := range windowStrs {
+			window, err := strconv.Atoi(strings.TrimSpace(windowStr))
+			if err != nil {
+				respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_moving_average")
+				return
+			}
+			query.MovingAverages = append(query.MovingAverages, window)
+		}
+	}
+
+	series, err := h.getHabitSeriesHandler.Handle(r.Context(), query)
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_series_parameters")
+			return
+		}
+		if err == errors.ErrNotFound {
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "habit_not_found")
+			return
+		}
+		if err == errors.ErrUnauthorized {
+			respondErrorI18n(w, r, h.translator, http.StatusForbidden, "access_denied")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_get_stats")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, series)
+}
+
+// GetCorrelations godoc
+// @Summary Get cross-habit correlations
+// @Description Get pairwise correlations between the user's habits over a date range. When one habit is BOOLEAN, the lift reports the average of the other habit on days it was done versus not done.
+// @Tags stats
+// @Produce json
+// @Security BearerAuth
+// @Param timezone query string true "IANA timezone used to resolve today"
+// @Param from query string false "Start date (YYYY-MM-DD), defaults to 90 days before to"
+// @Param to query string false "End date (YYYY-MM-DD), defaults to today"
+// @Param min_samples query int false "Minimum number of paired days to report a pair (default 7)"
+// @Success 200 {object} queries.CorrelationInsightsDTO
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /stats/correlations [get]
+func (h *StatsHandlers) GetCorrelations(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	from, to, ok := parseDateRange(w, r, h.translator, 90)
+	if !ok {
+		return
+	}
+
+	minSamples := 7
+	if minSamplesStr := r.URL.Query().Get("min_samples"); minSamplesStr != "" {
+		parsed, err := strconv.Atoi(minSamplesStr)
+		if err != nil || parsed < 0 {
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_min_samples")
+			return
+		}
+		minSamples = parsed
+	}
+
+	query := queries.GetCorrelationInsightsQuery{
+		UserID:     userID,
+		From:       from,
+		To:         to,
+		MinSamples: minSamples,
+	}
+
+	insights, err := h.getCorrelationsHandler.Handle(r.Context(), query)
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_date_range")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_get_stats")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, insights)
+}
+
+// GetBehaviourInsights godoc
+// @Summary Get behaviour insights across all habits
+// @Description Get completion rates by weekday, the weakest weekday and the distribution of completion times of day in the given timezone across all habits. Defaults to the last 90 days. The range may not exceed 366 days.
+// @Tags stats
+// @Produce json
+// @Security BearerAuth
+// @Param timezone query string true "IANA timezone (e.g., 'America/New_York', 'Europe/Madrid', 'UTC')"
+// @Param week_start query string false "First weekday in the response (default monday)"
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Success 200 {object} queries.BehaviourInsightsDTO
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /stats/behaviour [get]
+func (h *StatsHandlers) GetBehaviourInsights(w http.ResponseWriter, r *http.Request) {
+	h.getBehaviourInsights(w, r, "")
+}
+
+// GetHabitBehaviourInsights godoc
+// @Summary Get behaviour insights for a habit
+// @Description Get completion rates by weekday, the weakest weekday and the distribution of completion times of day in the given timezone for a habit. Defaults to the last 90 days. The range may not exceed 366 days.
+// @Tags stats
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Habit ID"
+// @Param timezone query string true "IANA timezone (e.g., 'America/New_York', 'Europe/Madrid', 'UTC')"
+// @Param week_start query string false "First weekday in the response (default monday)"
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Success 200 {object} queries.BehaviourInsightsDTO
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /stats/habits/{id}/behaviour [get]
+func (h *StatsHandlers) GetHabitBehaviourInsights(w http.ResponseWriter, r *http.Request) {
+	h.getBehaviourInsights(w, r, chi.URLParam(r, "id"))
+}
+
+func (h *StatsHandlers) getBehaviourInsights(w http.ResponseWriter, r *http.Request, habitID string) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	weekStart, ok := parseWeekStart(w, r, h.translator)
+	if !ok {
+		return
+	}
+
+	from, to, ok := parseDateRange(w, r, h.translator, 90)
+	if !ok {
+		return
+	}
+
+	loc, _ := time.LoadLocation(r.URL.Query().Get("timezone"))
+
+	query := queries.GetBehaviourInsightsQuery{
+		UserID:    userID,
+		HabitID:   habitID,
+		From:      from,
+		To:        to,
+		Location:  loc,
+		WeekStart: weekStart,
+	}
+
+	insights, err := h.getBehaviourHandler.Handle(r.Context(), query)
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_date_range")
+			return
+		}
+		if err == errors.ErrNotFound {
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "habit_not_found")
+			return
+		}
+		if err == errors.ErrUnauthorized {
+			respondErrorI18n(w, r, h.translator, http.StatusForbidden, "access_denied")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_get_stats")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, insights)
+}
+
+func parseWeekStart(w http.ResponseWriter, r *http.Request, translator *i18n.Translator) (time.Weekday, bool) {
+	weekStartStr := r.URL.Query().Get("week_start")
+	if weekStartStr == "" {
+		return time.Monday, true
+	}
+
+	weekStart, err := utils.ParseWeekday(weekStartStr)
+	if err != nil {
+		respondErrorI18n(w, r, translator, http.StatusBadRequest, "invalid_week_start")
+		return time.Sunday, false
+	}
+
+	return weekStart, true
+}
+
+func parseDateRange(w http.ResponseWriter, r *http.Request, translator *i18n.Translator, defaultDays int) (time.Time, time.Time, bool) {
+	timezone := r.URL.Query().Get("timezone")
+	if timezone == "" {
+		respondErrorI18n(w, r, translator, http.StatusBadRequest, "timezone_required")
+		return time.Time{}, time.Time{}, false
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		respondErrorI18n(w, r, translator, http.StatusBadRequest, "invalid_timezone")
+		return time.Time{}, time.Time{}, false
+	}
+
+	now := time.Now().In(loc)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if toStr := r.URL.Query().Get("to"); toStr != "" {
+		to, err = time.Parse("2006-01-02", toStr)
+		if err != nil {
+			respondErrorI18n(w, r, translator, http.StatusBadRequest, "invalid_to_date_format")
+			return time.Time{}, time.Time{}, false
+		}
+	}
+
+	from := to.AddDate(0, 0, -(defaultDays - 1))
+
+	if fromStr := r.URL.Query().Get("from"); fromStr != "" {
+		from, err = time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			respondErrorI18n(w, r, translator, http.StatusBadRequest, "invalid_from_date_format")
+			return time.Time{}, time.Time{}, false
+		}
+	}
+
+	return from, to, true
+}
@@ -94,6 +94,37 @@ func TestHabitStatsFlow(t *testing.T) {
 			t.Errorf("Expected 0 current streak after unmark, got %d", stats.CurrentStreak)
 		}
 	})
+
+	t.Run("Stats accept timezone and date range", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/stats/habits/"+habitID+"?timezone=Europe/Madrid&week_start=sunday&from=2025-01-01&to=2025-01-31", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		var stats queries.HabitStatsDTO
+		decodeResponse(t, rr, &stats)
+
+		if stats.WeekStart != "Sunday" {
+			t.Errorf("Expected week start Sunday, got %s", stats.WeekStart)
+		}
+		if stats.From.Format("2006-01-02") != "2025-01-01" || stats.To.Format("2006-01-02") != "2025-01-31" {
+			t.Errorf("Unexpected range %s - %s", stats.From, stats.To)
+		}
+	})
+
+	t.Run("Stats reject invalid timezone", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/stats/habits/"+habitID+"?timezone=Mars/Olympus", nil, token)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Stats reject inverted date range", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/stats/habits/"+habitID+"?from=2025-02-01&to=2025-01-01", nil, token)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rr.Code)
+		}
+	})
 }
 
 func TestHabitUpdateAffectsStats(t *testing.T) {
@@ -157,3 +188,321 @@ func TestHabitUpdateAffectsStats(t *testing.T) {
 		}
 	})
 }
+
+func TestDashboardStatsFlow(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.Close()
+
+	token := registerAndLogin(t, *ts.Router, "dashboard@example.com", "Password123!")
+
+	for _, name := range []string{"Meditation", "Reading"} {
+		habitBody := CreateHabitRequest{
+			Name:      name,
+			Type:      "BOOLEAN",
+			Frequency: "DAILY",
+		}
+		rr := makeRequest(t, *ts.Router, "POST", "/api/v1/habits", habitBody, token)
+		var habitResp map[string]string
+		decodeResponse(t, rr, &habitResp)
+
+		if name == "Meditation" {
+			markReq := MarkHabitRequest{
+				ScheduledDate: time.Now().UTC().Format("2006-01-02"),
+			}
+			makeRequest(t, *ts.Router, "POST", "/api/v1/habits/"+habitResp["id"]+"/mark", markReq, token)
+		}
+	}
+
+	t.Run("Requires timezone", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/stats/overview", nil, token)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Rejects invalid days", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/stats/overview?timezone=UTC&days=0", nil, token)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Returns aggregated stats", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/stats/overview?timezone=UTC&days=7", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		var stats queries.DashboardStatsDTO
+		decodeResponse(t, rr, &stats)
+
+		if stats.TotalHabits != 2 {
+			t.Errorf("Expected 2 habits, got %d", stats.TotalHabits)
+		}
+		if stats.CompletionRateToday != 50 {
+			t.Errorf("Expected today's completion rate 50, got %f", stats.CompletionRateToday)
+		}
+		if stats.ActiveStreaks != 1 {
+			t.Errorf("Expected 1 active streak, got %d", stats.ActiveStreaks)
+		}
+		if len(stats.Daily) != 7 {
+			t.Errorf("Expected 7 daily points, got %d", len(stats.Daily))
+		}
+	})
+}
+
+func TestHeatmapFlow(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.Close()
+
+	token := registerAndLogin(t, *ts.Router, "heatmap@example.com", "Password123!")
+
+	habitBody := CreateHabitRequest{
+		Name:      "Journaling",
+		Type:      "BOOLEAN",
+		Frequency: "DAILY",
+	}
+	rr := makeRequest(t, *ts.Router, "POST", "/api/v1/habits", habitBody, token)
+	var habitResp map[string]string
+	decodeResponse(t, rr, &habitResp)
+	habitID := habitResp["id"]
+
+	today := time.Now().UTC().Format("2006-01-02")
+	markReq := MarkHabitRequest{
+		ScheduledDate: today,
+	}
+	makeRequest(t, *ts.Router, "POST", "/api/v1/habits/"+habitID+"/mark", markReq, token)
+
+	t.Run("Defaults to a full year across all habits", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/stats/heatmap?timezone=UTC", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		var heatmap queries.HeatmapDTO
+		decodeResponse(t, rr, &heatmap)
+
+		if len(heatmap.Days) != 365 {
+			t.Fatalf("Expected 365 days, got %d", len(heatmap.Days))
+		}
+
+		last := heatmap.Days[len(heatmap.Days)-1]
+		if last.Level != queries.HeatmapLevelFull {
+			t.Errorf("Expected today to be full, got %s", last.Level)
+		}
+	})
+
+	t.Run("Returns heatmap for a single habit", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/stats/habits/"+habitID+"/heatmap?timezone=UTC&from="+today+"&to="+today, nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		var heatmap queries.HeatmapDTO
+		decodeResponse(t, rr, &heatmap)
+
+		if heatmap.HabitID != habitID {
+			t.Errorf("Expected habit ID %s, got %s", habitID, heatmap.HabitID)
+		}
+		if len(heatmap.Days) != 1 || heatmap.Days[0].Completed != 1 {
+			t.Errorf("Expected one completed day, got %+v", heatmap.Days)
+		}
+	})
+
+	t.Run("Rejects inverted range", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/stats/heatmap?timezone=UTC&from=2025-02-01&to=2025-01-01", nil, token)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rr.Code)
+		}
+	})
+}
+
+func TestHabitSeriesFlow(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.Close()
+
+	token := registerAndLogin(t, *ts.Router, "series@example.com", "Password123!")
+
+	target := 100.0
+	habitBody := CreateHabitRequest{
+		Name:        "Push-ups",
+		Type:        "COUNTER",
+		Frequency:   "DAILY",
+		TargetValue: &target,
+	}
+	rr := makeRequest(t, *ts.Router, "POST", "/api/v1/habits", habitBody, token)
+	var habitResp map[string]string
+	decodeResponse(t, rr, &habitResp)
+	habitID := habitResp["id"]
+
+	for _, date := range []string{"2025-01-06", "2025-01-07", "2025-01-13"} {
+		value := 10.0
+		markReq := MarkHabitRequest{
+			ScheduledDate: date,
+			Value:         &value,
+		}
+		makeRequest(t, *ts.Router, "POST", "/api/v1/habits/"+habitID+"/mark", markReq, token)
+	}
+
+	t.Run("Returns weekly buckets", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/stats/habits/"+habitID+"/series?timezone=UTC&granularity=week&from=2025-01-06&to=2025-01-19&moving_average=2", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		var series queries.HabitSeriesDTO
+		decodeResponse(t, rr, &series)
+
+		if len(series.Buckets) != 2 {
+			t.Fatalf("Expected 2 buckets, got %d", len(series.Buckets))
+		}
+		if series.Buckets[0].Sum != 20 || series.Buckets[1].Sum != 10 {
+			t.Errorf("Expected sums 20 and 10, got %f and %f", series.Buckets[0].Sum, series.Buckets[1].Sum)
+		}
+		if series.Buckets[1].MovingAverages["2"] != 15 {
+			t.Errorf("Expected moving average 15, got %f", series.Buckets[1].MovingAverages["2"])
+		}
+	})
+
+	t.Run("Rejects invalid granularity", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/stats/habits/"+habitID+"/series?timezone=UTC&granularity=hour", nil, token)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Rejects too many moving averages", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/stats/habits/"+habitID+"/series?timezone=UTC&moving_average=2,3,4,5,6,7", nil, token)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Rejects invalid week start", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/stats/habits/"+habitID+"/series?timezone=UTC&week_start=someday", nil, token)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rr.Code)
+		}
+	})
+}
+
+func TestCorrelationInsightsFlow(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.Close()
+
+	token := registerAndLogin(t, *ts.Router, "insights@example.com", "Password123!")
+
+	createHabit := func(body CreateHabitRequest) string {
+		rr := makeRequest(t, *ts.Router, "POST", "/api/v1/habits", body, token)
+		var habitResp map[string]string
+		decodeResponse(t, rr, &habitResp)
+		return habitResp["id"]
+	}
+
+	exerciseID := createHabit(CreateHabitRequest{Name: "Exercise", Type: "BOOLEAN", Frequency: "DAILY"})
+	sleepID := createHabit(CreateHabitRequest{Name: "Sleep score", Type: "VALUE", Frequency: "DAILY"})
+
+	today := time.Now().UTC()
+	for day := 0; day < 8; day++ {
+		date := today.AddDate(0, 0, -day).Format("2006-01-02")
+		score := 60.0
+		if day%2 == 0 {
+			makeRequest(t, *ts.Router, "POST", "/api/v1/habits/"+exerciseID+"/mark", MarkHabitRequest{ScheduledDate: date}, token)
+			score = 90.0
+		}
+		makeRequest(t, *ts.Router, "POST", "/api/v1/habits/"+sleepID+"/mark", MarkHabitRequest{ScheduledDate: date, Value: &score}, token)
+	}
+
+	t.Run("Returns correlations with sample sizes", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/stats/correlations?timezone=UTC&min_samples=1", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		var insights queries.CorrelationInsightsDTO
+		decodeResponse(t, rr, &insights)
+
+		if len(insights.Insights) != 1 {
+			t.Fatalf("Expected 1 insight, got %d", len(insights.Insights))
+		}
+		insight := insights.Insights[0]
+		if insight.HabitID != exerciseID || insight.OtherHabitID != sleepID {
+			t.Errorf("Expected exercise as condition for sleep score, got %s -> %s", insight.HabitID, insight.OtherHabitID)
+		}
+		if insight.Samples == 0 {
+			t.Error("Expected a non-zero sample size")
+		}
+	})
+
+	t.Run("Rejects invalid min samples", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/stats/correlations?timezone=UTC&min_samples=-1", nil, token)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Requires timezone", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/stats/correlations", nil, token)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rr.Code)
+		}
+	})
+}
+
+func TestBehaviourInsightsFlow(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.Close()
+
+	token := registerAndLogin(t, *ts.Router, "behaviour@example.com", "Password123!")
+
+	habitBody := CreateHabitRequest{
+		Name:      "Meditation",
+		Type:      "BOOLEAN",
+		Frequency: "DAILY",
+	}
+	rr := makeRequest(t, *ts.Router, "POST", "/api/v1/habits", habitBody, token)
+	var habitResp map[string]string
+	decodeResponse(t, rr, &habitResp)
+	habitID := habitResp["id"]
+
+	today := time.Now().UTC().Format("2006-01-02")
+	makeRequest(t, *ts.Router, "POST", "/api/v1/habits/"+habitID+"/mark", MarkHabitRequest{ScheduledDate: today}, token)
+
+	t.Run("Returns insights across habits", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/stats/behaviour?timezone=UTC", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		var insights queries.BehaviourInsightsDTO
+		decodeResponse(t, rr, &insights)
+
+		if len(insights.Weekdays) != 7 || len(insights.Hours) != 24 {
+			t.Fatalf("Expected 7 weekdays and 24 hours, got %d and %d", len(insights.Weekdays), len(insights.Hours))
+		}
+		if insights.PeakHour == nil {
+			t.Error("Expected a peak hour after completing the habit today")
+		}
+	})
+
+	t.Run("Returns insights for a habit", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/stats/habits/"+habitID+"/behaviour?timezone=UTC", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		var insights queries.BehaviourInsightsDTO
+		decodeResponse(t, rr, &insights)
+
+		if insights.HabitID != habitID {
+			t.Errorf("Expected habit %s, got %s", habitID, insights.HabitID)
+		}
+	})
+
+	t.Run("Requires timezone", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/stats/behaviour", nil, token)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rr.Code)
+		}
+	})
+}
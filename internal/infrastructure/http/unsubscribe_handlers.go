@@ -0,0 +1,70 @@
+package http
+
+import (
+	"net/http"
+	"net/url"
+
+	"apocapoc-api/internal/application/commands"
+	"apocapoc-api/internal/i18n"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type UnsubscribeHandlers struct {
+	unsubscribeHandler *commands.UnsubscribeHandler
+	appURL             string
+	translator         *i18n.Translator
+}
+
+func NewUnsubscribeHandlers(unsubscribeHandler *commands.UnsubscribeHandler, appURL string, translator *i18n.Translator) *UnsubscribeHandlers {
+	return &UnsubscribeHandlers{
+		unsubscribeHandler: unsubscribeHandler,
+		appURL:             appURL,
+		translator:         translator,
+	}
+}
+
+// Unsubscribe godoc
+// @Summary Unsubscribe from an email category
+// @Description Public one-click unsubscribe endpoint (RFC 8058) behind the List-Unsubscribe header. The signed token names the user and the email category to turn off, so no login is needed. Mail clients post List-Unsubscribe=One-Click as the form body, which is ignored.
+// @Tags notifications
+// @Produce json
+// @Param token query string true "Signed unsubscribe token"
+// @Success 200 {object} UnsubscribeResponse
+// @Failure 400 {object} ErrorResponse "Invalid unsubscribe token"
+// @Failure 404 {object} ErrorResponse "User not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /unsubscribe [post]
+func (h *UnsubscribeHandlers) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	category, err := h.unsubscribeHandler.Handle(r.Context(), commands.UnsubscribeCommand{
+		Token: r.URL.Query().Get("token"),
+	})
+	if err != nil {
+		switch err {
+		case errors.ErrInvalidInput:
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_unsubscribe_token")
+		case errors.ErrNotFound:
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "user_not_found")
+		default:
+			respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_unsubscribe")
+		}
+		return
+	}
+
+	lang := i18n.GetLanguageFromContext(r.Context())
+	respondJSON(w, http.StatusOK, UnsubscribeResponse{
+		Message:  h.translator.Success(lang, "unsubscribed"),
+		Category: string(category),
+	})
+}
+
+// UnsubscribePage godoc
+// @Summary Open the unsubscribe page
+// @Description Mail clients without one-click support open the List-Unsubscribe link in a browser. This redirects to the app's unsubscribe page, which asks the user to confirm, so following the link never unsubscribes by itself.
+// @Tags notifications
+// @Param token query string true "Signed unsubscribe token"
+// @Success 302
+// @Router /unsubscribe [get]
+func (h *UnsubscribeHandlers) UnsubscribePage(w http.ResponseWriter, r *http.Request) {
+	target := h.appURL + "/unsubscribe?token=" + url.QueryEscape(r.URL.Query().Get("token"))
+	http.Redirect(w, r, target, http.StatusFound)
+}
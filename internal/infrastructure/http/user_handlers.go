@@ -1,22 +1,48 @@
 package http
 
 import (
+	"encoding/json"
 	"net/http"
 
 	"apocapoc-api/internal/application/commands"
+	"apocapoc-api/internal/application/queries"
 	"apocapoc-api/internal/i18n"
 	"apocapoc-api/internal/shared/errors"
 )
 
 type UserHandlers struct {
-	deleteUserHandler *commands.DeleteUserHandler
-	translator        *i18n.Translator
+	deleteUserHandler                    *commands.DeleteUserHandler
+	getDigestSubscriptionHandler         *queries.GetDigestSubscriptionHandler
+	updateDigestSubscriptionHandler      *commands.UpdateDigestSubscriptionHandler
+	updateUserLanguageHandler            *commands.UpdateUserLanguageHandler
+	getNotificationPreferencesHandler    *queries.GetNotificationPreferencesHandler
+	updateNotificationPreferencesHandler *commands.UpdateNotificationPreferencesHandler
+	getNotificationScheduleHandler       *queries.GetNotificationScheduleHandler
+	updateNotificationScheduleHandler    *commands.UpdateNotificationScheduleHandler
+	translator                           *i18n.Translator
 }
 
-func NewUserHandlers(deleteUserHandler *commands.DeleteUserHandler, translator *i18n.Translator) *UserHandlers {
+func NewUserHandlers(
+	deleteUserHandler *commands.DeleteUserHandler,
+	getDigestSubscriptionHandler *queries.GetDigestSubscriptionHandler,
+	updateDigestSubscriptionHandler *commands.UpdateDigestSubscriptionHandler,
+	updateUserLanguageHandler *commands.UpdateUserLanguageHandler,
+	getNotificationPreferencesHandler *queries.GetNotificationPreferencesHandler,
+	updateNotificationPreferencesHandler *commands.UpdateNotificationPreferencesHandler,
+	getNotificationScheduleHandler *queries.GetNotificationScheduleHandler,
+	updateNotificationScheduleHandler *commands.UpdateNotificationScheduleHandler,
+	translator *i18n.Translator,
+) *UserHandlers {
 	return &UserHandlers{
-		deleteUserHandler: deleteUserHandler,
-		translator:        translator,
+		deleteUserHandler:                    deleteUserHandler,
+		getDigestSubscriptionHandler:         getDigestSubscriptionHandler,
+		updateDigestSubscriptionHandler:      updateDigestSubscriptionHandler,
+		updateUserLanguageHandler:            updateUserLanguageHandler,
+		getNotificationPreferencesHandler:    getNotificationPreferencesHandler,
+		updateNotificationPreferencesHandler: updateNotificationPreferencesHandler,
+		getNotificationScheduleHandler:       getNotificationScheduleHandler,
+		updateNotificationScheduleHandler:    updateNotificationScheduleHandler,
+		translator:                           translator,
 	}
 }
 
@@ -53,3 +79,256 @@ func (h *UserHandlers) DeleteAccount(w http.ResponseWriter, r *http.Request) {
 		"message": h.translator.Success(lang, "user_deleted"),
 	})
 }
+
+// GetDigestSubscription godoc
+// @Summary Get digest email settings
+// @Description Get whether the authenticated user receives the weekly and monthly progress digest emails
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} queries.DigestSubscriptionDTO
+// @Failure 401 {object} ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /users/me/digests [get]
+func (h *UserHandlers) GetDigestSubscription(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	subscription, err := h.getDigestSubscriptionHandler.Handle(r.Context(), queries.GetDigestSubscriptionQuery{
+		UserID: userID,
+	})
+	if err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_get_digest_settings")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, subscription)
+}
+
+// UpdateDigestSubscription godoc
+// @Summary Update digest email settings
+// @Description Opt in or out of the weekly and monthly progress digest emails. Omitted fields are left unchanged. Digests are sent in the language set with PUT /users/me/language.
+// @Tags users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body UpdateDigestSubscriptionRequest true "Digest settings"
+// @Success 200 {object} queries.DigestSubscriptionDTO
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /users/me/digests [put]
+func (h *UserHandlers) UpdateDigestSubscription(w http.ResponseWriter, r *http.Request) {
+	var req UpdateDigestSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	subscription, err := h.updateDigestSubscriptionHandler.Handle(r.Context(), commands.UpdateDigestSubscriptionCommand{
+		UserID:  userID,
+		Weekly:  req.Weekly,
+		Monthly: req.Monthly,
+	})
+	if err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_update_digest_settings")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, queries.NewDigestSubscriptionDTO(subscription))
+}
+
+// UpdateLanguage godoc
+// @Summary Update email language
+// @Description Set the language the authenticated user's emails are sent in. Unsupported languages fall back to English.
+// @Tags users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body UpdateUserLanguageRequest true "Language, e.g. es"
+// @Success 200 {object} UserLanguageResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 404 {object} ErrorResponse "User not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /users/me/language [put]
+func (h *UserHandlers) UpdateLanguage(w http.ResponseWriter, r *http.Request) {
+	var req UpdateUserLanguageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Language == "" {
+		respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	user, err := h.updateUserLanguageHandler.Handle(r.Context(), commands.UpdateUserLanguageCommand{
+		UserID:   userID,
+		Language: h.translator.GetLanguage(req.Language).String(),
+	})
+	if err != nil {
+		if err == errors.ErrNotFound {
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "user_not_found")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_update_language")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, UserLanguageResponse{Language: user.Language})
+}
+
+// GetNotificationPreferences godoc
+// @Summary Get notification preferences
+// @Description Get, for every notification category (reminders, digests, achievements, security), whether the authenticated user receives it on each channel it is sent on (email, push)
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} queries.NotificationPreferencesDTO
+// @Failure 401 {object} ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /users/me/notifications [get]
+func (h *UserHandlers) GetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	preferences, err := h.getNotificationPreferencesHandler.Handle(r.Context(), queries.GetNotificationPreferencesQuery{
+		UserID: userID,
+	})
+	if err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_get_notification_preferences")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, preferences)
+}
+
+// UpdateNotificationPreferences godoc
+// @Summary Update notification preferences
+// @Description Turn notification categories on or off per channel, e.g. {"digests": {"email": false}}. Omitted categories and channels are left unchanged. Security email cannot be turned off.
+// @Tags users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body UpdateNotificationPreferencesRequest true "Preferences by category and channel"
+// @Success 200 {object} queries.NotificationPreferencesDTO
+// @Failure 400 {object} ErrorResponse "Invalid request body or unknown category or channel"
+// @Failure 401 {object} ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /users/me/notifications [put]
+func (h *UserHandlers) UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	var req UpdateNotificationPreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	preferences, err := h.updateNotificationPreferencesHandler.Handle(r.Context(), commands.UpdateNotificationPreferencesCommand{
+		UserID:      userID,
+		Preferences: req,
+	})
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_notification_preferences")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_update_notification_preferences")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, queries.NewNotificationPreferencesDTO(preferences))
+}
+
+// GetNotificationSchedule godoc
+// @Summary Get notification schedule
+// @Description Get the authenticated user's quiet hours, in their timezone, and the maximum number of notifications per hour (0 means no limit). Empty quiet hours mean there are none.
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} queries.NotificationScheduleDTO
+// @Failure 401 {object} ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /users/me/notifications/schedule [get]
+func (h *UserHandlers) GetNotificationSchedule(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	schedule, err := h.getNotificationScheduleHandler.Handle(r.Context(), queries.GetNotificationScheduleQuery{
+		UserID: userID,
+	})
+	if err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_get_notification_schedule")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, schedule)
+}
+
+// UpdateNotificationSchedule godoc
+// @Summary Update notification schedule
+// @Description Replace the authenticated user's quiet hours and hourly limit. Notifications due during quiet hours, or over the limit, are held back and delivered as a single message per channel once they can be sent. Security email is never held back.
+// @Tags users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body UpdateNotificationScheduleRequest true "Quiet hours and hourly limit"
+// @Success 200 {object} queries.NotificationScheduleDTO
+// @Failure 400 {object} ErrorResponse "Invalid request body or schedule"
+// @Failure 401 {object} ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /users/me/notifications/schedule [put]
+func (h *UserHandlers) UpdateNotificationSchedule(w http.ResponseWriter, r *http.Request) {
+	var req UpdateNotificationScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	schedule, err := h.updateNotificationScheduleHandler.Handle(r.Context(), commands.UpdateNotificationScheduleCommand{
+		UserID:          userID,
+		QuietHoursStart: req.QuietHoursStart,
+		QuietHoursEnd:   req.QuietHoursEnd,
+		Timezone:        req.Timezone,
+		MaxPerHour:      req.MaxPerHour,
+	})
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_notification_schedule")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_update_notification_schedule")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, queries.NewNotificationScheduleDTO(schedule))
+}
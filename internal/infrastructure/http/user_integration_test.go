@@ -0,0 +1,64 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUserLanguageIntegration(t *testing.T) {
+	ts := setupTestServer(t)
+
+	body, _ := json.Marshal(RegisterRequest{Email: "lang@example.com", Password: "Password123!"})
+	req := httptest.NewRequest("POST", "/api/v1/auth/register", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "es-ES,es;q=0.9")
+	rr := httptest.NewRecorder()
+	(*ts.Router).ServeHTTP(rr, req)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+
+	storedLanguage := func() string {
+		var language string
+		ts.DB.QueryRow("SELECT language FROM users WHERE email = ?", "lang@example.com").Scan(&language)
+		return language
+	}
+	if language := storedLanguage(); language != "es" {
+		t.Errorf("Expected the request language to be stored at registration, got %q", language)
+	}
+
+	token := registerAndLogin(t, *ts.Router, "lang@example.com", "Password123!")
+
+	t.Run("Updates the language", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "PUT", "/api/v1/users/me/language", UpdateUserLanguageRequest{Language: "en-GB"}, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		var resp UserLanguageResponse
+		decodeResponse(t, rr, &resp)
+		if resp.Language != "en" || storedLanguage() != "en" {
+			t.Errorf("Expected the language to be normalized and stored, got %q", resp.Language)
+		}
+	})
+
+	t.Run("Falls back to English for unsupported languages", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "PUT", "/api/v1/users/me/language", UpdateUserLanguageRequest{Language: "tlh"}, token)
+
+		var resp UserLanguageResponse
+		decodeResponse(t, rr, &resp)
+		if resp.Language != "en" {
+			t.Errorf("Expected English, got %q", resp.Language)
+		}
+	})
+
+	t.Run("Rejects an empty language", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "PUT", "/api/v1/users/me/language", UpdateUserLanguageRequest{}, token)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rr.Code)
+		}
+	})
+}
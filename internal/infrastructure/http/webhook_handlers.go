@@ -0,0 +1,295 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"apocapoc-api/internal/application/commands"
+	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/i18n"
+	"apocapoc-api/internal/shared/errors"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type WebhookHandlers struct {
+	getWebhooksHandler              *queries.GetWebhooksHandler
+	getWebhookDeliveriesHandler     *queries.GetWebhookDeliveriesHandler
+	createWebhookHandler            *commands.CreateWebhookHandler
+	updateWebhookHandler            *commands.UpdateWebhookHandler
+	deleteWebhookHandler            *commands.DeleteWebhookHandler
+	redeliverWebhookDeliveryHandler *commands.RedeliverWebhookDeliveryHandler
+	translator                      *i18n.Translator
+}
+
+func NewWebhookHandlers(
+	getWebhooksHandler *queries.GetWebhooksHandler,
+	getWebhookDeliveriesHandler *queries.GetWebhookDeliveriesHandler,
+	createWebhookHandler *commands.CreateWebhookHandler,
+	updateWebhookHandler *commands.UpdateWebhookHandler,
+	deleteWebhookHandler *commands.DeleteWebhookHandler,
+	redeliverWebhookDeliveryHandler *commands.RedeliverWebhookDeliveryHandler,
+	translator *i18n.Translator,
+) *WebhookHandlers {
+	return &WebhookHandlers{
+		getWebhooksHandler:              getWebhooksHandler,
+		getWebhookDeliveriesHandler:     getWebhookDeliveriesHandler,
+		createWebhookHandler:            createWebhookHandler,
+		updateWebhookHandler:            updateWebhookHandler,
+		deleteWebhookHandler:            deleteWebhookHandler,
+		redeliverWebhookDeliveryHandler: redeliverWebhookDeliveryHandler,
+		translator:                      translator,
+	}
+}
+
+// GetWebhooks godoc
+// @Summary Get webhooks
+// @Description Get the webhooks registered by the user. Secrets are not included.
+// @Tags webhooks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} queries.WebhookDTO
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /webhooks [get]
+func (h *WebhookHandlers) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	webhooks, err := h.getWebhooksHandler.Handle(r.Context(), queries.GetWebhooksQuery{UserID: userID})
+	if err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_get_webhooks")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, webhooks)
+}
+
+// CreateWebhook godoc
+// @Summary Create a webhook
+// @Description Register an endpoint to receive habit.created, habit.marked, habit.unmarked, habit.archived and streak.broken events as signed POST requests. The X-Apocapoc-Signature header is "sha256=" followed by the hex HMAC-SHA256 of "<X-Apocapoc-Timestamp>.<body>" keyed with the secret, which is only returned here.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateWebhookRequest true "Webhook URL and event types"
+// @Success 201 {object} CreateWebhookResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /webhooks [post]
+func (h *WebhookHandlers) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	webhook, err := h.createWebhookHandler.Handle(r.Context(), commands.CreateWebhookCommand{
+		UserID: userID,
+		URL:    req.URL,
+		Events: req.Events,
+	})
+	if err != nil {
+		if err == errors.ErrInvalidInput {
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_webhook")
+			return
+		}
+		respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_create_webhook")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, CreateWebhookResponse{
+		WebhookDTO: *queries.NewWebhookDTO(webhook),
+		Secret:     webhook.Secret,
+	})
+}
+
+// UpdateWebhook godoc
+// @Summary Update a webhook
+// @Description Change the URL or event types of a webhook, or enable and disable it. Enabling a webhook that was disabled after repeated failures resets its failure count. Omitted fields are left unchanged.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID"
+// @Param request body UpdateWebhookRequest true "Webhook changes"
+// @Success 200 {object} queries.WebhookDTO
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /webhooks/{id} [put]
+func (h *WebhookHandlers) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req UpdateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_request_body")
+		return
+	}
+
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	webhook, err := h.updateWebhookHandler.Handle(r.Context(), commands.UpdateWebhookCommand{
+		WebhookID: chi.URLParam(r, "id"),
+		UserID:    userID,
+		URL:       req.URL,
+		Events:    req.Events,
+		Enabled:   req.Enabled,
+	})
+	if err != nil {
+		switch err {
+		case errors.ErrInvalidInput:
+			respondErrorI18n(w, r, h.translator, http.StatusBadRequest, "invalid_webhook")
+		case errors.ErrNotFound:
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "webhook_not_found")
+		case errors.ErrUnauthorized:
+			respondErrorI18n(w, r, h.translator, http.StatusForbidden, "access_denied")
+		default:
+			respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_update_webhook")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, queries.NewWebhookDTO(webhook))
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook
+// @Description Delete a webhook and its delivery log
+// @Tags webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /webhooks/{id} [delete]
+func (h *WebhookHandlers) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	err := h.deleteWebhookHandler.Handle(r.Context(), commands.DeleteWebhookCommand{
+		WebhookID: chi.URLParam(r, "id"),
+		UserID:    userID,
+	})
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "webhook_not_found")
+		case errors.ErrUnauthorized:
+			respondErrorI18n(w, r, h.translator, http.StatusForbidden, "access_denied")
+		default:
+			respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_delete_webhook")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// GetWebhookDeliveries godoc
+// @Summary Get webhook deliveries
+// @Description Get the delivery log of a webhook, newest first, with the payload, status and last response of each delivery
+// @Tags webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID"
+// @Param limit query int false "Number of deliveries (default 50, max 200)"
+// @Success 200 {array} queries.WebhookDeliveryDTO
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /webhooks/{id}/deliveries [get]
+func (h *WebhookHandlers) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	deliveries, err := h.getWebhookDeliveriesHandler.Handle(r.Context(), queries.GetWebhookDeliveriesQuery{
+		WebhookID: chi.URLParam(r, "id"),
+		UserID:    userID,
+		Limit:     limit,
+	})
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "webhook_not_found")
+		case errors.ErrUnauthorized:
+			respondErrorI18n(w, r, h.translator, http.StatusForbidden, "access_denied")
+		default:
+			respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_get_webhook_deliveries")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, deliveries)
+}
+
+// RedeliverWebhookDelivery godoc
+// @Summary Redeliver a webhook delivery
+// @Description Queue a logged delivery to be sent again with its original payload and a fresh set of retries
+// @Tags webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID"
+// @Param deliveryId path string true "Delivery ID"
+// @Success 202 {object} queries.WebhookDeliveryDTO
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /webhooks/{id}/deliveries/{deliveryId}/redeliver [post]
+func (h *WebhookHandlers) RedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		respondErrorI18n(w, r, h.translator, http.StatusUnauthorized, "user_not_authenticated")
+		return
+	}
+
+	delivery, err := h.redeliverWebhookDeliveryHandler.Handle(r.Context(), commands.RedeliverWebhookDeliveryCommand{
+		WebhookID:  chi.URLParam(r, "id"),
+		DeliveryID: chi.URLParam(r, "deliveryId"),
+		UserID:     userID,
+	})
+	if err != nil {
+		switch err {
+		case errors.ErrInvalidInput:
+			respondErrorI18n(w, r, h.translator, http.StatusConflict, "webhook_disabled")
+		case errors.ErrNotFound:
+			respondErrorI18n(w, r, h.translator, http.StatusNotFound, "webhook_delivery_not_found")
+		case errors.ErrUnauthorized:
+			respondErrorI18n(w, r, h.translator, http.StatusForbidden, "access_denied")
+		default:
+			respondErrorI18n(w, r, h.translator, http.StatusInternalServerError, "failed_redeliver_webhook")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusAccepted, queries.NewWebhookDeliveryDTO(delivery))
+}
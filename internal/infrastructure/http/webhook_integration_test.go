@@ -0,0 +1,181 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/infrastructure/persistence/sqlite"
+	"apocapoc-api/internal/infrastructure/webhook"
+)
+
+func TestWebhooksFlow(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.Close()
+
+	var (
+		mu       sync.Mutex
+		received []*http.Request
+		bodies   [][]byte
+	)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, r)
+		bodies = append(bodies, body)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	// Loopback receivers are refused, so the webhook names a public host and
+	// the worker's client connects to the test receiver instead.
+	receiverURL := "http://hooks.example.com/habits"
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, receiver.Listener.Addr().String())
+		},
+	}}
+	worker := webhook.NewWorker(sqlite.NewWebhookRepository(ts.DB), sqlite.NewWebhookDeliveryRepository(ts.DB), nil, client,
+		webhook.Config{Enabled: true, Interval: time.Minute})
+
+	token := registerAndLogin(t, *ts.Router, "webhooks@example.com", "Password123!")
+	otherToken := registerAndLogin(t, *ts.Router, "other-webhooks@example.com", "Password123!")
+
+	var created CreateWebhookResponse
+	t.Run("Creates a webhook with its secret", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "POST", "/api/v1/webhooks", CreateWebhookRequest{
+			URL:    receiverURL,
+			Events: []string{"habit.created", "habit.marked"},
+		}, token)
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+		decodeResponse(t, rr, &created)
+
+		if created.ID == "" || created.Secret == "" || !created.Enabled || len(created.Events) != 2 {
+			t.Errorf("Unexpected webhook: %+v", created)
+		}
+	})
+
+	t.Run("Rejects invalid webhooks", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "POST", "/api/v1/webhooks", CreateWebhookRequest{URL: "ftp://example.com", Events: []string{"habit.marked"}}, token)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for an unsupported URL, got %d", rr.Code)
+		}
+
+		rr = makeRequest(t, *ts.Router, "POST", "/api/v1/webhooks", CreateWebhookRequest{URL: receiverURL, Events: []string{"habit.deleted"}}, token)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for an unknown event, got %d", rr.Code)
+		}
+
+		rr = makeRequest(t, *ts.Router, "POST", "/api/v1/webhooks", CreateWebhookRequest{URL: receiver.URL, Events: []string{"habit.marked"}}, token)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for a loopback URL, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Lists webhooks without secrets", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/webhooks", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", rr.Code)
+		}
+		if body := rr.Body.String(); len(body) == 0 || strings.Contains(body, created.Secret) {
+			t.Errorf("Expected the secret to be hidden, got %s", body)
+		}
+	})
+
+	rr := makeRequest(t, *ts.Router, "POST", "/api/v1/habits", CreateHabitRequest{Name: "Stretch", Type: "BOOLEAN", Frequency: "DAILY"}, token)
+	var habitResp map[string]string
+	decodeResponse(t, rr, &habitResp)
+	today := time.Now().UTC().Format("2006-01-02")
+	makeRequest(t, *ts.Router, "POST", "/api/v1/habits/"+habitResp["id"]+"/mark", MarkHabitRequest{ScheduledDate: today}, token)
+	makeRequest(t, *ts.Router, "DELETE", "/api/v1/habits/"+habitResp["id"]+"/entries/"+today, nil, token)
+
+	t.Run("Delivers signed events", func(t *testing.T) {
+		ts.Events.DispatchDue(context.Background(), time.Now())
+		if delivered := worker.DeliverDue(context.Background(), time.Now()); delivered != 2 {
+			t.Fatalf("Expected 2 deliveries, got %d", delivered)
+		}
+
+		for i, req := range received {
+			timestamp, _ := strconv.ParseInt(req.Header.Get(webhook.HeaderTimestamp), 10, 64)
+			if !webhook.Verify(created.Secret, timestamp, bodies[i], req.Header.Get(webhook.HeaderSignature)) {
+				t.Errorf("Delivery %d has an invalid signature", i)
+			}
+		}
+		if received[0].Header.Get(webhook.HeaderEvent) != "habit.created" || received[1].Header.Get(webhook.HeaderEvent) != "habit.marked" {
+			t.Errorf("Unexpected events: %s, %s", received[0].Header.Get(webhook.HeaderEvent), received[1].Header.Get(webhook.HeaderEvent))
+		}
+	})
+
+	var deliveries []queries.WebhookDeliveryDTO
+	t.Run("Lists the delivery log", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "GET", "/api/v1/webhooks/"+created.ID+"/deliveries", nil, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", rr.Code)
+		}
+		decodeResponse(t, rr, &deliveries)
+
+		if len(deliveries) != 2 || deliveries[0].Status != "SUCCEEDED" || deliveries[0].LastStatusCode == nil || *deliveries[0].LastStatusCode != 200 {
+			t.Errorf("Unexpected delivery log: %+v", deliveries)
+		}
+
+		rr = makeRequest(t, *ts.Router, "GET", "/api/v1/webhooks/"+created.ID+"/deliveries", nil, otherToken)
+		if rr.Code != http.StatusForbidden {
+			t.Errorf("Expected status 403 for another user, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Redelivers a delivery", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "POST", "/api/v1/webhooks/"+created.ID+"/deliveries/"+deliveries[0].ID+"/redeliver", nil, token)
+		if rr.Code != http.StatusAccepted {
+			t.Fatalf("Expected status 202, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+
+		if delivered := worker.DeliverDue(context.Background(), time.Now()); delivered != 1 || len(received) != 3 {
+			t.Errorf("Expected the delivery to be sent again, got %d", delivered)
+		}
+		if received[2].Header.Get(webhook.HeaderDelivery) != deliveries[0].ID {
+			t.Errorf("Expected the redelivery to keep its delivery ID")
+		}
+	})
+
+	t.Run("Refuses to redeliver to a disabled webhook", func(t *testing.T) {
+		disabled := false
+		rr := makeRequest(t, *ts.Router, "PUT", "/api/v1/webhooks/"+created.ID, UpdateWebhookRequest{Enabled: &disabled}, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", rr.Code)
+		}
+
+		rr = makeRequest(t, *ts.Router, "POST", "/api/v1/webhooks/"+created.ID+"/deliveries/"+deliveries[0].ID+"/redeliver", nil, token)
+		if rr.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Deletes a webhook", func(t *testing.T) {
+		rr := makeRequest(t, *ts.Router, "DELETE", "/api/v1/webhooks/"+created.ID, nil, otherToken)
+		if rr.Code != http.StatusForbidden {
+			t.Errorf("Expected status 403 for another user, got %d", rr.Code)
+		}
+
+		rr = makeRequest(t, *ts.Router, "DELETE", "/api/v1/webhooks/"+created.ID, nil, token)
+		if rr.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", rr.Code)
+		}
+
+		rr = makeRequest(t, *ts.Router, "GET", "/api/v1/webhooks/"+created.ID+"/deliveries", nil, token)
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 after delete, got %d", rr.Code)
+		}
+	})
+}
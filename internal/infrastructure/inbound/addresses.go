@@ -0,0 +1,73 @@
+package inbound
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"apocapoc-api/internal/infrastructure/auth"
+)
+
+// Addresses builds the signed Reply-To addresses of reminders and digests by
+// adding a token to the local part of the inbound address, as in
+// "reply+TOKEN@example.com". It implements services.ReplyAddresses.
+type Addresses struct {
+	tokens *auth.ReplyTokens
+	local  string
+	domain string
+	now    func() time.Time
+}
+
+func NewAddresses(tokens *auth.ReplyTokens, address string) (*Addresses, error) {
+	parsed, err := mail.ParseAddress(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid inbound email address: %w", err)
+	}
+
+	local, domain, _ := strings.Cut(parsed.Address, "@")
+	if strings.Contains(local, "+") {
+		return nil, fmt.Errorf("inbound email address %q must not contain a +", address)
+	}
+
+	return &Addresses{
+		tokens: tokens,
+		local:  local,
+		domain: strings.ToLower(domain),
+		now:    time.Now,
+	}, nil
+}
+
+func (a *Addresses) HabitAddress(habitID string) string {
+	return a.address(auth.ReplyTokenHabit, habitID)
+}
+
+func (a *Addresses) UserAddress(userID string) string {
+	return a.address(auth.ReplyTokenUser, userID)
+}
+
+func (a *Addresses) address(kind auth.ReplyTokenKind, id string) string {
+	token, err := a.tokens.Sign(auth.ReplyToken{Kind: kind, ID: id, IssuedAt: a.now()})
+	if err != nil {
+		return ""
+	}
+	return a.local + "+" + token + "@" + a.domain
+}
+
+// Parse returns the token of a signed reply address, or
+// auth.ErrInvalidReplyToken for any other address.
+func (a *Addresses) Parse(address string) (*auth.ReplyToken, error) {
+	address = strings.Trim(strings.TrimSpace(address), "<>")
+
+	local, domain, ok := strings.Cut(address, "@")
+	if !ok || !strings.EqualFold(domain, a.domain) {
+		return nil, auth.ErrInvalidReplyToken
+	}
+
+	base, token, ok := strings.Cut(local, "+")
+	if !ok || !strings.EqualFold(base, a.local) {
+		return nil, auth.ErrInvalidReplyToken
+	}
+
+	return a.tokens.Verify(token)
+}
@@ -0,0 +1,218 @@
+package inbound
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxCommands bounds how many lines of a reply are read as commands.
+const maxCommands = 20
+
+var (
+	errNoText = errors.New("no text part in message")
+
+	htmlTags = regexp.MustCompile(`<[^>]*>`)
+)
+
+var (
+	doneWords = map[string]bool{"done": true, "yes": true, "y": true, "ok": true, "hecho": true, "sí": true, "si": true}
+	skipWords = map[string]bool{"skip": true, "no": true, "n": true, "saltar": true}
+)
+
+type action string
+
+const (
+	actionDone action = "done"
+	actionSkip action = "skip"
+)
+
+type command struct {
+	action action
+	// habit is the name given in the command, which replies about a single
+	// habit leave empty.
+	habit string
+	value *float64
+}
+
+// isAutoSubmitted reports whether the message was sent by a machine, like an
+// out-of-office reply, which must not be answered.
+func isAutoSubmitted(header mail.Header) bool {
+	if value := strings.ToLower(header.Get("Auto-Submitted")); value != "" && value != "no" {
+		return true
+	}
+	switch strings.ToLower(header.Get("Precedence")) {
+	case "bulk", "junk", "list", "auto_reply":
+		return true
+	}
+	return false
+}
+
+// replyText returns the plain text of a message, preferring the text part of
+// a multipart message over its HTML part.
+func replyText(message *mail.Message) (string, error) {
+	return partText(message.Header.Get("Content-Type"), message.Header.Get("Content-Transfer-Encoding"), message.Body)
+}
+
+func partText(contentType, encoding string, body io.Reader) (string, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain"
+	}
+
+	switch strings.ToLower(encoding) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		return multipartText(multipart.NewReader(body, params["boundary"]))
+	}
+	if mediaType != "text/plain" && mediaType != "text/html" {
+		return "", errNoText
+	}
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+	if mediaType == "text/html" {
+		return htmlText(string(content)), nil
+	}
+	return string(content), nil
+}
+
+func multipartText(reader *multipart.Reader) (string, error) {
+	var html string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		contentType := part.Header.Get("Content-Type")
+		if contentType == "" {
+			contentType = "text/plain"
+		}
+		mediaType, _, _ := mime.ParseMediaType(contentType)
+
+		text, err := partText(contentType, part.Header.Get("Content-Transfer-Encoding"), part)
+		if err == errNoText {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if mediaType != "text/html" {
+			return text, nil
+		}
+		if html == "" {
+			html = text
+		}
+	}
+
+	if html == "" {
+		return "", errNoText
+	}
+	return html, nil
+}
+
+func htmlText(html string) string {
+	for _, tag := range []string{"<br>", "<br/>", "<br />", "</p>", "</div>"} {
+		html = strings.ReplaceAll(html, tag, tag+"\n")
+	}
+	return htmlTags.ReplaceAllString(html, "")
+}
+
+// commandLines returns the lines the user wrote above the quoted email,
+// skipping blank lines.
+func commandLines(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if isQuoteStart(line) {
+			break
+		}
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+		if len(lines) == maxCommands {
+			break
+		}
+	}
+	return lines
+}
+
+// isQuoteStart recognizes where mail clients start quoting the email being
+// replied to, or where the signature starts.
+func isQuoteStart(line string) bool {
+	lower := strings.ToLower(line)
+	return strings.HasPrefix(line, ">") ||
+		line == "--" ||
+		strings.HasPrefix(line, "-----") ||
+		strings.HasPrefix(line, "_____") ||
+		strings.HasSuffix(lower, "wrote:") ||
+		strings.HasSuffix(lower, "escribió:") ||
+		strings.HasPrefix(lower, "from:") ||
+		strings.HasPrefix(lower, "de:")
+}
+
+// parseCommand reads "done", "skip" and numbers, optionally with a habit
+// name: "done Reading", "skip Gym", "Water 3" or "3 Water". Lines that are
+// none of these are not commands.
+func parseCommand(line string) (command, bool) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return command{}, false
+	}
+
+	cmd := command{action: actionDone}
+	recognized := false
+
+	switch word := strings.ToLower(strings.TrimRight(fields[0], ".!,:;")); {
+	case doneWords[word]:
+		fields, recognized = fields[1:], true
+	case skipWords[word]:
+		cmd.action = actionSkip
+		fields, recognized = fields[1:], true
+	}
+
+	if cmd.action == actionDone && len(fields) > 0 {
+		if value, ok := parseValue(fields[len(fields)-1]); ok {
+			cmd.value = &value
+			fields = fields[:len(fields)-1]
+		} else if value, ok := parseValue(fields[0]); ok {
+			cmd.value = &value
+			fields = fields[1:]
+		}
+	}
+
+	if !recognized && cmd.value == nil {
+		return command{}, false
+	}
+
+	cmd.habit = strings.TrimRight(strings.Join(fields, " "), ".!,")
+	return cmd, true
+}
+
+// parseValue accepts decimal commas, as in "2,5".
+func parseValue(field string) (float64, bool) {
+	value, err := strconv.ParseFloat(strings.Replace(strings.TrimRight(field, ".!"), ",", ".", 1), 64)
+	if err != nil || value < 0 {
+		return 0, false
+	}
+	return value, true
+}
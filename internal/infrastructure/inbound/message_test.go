@@ -0,0 +1,68 @@
+package inbound
+
+import (
+	"net/mail"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCommand(t *testing.T) {
+	value := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		line     string
+		expected *command
+	}{
+		{"done", &command{action: actionDone}},
+		{"Yes!", &command{action: actionDone}},
+		{"hecho Lectura", &command{action: actionDone, habit: "Lectura"}},
+		{"skip", &command{action: actionSkip}},
+		{"skip Gym.", &command{action: actionSkip, habit: "Gym"}},
+		{"3", &command{action: actionDone, value: value(3)}},
+		{"Water 2,5", &command{action: actionDone, habit: "Water", value: value(2.5)}},
+		{"4 glasses", &command{action: actionDone, habit: "glasses", value: value(4)}},
+		{"done Read 20 pages", &command{action: actionDone, habit: "Read 20 pages"}},
+		{"Thanks!", nil},
+		{"-3", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			cmd, ok := parseCommand(tt.line)
+			if tt.expected == nil {
+				if ok {
+					t.Errorf("Expected no command, got %+v", cmd)
+				}
+				return
+			}
+			if !ok || !reflect.DeepEqual(cmd, *tt.expected) {
+				t.Errorf("Expected %+v, got %+v", *tt.expected, cmd)
+			}
+		})
+	}
+}
+
+func TestCommandLinesStopAtTheQuote(t *testing.T) {
+	text := "done reading\n\nwater 3\n-- \nSent from my phone\n"
+	if lines := commandLines(text); !reflect.DeepEqual(lines, []string{"done reading", "water 3"}) {
+		t.Errorf("Unexpected lines: %q", lines)
+	}
+
+	text = "skip\r\n________________________________\r\nFrom: Apocapoc\r\n"
+	if lines := commandLines(text); !reflect.DeepEqual(lines, []string{"skip"}) {
+		t.Errorf("Unexpected lines: %q", lines)
+	}
+}
+
+func TestReplyTextFallsBackToHTML(t *testing.T) {
+	message, _ := mail.ReadMessage(strings.NewReader("Content-Type: text/html\r\nContent-Transfer-Encoding: base64\r\n\r\nPGRpdj5kb25lPC9kaXY+PGRpdj5yZWFkaW5nPC9kaXY+\r\n"))
+
+	text, err := replyText(message)
+	if err != nil {
+		t.Fatalf("replyText failed: %v", err)
+	}
+	if lines := commandLines(text); !reflect.DeepEqual(lines, []string{"done", "reading"}) {
+		t.Errorf("Unexpected lines from %q", text)
+	}
+}
@@ -0,0 +1,270 @@
+package inbound
+
+import (
+	"context"
+	"net/mail"
+	"strconv"
+	"time"
+
+	"apocapoc-api/internal/application/commands"
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/domain/services"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/infrastructure/auth"
+	"apocapoc-api/internal/infrastructure/logger"
+	"apocapoc-api/internal/shared/errors"
+	"apocapoc-api/internal/shared/utils"
+)
+
+// ReplyWindow is how long after an email was sent replying to it still
+// checks in habits.
+const ReplyWindow = 7 * 24 * time.Hour
+
+// Outcomes of a command, each with a reply_result_ email text.
+const (
+	outcomeMarked         = "marked"
+	outcomeRecorded       = "recorded"
+	outcomeSkipped        = "skipped"
+	outcomeAlreadyMarked  = "already_marked"
+	outcomeInvalidValue   = "invalid_value"
+	outcomeNotFound       = "not_found"
+	outcomeAmbiguous      = "ambiguous"
+	outcomeFailed         = "failed"
+	outcomeHabitRequired  = "habit_required"
+	outcomeExpired        = "expired"
+	outcomeUnknownCommand = "unknown"
+)
+
+// Processor checks in habits from replies to reminders and digests. A reply
+// to a reminder is about the reminded habit on the day it was reminded of,
+// so it only needs "done", "skip" or a number. A reply to a digest names the
+// habits, one per line, and checks them in for the day it is received. The
+// user is answered with what was done, at their account's address rather than
+// the sender's, so the reply address cannot be used to send mail elsewhere.
+type Processor struct {
+	addresses    *Addresses
+	userRepo     repositories.UserRepository
+	habitRepo    repositories.HabitRepository
+	reminderRepo repositories.HabitReminderRepository
+	scheduleRepo repositories.NotificationScheduleRepository
+	markHandler  *commands.MarkHabitHandler
+	mailer       services.Mailer
+	// location is the time zone of users who have not set one.
+	location *time.Location
+	now      func() time.Time
+}
+
+func NewProcessor(
+	addresses *Addresses,
+	userRepo repositories.UserRepository,
+	habitRepo repositories.HabitRepository,
+	reminderRepo repositories.HabitReminderRepository,
+	scheduleRepo repositories.NotificationScheduleRepository,
+	markHandler *commands.MarkHabitHandler,
+	mailer services.Mailer,
+	location *time.Location,
+) *Processor {
+	return &Processor{
+		addresses:    addresses,
+		userRepo:     userRepo,
+		habitRepo:    habitRepo,
+		reminderRepo: reminderRepo,
+		scheduleRepo: scheduleRepo,
+		markHandler:  markHandler,
+		mailer:       mailer,
+		location:     location,
+		now:          time.Now,
+	}
+}
+
+// Accepts reports whether mail to the address is a reply the processor can
+// handle.
+func (p *Processor) Accepts(address string) bool {
+	_, err := p.addresses.Parse(address)
+	return err == nil
+}
+
+// Process handles a reply sent to recipient. Replies sent by machines, like
+// out-of-office messages, are dropped.
+func (p *Processor) Process(ctx context.Context, recipient string, message *mail.Message) error {
+	token, err := p.addresses.Parse(recipient)
+	if err != nil {
+		return err
+	}
+
+	if isAutoSubmitted(message.Header) {
+		logger.Debug().Str("recipient", recipient).Msg("Ignoring automatic reply")
+		return nil
+	}
+
+	var user *entities.User
+	var habit *entities.Habit
+	if token.Kind == auth.ReplyTokenHabit {
+		habit, err = p.habitRepo.FindByID(ctx, token.ID)
+		if err != nil {
+			return err
+		}
+		if habit == nil {
+			return errors.ErrNotFound
+		}
+		user, err = p.userRepo.FindByID(ctx, habit.UserID)
+	} else {
+		user, err = p.userRepo.FindByID(ctx, token.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	var results []map[string]string
+	if p.now().Sub(token.IssuedAt) > ReplyWindow {
+		results = []map[string]string{{"Outcome": outcomeExpired}}
+	} else {
+		text, err := replyText(message)
+		if err != nil && err != errNoText {
+			return err
+		}
+
+		if habit != nil {
+			results = p.replyToHabit(ctx, habit, token.IssuedAt, commandLines(text))
+		} else if results, err = p.replyToUser(ctx, user, commandLines(text)); err != nil {
+			return err
+		}
+	}
+
+	return p.mailer.SendTemplate(ctx, services.TemplatedEmail{
+		UserID:   user.ID,
+		To:       user.Email,
+		Language: user.Language,
+		Template: services.EmailTemplateReplyConfirmation,
+		Data:     map[string]interface{}{"Results": results},
+	})
+}
+
+// replyToHabit runs the first command in the reply, ignoring any habit name
+// it gives.
+func (p *Processor) replyToHabit(ctx context.Context, habit *entities.Habit, remindedAt time.Time, lines []string) []map[string]string {
+	for _, line := range lines {
+		cmd, ok := parseCommand(line)
+		if !ok {
+			continue
+		}
+		date := localDate(remindedAt, p.habitLocation(ctx, habit))
+		return []map[string]string{p.run(ctx, habit, date, cmd)}
+	}
+	return []map[string]string{{"Outcome": outcomeUnknownCommand}}
+}
+
+func (p *Processor) replyToUser(ctx context.Context, user *entities.User, lines []string) ([]map[string]string, error) {
+	habits, err := p.habitRepo.FindActiveByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	date := localDate(p.now(), p.userLocation(ctx, user.ID))
+
+	var results []map[string]string
+	for _, line := range lines {
+		cmd, ok := parseCommand(line)
+		if !ok {
+			continue
+		}
+		if cmd.habit == "" {
+			results = append(results, map[string]string{"Outcome": outcomeHabitRequired})
+			continue
+		}
+
+		matches := matchHabits(habits, cmd.habit)
+		switch len(matches) {
+		case 0:
+			results = append(results, map[string]string{"Outcome": outcomeNotFound, "Habit": cmd.habit})
+		case 1:
+			results = append(results, p.run(ctx, matches[0], date, cmd))
+		default:
+			results = append(results, map[string]string{"Outcome": outcomeAmbiguous, "Habit": cmd.habit})
+		}
+	}
+
+	if len(results) == 0 {
+		return []map[string]string{{"Outcome": outcomeUnknownCommand}}, nil
+	}
+	return results, nil
+}
+
+func (p *Processor) run(ctx context.Context, habit *entities.Habit, date time.Time, cmd command) map[string]string {
+	result := map[string]string{"Habit": habit.Name}
+
+	if cmd.action == actionSkip {
+		result["Outcome"] = outcomeSkipped
+		return result
+	}
+	if !habit.IsActive() {
+		result["Outcome"] = outcomeNotFound
+		return result
+	}
+	if cmd.value != nil && habit.Type == value_objects.HabitTypeBoolean {
+		result["Outcome"] = outcomeInvalidValue
+		return result
+	}
+
+	err := p.markHandler.Handle(ctx, commands.MarkHabitCommand{
+		HabitID:       habit.ID,
+		ScheduledDate: date,
+		Value:         cmd.value,
+	})
+	switch {
+	case err == errors.ErrAlreadyExists:
+		result["Outcome"] = outcomeAlreadyMarked
+	case err != nil:
+		logger.Error().Err(err).Str("habit_id", habit.ID).Msg("Failed to mark habit from email reply")
+		result["Outcome"] = outcomeFailed
+	case cmd.value != nil:
+		result["Outcome"] = outcomeRecorded
+		result["Value"] = strconv.FormatFloat(*cmd.value, 'f', -1, 64)
+	default:
+		result["Outcome"] = outcomeMarked
+	}
+	return result
+}
+
+// habitLocation is the time zone of the habit's reminder, which is the one
+// the reminded day was worked out in.
+func (p *Processor) habitLocation(ctx context.Context, habit *entities.Habit) *time.Location {
+	reminders, err := p.reminderRepo.FindByHabitID(ctx, habit.ID)
+	if err == nil && len(reminders) > 0 {
+		if loc, err := time.LoadLocation(reminders[0].Timezone); err == nil {
+			return loc
+		}
+	}
+	return p.userLocation(ctx, habit.UserID)
+}
+
+func (p *Processor) userLocation(ctx context.Context, userID string) *time.Location {
+	schedule, err := p.scheduleRepo.FindByUserID(ctx, userID)
+	if err == nil {
+		if loc, err := time.LoadLocation(schedule.Timezone); err == nil {
+			return loc
+		}
+	}
+	return p.location
+}
+
+// localDate is the day at t in loc, as the UTC midnight habit entries are
+// recorded on.
+func localDate(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// matchHabits returns the habits whose name matches name.
+func matchHabits(habits []*entities.Habit, name string) []*entities.Habit {
+	names := make([]string, len(habits))
+	for i, habit := range habits {
+		names[i] = habit.Name
+	}
+
+	var found []*entities.Habit
+	for _, i := range utils.MatchHabitNames(names, name) {
+		found = append(found, habits[i])
+	}
+	return found
+}
@@ -0,0 +1,257 @@
+package inbound
+
+import (
+	"context"
+	"database/sql"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/application/commands"
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/services"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/infrastructure/auth"
+	"apocapoc-api/internal/infrastructure/persistence/sqlite"
+
+	_ "modernc.org/sqlite"
+)
+
+type recordingMailer struct {
+	sent []services.TemplatedEmail
+}
+
+func (m *recordingMailer) SendTemplate(ctx context.Context, email services.TemplatedEmail) error {
+	m.sent = append(m.sent, email)
+	return nil
+}
+
+type testEnv struct {
+	db           *sql.DB
+	processor    *Processor
+	addresses    *Addresses
+	mailer       *recordingMailer
+	user         *entities.User
+	habitRepo    *sqlite.HabitRepository
+	reminderRepo *sqlite.HabitReminderRepository
+	now          time.Time
+}
+
+func setupProcessor(t *testing.T) *testEnv {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := sqlite.RunMigrations(db); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := sqlite.NewUserRepository(db)
+	habitRepo := sqlite.NewHabitRepository(db)
+	entryRepo := sqlite.NewHabitEntryRepository(db)
+	statsRepo := sqlite.NewHabitStatsRepository(db)
+	pointsRepo := sqlite.NewPointsRepository(db)
+	reminderRepo := sqlite.NewHabitReminderRepository(db)
+	scheduleRepo := sqlite.NewNotificationScheduleRepository(db)
+	transactor := sqlite.NewTransactor(db)
+
+	user := entities.NewUser("reply@example.com", "hash")
+	user.Language = "es"
+	if err := userRepo.Create(context.Background(), user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	addresses, err := NewAddresses(auth.NewReplyTokens("test-secret"), "Apocapoc <reply@Apocapoc.app>")
+	if err != nil {
+		t.Fatalf("NewAddresses failed: %v", err)
+	}
+
+	now := time.Date(2026, 3, 10, 20, 0, 0, 0, time.UTC)
+	addresses.now = func() time.Time { return now }
+
+	mailer := &recordingMailer{}
+	processor := NewProcessor(
+		addresses,
+		userRepo,
+		habitRepo,
+		reminderRepo,
+		scheduleRepo,
+		commands.NewMarkHabitHandler(entryRepo, habitRepo, statsRepo, pointsRepo, transactor, nil, nil),
+		mailer,
+		time.UTC,
+	)
+	processor.now = func() time.Time { return now }
+
+	return &testEnv{db, processor, addresses, mailer, user, habitRepo, reminderRepo, now}
+}
+
+func (env *testEnv) createHabit(t *testing.T, name string, habitType value_objects.HabitType) *entities.Habit {
+	habit := entities.NewHabit(env.user.ID, name, habitType, value_objects.FrequencyDaily, false, false)
+	if err := env.habitRepo.Create(context.Background(), habit); err != nil {
+		t.Fatalf("Failed to create habit: %v", err)
+	}
+	return habit
+}
+
+func (env *testEnv) reply(t *testing.T, recipient, body string) []map[string]string {
+	t.Helper()
+	message, err := mail.ReadMessage(strings.NewReader("From: reply@example.com\r\nSubject: Re: Reminder\r\n\r\n" + body))
+	if err != nil {
+		t.Fatalf("Invalid message: %v", err)
+	}
+
+	sent := len(env.mailer.sent)
+	if err := env.processor.Process(context.Background(), recipient, message); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if len(env.mailer.sent) != sent+1 {
+		t.Fatalf("Expected a confirmation email, got %d", len(env.mailer.sent)-sent)
+	}
+
+	confirmation := env.mailer.sent[sent]
+	if confirmation.To != env.user.Email || confirmation.Template != services.EmailTemplateReplyConfirmation || confirmation.Language != "es" {
+		t.Errorf("Unexpected confirmation: %+v", confirmation)
+	}
+	return confirmation.Data["Results"].([]map[string]string)
+}
+
+func (env *testEnv) entryValues(t *testing.T, habitID string) map[string]float64 {
+	rows, err := env.db.Query("SELECT scheduled_date, COALESCE(value, 0) FROM habit_entries WHERE habit_id = ?", habitID)
+	if err != nil {
+		t.Fatalf("Failed to query entries: %v", err)
+	}
+	defer rows.Close()
+
+	values := make(map[string]float64)
+	for rows.Next() {
+		var date time.Time
+		var value float64
+		rows.Scan(&date, &value)
+		values[date.Format("2006-01-02")] = value
+	}
+	return values
+}
+
+func TestProcessorMarksTheRemindedHabit(t *testing.T) {
+	env := setupProcessor(t)
+	reading := env.createHabit(t, "Reading", value_objects.HabitTypeBoolean)
+	// The reminder went out on the evening of March 10 in Tokyo, which is
+	// already March 11 there.
+	env.reminderRepo.Create(context.Background(), entities.NewHabitReminder(reading.ID, env.user.ID, "05:00", "Asia/Tokyo"))
+	address := env.addresses.HabitAddress(reading.ID)
+
+	results := env.reply(t, address, "Done!\r\n\r\nOn Tue, Mar 10, 2026 Apocapoc wrote:\r\n> skip\r\n")
+	if results[0]["Outcome"] != outcomeMarked || results[0]["Habit"] != "Reading" {
+		t.Errorf("Unexpected results: %v", results)
+	}
+	if _, ok := env.entryValues(t, reading.ID)["2026-03-11"]; !ok {
+		t.Errorf("Expected the habit to be marked on the reminded day, got %v", env.entryValues(t, reading.ID))
+	}
+
+	results = env.reply(t, address, "yes")
+	if results[0]["Outcome"] != outcomeAlreadyMarked {
+		t.Errorf("Expected the second reply to find it marked, got %v", results)
+	}
+}
+
+func TestProcessorRecordsCounterValues(t *testing.T) {
+	env := setupProcessor(t)
+	water := env.createHabit(t, "Water", value_objects.HabitTypeCounter)
+	address := env.addresses.HabitAddress(water.ID)
+
+	results := env.reply(t, address, "3")
+	if results[0]["Outcome"] != outcomeRecorded || results[0]["Value"] != "3" {
+		t.Errorf("Unexpected results: %v", results)
+	}
+	env.reply(t, address, "2 more glasses")
+	if value := env.entryValues(t, water.ID)["2026-03-10"]; value != 5 {
+		t.Errorf("Expected the counter to add up to 5, got %v", value)
+	}
+
+	reading := env.createHabit(t, "Reading", value_objects.HabitTypeBoolean)
+	results = env.reply(t, env.addresses.HabitAddress(reading.ID), "3")
+	if results[0]["Outcome"] != outcomeInvalidValue {
+		t.Errorf("Expected a number to be refused for a yes/no habit, got %v", results)
+	}
+}
+
+func TestProcessorSkipsAndExplainsUnknownReplies(t *testing.T) {
+	env := setupProcessor(t)
+	gym := env.createHabit(t, "Gym", value_objects.HabitTypeBoolean)
+	address := env.addresses.HabitAddress(gym.ID)
+
+	if results := env.reply(t, address, "skip"); results[0]["Outcome"] != outcomeSkipped {
+		t.Errorf("Expected a skip, got %v", results)
+	}
+	if results := env.reply(t, address, "Thanks for the reminder"); results[0]["Outcome"] != outcomeUnknownCommand {
+		t.Errorf("Expected an unknown reply, got %v", results)
+	}
+	if len(env.entryValues(t, gym.ID)) != 0 {
+		t.Error("Expected nothing to be marked")
+	}
+}
+
+func TestProcessorMarksHabitsNamedInDigestReplies(t *testing.T) {
+	env := setupProcessor(t)
+	reading := env.createHabit(t, "Reading", value_objects.HabitTypeBoolean)
+	water := env.createHabit(t, "Water", value_objects.HabitTypeCounter)
+	env.createHabit(t, "Walk the dog", value_objects.HabitTypeBoolean)
+	env.createHabit(t, "Walk 10k steps", value_objects.HabitTypeBoolean)
+
+	results := env.reply(t, env.addresses.UserAddress(env.user.ID), "done reading\r\nwater 4\r\ndone walk\r\ndone\r\nskip walk the dog\r\nsi piano\r\n")
+
+	expected := []string{outcomeMarked, outcomeRecorded, outcomeAmbiguous, outcomeHabitRequired, outcomeSkipped, outcomeNotFound}
+	if len(results) != len(expected) {
+		t.Fatalf("Expected %d results, got %v", len(expected), results)
+	}
+	for i, outcome := range expected {
+		if results[i]["Outcome"] != outcome {
+			t.Errorf("Expected result %d to be %s, got %v", i, outcome, results[i])
+		}
+	}
+
+	if _, ok := env.entryValues(t, reading.ID)["2026-03-10"]; !ok {
+		t.Error("Expected Reading to be marked today")
+	}
+	if value := env.entryValues(t, water.ID)["2026-03-10"]; value != 4 {
+		t.Errorf("Expected Water to be 4, got %v", value)
+	}
+}
+
+func TestProcessorRefusesOldAndForgedReplies(t *testing.T) {
+	env := setupProcessor(t)
+	reading := env.createHabit(t, "Reading", value_objects.HabitTypeBoolean)
+	address := env.addresses.HabitAddress(reading.ID)
+
+	env.processor.now = func() time.Time { return env.now.Add(ReplyWindow + time.Hour) }
+	if results := env.reply(t, address, "done"); results[0]["Outcome"] != outcomeExpired {
+		t.Errorf("Expected an expired reply, got %v", results)
+	}
+	if len(env.entryValues(t, reading.ID)) != 0 {
+		t.Error("Expected an old reply not to mark the habit")
+	}
+
+	forged := strings.Replace(address, "reply+", "reply+a", 1)
+	if env.processor.Accepts(forged) || env.processor.Accepts("reply@apocapoc.app") || env.processor.Accepts(strings.Replace(address, "apocapoc.app", "example.com", 1)) {
+		t.Error("Expected only signed reply addresses to be accepted")
+	}
+	if !env.processor.Accepts("<" + strings.ToUpper(address) + ">") {
+		t.Error("Expected the address to be accepted whatever its case")
+	}
+}
+
+func TestProcessorIgnoresAutomaticReplies(t *testing.T) {
+	env := setupProcessor(t)
+	reading := env.createHabit(t, "Reading", value_objects.HabitTypeBoolean)
+
+	message, _ := mail.ReadMessage(strings.NewReader("Auto-Submitted: auto-replied\r\nSubject: Out of office\r\n\r\nYes, I am away.\r\n"))
+	if err := env.processor.Process(context.Background(), env.addresses.HabitAddress(reading.ID), message); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if len(env.mailer.sent) != 0 || len(env.entryValues(t, reading.ID)) != 0 {
+		t.Error("Expected an automatic reply to be ignored")
+	}
+}
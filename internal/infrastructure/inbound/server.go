@@ -0,0 +1,257 @@
+package inbound
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"time"
+
+	"apocapoc-api/internal/infrastructure/logger"
+	"apocapoc-api/internal/shared/errors"
+)
+
+const (
+	// MaxMessageSize bounds the replies accepted, which only need a few
+	// lines of text above the quoted email.
+	MaxMessageSize = 1 << 20
+
+	maxRecipients  = 10
+	commandTimeout = 5 * time.Minute
+	processTimeout = 30 * time.Second
+)
+
+type Config struct {
+	Enabled bool
+	// Addr is the address the SMTP listener binds to, like ":2525".
+	Addr string
+}
+
+// Server is a minimal SMTP listener that receives replies from the mail
+// server in front of it. It refuses every recipient except signed reply
+// addresses, so it cannot be used as a relay, and it does not offer TLS or
+// authentication, which the mail server in front handles.
+type Server struct {
+	processor *Processor
+	config    Config
+	// hostname is the domain of the reply addresses, which the listener
+	// greets with.
+	hostname string
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu    sync.Mutex
+	conns map[net.Conn]struct{}
+}
+
+func NewServer(processor *Processor, config Config) *Server {
+	return &Server{
+		processor: processor,
+		config:    config,
+		conns:     make(map[net.Conn]struct{}),
+	}
+}
+
+func (s *Server) Start() {
+	if !s.config.Enabled {
+		logger.Info().Msg("Inbound email is disabled")
+		return
+	}
+
+	listener, err := net.Listen("tcp", s.config.Addr)
+	if err != nil {
+		logger.Error().Err(err).Str("addr", s.config.Addr).Msg("Failed to start inbound email listener")
+		return
+	}
+	s.listener = listener
+	s.hostname = s.processor.addresses.domain
+
+	logger.Info().Str("addr", listener.Addr().String()).Msg("Starting inbound email listener")
+
+	s.wg.Add(1)
+	go s.accept()
+}
+
+func (s *Server) Stop() {
+	if s.listener == nil {
+		return
+	}
+	s.listener.Close()
+
+	s.mu.Lock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	logger.Info().Msg("Inbound email listener stopped")
+}
+
+func (s *Server) accept() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.serve(conn)
+
+			s.mu.Lock()
+			delete(s.conns, conn)
+			s.mu.Unlock()
+		}()
+	}
+}
+
+type session struct {
+	from       string
+	recipients []string
+}
+
+func (s *Server) serve(netConn net.Conn) {
+	conn := textproto.NewConn(netConn)
+	defer conn.Close()
+
+	reply := func(code int, message string) error {
+		return conn.PrintfLine("%d %s", code, message)
+	}
+
+	if err := reply(220, s.hostname+" ESMTP"); err != nil {
+		return
+	}
+
+	var current *session
+	for {
+		netConn.SetDeadline(time.Now().Add(commandTimeout))
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "HELO":
+			err = reply(250, s.hostname)
+		case "EHLO":
+			err = conn.PrintfLine("250-%s\r\n250-8BITMIME\r\n250-PIPELINING\r\n250 SIZE %d", s.hostname, MaxMessageSize)
+		case "MAIL":
+			from, ok := pathArgument(arg, "FROM:")
+			if !ok {
+				err = reply(501, "5.5.4 Syntax: MAIL FROM:<address>")
+				break
+			}
+			current = &session{from: from}
+			err = reply(250, "2.1.0 OK")
+		case "RCPT":
+			to, ok := pathArgument(arg, "TO:")
+			switch {
+			case current == nil:
+				err = reply(503, "5.5.1 MAIL first")
+			case !ok:
+				err = reply(501, "5.5.4 Syntax: RCPT TO:<address>")
+			case len(current.recipients) == maxRecipients:
+				err = reply(452, "4.5.3 Too many recipients")
+			case !s.processor.Accepts(to):
+				err = reply(550, "5.1.1 No such recipient")
+			default:
+				current.recipients = append(current.recipients, to)
+				err = reply(250, "2.1.5 OK")
+			}
+		case "DATA":
+			if current == nil || len(current.recipients) == 0 {
+				err = reply(503, "5.5.1 RCPT first")
+				break
+			}
+			if err = reply(354, "End data with <CR><LF>.<CR><LF>"); err != nil {
+				return
+			}
+			err = s.receive(conn, current, reply)
+			current = nil
+		case "RSET":
+			current = nil
+			err = reply(250, "2.0.0 OK")
+		case "NOOP":
+			err = reply(250, "2.0.0 OK")
+		case "VRFY":
+			err = reply(252, "2.5.0 Cannot verify")
+		case "QUIT":
+			reply(221, "2.0.0 Bye")
+			return
+		default:
+			err = reply(502, "5.5.2 Command not recognized")
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// receive reads the message and processes it for every recipient. Failures
+// are answered with a temporary error, so the sending server retries, except
+// for replies about habits or accounts that no longer exist, which are
+// dropped.
+func (s *Server) receive(conn *textproto.Conn, current *session, reply func(int, string) error) error {
+	dot := conn.DotReader()
+	data, err := io.ReadAll(io.LimitReader(dot, MaxMessageSize+1))
+	if err != nil {
+		return err
+	}
+	if len(data) > MaxMessageSize {
+		// Drain the rest of the message before answering.
+		if _, err := io.Copy(io.Discard, dot); err != nil {
+			return err
+		}
+		return reply(552, "5.3.4 Message too big")
+	}
+
+	message, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return reply(554, "5.6.0 Malformed message")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), processTimeout)
+	defer cancel()
+
+	for _, recipient := range current.recipients {
+		err := s.processor.Process(ctx, recipient, message)
+		if err == errors.ErrNotFound {
+			logger.Warn().Str("recipient", recipient).Msg("Dropping email reply about something that no longer exists")
+		} else if err != nil {
+			logger.Error().Err(err).
+				Str("from", current.from).
+				Str("recipient", recipient).
+				Msg("Failed to process email reply")
+			return reply(451, "4.3.0 Try again later")
+		}
+		// Every recipient reads the same message.
+		message, _ = mail.ReadMessage(bytes.NewReader(data))
+	}
+
+	return reply(250, "2.0.0 OK")
+}
+
+// pathArgument returns the address in "FROM:<address> SIZE=123".
+func pathArgument(arg, prefix string) (string, bool) {
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return "", false
+	}
+	path := strings.TrimSpace(arg[len(prefix):])
+	path, _, _ = strings.Cut(path, " ")
+	if !strings.HasPrefix(path, "<") || !strings.HasSuffix(path, ">") {
+		return "", false
+	}
+	return strings.Trim(path, "<>"), true
+}
@@ -0,0 +1,75 @@
+package inbound
+
+import (
+	"net/smtp"
+	"strings"
+	"testing"
+
+	"apocapoc-api/internal/domain/value_objects"
+)
+
+func startServer(t *testing.T, env *testEnv) string {
+	server := NewServer(env.processor, Config{Enabled: true, Addr: "127.0.0.1:0"})
+	server.Start()
+	if server.listener == nil {
+		t.Fatal("Expected the listener to start")
+	}
+	t.Cleanup(server.Stop)
+	return server.listener.Addr().String()
+}
+
+func TestServerReceivesReplies(t *testing.T) {
+	env := setupProcessor(t)
+	water := env.createHabit(t, "Water", value_objects.HabitTypeCounter)
+	addr := startServer(t, env)
+	recipient := env.addresses.HabitAddress(water.ID)
+
+	// A multipart reply as mail clients send it, with the text part in
+	// quoted-printable and the reminder quoted below.
+	message := strings.Join([]string{
+		"From: User <reply@example.com>",
+		"To: " + recipient,
+		"Subject: Re: Reminder: Water",
+		"MIME-Version: 1.0",
+		`Content-Type: multipart/alternative; boundary="b1"`,
+		"",
+		"--b1",
+		"Content-Type: text/plain; charset=utf-8",
+		"Content-Transfer-Encoding: quoted-printable",
+		"",
+		"4",
+		"",
+		"On Tue, Mar 10, 2026 at 8:00 PM Apocapoc <reply@apocapoc.app> wrote:",
+		"> Time for Water",
+		"--b1",
+		"Content-Type: text/html; charset=utf-8",
+		"",
+		"<div>4</div>",
+		"--b1--",
+		"",
+	}, "\r\n")
+
+	if err := smtp.SendMail(addr, nil, "reply@example.com", []string{recipient}, []byte(message)); err != nil {
+		t.Fatalf("SendMail failed: %v", err)
+	}
+
+	if value := env.entryValues(t, water.ID)["2026-03-10"]; value != 4 {
+		t.Errorf("Expected the counter to be 4, got %v", value)
+	}
+	if len(env.mailer.sent) != 1 {
+		t.Errorf("Expected a confirmation email, got %d", len(env.mailer.sent))
+	}
+}
+
+func TestServerRefusesOtherRecipients(t *testing.T) {
+	env := setupProcessor(t)
+	addr := startServer(t, env)
+
+	err := smtp.SendMail(addr, nil, "spammer@example.com", []string{"someone@example.com"}, []byte("Subject: Hi\r\n\r\nHi\r\n"))
+	if err == nil || !strings.Contains(err.Error(), "550") {
+		t.Errorf("Expected the recipient to be refused, got %v", err)
+	}
+	if len(env.mailer.sent) != 0 {
+		t.Error("Expected nothing to be sent")
+	}
+}
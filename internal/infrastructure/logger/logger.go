@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"io"
 	"os"
 	"strings"
@@ -88,3 +89,11 @@ func Fatal() *zerolog.Event {
 func With() zerolog.Context {
 	return Log.With()
 }
+
+// ErrorLog writes the errors application commands recover from to the
+// global logger.
+type ErrorLog struct{}
+
+func (ErrorLog) LogError(ctx context.Context, err error, userID, message string) {
+	Log.Error().Err(err).Str("user_id", userID).Msg(message)
+}
@@ -0,0 +1,29 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestErrorLog_WritesEntry(t *testing.T) {
+	previous := Log
+	defer func() { Log = previous }()
+
+	var output bytes.Buffer
+	Log = zerolog.New(&output)
+
+	ErrorLog{}.LogError(context.Background(), fmt.Errorf("smtp unavailable"), "user-123", "Failed to send verification email")
+
+	var entry map[string]string
+	if err := json.Unmarshal(output.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected a JSON log entry, got %q", output.String())
+	}
+	if entry["level"] != "error" || entry["error"] != "smtp unavailable" || entry["user_id"] != "user-123" || entry["message"] != "Failed to send verification email" {
+		t.Errorf("Unexpected log entry: %v", entry)
+	}
+}
@@ -0,0 +1,498 @@
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"apocapoc-api/internal/application/commands"
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/infrastructure/logger"
+	"apocapoc-api/internal/shared/errors"
+	"apocapoc-api/internal/shared/utils"
+)
+
+const (
+	keepAlive      = 60 * time.Second
+	commandTimeout = 10 * time.Second
+)
+
+type Config struct {
+	Enabled  bool
+	Interval time.Duration
+}
+
+// Bridge keeps a connection to the MQTT broker of every user who set one up.
+// It publishes the state of each active habit to a retained topic along with
+// Home Assistant discovery configs, and marks or unmarks habits from their
+// command topics. States are refreshed on every run, which also rolls them
+// over at midnight, and whenever a habit event for the user is handled.
+type Bridge struct {
+	integrationRepo repositories.MQTTIntegrationRepository
+	habitRepo       repositories.HabitRepository
+	entryRepo       repositories.HabitEntryRepository
+	statsRepo       repositories.HabitStatsRepository
+	markHandler     *commands.MarkHabitHandler
+	unmarkHandler   *commands.UnmarkHabitHandler
+	config          Config
+	now             func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]*session
+	stopCh   chan struct{}
+}
+
+// session is the connection to one user's broker and what was last
+// published there, so unchanged configs and states are not sent again.
+type session struct {
+	integration *entities.MQTTIntegration
+	topics      topics
+	client      *Client
+
+	mu          sync.Mutex
+	discoveries map[string]string
+	states      map[string]string
+}
+
+func NewBridge(
+	integrationRepo repositories.MQTTIntegrationRepository,
+	habitRepo repositories.HabitRepository,
+	entryRepo repositories.HabitEntryRepository,
+	statsRepo repositories.HabitStatsRepository,
+	markHandler *commands.MarkHabitHandler,
+	unmarkHandler *commands.UnmarkHabitHandler,
+	config Config,
+) *Bridge {
+	return &Bridge{
+		integrationRepo: integrationRepo,
+		habitRepo:       habitRepo,
+		entryRepo:       entryRepo,
+		statsRepo:       statsRepo,
+		markHandler:     markHandler,
+		unmarkHandler:   unmarkHandler,
+		config:          config,
+		now:             time.Now,
+		sessions:        make(map[string]*session),
+		stopCh:          make(chan struct{}),
+	}
+}
+
+func (b *Bridge) Start() {
+	if !b.config.Enabled {
+		logger.Info().Msg("MQTT bridge is disabled")
+		return
+	}
+
+	logger.Info().
+		Dur("interval", b.config.Interval).
+		Msg("Starting MQTT bridge")
+
+	go b.run()
+}
+
+func (b *Bridge) run() {
+	b.Sync(context.Background())
+
+	ticker := time.NewTicker(b.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			logger.Debug().Msg("Syncing MQTT integrations")
+			b.Sync(context.Background())
+
+		case <-b.stopCh:
+			b.closeAll()
+			logger.Info().Msg("MQTT bridge stopped")
+			return
+		}
+	}
+}
+
+func (b *Bridge) Stop() {
+	if b.config.Enabled {
+		close(b.stopCh)
+	}
+}
+
+// Sync connects to the brokers of new or changed integrations, reconnects
+// lost connections, drops the ones of deleted integrations and publishes
+// every user's habits. It returns how many users were published.
+func (b *Bridge) Sync(ctx context.Context) int {
+	integrations, err := b.integrationRepo.FindAll(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to load MQTT integrations")
+		return 0
+	}
+
+	current := make(map[string]*entities.MQTTIntegration, len(integrations))
+	for _, integration := range integrations {
+		current[integration.UserID] = integration
+	}
+
+	b.mu.Lock()
+	for userID, s := range b.sessions {
+		integration, ok := current[userID]
+		switch {
+		case !ok || !integration.UpdatedAt.Equal(s.integration.UpdatedAt):
+			// Settings changed or were removed, so whatever was retained
+			// under the old topics is cleared before disconnecting.
+			s.clear()
+			s.client.Close()
+			delete(b.sessions, userID)
+		case s.client.Err() != nil:
+			logger.Warn().Err(s.client.Err()).Str("user_id", userID).Msg("Lost connection to MQTT broker")
+			delete(b.sessions, userID)
+		}
+	}
+	b.mu.Unlock()
+
+	published := 0
+	for _, integration := range integrations {
+		s, err := b.session(ctx, integration)
+		if err != nil {
+			logger.Warn().Err(err).
+				Str("user_id", integration.UserID).
+				Str("broker", integration.BrokerURL).
+				Msg("Failed to connect to MQTT broker")
+			continue
+		}
+		if err := b.publish(ctx, s); err != nil {
+			logger.Error().Err(err).Str("user_id", integration.UserID).Msg("Failed to publish habits to MQTT")
+			continue
+		}
+		published++
+	}
+
+	return published
+}
+
+// HandleEvent publishes the habits of the user a habit event is about, so
+// changes made anywhere show up right away.
+func (b *Bridge) HandleEvent(ctx context.Context, event *entities.Event) error {
+	b.mu.Lock()
+	s, ok := b.sessions[event.UserID]
+	b.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	return b.publish(ctx, s)
+}
+
+func (b *Bridge) session(ctx context.Context, integration *entities.MQTTIntegration) (*session, error) {
+	b.mu.Lock()
+	s, ok := b.sessions[integration.UserID]
+	b.mu.Unlock()
+	if ok {
+		return s, nil
+	}
+
+	s = &session{
+		integration: integration,
+		topics:      newTopics(integration),
+		discoveries: make(map[string]string),
+		states:      make(map[string]string),
+	}
+
+	client, err := Dial(ctx, Options{
+		BrokerURL: integration.BrokerURL,
+		ClientID:  clientID(integration.UserID),
+		Username:  integration.Username,
+		Password:  integration.Password,
+		KeepAlive: keepAlive,
+		Will:      &Message{Topic: s.topics.availability(), Payload: []byte(payloadOffline), Retained: true},
+	}, func(message Message) {
+		b.handleCommand(s, message)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Commands may arrive as soon as the subscription is granted, and
+	// publish reads the client under the session's lock.
+	s.mu.Lock()
+	s.client = client
+	s.mu.Unlock()
+
+	if err := client.Subscribe(ctx, s.topics.commandFilter()); err != nil {
+		client.Close()
+		return nil, err
+	}
+	if err := client.Publish(Message{Topic: s.topics.availability(), Payload: []byte(payloadOnline), Retained: true}); err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	logger.Info().Str("user_id", integration.UserID).Msg("Connected to MQTT broker")
+
+	b.mu.Lock()
+	b.sessions[integration.UserID] = s
+	b.mu.Unlock()
+
+	return s, nil
+}
+
+// clientID is unique per user and within the 23 characters every MQTT 3.1.1
+// broker accepts.
+func clientID(userID string) string {
+	id := strings.ReplaceAll(userID, "-", "")
+	if len(id) > 14 {
+		id = id[:14]
+	}
+	return "apocapoc-" + id
+}
+
+func (b *Bridge) publish(ctx context.Context, s *session) error {
+	userID := s.integration.UserID
+	today := s.integration.Today(b.now())
+
+	habits, err := b.habitRepo.FindActiveByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	stats, err := b.statsRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	statsByID := make(map[string]*entities.HabitStats, len(stats))
+	for _, habitStats := range stats {
+		statsByID[habitStats.HabitID] = habitStats
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	active := make(map[string]bool, len(habits))
+	for _, habit := range habits {
+		active[habit.ID] = true
+
+		discoveries := discoveryMessages(s.topics, habit)
+		fingerprint := discoveryFingerprint(discoveries)
+		if previous, ok := s.discoveries[habit.ID]; !ok || previous != fingerprint {
+			if ok {
+				// The habit's type may have changed, which changes its
+				// entities, so the old ones are removed first.
+				if err := s.send(removalMessages(s.topics, habit.ID)[1:]); err != nil {
+					return err
+				}
+			}
+			if err := s.send(discoveries); err != nil {
+				return err
+			}
+			s.discoveries[habit.ID] = fingerprint
+		}
+
+		entry, err := b.todaysEntry(ctx, habit.ID, today)
+		if err != nil {
+			return err
+		}
+		state := newHabitState(habit, today, entry, statsByID[habit.ID])
+		payload, _ := json.Marshal(state)
+		if s.states[habit.ID] != string(payload) {
+			if err := s.client.Publish(Message{Topic: s.topics.state(habit.ID), Payload: payload, Retained: true}); err != nil {
+				return err
+			}
+			s.states[habit.ID] = string(payload)
+		}
+	}
+
+	for habitID := range s.discoveries {
+		if !active[habitID] {
+			if err := s.send(removalMessages(s.topics, habitID)); err != nil {
+				return err
+			}
+			delete(s.discoveries, habitID)
+			delete(s.states, habitID)
+		}
+	}
+
+	return nil
+}
+
+// todaysEntry returns the habit's entry for today, or nil when it is not done
+// today.
+func (b *Bridge) todaysEntry(ctx context.Context, habitID string, today time.Time) (*entities.HabitEntry, error) {
+	entries, err := b.entryRepo.FindByHabitIDAndDateRange(ctx, habitID, today, today)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.ScheduledDate.Format("2006-01-02") == today.Format("2006-01-02") {
+			return entry, nil
+		}
+	}
+	return nil, nil
+}
+
+func newHabitState(habit *entities.Habit, today time.Time, entry *entities.HabitEntry, stats *entities.HabitStats) habitState {
+	state := habitState{
+		Name:      habit.Name,
+		Date:      today.Format("2006-01-02"),
+		Scheduled: utils.ShouldAppearToday(string(habit.Frequency), habit.SpecificDays, habit.SpecificDates, today),
+		Target:    habit.TargetValue,
+	}
+
+	if entry != nil {
+		state.Done = true
+		state.Value = 1
+		if entry.Value != nil {
+			state.Value = *entry.Value
+		}
+	}
+	if stats != nil {
+		state.Streak = stats.CurrentStreakAt(habit, today)
+		state.LongestStreak = stats.LongestStreak
+	}
+
+	return state
+}
+
+func discoveryFingerprint(messages []Message) string {
+	payloads := make([]string, 0, len(messages))
+	for _, message := range messages {
+		payloads = append(payloads, message.Topic+"\n"+string(message.Payload))
+	}
+	sort.Strings(payloads)
+	return strings.Join(payloads, "\n")
+}
+
+func (s *session) send(messages []Message) error {
+	for _, message := range messages {
+		if err := s.client.Publish(message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clear removes everything the session retained on the broker.
+func (s *session) clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for habitID := range s.discoveries {
+		s.send(removalMessages(s.topics, habitID))
+	}
+	s.client.Publish(Message{Topic: s.topics.availability(), Retained: true})
+}
+
+func (b *Bridge) closeAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for userID, s := range b.sessions {
+		s.client.Publish(Message{Topic: s.topics.availability(), Payload: []byte(payloadOffline), Retained: true})
+		s.client.Close()
+		delete(b.sessions, userID)
+	}
+}
+
+// handleCommand marks or unmarks a habit for today from a message on its
+// command topic. "ON" marks it and "OFF" unmarks it. A number sets the value
+// of a counter or value habit, and 0 unmarks it. Habits that are not active
+// habits of the integration's user are ignored.
+func (b *Bridge) handleCommand(s *session, message Message) {
+	habitID, ok := s.topics.habitID(message.Topic)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	log := logger.With().Str("user_id", s.integration.UserID).Str("habit_id", habitID).Logger()
+
+	habit, err := b.habitRepo.FindByID(ctx, habitID)
+	if err != nil || habit == nil || habit.UserID != s.integration.UserID || !habit.IsActive() {
+		log.Warn().Msg("Ignoring MQTT command for unknown habit")
+		return
+	}
+
+	if err := b.apply(ctx, s, habit, strings.TrimSpace(string(message.Payload))); err != nil {
+		log.Warn().Err(err).Str("payload", string(message.Payload)).Msg("Failed to apply MQTT command")
+	}
+
+	if err := b.publish(ctx, s); err != nil {
+		log.Error().Err(err).Msg("Failed to publish habits to MQTT")
+	}
+}
+
+func (b *Bridge) apply(ctx context.Context, s *session, habit *entities.Habit, payload string) error {
+	today := s.integration.Today(b.now())
+
+	mark := func(value *float64) error {
+		err := b.markHandler.Handle(ctx, commands.MarkHabitCommand{
+			HabitID:       habit.ID,
+			ScheduledDate: today,
+			Value:         value,
+		})
+		if err == errors.ErrAlreadyExists {
+			return nil
+		}
+		return err
+	}
+	unmark := func() error {
+		err := b.unmarkHandler.Handle(ctx, commands.UnmarkHabitCommand{
+			HabitID:       habit.ID,
+			UserID:        habit.UserID,
+			ScheduledDate: today,
+		})
+		if err == errors.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+
+	switch strings.ToUpper(payload) {
+	case payloadOn:
+		return mark(nil)
+	case payloadOff:
+		return unmark()
+	}
+
+	if habit.Type == value_objects.HabitTypeBoolean {
+		return errors.ErrInvalidInput
+	}
+	value, err := strconv.ParseFloat(payload, 64)
+	if err != nil || value < 0 {
+		return errors.ErrInvalidInput
+	}
+	if value == 0 {
+		return unmark()
+	}
+
+	entry, err := b.todaysEntry(ctx, habit.ID, today)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return mark(&value)
+	}
+	current := 0.0
+	if entry.Value != nil {
+		current = *entry.Value
+	}
+	if habit.Type == value_objects.HabitTypeCounter {
+		// Marking a counter adds to today's count.
+		increment := value - current
+		if increment == 0 {
+			return nil
+		}
+		return mark(&increment)
+	}
+	if current == value {
+		return nil
+	}
+	if err := unmark(); err != nil {
+		return err
+	}
+	return mark(&value)
+}
@@ -0,0 +1,226 @@
+package mqtt
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/application/commands"
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/infrastructure/persistence/sqlite"
+
+	_ "modernc.org/sqlite"
+)
+
+type testEnv struct {
+	db              *sql.DB
+	broker          *testBroker
+	bridge          *Bridge
+	user            *entities.User
+	habitRepo       *sqlite.HabitRepository
+	integrationRepo *sqlite.MQTTIntegrationRepository
+}
+
+func setupBridge(t *testing.T) *testEnv {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := sqlite.RunMigrations(db); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := sqlite.NewUserRepository(db)
+	habitRepo := sqlite.NewHabitRepository(db)
+	entryRepo := sqlite.NewHabitEntryRepository(db)
+	statsRepo := sqlite.NewHabitStatsRepository(db)
+	pointsRepo := sqlite.NewPointsRepository(db)
+	integrationRepo := sqlite.NewMQTTIntegrationRepository(db)
+	transactor := sqlite.NewTransactor(db)
+
+	user := entities.NewUser("mqtt@example.com", "hash")
+	userRepo.Create(context.Background(), user)
+
+	broker := newTestBroker(t, "ha", "secret")
+	integrationRepo.Save(context.Background(), entities.NewMQTTIntegration(user.ID, broker.url(), "ha", "secret", "apocapoc", "homeassistant", "UTC"))
+
+	bridge := NewBridge(
+		integrationRepo,
+		habitRepo,
+		entryRepo,
+		statsRepo,
+		commands.NewMarkHabitHandler(entryRepo, habitRepo, statsRepo, pointsRepo, transactor, nil, nil),
+		commands.NewUnmarkHabitHandler(habitRepo, entryRepo, statsRepo, pointsRepo, transactor, nil),
+		Config{Enabled: true, Interval: time.Minute},
+	)
+	bridge.now = func() time.Time { return time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC) }
+	t.Cleanup(bridge.closeAll)
+
+	return &testEnv{db, broker, bridge, user, habitRepo, integrationRepo}
+}
+
+func (env *testEnv) createHabit(t *testing.T, name string, habitType value_objects.HabitType) *entities.Habit {
+	habit := entities.NewHabit(env.user.ID, name, habitType, value_objects.FrequencyDaily, false, false)
+	habit.CreatedAt = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	if err := env.habitRepo.Create(context.Background(), habit); err != nil {
+		t.Fatalf("Failed to create habit: %v", err)
+	}
+	return habit
+}
+
+// retained waits for the broker to hold a retained message on the topic,
+// since messages are published without waiting for the broker.
+func (env *testEnv) retained(t *testing.T, topic string) string {
+	t.Helper()
+	var payload string
+	waitFor(t, "a retained message on "+topic, func() bool {
+		var ok bool
+		payload, ok = env.broker.retainedMessage(topic)
+		return ok
+	})
+	return payload
+}
+
+func (env *testEnv) state(t *testing.T, habitID string) habitState {
+	t.Helper()
+	payload := env.retained(t, "apocapoc/habit/"+habitID+"/state")
+	var state habitState
+	if err := json.Unmarshal([]byte(payload), &state); err != nil {
+		t.Fatalf("Invalid state payload %q: %v", payload, err)
+	}
+	return state
+}
+
+func (env *testEnv) command(habitID, payload string) {
+	env.broker.route(Message{Topic: "apocapoc/habit/" + habitID + "/set", Payload: []byte(payload)})
+}
+
+func TestBridgePublishesDiscoveryAndState(t *testing.T) {
+	env := setupBridge(t)
+	reading := env.createHabit(t, "Reading", value_objects.HabitTypeBoolean)
+	water := env.createHabit(t, "Water", value_objects.HabitTypeCounter)
+
+	if published := env.bridge.Sync(context.Background()); published != 1 {
+		t.Fatalf("Expected 1 user published, got %d", published)
+	}
+
+	if status := env.retained(t, "apocapoc/status"); status != "online" {
+		t.Errorf("Expected the bridge to be online, got %q", status)
+	}
+
+	payload := env.retained(t, "homeassistant/switch/apocapoc/"+reading.ID+"_done/config")
+	var config map[string]interface{}
+	json.Unmarshal([]byte(payload), &config)
+	if config["command_topic"] != "apocapoc/habit/"+reading.ID+"/set" || config["state_topic"] != "apocapoc/habit/"+reading.ID+"/state" {
+		t.Errorf("Unexpected switch config: %s", payload)
+	}
+	env.retained(t, "homeassistant/number/apocapoc/"+water.ID+"_value/config")
+	env.retained(t, "homeassistant/sensor/apocapoc/"+water.ID+"_streak/config")
+
+	state := env.state(t, reading.ID)
+	if state.Done || !state.Scheduled || state.Date != "2026-03-10" || state.Name != "Reading" {
+		t.Errorf("Unexpected state: %+v", state)
+	}
+}
+
+func TestBridgeMarksHabitsFromCommands(t *testing.T) {
+	env := setupBridge(t)
+	reading := env.createHabit(t, "Reading", value_objects.HabitTypeBoolean)
+	water := env.createHabit(t, "Water", value_objects.HabitTypeCounter)
+	env.bridge.Sync(context.Background())
+	env.state(t, water.ID)
+
+	env.command(reading.ID, "ON")
+	waitFor(t, "the habit to be marked", func() bool {
+		return env.state(t, reading.ID).Done
+	})
+	if state := env.state(t, reading.ID); state.Streak != 1 {
+		t.Errorf("Expected a streak of 1, got %+v", state)
+	}
+
+	env.command(reading.ID, "OFF")
+	waitFor(t, "the habit to be unmarked", func() bool {
+		return !env.state(t, reading.ID).Done
+	})
+
+	env.command(water.ID, "3")
+	waitFor(t, "the counter to be set", func() bool {
+		return env.state(t, water.ID).Value == 3
+	})
+	env.command(water.ID, "5")
+	waitFor(t, "the counter to be raised", func() bool {
+		return env.state(t, water.ID).Value == 5
+	})
+	env.command(water.ID, "0")
+	waitFor(t, "the counter to be cleared", func() bool {
+		return !env.state(t, water.ID).Done
+	})
+}
+
+func TestBridgeIgnoresOtherUsersHabits(t *testing.T) {
+	env := setupBridge(t)
+	other := entities.NewUser("other@example.com", "hash")
+	sqlite.NewUserRepository(env.db).Create(context.Background(), other)
+	foreign := entities.NewHabit(other.ID, "Foreign", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	env.habitRepo.Create(context.Background(), foreign)
+	own := env.createHabit(t, "Reading", value_objects.HabitTypeBoolean)
+	env.bridge.Sync(context.Background())
+	env.state(t, own.ID)
+
+	env.command(foreign.ID, "ON")
+	env.command(own.ID, "ON")
+	waitFor(t, "the own habit to be marked", func() bool {
+		return env.state(t, own.ID).Done
+	})
+
+	var count int
+	env.db.QueryRow("SELECT COUNT(*) FROM habit_entries WHERE habit_id = ?", foreign.ID).Scan(&count)
+	if count != 0 {
+		t.Error("Expected another user's habit not to be marked")
+	}
+}
+
+func TestBridgeRemovesArchivedHabitsAndDeletedIntegrations(t *testing.T) {
+	env := setupBridge(t)
+	reading := env.createHabit(t, "Reading", value_objects.HabitTypeBoolean)
+	gym := env.createHabit(t, "Gym", value_objects.HabitTypeBoolean)
+	env.bridge.Sync(context.Background())
+	env.retained(t, "homeassistant/switch/apocapoc/"+gym.ID+"_done/config")
+
+	gym.Archive()
+	env.habitRepo.Update(context.Background(), gym)
+	env.bridge.HandleEvent(context.Background(), entities.NewEvent(entities.EventHabitArchived, env.user.ID, nil))
+
+	waitFor(t, "the archived habit to be removed", func() bool {
+		_, ok := env.broker.retainedMessage("homeassistant/switch/apocapoc/" + gym.ID + "_done/config")
+		return !ok
+	})
+	if _, ok := env.broker.retainedMessage("homeassistant/switch/apocapoc/" + reading.ID + "_done/config"); !ok {
+		t.Error("Expected the active habit to stay")
+	}
+
+	env.integrationRepo.DeleteByUserID(context.Background(), env.user.ID)
+	if published := env.bridge.Sync(context.Background()); published != 0 {
+		t.Errorf("Expected nothing published, got %d", published)
+	}
+	waitFor(t, "everything retained to be cleared", func() bool {
+		return len(env.broker.retainedTopics("")) == 0
+	})
+}
+
+func TestBridgeSkipsUnreachableBrokers(t *testing.T) {
+	env := setupBridge(t)
+	integration, _ := env.integrationRepo.FindByUserID(context.Background(), env.user.ID)
+	integration.Password = "wrong"
+	integration.UpdatedAt = time.Now()
+	env.integrationRepo.Save(context.Background(), integration)
+
+	if published := env.bridge.Sync(context.Background()); published != 0 {
+		t.Errorf("Expected the refused connection to be skipped, got %d published", published)
+	}
+}
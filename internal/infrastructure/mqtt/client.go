@@ -0,0 +1,296 @@
+package mqtt
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	dialTimeout  = 10 * time.Second
+	writeTimeout = 10 * time.Second
+)
+
+var ErrClosed = errors.New("mqtt connection closed")
+
+// Message is a PUBLISH packet. Messages are sent with QoS 0.
+type Message struct {
+	Topic    string
+	Payload  []byte
+	Retained bool
+}
+
+type Options struct {
+	BrokerURL string
+	ClientID  string
+	Username  string
+	Password  string
+	KeepAlive time.Duration
+	// Will is published by the broker when the connection drops without a
+	// DISCONNECT.
+	Will *Message
+}
+
+// Client is a minimal MQTT 3.1.1 client: it publishes and subscribes with
+// QoS 0 over a clean session, which is all the habit bridge needs.
+type Client struct {
+	conn      net.Conn
+	reader    *bufio.Reader
+	keepAlive time.Duration
+	handler   func(Message)
+
+	writeMu sync.Mutex
+
+	mu       sync.Mutex
+	nextID   uint16
+	subacks  map[uint16]chan byte
+	err      error
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+// Dial connects to the broker and waits for it to accept the session.
+// handler is called with every message on the subscribed topics, one at a
+// time from the connection's read loop, so it must not call Subscribe.
+func Dial(ctx context.Context, opts Options, handler func(Message)) (*Client, error) {
+	address, useTLS, err := brokerAddress(opts.BrokerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(dialCtx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	if useTLS {
+		host, _, _ := net.SplitHostPort(address)
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: host})
+		if err := tlsConn.HandshakeContext(dialCtx); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	client := &Client{
+		conn:      conn,
+		reader:    bufio.NewReader(conn),
+		keepAlive: opts.KeepAlive,
+		handler:   handler,
+		subacks:   make(map[uint16]chan byte),
+		done:      make(chan struct{}),
+	}
+
+	if err := client.connect(dialCtx, opts); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	go client.readLoop()
+	if client.keepAlive > 0 {
+		go client.pingLoop()
+	}
+
+	return client, nil
+}
+
+func brokerAddress(brokerURL string) (string, bool, error) {
+	parsed, err := url.Parse(brokerURL)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid broker url: %w", err)
+	}
+
+	var useTLS bool
+	port := "1883"
+	switch parsed.Scheme {
+	case "mqtt", "tcp":
+	case "mqtts", "ssl":
+		useTLS = true
+		port = "8883"
+	default:
+		return "", false, fmt.Errorf("unsupported broker url scheme %q", parsed.Scheme)
+	}
+	if parsed.Port() != "" {
+		port = parsed.Port()
+	}
+
+	return net.JoinHostPort(parsed.Hostname(), port), useTLS, nil
+}
+
+func (c *Client) connect(ctx context.Context, opts Options) error {
+	deadline, _ := ctx.Deadline()
+	c.conn.SetDeadline(deadline)
+	defer c.conn.SetDeadline(time.Time{})
+
+	if _, err := c.conn.Write(encodeConnect(opts)); err != nil {
+		return err
+	}
+
+	header, body, err := readPacket(c.reader)
+	if err != nil {
+		return err
+	}
+	if header&0xf0 != packetConnack || len(body) != 2 {
+		return errMalformedPacket
+	}
+	if code := body[1]; code != 0 {
+		if reason, ok := connackErrors[code]; ok {
+			return fmt.Errorf("mqtt connection refused: %s", reason)
+		}
+		return fmt.Errorf("mqtt connection refused with code %d", code)
+	}
+
+	return nil
+}
+
+func (c *Client) readLoop() {
+	for {
+		if c.keepAlive > 0 {
+			c.conn.SetReadDeadline(time.Now().Add(c.keepAlive * 3 / 2))
+		}
+
+		header, body, err := readPacket(c.reader)
+		if err != nil {
+			c.fail(err)
+			return
+		}
+
+		switch header & 0xf0 {
+		case packetPublish:
+			message, packetID, err := decodePublish(header, body)
+			if err != nil {
+				c.fail(err)
+				return
+			}
+			if header&publishQoSMask == 0x02 {
+				c.write(encodePuback(packetID))
+			}
+			if c.handler != nil {
+				c.handler(message)
+			}
+		case packetSuback:
+			if len(body) < 3 {
+				c.fail(errMalformedPacket)
+				return
+			}
+			c.mu.Lock()
+			ch, ok := c.subacks[binary.BigEndian.Uint16(body)]
+			c.mu.Unlock()
+			if ok {
+				ch <- body[2]
+			}
+		}
+	}
+}
+
+func (c *Client) pingLoop() {
+	ticker := time.NewTicker(c.keepAlive / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.write(encodePacket(packetPingreq, nil)); err != nil {
+				c.fail(err)
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *Client) write(packet []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.Err()
+	default:
+	}
+
+	c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	_, err := c.conn.Write(packet)
+	return err
+}
+
+func (c *Client) fail(err error) {
+	c.doneOnce.Do(func() {
+		c.mu.Lock()
+		c.err = err
+		c.mu.Unlock()
+		c.conn.Close()
+		close(c.done)
+	})
+}
+
+func (c *Client) Publish(message Message) error {
+	return c.write(encodePublish(message))
+}
+
+// Subscribe asks for the messages on the topic filter and waits for the
+// broker to grant it.
+func (c *Client) Subscribe(ctx context.Context, topic string) error {
+	c.mu.Lock()
+	c.nextID++
+	if c.nextID == 0 {
+		c.nextID = 1
+	}
+	packetID := c.nextID
+	ch := make(chan byte, 1)
+	c.subacks[packetID] = ch
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.subacks, packetID)
+		c.mu.Unlock()
+	}()
+
+	if err := c.write(encodeSubscribe(packetID, topic)); err != nil {
+		return err
+	}
+
+	select {
+	case code := <-ch:
+		if code == 0x80 {
+			return fmt.Errorf("mqtt subscription to %s refused", topic)
+		}
+		return nil
+	case <-c.done:
+		return c.Err()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Done is closed when the connection is lost or closed.
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns why the connection ended, or nil while it is open.
+func (c *Client) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// Close disconnects cleanly, so the broker does not publish the will.
+func (c *Client) Close() error {
+	err := c.write(encodePacket(packetDisconnect, nil))
+	c.fail(ErrClosed)
+	return err
+}
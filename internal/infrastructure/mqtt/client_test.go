@@ -0,0 +1,319 @@
+package mqtt
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testBroker is a local MQTT broker that keeps retained messages and routes
+// published messages to matching subscriptions, which is enough to test the
+// client and the bridge end to end.
+type testBroker struct {
+	listener net.Listener
+	username string
+	password string
+
+	mu       sync.Mutex
+	retained map[string]string
+	conns    []*brokerConn
+}
+
+type brokerConn struct {
+	conn    net.Conn
+	writeMu sync.Mutex
+	filters []string
+}
+
+func newTestBroker(t *testing.T, username, password string) *testBroker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	broker := &testBroker{
+		listener: listener,
+		username: username,
+		password: password,
+		retained: make(map[string]string),
+	}
+	t.Cleanup(func() {
+		listener.Close()
+		broker.mu.Lock()
+		for _, c := range broker.conns {
+			c.conn.Close()
+		}
+		broker.mu.Unlock()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go broker.serve(conn)
+		}
+	}()
+
+	return broker
+}
+
+func (b *testBroker) url() string {
+	return "mqtt://" + b.listener.Addr().String()
+}
+
+func (b *testBroker) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	c := &brokerConn{conn: conn}
+
+	header, body, err := readPacket(reader)
+	if err != nil || header != packetConnect {
+		return
+	}
+	will, username, password, ok := decodeConnect(body)
+	if !ok {
+		return
+	}
+	if username != b.username || password != b.password {
+		conn.Write(encodePacket(packetConnack, []byte{0, 4}))
+		return
+	}
+	conn.Write(encodePacket(packetConnack, []byte{0, 0}))
+
+	b.mu.Lock()
+	b.conns = append(b.conns, c)
+	b.mu.Unlock()
+
+	for {
+		header, body, err := readPacket(reader)
+		if err != nil {
+			if will != nil {
+				b.route(*will)
+			}
+			return
+		}
+
+		switch header & 0xf0 {
+		case packetPublish:
+			message, _, err := decodePublish(header, body)
+			if err != nil {
+				return
+			}
+			b.route(message)
+		case packetSubscribe:
+			packetID := binary.BigEndian.Uint16(body)
+			filter, _, _ := readString(body[2:])
+			b.mu.Lock()
+			c.filters = append(c.filters, filter)
+			b.mu.Unlock()
+			c.write(encodePacket(packetSuback, append(binary.BigEndian.AppendUint16(nil, packetID), 0)))
+		case packetPingreq:
+			c.write(encodePacket(packetPingresp, nil))
+		case packetDisconnect:
+			return
+		}
+	}
+}
+
+func decodeConnect(body []byte) (*Message, string, string, bool) {
+	protocol, rest, err := readString(body)
+	if err != nil || protocol != "MQTT" || len(rest) < 4 {
+		return nil, "", "", false
+	}
+	flags := rest[1]
+	rest = rest[4:]
+
+	if _, rest, err = readString(rest); err != nil {
+		return nil, "", "", false
+	}
+
+	var will *Message
+	if flags&connectFlagWill != 0 {
+		topic, next, err := readString(rest)
+		if err != nil {
+			return nil, "", "", false
+		}
+		payload, next, err := readString(next)
+		if err != nil {
+			return nil, "", "", false
+		}
+		will = &Message{Topic: topic, Payload: []byte(payload), Retained: flags&connectFlagWillRetain != 0}
+		rest = next
+	}
+
+	var username, password string
+	if flags&connectFlagUsername != 0 {
+		if username, rest, err = readString(rest); err != nil {
+			return nil, "", "", false
+		}
+	}
+	if flags&connectFlagPassword != 0 {
+		if password, _, err = readString(rest); err != nil {
+			return nil, "", "", false
+		}
+	}
+
+	return will, username, password, true
+}
+
+// route stores retained messages and sends the message to every matching
+// subscription, as Home Assistant publishing a command would.
+func (b *testBroker) route(message Message) {
+	b.mu.Lock()
+	if message.Retained {
+		if len(message.Payload) == 0 {
+			delete(b.retained, message.Topic)
+		} else {
+			b.retained[message.Topic] = string(message.Payload)
+		}
+	}
+	var targets []*brokerConn
+	for _, c := range b.conns {
+		for _, filter := range c.filters {
+			if topicMatches(filter, message.Topic) {
+				targets = append(targets, c)
+				break
+			}
+		}
+	}
+	b.mu.Unlock()
+
+	for _, c := range targets {
+		c.write(encodePublish(Message{Topic: message.Topic, Payload: message.Payload}))
+	}
+}
+
+func (c *brokerConn) write(packet []byte) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.conn.Write(packet)
+}
+
+func (b *testBroker) retainedMessage(topic string) (string, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	payload, ok := b.retained[topic]
+	return payload, ok
+}
+
+func (b *testBroker) retainedTopics(prefix string) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var topics []string
+	for topic := range b.retained {
+		if strings.HasPrefix(topic, prefix) {
+			topics = append(topics, topic)
+		}
+	}
+	return topics
+}
+
+func topicMatches(filter, topic string) bool {
+	filterLevels := strings.Split(filter, "/")
+	topicLevels := strings.Split(topic, "/")
+	for i, level := range filterLevels {
+		if level == "#" {
+			return true
+		}
+		if i >= len(topicLevels) || (level != "+" && level != topicLevels[i]) {
+			return false
+		}
+	}
+	return len(filterLevels) == len(topicLevels)
+}
+
+func waitFor(t *testing.T, description string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", description)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestClientPublishAndSubscribe(t *testing.T) {
+	broker := newTestBroker(t, "ha", "secret")
+	ctx := context.Background()
+
+	received := make(chan Message, 1)
+	subscriber, err := Dial(ctx, Options{BrokerURL: broker.url(), ClientID: "subscriber", Username: "ha", Password: "secret"}, func(message Message) {
+		received <- message
+	})
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer subscriber.Close()
+
+	if err := subscriber.Subscribe(ctx, "home/+/set"); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	publisher, err := Dial(ctx, Options{
+		BrokerURL: broker.url(),
+		ClientID:  "publisher",
+		Username:  "ha",
+		Password:  "secret",
+		KeepAlive: time.Minute,
+		Will:      &Message{Topic: "home/status", Payload: []byte("offline"), Retained: true},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+
+	if err := publisher.Publish(Message{Topic: "home/light/set", Payload: []byte("ON")}); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	select {
+	case message := <-received:
+		if message.Topic != "home/light/set" || string(message.Payload) != "ON" {
+			t.Errorf("Unexpected message: %+v", message)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for the message")
+	}
+
+	if err := publisher.Publish(Message{Topic: "home/light/state", Payload: []byte("on"), Retained: true}); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	waitFor(t, "the retained state", func() bool {
+		payload, _ := broker.retainedMessage("home/light/state")
+		return payload == "on"
+	})
+
+	publisher.Close()
+	<-publisher.Done()
+	if publisher.Err() != ErrClosed {
+		t.Errorf("Expected ErrClosed, got %v", publisher.Err())
+	}
+	if err := publisher.Publish(Message{Topic: "home/light/state", Payload: []byte("off")}); err == nil {
+		t.Error("Expected publishing on a closed client to fail")
+	}
+	time.Sleep(50 * time.Millisecond)
+	if _, ok := broker.retainedMessage("home/status"); ok {
+		t.Error("Expected a clean disconnect not to publish the will")
+	}
+}
+
+func TestClientRejectedCredentials(t *testing.T) {
+	broker := newTestBroker(t, "ha", "secret")
+
+	_, err := Dial(context.Background(), Options{BrokerURL: broker.url(), ClientID: "client", Username: "ha", Password: "wrong"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "bad user name or password") {
+		t.Errorf("Expected the broker to refuse the credentials, got %v", err)
+	}
+}
+
+func TestClientUnsupportedScheme(t *testing.T) {
+	if _, err := Dial(context.Background(), Options{BrokerURL: "http://localhost:1883"}, nil); err == nil {
+		t.Error("Expected an error for an http URL")
+	}
+}
@@ -0,0 +1,159 @@
+package mqtt
+
+import (
+	"encoding/json"
+	"strings"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/shared/constants"
+)
+
+const (
+	payloadOnline  = "online"
+	payloadOffline = "offline"
+	payloadOn      = "ON"
+	payloadOff     = "OFF"
+
+	doneTemplate = "{{ 'ON' if value_json.done else 'OFF' }}"
+)
+
+// habitState is the retained payload on a habit's state topic.
+type habitState struct {
+	Name          string   `json:"name"`
+	Date          string   `json:"date"`
+	Scheduled     bool     `json:"scheduled"`
+	Done          bool     `json:"done"`
+	Value         float64  `json:"value"`
+	Target        *float64 `json:"target,omitempty"`
+	Streak        int      `json:"streak"`
+	LongestStreak int      `json:"longest_streak"`
+}
+
+type topics struct {
+	prefix          string
+	discoveryPrefix string
+}
+
+func newTopics(integration *entities.MQTTIntegration) topics {
+	return topics{prefix: integration.TopicPrefix, discoveryPrefix: integration.DiscoveryPrefix}
+}
+
+func (t topics) availability() string {
+	return t.prefix + "/status"
+}
+
+func (t topics) state(habitID string) string {
+	return t.prefix + "/habit/" + habitID + "/state"
+}
+
+func (t topics) command(habitID string) string {
+	return t.prefix + "/habit/" + habitID + "/set"
+}
+
+// commandFilter matches the command topic of every habit.
+func (t topics) commandFilter() string {
+	return t.prefix + "/habit/+/set"
+}
+
+// habitID returns the habit a command topic belongs to.
+func (t topics) habitID(commandTopic string) (string, bool) {
+	rest, ok := strings.CutPrefix(commandTopic, t.prefix+"/habit/")
+	if !ok {
+		return "", false
+	}
+	habitID, ok := strings.CutSuffix(rest, "/set")
+	if !ok || habitID == "" || strings.Contains(habitID, "/") {
+		return "", false
+	}
+	return habitID, true
+}
+
+func (t topics) discovery(component, habitID, object string) string {
+	return t.discoveryPrefix + "/" + component + "/apocapoc/" + habitID + "_" + object + "/config"
+}
+
+// discoveryMessages returns the Home Assistant discovery configs for a habit,
+// grouped as one device per habit. Yes/no habits get a switch; counter and
+// value habits get a number to set and a binary sensor telling whether they
+// are done. Every habit gets a streak sensor.
+func discoveryMessages(t topics, habit *entities.Habit) []Message {
+	device := map[string]interface{}{
+		"identifiers":  []string{"apocapoc_" + habit.ID},
+		"name":         habit.Name,
+		"manufacturer": constants.AppName,
+		"model":        "Habit",
+	}
+	entity := func(object, name string, extra map[string]interface{}) map[string]interface{} {
+		config := map[string]interface{}{
+			"name":               name,
+			"unique_id":          "apocapoc_" + habit.ID + "_" + object,
+			"state_topic":        t.state(habit.ID),
+			"availability_topic": t.availability(),
+			"device":             device,
+		}
+		for key, value := range extra {
+			config[key] = value
+		}
+		return config
+	}
+
+	configs := map[string]map[string]interface{}{
+		t.discovery("sensor", habit.ID, "streak"): entity("streak", "Streak", map[string]interface{}{
+			"value_template":      "{{ value_json.streak }}",
+			"unit_of_measurement": "d",
+			"state_class":         "measurement",
+			"icon":                "mdi:fire",
+		}),
+	}
+
+	if habit.Type == value_objects.HabitTypeBoolean {
+		configs[t.discovery("switch", habit.ID, "done")] = entity("done", "Done", map[string]interface{}{
+			"command_topic":  t.command(habit.ID),
+			"value_template": doneTemplate,
+			"payload_on":     payloadOn,
+			"payload_off":    payloadOff,
+			"icon":           "mdi:check-circle-outline",
+		})
+	} else {
+		name, step := "Value", 0.01
+		if habit.Type == value_objects.HabitTypeCounter {
+			name, step = "Count", 1
+		}
+		configs[t.discovery("binary_sensor", habit.ID, "done")] = entity("done", "Done", map[string]interface{}{
+			"value_template": doneTemplate,
+			"payload_on":     payloadOn,
+			"payload_off":    payloadOff,
+		})
+		configs[t.discovery("number", habit.ID, "value")] = entity("value", name, map[string]interface{}{
+			"command_topic":  t.command(habit.ID),
+			"value_template": "{{ value_json.value }}",
+			"min":            0,
+			"max":            1000000,
+			"step":           step,
+			"mode":           "box",
+		})
+	}
+
+	messages := make([]Message, 0, len(configs))
+	for topic, config := range configs {
+		payload, _ := json.Marshal(config)
+		messages = append(messages, Message{Topic: topic, Payload: payload, Retained: true})
+	}
+	return messages
+}
+
+// removalMessages clear everything retained for a habit, which makes Home
+// Assistant remove its entities.
+func removalMessages(t topics, habitID string) []Message {
+	messages := []Message{{Topic: t.state(habitID), Retained: true}}
+	for _, discovery := range [][2]string{
+		{"sensor", "streak"},
+		{"switch", "done"},
+		{"binary_sensor", "done"},
+		{"number", "value"},
+	} {
+		messages = append(messages, Message{Topic: t.discovery(discovery[0], habitID, discovery[1]), Retained: true})
+	}
+	return messages
+}
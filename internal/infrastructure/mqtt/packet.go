@@ -0,0 +1,191 @@
+package mqtt
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Control packet types of MQTT 3.1.1, shifted into the first header byte.
+const (
+	packetConnect    byte = 1 << 4
+	packetConnack    byte = 2 << 4
+	packetPublish    byte = 3 << 4
+	packetPuback     byte = 4 << 4
+	packetSubscribe  byte = 8 << 4
+	packetSuback     byte = 9 << 4
+	packetPingreq    byte = 12 << 4
+	packetPingresp   byte = 13 << 4
+	packetDisconnect byte = 14 << 4
+)
+
+const (
+	protocolLevel = 4
+
+	connectFlagCleanSession = 0x02
+	connectFlagWill         = 0x04
+	connectFlagWillRetain   = 0x20
+	connectFlagPassword     = 0x40
+	connectFlagUsername     = 0x80
+
+	publishFlagRetain = 0x01
+	publishQoSMask    = 0x06
+
+	// maxPacketSize bounds what is read from the broker, since habit
+	// commands are tiny.
+	maxPacketSize = 1 << 20
+)
+
+var errMalformedPacket = errors.New("malformed mqtt packet")
+
+var connackErrors = map[byte]string{
+	1: "unacceptable protocol version",
+	2: "client identifier rejected",
+	3: "server unavailable",
+	4: "bad user name or password",
+	5: "not authorized",
+}
+
+func encodePacket(header byte, body []byte) []byte {
+	packet := []byte{header}
+	length := len(body)
+	for {
+		digit := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			digit |= 0x80
+		}
+		packet = append(packet, digit)
+		if length == 0 {
+			break
+		}
+	}
+	return append(packet, body...)
+}
+
+func appendString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
+
+func encodeConnect(opts Options) []byte {
+	flags := byte(connectFlagCleanSession)
+	if opts.Will != nil {
+		flags |= connectFlagWill
+		if opts.Will.Retained {
+			flags |= connectFlagWillRetain
+		}
+	}
+	if opts.Username != "" {
+		flags |= connectFlagUsername
+	}
+	if opts.Password != "" {
+		flags |= connectFlagPassword
+	}
+
+	body := appendString(nil, "MQTT")
+	body = append(body, protocolLevel, flags)
+	body = binary.BigEndian.AppendUint16(body, uint16(opts.KeepAlive.Seconds()))
+	body = appendString(body, opts.ClientID)
+	if opts.Will != nil {
+		body = appendString(body, opts.Will.Topic)
+		body = appendString(body, string(opts.Will.Payload))
+	}
+	if opts.Username != "" {
+		body = appendString(body, opts.Username)
+	}
+	if opts.Password != "" {
+		body = appendString(body, opts.Password)
+	}
+
+	return encodePacket(packetConnect, body)
+}
+
+func encodePublish(message Message) []byte {
+	header := packetPublish
+	if message.Retained {
+		header |= publishFlagRetain
+	}
+	body := appendString(nil, message.Topic)
+	return encodePacket(header, append(body, message.Payload...))
+}
+
+func encodeSubscribe(packetID uint16, topic string) []byte {
+	body := binary.BigEndian.AppendUint16(nil, packetID)
+	body = appendString(body, topic)
+	return encodePacket(packetSubscribe|0x02, append(body, 0))
+}
+
+func encodePuback(packetID uint16) []byte {
+	return encodePacket(packetPuback, binary.BigEndian.AppendUint16(nil, packetID))
+}
+
+// readPacket returns the first header byte and the rest of the packet.
+func readPacket(r *bufio.Reader) (byte, []byte, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	length, multiplier := 0, 1
+	for i := 0; ; i++ {
+		if i == 4 {
+			return 0, nil, errMalformedPacket
+		}
+		digit, err := r.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length += int(digit&0x7f) * multiplier
+		multiplier *= 128
+		if digit&0x80 == 0 {
+			break
+		}
+	}
+	if length > maxPacketSize {
+		return 0, nil, fmt.Errorf("mqtt packet of %d bytes is too large", length)
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, nil, err
+	}
+	return header, body, nil
+}
+
+func readString(b []byte) (string, []byte, error) {
+	if len(b) < 2 {
+		return "", nil, errMalformedPacket
+	}
+	length := int(binary.BigEndian.Uint16(b))
+	if len(b) < 2+length {
+		return "", nil, errMalformedPacket
+	}
+	return string(b[2 : 2+length]), b[2+length:], nil
+}
+
+// decodePublish returns the message and, for QoS 1 and 2, the packet ID to
+// acknowledge.
+func decodePublish(header byte, body []byte) (Message, uint16, error) {
+	topic, rest, err := readString(body)
+	if err != nil {
+		return Message{}, 0, err
+	}
+
+	var packetID uint16
+	if header&publishQoSMask != 0 {
+		if len(rest) < 2 {
+			return Message{}, 0, errMalformedPacket
+		}
+		packetID = binary.BigEndian.Uint16(rest)
+		rest = rest[2:]
+	}
+
+	return Message{
+		Topic:    topic,
+		Payload:  rest,
+		Retained: header&publishFlagRetain != 0,
+	}, packetID, nil
+}
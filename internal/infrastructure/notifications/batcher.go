@@ -0,0 +1,196 @@
+package notifications
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/domain/services"
+	"apocapoc-api/internal/infrastructure/logger"
+	"apocapoc-api/internal/shared/errors"
+)
+
+// logRetention is how long sent notifications are kept for the hourly limit.
+const logRetention = 24 * time.Hour
+
+type Config struct {
+	Enabled  bool
+	Interval time.Duration
+}
+
+// Batcher periodically delivers the notifications held back by the Gate, as a
+// single message per user and channel, once the user is out of quiet hours
+// and under their hourly limit.
+type Batcher struct {
+	heldRepo     repositories.HeldNotificationRepository
+	scheduleRepo repositories.NotificationScheduleRepository
+	logRepo      repositories.NotificationLogRepository
+	userRepo     repositories.UserRepository
+	senders      map[entities.NotificationChannel]services.NotificationBatchSender
+	config       Config
+	stopCh       chan struct{}
+}
+
+func NewBatcher(
+	heldRepo repositories.HeldNotificationRepository,
+	scheduleRepo repositories.NotificationScheduleRepository,
+	logRepo repositories.NotificationLogRepository,
+	userRepo repositories.UserRepository,
+	senders []services.NotificationBatchSender,
+	config Config,
+) *Batcher {
+	byChannel := make(map[entities.NotificationChannel]services.NotificationBatchSender, len(senders))
+	for _, sender := range senders {
+		byChannel[sender.Channel()] = sender
+	}
+
+	return &Batcher{
+		heldRepo:     heldRepo,
+		scheduleRepo: scheduleRepo,
+		logRepo:      logRepo,
+		userRepo:     userRepo,
+		senders:      byChannel,
+		config:       config,
+		stopCh:       make(chan struct{}),
+	}
+}
+
+func (b *Batcher) Start() {
+	if !b.config.Enabled {
+		logger.Info().Msg("Notification batcher is disabled")
+		return
+	}
+
+	logger.Info().
+		Dur("interval", b.config.Interval).
+		Msg("Starting notification batcher")
+
+	go b.run()
+}
+
+func (b *Batcher) run() {
+	b.DeliverDue(context.Background(), time.Now())
+
+	ticker := time.NewTicker(b.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			logger.Debug().Msg("Checking for held notifications")
+			b.DeliverDue(context.Background(), time.Now())
+
+		case <-b.stopCh:
+			logger.Info().Msg("Notification batcher stopped")
+			return
+		}
+	}
+}
+
+func (b *Batcher) Stop() {
+	if b.config.Enabled {
+		close(b.stopCh)
+	}
+}
+
+// DeliverDue sends the held notifications of every user who can be notified
+// at now, and returns how many batches were sent. Failed batches are retried
+// on the next run.
+func (b *Batcher) DeliverDue(ctx context.Context, now time.Time) int {
+	if _, err := b.logRepo.DeleteBefore(ctx, now.Add(-logRetention)); err != nil {
+		logger.Error().Err(err).Msg("Failed to prune notification log")
+	}
+
+	userIDs, err := b.heldRepo.FindUserIDs(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to load held notifications")
+		return 0
+	}
+
+	sent := 0
+	for _, userID := range userIDs {
+		delivered, err := b.deliver(ctx, userID, now)
+		if err != nil {
+			logger.Error().Err(err).
+				Str("user_id", userID).
+				Msg("Failed to deliver held notifications")
+		}
+		sent += delivered
+	}
+
+	return sent
+}
+
+func (b *Batcher) deliver(ctx context.Context, userID string, now time.Time) (int, error) {
+	schedule, err := findSchedule(ctx, b.scheduleRepo, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	hold, err := shouldHold(ctx, b.logRepo, schedule, now)
+	if err != nil || hold {
+		return 0, err
+	}
+
+	held, err := b.heldRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	user, err := b.userRepo.FindByID(ctx, userID)
+	if err == errors.ErrNotFound {
+		return 0, b.drop(ctx, held)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	byChannel := make(map[entities.NotificationChannel][]*entities.HeldNotification)
+	var channels []entities.NotificationChannel
+	for _, notification := range held {
+		if _, ok := byChannel[notification.Channel]; !ok {
+			channels = append(channels, notification.Channel)
+		}
+		byChannel[notification.Channel] = append(byChannel[notification.Channel], notification)
+	}
+
+	sent := 0
+	for _, channel := range channels {
+		notifications := byChannel[channel]
+
+		sender, ok := b.senders[channel]
+		if !ok {
+			logger.Warn().
+				Str("channel", string(channel)).
+				Int("count", len(notifications)).
+				Msg("No batch sender for channel, dropping held notifications")
+			if err := b.drop(ctx, notifications); err != nil {
+				return sent, err
+			}
+			continue
+		}
+
+		if err := sender.SendBatch(ctx, user, notifications); err != nil {
+			return sent, err
+		}
+		if err := b.logRepo.Record(ctx, userID, channel, now); err != nil {
+			return sent, err
+		}
+		if err := b.drop(ctx, notifications); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+func (b *Batcher) drop(ctx context.Context, notifications []*entities.HeldNotification) error {
+	for _, notification := range notifications {
+		if err := b.heldRepo.Delete(ctx, notification.ID); err != nil && err != errors.ErrNotFound {
+			return err
+		}
+	}
+	return nil
+}
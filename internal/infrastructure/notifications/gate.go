@@ -0,0 +1,105 @@
+package notifications
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/domain/services"
+	"apocapoc-api/internal/shared/errors"
+)
+
+// Gate applies a user's notification preferences, quiet hours and hourly
+// limit. Notifications it lets through are counted towards the limit, and
+// those it holds back are stored for the Batcher.
+type Gate struct {
+	preferenceRepo repositories.NotificationPreferenceRepository
+	scheduleRepo   repositories.NotificationScheduleRepository
+	heldRepo       repositories.HeldNotificationRepository
+	logRepo        repositories.NotificationLogRepository
+	now            func() time.Time
+}
+
+func NewGate(
+	preferenceRepo repositories.NotificationPreferenceRepository,
+	scheduleRepo repositories.NotificationScheduleRepository,
+	heldRepo repositories.HeldNotificationRepository,
+	logRepo repositories.NotificationLogRepository,
+) *Gate {
+	return &Gate{
+		preferenceRepo: preferenceRepo,
+		scheduleRepo:   scheduleRepo,
+		heldRepo:       heldRepo,
+		logRepo:        logRepo,
+		now:            time.Now,
+	}
+}
+
+func (g *Gate) Admit(ctx context.Context, userID string, category entities.NotificationCategory, channel entities.NotificationChannel, title, content string) (services.NotificationDecision, error) {
+	if category.IsMandatory() {
+		return services.NotificationSend, nil
+	}
+
+	preferences, err := g.preferenceRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if !preferences.IsEnabled(category, channel) {
+		return services.NotificationSkip, nil
+	}
+
+	schedule, err := findSchedule(ctx, g.scheduleRepo, userID)
+	if err != nil {
+		return "", err
+	}
+
+	now := g.now()
+	hold, err := shouldHold(ctx, g.logRepo, schedule, now)
+	if err != nil {
+		return "", err
+	}
+	if hold {
+		notification := entities.NewHeldNotification(userID, category, channel, title)
+		notification.Content = content
+		notification.CreatedAt = now
+		if err := g.heldRepo.Create(ctx, notification); err != nil {
+			return "", err
+		}
+		return services.NotificationHold, nil
+	}
+
+	if err := g.logRepo.Record(ctx, userID, channel, now); err != nil {
+		return "", err
+	}
+
+	return services.NotificationSend, nil
+}
+
+// findSchedule returns the user's schedule, or the default one without quiet
+// hours or limit if they never set it.
+func findSchedule(ctx context.Context, scheduleRepo repositories.NotificationScheduleRepository, userID string) (*entities.NotificationSchedule, error) {
+	schedule, err := scheduleRepo.FindByUserID(ctx, userID)
+	if err == errors.ErrNotFound {
+		return entities.NewNotificationSchedule(userID), nil
+	}
+	return schedule, err
+}
+
+// shouldHold reports whether the user is in quiet hours or has reached their
+// hourly limit at now.
+func shouldHold(ctx context.Context, logRepo repositories.NotificationLogRepository, schedule *entities.NotificationSchedule, now time.Time) (bool, error) {
+	if schedule.InQuietHours(now) {
+		return true, nil
+	}
+	if schedule.MaxPerHour <= 0 {
+		return false, nil
+	}
+
+	sent, err := logRepo.CountSince(ctx, schedule.UserID, now.Add(-time.Hour))
+	if err != nil {
+		return false, err
+	}
+
+	return sent >= schedule.MaxPerHour, nil
+}
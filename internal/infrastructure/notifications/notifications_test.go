@@ -0,0 +1,170 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/services"
+	"apocapoc-api/internal/infrastructure/persistence/sqlite"
+)
+
+type recordingBatchSender struct {
+	channel entities.NotificationChannel
+	batches [][]string
+}
+
+func (s *recordingBatchSender) Channel() entities.NotificationChannel {
+	return s.channel
+}
+
+func (s *recordingBatchSender) SendBatch(ctx context.Context, user *entities.User, notifications []*entities.HeldNotification) error {
+	var titles []string
+	for _, notification := range notifications {
+		titles = append(titles, notification.Title)
+	}
+	s.batches = append(s.batches, titles)
+	return nil
+}
+
+func setupTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := sqlite.RunMigrations(db); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	return db
+}
+
+func TestGate_Admit(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	userRepo := sqlite.NewUserRepository(db)
+	preferenceRepo := sqlite.NewNotificationPreferenceRepository(db)
+	scheduleRepo := sqlite.NewNotificationScheduleRepository(db)
+	heldRepo := sqlite.NewHeldNotificationRepository(db)
+	logRepo := sqlite.NewNotificationLogRepository(db)
+
+	user := entities.NewUser("gate@example.com", "hash")
+	userRepo.Create(ctx, user)
+	preferenceRepo.Save(ctx, entities.NewNotificationPreference(user.ID, entities.NotificationReminders, entities.NotificationPush, false))
+
+	schedule := entities.NewNotificationSchedule(user.ID)
+	schedule.QuietHoursStart = "22:00"
+	schedule.QuietHoursEnd = "07:00"
+	schedule.Timezone = "Europe/Madrid"
+	schedule.MaxPerHour = 2
+	scheduleRepo.Save(ctx, schedule)
+
+	gate := NewGate(preferenceRepo, scheduleRepo, heldRepo, logRepo)
+	admit := func(now time.Time, category entities.NotificationCategory, channel entities.NotificationChannel) services.NotificationDecision {
+		t.Helper()
+		gate.now = func() time.Time { return now }
+		decision, err := gate.Admit(ctx, user.ID, category, channel, "Title", "")
+		if err != nil {
+			t.Fatalf("Admit failed: %v", err)
+		}
+		return decision
+	}
+
+	// 23:30 in Madrid during winter.
+	night := time.Date(2025, 1, 15, 22, 30, 0, 0, time.UTC)
+	day := time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC)
+
+	if decision := admit(day, entities.NotificationReminders, entities.NotificationPush); decision != services.NotificationSkip {
+		t.Errorf("Expected a turned off category to be skipped, got %s", decision)
+	}
+	if decision := admit(night, entities.NotificationReminders, entities.NotificationEmail); decision != services.NotificationHold {
+		t.Errorf("Expected quiet hours to hold, got %s", decision)
+	}
+	if decision := admit(night, entities.NotificationSecurity, entities.NotificationEmail); decision != services.NotificationSend {
+		t.Errorf("Expected security email to ignore quiet hours, got %s", decision)
+	}
+
+	for i := 0; i < 2; i++ {
+		if decision := admit(day, entities.NotificationAchievements, entities.NotificationEmail); decision != services.NotificationSend {
+			t.Fatalf("Expected notification %d to be sent, got %s", i+1, decision)
+		}
+	}
+	if decision := admit(day, entities.NotificationAchievements, entities.NotificationEmail); decision != services.NotificationHold {
+		t.Errorf("Expected the hourly limit to hold, got %s", decision)
+	}
+	if decision := admit(day.Add(time.Hour), entities.NotificationAchievements, entities.NotificationEmail); decision != services.NotificationSend {
+		t.Errorf("Expected the limit to reset after an hour, got %s", decision)
+	}
+
+	held, _ := heldRepo.FindByUserID(ctx, user.ID)
+	if len(held) != 2 {
+		t.Errorf("Expected 2 held notifications, got %d", len(held))
+	}
+}
+
+func TestBatcher_DeliverDue(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	userRepo := sqlite.NewUserRepository(db)
+	scheduleRepo := sqlite.NewNotificationScheduleRepository(db)
+	heldRepo := sqlite.NewHeldNotificationRepository(db)
+	logRepo := sqlite.NewNotificationLogRepository(db)
+
+	user := entities.NewUser("batch@example.com", "hash")
+	userRepo.Create(ctx, user)
+
+	schedule := entities.NewNotificationSchedule(user.ID)
+	schedule.QuietHoursStart = "22:00"
+	schedule.QuietHoursEnd = "07:00"
+	scheduleRepo.Save(ctx, schedule)
+
+	for _, notification := range []*entities.HeldNotification{
+		entities.NewHeldNotification(user.ID, entities.NotificationReminders, entities.NotificationPush, "Read"),
+		entities.NewHeldNotification(user.ID, entities.NotificationReminders, entities.NotificationPush, "Run"),
+		entities.NewHeldNotification(user.ID, entities.NotificationAchievements, entities.NotificationEmail, "Week streak"),
+		entities.NewHeldNotification("deleted-user", entities.NotificationReminders, entities.NotificationPush, "Gone"),
+	} {
+		heldRepo.Create(ctx, notification)
+	}
+
+	email := &recordingBatchSender{channel: entities.NotificationEmail}
+	push := &recordingBatchSender{channel: entities.NotificationPush}
+	batcher := NewBatcher(heldRepo, scheduleRepo, logRepo, userRepo,
+		[]services.NotificationBatchSender{email, push},
+		Config{Enabled: true, Interval: time.Minute},
+	)
+
+	night := time.Date(2025, 1, 15, 23, 0, 0, 0, time.UTC)
+	if sent := batcher.DeliverDue(ctx, night); sent != 0 {
+		t.Fatalf("Expected nothing during quiet hours, got %d", sent)
+	}
+
+	morning := time.Date(2025, 1, 16, 7, 0, 0, 0, time.UTC)
+	if sent := batcher.DeliverDue(ctx, morning); sent != 2 {
+		t.Fatalf("Expected one batch per channel, got %d", sent)
+	}
+	if len(push.batches) != 1 || len(push.batches[0]) != 2 || push.batches[0][0] != "Read" {
+		t.Errorf("Unexpected push batches: %v", push.batches)
+	}
+	if len(email.batches) != 1 || email.batches[0][0] != "Week streak" {
+		t.Errorf("Unexpected email batches: %v", email.batches)
+	}
+
+	count, _ := logRepo.CountSince(ctx, user.ID, morning.Add(-time.Hour))
+	if count != 2 {
+		t.Errorf("Expected each batch to count once towards the limit, got %d", count)
+	}
+
+	if userIDs, _ := heldRepo.FindUserIDs(ctx); len(userIDs) != 0 {
+		t.Errorf("Expected no held notifications left, got %v", userIDs)
+	}
+	if sent := batcher.DeliverDue(ctx, morning.Add(time.Minute)); sent != 0 {
+		t.Errorf("Expected nothing left to send, got %d", sent)
+	}
+}
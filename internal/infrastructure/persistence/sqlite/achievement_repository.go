@@ -0,0 +1,87 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type AchievementRepository struct {
+	db *sql.DB
+}
+
+func NewAchievementRepository(db *sql.DB) *AchievementRepository {
+	return &AchievementRepository{db: db}
+}
+
+func (r *AchievementRepository) Create(ctx context.Context, achievement *entities.Achievement) error {
+	achievement.ID = uuid.New().String()
+
+	query := `
+		INSERT INTO achievements (id, user_id, code, habit_id, unlocked_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		achievement.ID,
+		achievement.UserID,
+		string(achievement.Code),
+		achievement.HabitID,
+		achievement.UnlockedAt,
+	)
+
+	if err != nil {
+		if isUniqueConstraintError(err) {
+			return errors.ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create achievement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AchievementRepository) FindByUserID(ctx context.Context, userID string) ([]*entities.Achievement, error) {
+	query := `
+		SELECT id, user_id, code, habit_id, unlocked_at
+		FROM achievements
+		WHERE user_id = ?
+		ORDER BY unlocked_at ASC
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find achievements: %w", err)
+	}
+	defer rows.Close()
+
+	var achievements []*entities.Achievement
+	for rows.Next() {
+		var (
+			achievement entities.Achievement
+			code        string
+			habitID     sql.NullString
+		)
+
+		if err := rows.Scan(&achievement.ID, &achievement.UserID, &code, &habitID, &achievement.UnlockedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan achievement: %w", err)
+		}
+
+		achievement.Code = entities.AchievementCode(code)
+		if habitID.Valid {
+			achievement.HabitID = &habitID.String
+		}
+
+		achievements = append(achievements, &achievement)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate achievements: %w", err)
+	}
+
+	return achievements, nil
+}
@@ -0,0 +1,55 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/shared/errors"
+)
+
+func TestAchievementRepositoryCreateAndFind(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewUserRepository(db)
+	habitRepo := NewHabitRepository(db)
+	repo := NewAchievementRepository(db)
+	ctx := context.Background()
+
+	user := entities.NewUser("achiever@example.com", "hash")
+	if err := userRepo.Create(ctx, user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	habit := entities.NewHabit(user.ID, "Reading", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habitRepo.Create(ctx, habit)
+
+	if err := repo.Create(ctx, entities.NewAchievement(user.ID, entities.AchievementFirstCompletion, &habit.ID)); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := repo.Create(ctx, entities.NewAchievement(user.ID, entities.AchievementPerfectWeek, nil)); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	err := repo.Create(ctx, entities.NewAchievement(user.ID, entities.AchievementFirstCompletion, &habit.ID))
+	if err != errors.ErrAlreadyExists {
+		t.Errorf("Expected ErrAlreadyExists for a duplicate code, got %v", err)
+	}
+
+	achievements, err := repo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("FindByUserID failed: %v", err)
+	}
+
+	if len(achievements) != 2 {
+		t.Fatalf("Expected 2 achievements, got %d", len(achievements))
+	}
+	if achievements[0].Code != entities.AchievementFirstCompletion || achievements[0].HabitID == nil || *achievements[0].HabitID != habit.ID {
+		t.Errorf("Unexpected first achievement: %+v", achievements[0])
+	}
+	if achievements[1].HabitID != nil {
+		t.Errorf("Expected perfect_week to have no habit, got %v", *achievements[1].HabitID)
+	}
+}
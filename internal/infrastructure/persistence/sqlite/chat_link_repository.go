@@ -0,0 +1,143 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type ChatLinkRepository struct {
+	db *sql.DB
+}
+
+func NewChatLinkRepository(db *sql.DB) *ChatLinkRepository {
+	return &ChatLinkRepository{db: db}
+}
+
+func (r *ChatLinkRepository) Save(ctx context.Context, link *entities.ChatLink) error {
+	if _, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM chat_links WHERE chat_id = ? AND user_id != ?`, link.ChatID, link.UserID); err != nil {
+		return fmt.Errorf("failed to unlink chat: %w", err)
+	}
+
+	query := `
+		INSERT INTO chat_links (user_id, chat_id, timezone, created_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(user_id) DO UPDATE SET
+			chat_id = excluded.chat_id,
+			timezone = excluded.timezone,
+			created_at = excluded.created_at
+	`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, link.UserID, link.ChatID, link.Timezone, link.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save chat link: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ChatLinkRepository) FindByUserID(ctx context.Context, userID string) (*entities.ChatLink, error) {
+	return r.findOne(ctx, `WHERE user_id = ?`, userID)
+}
+
+func (r *ChatLinkRepository) FindByChatID(ctx context.Context, chatID int64) (*entities.ChatLink, error) {
+	return r.findOne(ctx, `WHERE chat_id = ?`, chatID)
+}
+
+func (r *ChatLinkRepository) findOne(ctx context.Context, where string, arg interface{}) (*entities.ChatLink, error) {
+	query := `SELECT user_id, chat_id, timezone, created_at FROM chat_links ` + where
+
+	var link entities.ChatLink
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, arg).Scan(
+		&link.UserID,
+		&link.ChatID,
+		&link.Timezone,
+		&link.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find chat link: %w", err)
+	}
+
+	return &link, nil
+}
+
+func (r *ChatLinkRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM chat_links WHERE user_id = ?`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete chat link: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+type ChatLinkCodeRepository struct {
+	db *sql.DB
+}
+
+func NewChatLinkCodeRepository(db *sql.DB) *ChatLinkCodeRepository {
+	return &ChatLinkCodeRepository{db: db}
+}
+
+func (r *ChatLinkCodeRepository) Create(ctx context.Context, code *entities.ChatLinkCode) error {
+	if _, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM chat_link_codes WHERE user_id = ?`, code.UserID); err != nil {
+		return fmt.Errorf("failed to delete previous chat link code: %w", err)
+	}
+
+	query := `
+		INSERT INTO chat_link_codes (code, user_id, timezone, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, code.Code, code.UserID, code.Timezone, code.ExpiresAt, code.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create chat link code: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ChatLinkCodeRepository) FindByCode(ctx context.Context, code string) (*entities.ChatLinkCode, error) {
+	query := `SELECT code, user_id, timezone, expires_at, created_at FROM chat_link_codes WHERE code = ?`
+
+	var linkCode entities.ChatLinkCode
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, code).Scan(
+		&linkCode.Code,
+		&linkCode.UserID,
+		&linkCode.Timezone,
+		&linkCode.ExpiresAt,
+		&linkCode.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find chat link code: %w", err)
+	}
+
+	return &linkCode, nil
+}
+
+func (r *ChatLinkCodeRepository) Delete(ctx context.Context, code string) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM chat_link_codes WHERE code = ?`, code)
+	if err != nil {
+		return fmt.Errorf("failed to delete chat link code: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+func TestChatLinkRepositorySaveAndFind(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewUserRepository(db)
+	repo := NewChatLinkRepository(db)
+	ctx := context.Background()
+
+	user := entities.NewUser("chat@example.com", "hash")
+	other := entities.NewUser("other@example.com", "hash")
+	userRepo.Create(ctx, user)
+	userRepo.Create(ctx, other)
+
+	if _, err := repo.FindByUserID(ctx, user.ID); err != errors.ErrNotFound {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+
+	if err := repo.Save(ctx, entities.NewChatLink(user.ID, 100, "UTC")); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := repo.Save(ctx, entities.NewChatLink(user.ID, 200, "Europe/Madrid")); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	link, err := repo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("FindByUserID failed: %v", err)
+	}
+	if link.ChatID != 200 || link.Timezone != "Europe/Madrid" {
+		t.Errorf("Expected the second chat to replace the first, got %+v", link)
+	}
+	if _, err := repo.FindByChatID(ctx, 100); err != errors.ErrNotFound {
+		t.Errorf("Expected the first chat to be unlinked, got %v", err)
+	}
+
+	if err := repo.Save(ctx, entities.NewChatLink(other.ID, 200, "UTC")); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	link, err = repo.FindByChatID(ctx, 200)
+	if err != nil || link.UserID != other.ID {
+		t.Fatalf("Expected the chat to move to the other user, got %+v (%v)", link, err)
+	}
+	if _, err := repo.FindByUserID(ctx, user.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected the first user to lose the chat, got %v", err)
+	}
+
+	if err := repo.DeleteByUserID(ctx, other.ID); err != nil {
+		t.Fatalf("DeleteByUserID failed: %v", err)
+	}
+	if err := repo.DeleteByUserID(ctx, other.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestChatLinkCodeRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewUserRepository(db)
+	repo := NewChatLinkCodeRepository(db)
+	ctx := context.Background()
+
+	user := entities.NewUser("code@example.com", "hash")
+	userRepo.Create(ctx, user)
+
+	repo.Create(ctx, entities.NewChatLinkCode(user.ID, "FIRST234", "UTC"))
+	if err := repo.Create(ctx, entities.NewChatLinkCode(user.ID, "SECOND23", "Europe/Madrid")); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if _, err := repo.FindByCode(ctx, "FIRST234"); err != errors.ErrNotFound {
+		t.Errorf("Expected a new code to replace the previous one, got %v", err)
+	}
+
+	code, err := repo.FindByCode(ctx, "SECOND23")
+	if err != nil {
+		t.Fatalf("FindByCode failed: %v", err)
+	}
+	if code.UserID != user.ID || code.Timezone != "Europe/Madrid" || code.IsExpired() {
+		t.Errorf("Unexpected code: %+v", code)
+	}
+
+	if err := repo.Delete(ctx, "SECOND23"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := repo.FindByCode(ctx, "SECOND23"); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if err := repo.Delete(ctx, "SECOND23"); err != errors.ErrNotFound {
+		t.Errorf("Expected deleting a used code to fail with ErrNotFound, got %v", err)
+	}
+}
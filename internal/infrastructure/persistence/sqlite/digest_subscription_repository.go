@@ -0,0 +1,150 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type DigestSubscriptionRepository struct {
+	db *sql.DB
+}
+
+func NewDigestSubscriptionRepository(db *sql.DB) *DigestSubscriptionRepository {
+	return &DigestSubscriptionRepository{db: db}
+}
+
+func (r *DigestSubscriptionRepository) FindByUserID(ctx context.Context, userID string) (*entities.DigestSubscription, error) {
+	query := `
+		SELECT user_id, weekly, monthly, last_weekly_period, last_monthly_period, updated_at
+		FROM digest_subscriptions
+		WHERE user_id = ?
+	`
+
+	subscription, err := scanDigestSubscription(conn(ctx, r.db).QueryRowContext(ctx, query, userID))
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (r *DigestSubscriptionRepository) FindEnabled(ctx context.Context) ([]*entities.DigestSubscription, error) {
+	query := `
+		SELECT user_id, weekly, monthly, last_weekly_period, last_monthly_period, updated_at
+		FROM digest_subscriptions
+		WHERE weekly = 1 OR monthly = 1
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find digest subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subscriptions []*entities.DigestSubscription
+	for rows.Next() {
+		subscription, err := scanDigestSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate digest subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (r *DigestSubscriptionRepository) Save(ctx context.Context, subscription *entities.DigestSubscription) error {
+	query := `
+		INSERT INTO digest_subscriptions (
+			user_id, weekly, monthly, last_weekly_period, last_monthly_period, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(user_id) DO UPDATE SET
+			weekly = excluded.weekly,
+			monthly = excluded.monthly,
+			last_weekly_period = excluded.last_weekly_period,
+			last_monthly_period = excluded.last_monthly_period,
+			updated_at = excluded.updated_at
+	`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		subscription.UserID,
+		subscription.Weekly,
+		subscription.Monthly,
+		formatDate(subscription.LastWeeklyPeriod),
+		formatDate(subscription.LastMonthlyPeriod),
+		subscription.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save digest subscription: %w", err)
+	}
+
+	return nil
+}
+
+func scanDigestSubscription(row scanner) (*entities.DigestSubscription, error) {
+	var (
+		subscription      entities.DigestSubscription
+		lastWeeklyPeriod  sql.NullString
+		lastMonthlyPeriod sql.NullString
+	)
+
+	err := row.Scan(
+		&subscription.UserID,
+		&subscription.Weekly,
+		&subscription.Monthly,
+		&lastWeeklyPeriod,
+		&lastMonthlyPeriod,
+		&subscription.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan digest subscription: %w", err)
+	}
+
+	if subscription.LastWeeklyPeriod, err = parseDate(lastWeeklyPeriod); err != nil {
+		return nil, err
+	}
+	if subscription.LastMonthlyPeriod, err = parseDate(lastMonthlyPeriod); err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func formatDate(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+	formatted := date.Format("2006-01-02")
+	return &formatted
+}
+
+func parseDate(value sql.NullString) (*time.Time, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value.String)
+	if err != nil {
+		date, err = time.Parse(time.RFC3339, value.String)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse date: %w", err)
+		}
+	}
+
+	return &date, nil
+}
@@ -0,0 +1,65 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+func TestDigestSubscriptionRepositorySaveAndFind(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewUserRepository(db)
+	repo := NewDigestSubscriptionRepository(db)
+	ctx := context.Background()
+
+	subscriber := entities.NewUser("digest@example.com", "hash")
+	other := entities.NewUser("nodigest@example.com", "hash")
+	userRepo.Create(ctx, subscriber)
+	userRepo.Create(ctx, other)
+
+	if _, err := repo.FindByUserID(ctx, subscriber.ID); err != errors.ErrNotFound {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+
+	subscription := entities.NewDigestSubscription(subscriber.ID)
+	subscription.Weekly = true
+	if err := repo.Save(ctx, subscription); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := repo.Save(ctx, entities.NewDigestSubscription(other.ID)); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	periodStart := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	subscription.MarkSent(entities.DigestWeekly, periodStart)
+	if err := repo.Save(ctx, subscription); err != nil {
+		t.Fatalf("Save failed on update: %v", err)
+	}
+
+	found, err := repo.FindByUserID(ctx, subscriber.ID)
+	if err != nil {
+		t.Fatalf("FindByUserID failed: %v", err)
+	}
+	if !found.Weekly || found.Monthly {
+		t.Errorf("Unexpected subscription: %+v", found)
+	}
+	if found.LastWeeklyPeriod == nil || !found.LastWeeklyPeriod.Equal(periodStart) {
+		t.Errorf("Expected last weekly period %s, got %v", periodStart, found.LastWeeklyPeriod)
+	}
+	if found.LastMonthlyPeriod != nil {
+		t.Errorf("Expected no last monthly period, got %v", found.LastMonthlyPeriod)
+	}
+
+	enabled, err := repo.FindEnabled(ctx)
+	if err != nil {
+		t.Fatalf("FindEnabled failed: %v", err)
+	}
+	if len(enabled) != 1 || enabled[0].UserID != subscriber.ID {
+		t.Errorf("Expected only the opted-in user, got %+v", enabled)
+	}
+}
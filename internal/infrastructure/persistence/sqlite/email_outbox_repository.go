@@ -0,0 +1,235 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type EmailOutboxRepository struct {
+	db *sql.DB
+}
+
+func NewEmailOutboxRepository(db *sql.DB) *EmailOutboxRepository {
+	return &EmailOutboxRepository{db: db}
+}
+
+// Times are stored in UTC so that FindDue and DeleteSentBefore can compare
+// them as text.
+
+func (r *EmailOutboxRepository) Create(ctx context.Context, email *entities.OutgoingEmail) error {
+	email.ID = uuid.New().String()
+
+	headers, err := json.Marshal(email.Headers)
+	if err != nil {
+		return fmt.Errorf("failed to marshal email headers: %w", err)
+	}
+
+	query := `
+		INSERT INTO email_outbox (
+			id, recipient, subject, body, text_body, is_html, headers, status,
+			attempts, next_attempt_at, last_error, created_at, sent_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, query,
+		email.ID,
+		email.To,
+		email.Subject,
+		email.Body,
+		email.TextBody,
+		email.IsHTML,
+		string(headers),
+		string(email.Status),
+		email.Attempts,
+		email.NextAttemptAt.UTC(),
+		email.LastError,
+		email.CreatedAt.UTC(),
+		utcTime(email.SentAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to queue email: %w", err)
+	}
+
+	return nil
+}
+
+func (r *EmailOutboxRepository) FindByID(ctx context.Context, id string) (*entities.OutgoingEmail, error) {
+	query := `
+		SELECT id, recipient, subject, body, text_body, is_html, headers, status,
+		       attempts, next_attempt_at, last_error, created_at, sent_at
+		FROM email_outbox
+		WHERE id = ?
+	`
+
+	email, err := scanOutgoingEmail(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return email, nil
+}
+
+func (r *EmailOutboxRepository) FindByStatus(ctx context.Context, status entities.OutgoingEmailStatus, limit int) ([]*entities.OutgoingEmail, error) {
+	query := `
+		SELECT id, recipient, subject, body, text_body, is_html, headers, status,
+		       attempts, next_attempt_at, last_error, created_at, sent_at
+		FROM email_outbox
+		WHERE status = ?
+		ORDER BY created_at DESC
+		LIMIT ?
+	`
+
+	return r.findMany(ctx, query, string(status), limit)
+}
+
+func (r *EmailOutboxRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.OutgoingEmail, error) {
+	query := `
+		SELECT id, recipient, subject, body, text_body, is_html, headers, status,
+		       attempts, next_attempt_at, last_error, created_at, sent_at
+		FROM email_outbox
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY next_attempt_at ASC
+		LIMIT ?
+	`
+
+	return r.findMany(ctx, query, string(entities.OutgoingEmailPending), now.UTC(), limit)
+}
+
+func (r *EmailOutboxRepository) CountByStatus(ctx context.Context) (map[entities.OutgoingEmailStatus]int, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT status, COUNT(*) FROM email_outbox GROUP BY status`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count emails: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[entities.OutgoingEmailStatus]int{}
+	for rows.Next() {
+		var (
+			status entities.OutgoingEmailStatus
+			count  int
+		)
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan email count: %w", err)
+		}
+		counts[status] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate email counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+func (r *EmailOutboxRepository) Update(ctx context.Context, email *entities.OutgoingEmail) error {
+	query := `
+		UPDATE email_outbox
+		SET status = ?, attempts = ?, next_attempt_at = ?, last_error = ?, sent_at = ?
+		WHERE id = ?
+	`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query,
+		string(email.Status),
+		email.Attempts,
+		email.NextAttemptAt.UTC(),
+		email.LastError,
+		utcTime(email.SentAt),
+		email.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update email: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *EmailOutboxRepository) DeleteSentBefore(ctx context.Context, before time.Time) (int, error) {
+	query := `DELETE FROM email_outbox WHERE status = ? AND sent_at < ?`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, string(entities.OutgoingEmailSent), before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete sent emails: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	return int(rows), nil
+}
+
+func (r *EmailOutboxRepository) findMany(ctx context.Context, query string, args ...interface{}) ([]*entities.OutgoingEmail, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find emails: %w", err)
+	}
+	defer rows.Close()
+
+	var emails []*entities.OutgoingEmail
+	for rows.Next() {
+		email, err := scanOutgoingEmail(rows)
+		if err != nil {
+			return nil, err
+		}
+		emails = append(emails, email)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate emails: %w", err)
+	}
+
+	return emails, nil
+}
+
+func scanOutgoingEmail(row scanner) (*entities.OutgoingEmail, error) {
+	var (
+		email   entities.OutgoingEmail
+		headers string
+		sentAt  sql.NullTime
+	)
+
+	err := row.Scan(
+		&email.ID,
+		&email.To,
+		&email.Subject,
+		&email.Body,
+		&email.TextBody,
+		&email.IsHTML,
+		&headers,
+		&email.Status,
+		&email.Attempts,
+		&email.NextAttemptAt,
+		&email.LastError,
+		&email.CreatedAt,
+		&sentAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan email: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(headers), &email.Headers); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal email headers: %w", err)
+	}
+
+	if sentAt.Valid {
+		email.SentAt = &sentAt.Time
+	}
+
+	return &email, nil
+}
@@ -0,0 +1,67 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+func TestEmailOutboxRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewEmailOutboxRepository(db)
+	ctx := context.Background()
+
+	email := entities.NewOutgoingEmail("user@example.com", "Verify your email address", "<p>Hi</p>", true)
+	email.TextBody = "Hi"
+	email.Headers = map[string]string{"List-Unsubscribe": "<https://example.com/u>"}
+	if err := repo.Create(ctx, email); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	now := time.Now()
+	due, err := repo.FindDue(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("FindDue failed: %v", err)
+	}
+	if len(due) != 1 || due[0].To != "user@example.com" || due[0].Subject != "Verify your email address" || !due[0].IsHTML || due[0].TextBody != "Hi" || due[0].Headers["List-Unsubscribe"] != "<https://example.com/u>" {
+		t.Fatalf("Unexpected due emails: %+v", due)
+	}
+
+	due[0].RecordFailure("connection refused", now)
+	if err := repo.Update(ctx, due[0]); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if due, _ := repo.FindDue(ctx, now, 10); len(due) != 0 {
+		t.Errorf("Expected the email to wait for its retry, got %d due", len(due))
+	}
+
+	sent := entities.NewOutgoingEmail("other@example.com", "Reminder", "Body", false)
+	repo.Create(ctx, sent)
+	sent.RecordSuccess(now)
+	repo.Update(ctx, sent)
+
+	counts, err := repo.CountByStatus(ctx)
+	if err != nil {
+		t.Fatalf("CountByStatus failed: %v", err)
+	}
+	if counts[entities.OutgoingEmailPending] != 1 || counts[entities.OutgoingEmailSent] != 1 || counts[entities.OutgoingEmailFailed] != 0 {
+		t.Errorf("Unexpected counts: %+v", counts)
+	}
+
+	pending, _ := repo.FindByStatus(ctx, entities.OutgoingEmailPending, 10)
+	if len(pending) != 1 || pending[0].ID != email.ID || pending[0].LastError != "connection refused" {
+		t.Errorf("Unexpected pending emails: %+v", pending)
+	}
+
+	if deleted, _ := repo.DeleteSentBefore(ctx, now.Add(time.Hour)); deleted != 1 {
+		t.Errorf("Expected 1 sent email to be deleted, deleted %d", deleted)
+	}
+	if _, err := repo.FindByID(ctx, sent.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type EventOutboxRepository struct {
+	db *sql.DB
+}
+
+func NewEventOutboxRepository(db *sql.DB) *EventOutboxRepository {
+	return &EventOutboxRepository{db: db}
+}
+
+// Times are stored in UTC so that FindDue and DeleteProcessedBefore can
+// compare them as text.
+
+func (r *EventOutboxRepository) Create(ctx context.Context, event *entities.OutboxEvent) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("failed to encode event data: %w", err)
+	}
+	handledBy, _ := json.Marshal(event.HandledBy)
+
+	query := `
+		INSERT INTO event_outbox (
+			id, type, user_id, data, occurred_at, status, attempts,
+			next_attempt_at, last_error, handled_by, processed_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, query,
+		event.ID,
+		string(event.Type),
+		event.UserID,
+		string(data),
+		event.OccurredAt.UTC(),
+		string(event.Status),
+		event.Attempts,
+		event.NextAttemptAt.UTC(),
+		event.LastError,
+		string(handledBy),
+		utcTime(event.ProcessedAt),
+	)
+	if err != nil {
+		if isUniqueConstraintError(err) {
+			return errors.ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create outbox event: %w", err)
+	}
+
+	return nil
+}
+
+func (r *EventOutboxRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.OutboxEvent, error) {
+	query := `
+		SELECT id, type, user_id, data, occurred_at, status, attempts,
+		       next_attempt_at, last_error, handled_by, processed_at
+		FROM event_outbox
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY occurred_at ASC
+		LIMIT ?
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, string(entities.OutboxEventPending), now.UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*entities.OutboxEvent
+	for rows.Next() {
+		event, err := scanOutboxEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate outbox events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (r *EventOutboxRepository) Update(ctx context.Context, event *entities.OutboxEvent) error {
+	handledBy, _ := json.Marshal(event.HandledBy)
+
+	query := `
+		UPDATE event_outbox
+		SET status = ?, attempts = ?, next_attempt_at = ?, last_error = ?, handled_by = ?, processed_at = ?
+		WHERE id = ?
+	`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query,
+		string(event.Status),
+		event.Attempts,
+		event.NextAttemptAt.UTC(),
+		event.LastError,
+		string(handledBy),
+		utcTime(event.ProcessedAt),
+		event.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update outbox event: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *EventOutboxRepository) DeleteProcessedBefore(ctx context.Context, before time.Time) (int, error) {
+	query := `DELETE FROM event_outbox WHERE status = ? AND processed_at < ?`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, string(entities.OutboxEventProcessed), before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete outbox events: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	return int(rows), nil
+}
+
+func scanOutboxEvent(row scanner) (*entities.OutboxEvent, error) {
+	var (
+		event       entities.OutboxEvent
+		data        string
+		handledBy   string
+		processedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&event.ID,
+		&event.Type,
+		&event.UserID,
+		&data,
+		&event.OccurredAt,
+		&event.Status,
+		&event.Attempts,
+		&event.NextAttemptAt,
+		&event.LastError,
+		&handledBy,
+		&processedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(data), &event.Data); err != nil {
+		return nil, fmt.Errorf("failed to decode event data: %w", err)
+	}
+	if err := json.Unmarshal([]byte(handledBy), &event.HandledBy); err != nil {
+		return nil, fmt.Errorf("failed to decode event subscribers: %w", err)
+	}
+	if processedAt.Valid {
+		event.ProcessedAt = &processedAt.Time
+	}
+
+	return &event, nil
+}
+
+func utcTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC()
+}
@@ -0,0 +1,79 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+func TestEventOutboxRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewUserRepository(db)
+	repo := NewEventOutboxRepository(db)
+	ctx := context.Background()
+
+	user := entities.NewUser("outbox@example.com", "hash")
+	userRepo.Create(ctx, user)
+
+	event := entities.NewOutboxEvent(entities.NewEvent(entities.EventHabitMarked, user.ID, map[string]interface{}{
+		"habit": map[string]interface{}{"id": "habit-1"},
+		"value": 2.5,
+	}))
+	if err := repo.Create(ctx, event); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := repo.Create(ctx, event); err != errors.ErrAlreadyExists {
+		t.Errorf("Expected ErrAlreadyExists for a duplicate event, got %v", err)
+	}
+
+	now := time.Now()
+	due, err := repo.FindDue(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("FindDue failed: %v", err)
+	}
+	if len(due) != 1 {
+		t.Fatalf("Expected 1 due event, got %d", len(due))
+	}
+
+	found := due[0]
+	habit, _ := found.Data["habit"].(map[string]interface{})
+	if found.ID != event.ID || found.Type != entities.EventHabitMarked || found.UserID != user.ID ||
+		habit["id"] != "habit-1" || found.Data["value"] != 2.5 {
+		t.Errorf("Unexpected event: %+v", found)
+	}
+
+	found.MarkHandledBy("webhooks")
+	found.RecordFailure("email down", now)
+	if err := repo.Update(ctx, found); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	if due, _ := repo.FindDue(ctx, now, 10); len(due) != 0 {
+		t.Errorf("Expected the event to wait for its retry, got %d due", len(due))
+	}
+
+	due, _ = repo.FindDue(ctx, now.Add(time.Minute), 10)
+	if len(due) != 1 || due[0].Attempts != 1 || due[0].LastError != "email down" || !due[0].IsHandledBy("webhooks") {
+		t.Fatalf("Expected the failed attempt to be stored, got %+v", due)
+	}
+
+	due[0].MarkProcessed(now)
+	repo.Update(ctx, due[0])
+
+	if deleted, _ := repo.DeleteProcessedBefore(ctx, now.Add(-time.Hour)); deleted != 0 {
+		t.Errorf("Expected recent events to be kept, deleted %d", deleted)
+	}
+	if deleted, _ := repo.DeleteProcessedBefore(ctx, now.Add(time.Hour)); deleted != 1 {
+		t.Errorf("Expected 1 processed event to be deleted, deleted %d", deleted)
+	}
+
+	missing := entities.NewOutboxEvent(entities.NewEvent(entities.EventHabitCreated, user.ID, nil))
+	if err := repo.Update(ctx, missing); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
@@ -28,7 +28,7 @@ func (r *HabitEntryRepository) Create(ctx context.Context, entry *entities.Habit
 		VALUES (?, ?, ?, ?, ?)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
 		entry.ID,
 		entry.HabitID,
 		entry.ScheduledDate.Format("2006-01-02"),
@@ -60,7 +60,7 @@ func (r *HabitEntryRepository) FindByHabitIDAndDateRange(
 		ORDER BY scheduled_date ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query,
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query,
 		habitID,
 		from.Format("2006-01-02"),
 		to.Format("2006-01-02"),
@@ -80,7 +80,7 @@ func (r *HabitEntryRepository) Update(ctx context.Context, entry *entities.Habit
 		WHERE id = ?
 	`
 
-	result, err := r.db.ExecContext(ctx, query, entry.Value, entry.CompletedAt, entry.ID)
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, entry.Value, entry.CompletedAt, entry.ID)
 	if err != nil {
 		return fmt.Errorf("failed to update entry: %w", err)
 	}
@@ -141,7 +141,7 @@ func (r *HabitEntryRepository) FindByID(ctx context.Context, id string) (*entiti
 		scheduledDate string
 	)
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&entry.ID,
 		&entry.HabitID,
 		&scheduledDate,
@@ -176,7 +176,7 @@ func (r *HabitEntryRepository) FindByHabitID(ctx context.Context, habitID string
 		ORDER BY scheduled_date DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, habitID)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, habitID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find entries: %w", err)
 	}
@@ -194,7 +194,35 @@ func (r *HabitEntryRepository) FindByUserID(ctx context.Context, userID string)
 		ORDER BY he.scheduled_date DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find entries: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanEntries(rows)
+}
+
+func (r *HabitEntryRepository) FindByUserIDAndDateRange(
+	ctx context.Context,
+	userID string,
+	from, to time.Time,
+) ([]*entities.HabitEntry, error) {
+	query := `
+		SELECT he.id, he.habit_id, he.scheduled_date, he.completed_at, he.value
+		FROM habit_entries he
+		INNER JOIN habits h ON he.habit_id = h.id
+		WHERE h.user_id = ?
+		  AND he.scheduled_date >= ?
+		  AND he.scheduled_date <= ?
+		ORDER BY he.scheduled_date ASC
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query,
+		userID,
+		from.Format("2006-01-02"),
+		to.Format("2006-01-02"),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find entries: %w", err)
 	}
@@ -212,7 +240,7 @@ func (r *HabitEntryRepository) FindPendingByHabitID(ctx context.Context, habitID
 		ORDER BY scheduled_date DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, habitID, beforeDate.Format("2006-01-02"))
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, habitID, beforeDate.Format("2006-01-02"))
 	if err != nil {
 		return nil, fmt.Errorf("failed to find pending entries: %w", err)
 	}
@@ -224,7 +252,7 @@ func (r *HabitEntryRepository) FindPendingByHabitID(ctx context.Context, habitID
 func (r *HabitEntryRepository) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM habit_entries WHERE id = ?`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete entry: %w", err)
 	}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
 	"apocapoc-api/internal/shared/errors"
 )
 
@@ -204,3 +205,40 @@ func TestHabitEntryRepositoryOrderedByDate(t *testing.T) {
 		t.Errorf("Third entry should be %s, got %s", date3.Format("2006-01-02"), entries[2].ScheduledDate.Format("2006-01-02"))
 	}
 }
+
+func TestHabitEntryRepositoryFindByUserIDAndDateRange(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	habitRepo := NewHabitRepository(db)
+	repo := NewHabitEntryRepository(db)
+	ctx := context.Background()
+
+	ownHabit := entities.NewHabit("user-heatmap", "Reading", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	otherHabit := entities.NewHabit("user-other", "Running", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habitRepo.Create(ctx, ownHabit)
+	habitRepo.Create(ctx, otherHabit)
+
+	repo.Create(ctx, entities.NewHabitEntry(ownHabit.ID, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), nil))
+	repo.Create(ctx, entities.NewHabitEntry(ownHabit.ID, time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC), nil))
+	repo.Create(ctx, entities.NewHabitEntry(ownHabit.ID, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), nil))
+	repo.Create(ctx, entities.NewHabitEntry(otherHabit.ID, time.Date(2025, 6, 5, 0, 0, 0, 0, time.UTC), nil))
+
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	entries, err := repo.FindByUserIDAndDateRange(ctx, "user-heatmap", from, to)
+	if err != nil {
+		t.Fatalf("FindByUserIDAndDateRange failed: %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	for _, entry := range entries {
+		if entry.HabitID != ownHabit.ID {
+			t.Errorf("Expected entries only for habit %s, got %s", ownHabit.ID, entry.HabitID)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type HabitReminderRepository struct {
+	db *sql.DB
+}
+
+func NewHabitReminderRepository(db *sql.DB) *HabitReminderRepository {
+	return &HabitReminderRepository{db: db}
+}
+
+func (r *HabitReminderRepository) Create(ctx context.Context, reminder *entities.HabitReminder) error {
+	reminder.ID = uuid.New().String()
+
+	query := `
+		INSERT INTO habit_reminders (
+			id, habit_id, user_id, time_of_day, timezone, enabled, last_sent_on, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		reminder.ID,
+		reminder.HabitID,
+		reminder.UserID,
+		reminder.TimeOfDay,
+		reminder.Timezone,
+		reminder.Enabled,
+		formatDate(reminder.LastSentOn),
+		reminder.CreatedAt,
+		reminder.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create habit reminder: %w", err)
+	}
+
+	return nil
+}
+
+func (r *HabitReminderRepository) FindByID(ctx context.Context, id string) (*entities.HabitReminder, error) {
+	query := `
+		SELECT id, habit_id, user_id, time_of_day, timezone, enabled, last_sent_on, created_at, updated_at
+		FROM habit_reminders
+		WHERE id = ?
+	`
+
+	reminder, err := scanHabitReminder(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return reminder, nil
+}
+
+func (r *HabitReminderRepository) FindByHabitID(ctx context.Context, habitID string) ([]*entities.HabitReminder, error) {
+	query := `
+		SELECT id, habit_id, user_id, time_of_day, timezone, enabled, last_sent_on, created_at, updated_at
+		FROM habit_reminders
+		WHERE habit_id = ?
+		ORDER BY time_of_day ASC, created_at ASC
+	`
+
+	return r.findMany(ctx, query, habitID)
+}
+
+func (r *HabitReminderRepository) FindEnabled(ctx context.Context) ([]*entities.HabitReminder, error) {
+	query := `
+		SELECT r.id, r.habit_id, r.user_id, r.time_of_day, r.timezone, r.enabled, r.last_sent_on, r.created_at, r.updated_at
+		FROM habit_reminders r
+		INNER JOIN habits h ON h.id = r.habit_id
+		WHERE r.enabled = 1 AND h.archived_at IS NULL
+	`
+
+	return r.findMany(ctx, query)
+}
+
+func (r *HabitReminderRepository) Update(ctx context.Context, reminder *entities.HabitReminder) error {
+	query := `
+		UPDATE habit_reminders
+		SET time_of_day = ?, timezone = ?, enabled = ?, last_sent_on = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query,
+		reminder.TimeOfDay,
+		reminder.Timezone,
+		reminder.Enabled,
+		formatDate(reminder.LastSentOn),
+		reminder.UpdatedAt,
+		reminder.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update habit reminder: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *HabitReminderRepository) Delete(ctx context.Context, id string) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM habit_reminders WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete habit reminder: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *HabitReminderRepository) findMany(ctx context.Context, query string, args ...interface{}) ([]*entities.HabitReminder, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find habit reminders: %w", err)
+	}
+	defer rows.Close()
+
+	var reminders []*entities.HabitReminder
+	for rows.Next() {
+		reminder, err := scanHabitReminder(rows)
+		if err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, reminder)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate habit reminders: %w", err)
+	}
+
+	return reminders, nil
+}
+
+func scanHabitReminder(row scanner) (*entities.HabitReminder, error) {
+	var (
+		reminder   entities.HabitReminder
+		lastSentOn sql.NullString
+	)
+
+	err := row.Scan(
+		&reminder.ID,
+		&reminder.HabitID,
+		&reminder.UserID,
+		&reminder.TimeOfDay,
+		&reminder.Timezone,
+		&reminder.Enabled,
+		&lastSentOn,
+		&reminder.CreatedAt,
+		&reminder.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan habit reminder: %w", err)
+	}
+
+	if reminder.LastSentOn, err = parseDate(lastSentOn); err != nil {
+		return nil, err
+	}
+
+	return &reminder, nil
+}
@@ -0,0 +1,77 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/shared/errors"
+)
+
+func TestHabitReminderRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewUserRepository(db)
+	habitRepo := NewHabitRepository(db)
+	repo := NewHabitReminderRepository(db)
+	ctx := context.Background()
+
+	user := entities.NewUser("reminders@example.com", "hash")
+	userRepo.Create(ctx, user)
+
+	habit := entities.NewHabit(user.ID, "Stretch", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	archived := entities.NewHabit(user.ID, "Old habit", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habitRepo.Create(ctx, habit)
+	habitRepo.Create(ctx, archived)
+	archived.Archive()
+	habitRepo.Update(ctx, archived)
+
+	evening := entities.NewHabitReminder(habit.ID, user.ID, "21:00", "Europe/Madrid")
+	morning := entities.NewHabitReminder(habit.ID, user.ID, "08:00", "Europe/Madrid")
+	for _, reminder := range []*entities.HabitReminder{evening, morning, entities.NewHabitReminder(archived.ID, user.ID, "09:00", "UTC")} {
+		if err := repo.Create(ctx, reminder); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	reminders, err := repo.FindByHabitID(ctx, habit.ID)
+	if err != nil {
+		t.Fatalf("FindByHabitID failed: %v", err)
+	}
+	if len(reminders) != 2 || reminders[0].ID != morning.ID {
+		t.Fatalf("Expected both reminders ordered by time, got %+v", reminders)
+	}
+
+	sentOn := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	evening.Enabled = false
+	evening.MarkSent(sentOn)
+	if err := repo.Update(ctx, evening); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	found, err := repo.FindByID(ctx, evening.ID)
+	if err != nil {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+	if found.Enabled || found.LastSentOn == nil || !found.LastSentOn.Equal(sentOn) {
+		t.Errorf("Unexpected reminder after update: %+v", found)
+	}
+
+	enabled, err := repo.FindEnabled(ctx)
+	if err != nil {
+		t.Fatalf("FindEnabled failed: %v", err)
+	}
+	if len(enabled) != 1 || enabled[0].ID != morning.ID {
+		t.Errorf("Expected only the enabled reminder of the active habit, got %+v", enabled)
+	}
+
+	if err := repo.Delete(ctx, morning.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := repo.FindByID(ctx, morning.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+}
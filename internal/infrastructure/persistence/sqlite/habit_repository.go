@@ -31,11 +31,11 @@ func (r *HabitRepository) Create(ctx context.Context, habit *entities.Habit) err
 	query := `
 		INSERT INTO habits (
 			id, user_id, name, description, type, frequency,
-			specific_days, specific_dates, carry_over, is_negative, target_value, created_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			specific_days, specific_dates, carry_over, is_negative, target_value, difficulty, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
 		habit.ID,
 		habit.UserID,
 		habit.Name,
@@ -47,6 +47,7 @@ func (r *HabitRepository) Create(ctx context.Context, habit *entities.Habit) err
 		habit.CarryOver,
 		habit.IsNegative,
 		habit.TargetValue,
+		habit.Difficulty,
 		habit.CreatedAt,
 	)
 
@@ -61,7 +62,7 @@ func (r *HabitRepository) FindByID(ctx context.Context, id string) (*entities.Ha
 	query := `
 		SELECT id, user_id, name, description, type, frequency,
 			   specific_days, specific_dates, carry_over, is_negative, target_value,
-			   created_at, archived_at
+			   difficulty, created_at, archived_at
 		FROM habits
 		WHERE id = ?
 	`
@@ -73,7 +74,7 @@ func (r *HabitRepository) FindByID(ctx context.Context, id string) (*entities.Ha
 		archivedAt    sql.NullTime
 	)
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&habit.ID,
 		&habit.UserID,
 		&habit.Name,
@@ -85,6 +86,7 @@ func (r *HabitRepository) FindByID(ctx context.Context, id string) (*entities.Ha
 		&habit.CarryOver,
 		&habit.IsNegative,
 		&habit.TargetValue,
+		&habit.Difficulty,
 		&habit.CreatedAt,
 		&archivedAt,
 	)
@@ -113,13 +115,13 @@ func (r *HabitRepository) FindActiveByUserID(ctx context.Context, userID string)
 	query := `
 		SELECT id, user_id, name, description, type, frequency,
 			   specific_days, specific_dates, carry_over, is_negative, target_value,
-			   created_at, archived_at
+			   difficulty, created_at, archived_at
 		FROM habits
 		WHERE user_id = ? AND archived_at IS NULL
 		ORDER BY created_at DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find habits: %w", err)
 	}
@@ -136,11 +138,11 @@ func (r *HabitRepository) Update(ctx context.Context, habit *entities.Habit) err
 		UPDATE habits
 		SET name = ?, description = ?, type = ?, frequency = ?,
 			specific_days = ?, specific_dates = ?, carry_over = ?, is_negative = ?,
-			target_value = ?, archived_at = ?
+			target_value = ?, difficulty = ?, archived_at = ?
 		WHERE id = ?
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := conn(ctx, r.db).ExecContext(ctx, query,
 		habit.Name,
 		habit.Description,
 		habit.Type,
@@ -150,6 +152,7 @@ func (r *HabitRepository) Update(ctx context.Context, habit *entities.Habit) err
 		habit.CarryOver,
 		habit.IsNegative,
 		habit.TargetValue,
+		habit.Difficulty,
 		habit.ArchivedAt,
 		habit.ID,
 	)
@@ -189,6 +192,7 @@ func (r *HabitRepository) scanHabits(rows *sql.Rows) ([]*entities.Habit, error)
 			&habit.CarryOver,
 			&habit.IsNegative,
 			&habit.TargetValue,
+			&habit.Difficulty,
 			&habit.CreatedAt,
 			&archivedAt,
 		)
@@ -217,13 +221,31 @@ func (r *HabitRepository) FindByUserID(ctx context.Context, userID string) ([]*e
 	query := `
 		SELECT id, user_id, name, description, type, frequency,
 			   specific_days, specific_dates, carry_over, is_negative, target_value,
-			   created_at, archived_at
+			   difficulty, created_at, archived_at
 		FROM habits
 		WHERE user_id = ?
 		ORDER BY created_at DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find habits: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanHabits(rows)
+}
+
+func (r *HabitRepository) FindAll(ctx context.Context) ([]*entities.Habit, error) {
+	query := `
+		SELECT id, user_id, name, description, type, frequency,
+			   specific_days, specific_dates, carry_over, is_negative, target_value,
+			   difficulty, created_at, archived_at
+		FROM habits
+		ORDER BY created_at ASC
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find habits: %w", err)
 	}
@@ -235,7 +257,7 @@ func (r *HabitRepository) FindByUserID(ctx context.Context, userID string) ([]*e
 func (r *HabitRepository) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM habits WHERE id = ?`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete habit: %w", err)
 	}
@@ -252,14 +274,14 @@ func (r *HabitRepository) FindActiveByUserIDWithPagination(ctx context.Context,
 	query := `
 		SELECT id, user_id, name, description, type, frequency,
 			   specific_days, specific_dates, carry_over, is_negative, target_value,
-			   created_at, archived_at
+			   difficulty, created_at, archived_at
 		FROM habits
 		WHERE user_id = ? AND archived_at IS NULL
 		ORDER BY created_at DESC
 		LIMIT ? OFFSET ?
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, params.Limit(), params.Offset())
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID, params.Limit(), params.Offset())
 	if err != nil {
 		return nil, fmt.Errorf("failed to find habits: %w", err)
 	}
@@ -276,7 +298,7 @@ func (r *HabitRepository) CountActiveByUserID(ctx context.Context, userID string
 	`
 
 	var count int
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, userID).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count habits: %w", err)
 	}
@@ -288,7 +310,7 @@ func (r *HabitRepository) FindByUserIDFiltered(ctx context.Context, userID strin
 	baseQuery := `
 		SELECT id, user_id, name, description, type, frequency,
 			   specific_days, specific_dates, carry_over, is_negative, target_value,
-			   created_at, archived_at
+			   difficulty, created_at, archived_at
 		FROM habits
 		WHERE user_id = ?`
 
@@ -326,7 +348,7 @@ func (r *HabitRepository) FindByUserIDFiltered(ctx context.Context, userID strin
 		args = append(args, paginationParams.Limit(), paginationParams.Offset())
 	}
 
-	rows, err := r.db.QueryContext(ctx, baseQuery, args...)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, baseQuery, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find habits: %w", err)
 	}
@@ -366,7 +388,7 @@ func (r *HabitRepository) CountByUserIDFiltered(ctx context.Context, userID stri
 	}
 
 	var count int
-	err := r.db.QueryRowContext(ctx, baseQuery, args...).Scan(&count)
+	err := conn(ctx, r.db).QueryRowContext(ctx, baseQuery, args...).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count habits: %w", err)
 	}
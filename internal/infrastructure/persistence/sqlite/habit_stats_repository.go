@@ -0,0 +1,179 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type HabitStatsRepository struct {
+	db *sql.DB
+}
+
+func NewHabitStatsRepository(db *sql.DB) *HabitStatsRepository {
+	return &HabitStatsRepository{db: db}
+}
+
+func (r *HabitStatsRepository) FindByHabitID(ctx context.Context, habitID string) (*entities.HabitStats, error) {
+	query := `
+		SELECT habit_id, total_completions, scheduled_completions, current_streak,
+			   longest_streak, last_completed_date, strength, strength_date, updated_at
+		FROM habit_stats
+		WHERE habit_id = ?
+	`
+
+	stats, err := scanHabitStats(conn(ctx, r.db).QueryRowContext(ctx, query, habitID))
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanHabitStats(row scanner) (*entities.HabitStats, error) {
+	var (
+		stats             entities.HabitStats
+		lastCompletedDate sql.NullString
+		strengthDate      sql.NullString
+	)
+
+	err := row.Scan(
+		&stats.HabitID,
+		&stats.TotalCompletions,
+		&stats.ScheduledCompletions,
+		&stats.CurrentStreak,
+		&stats.LongestStreak,
+		&lastCompletedDate,
+		&stats.Strength,
+		&strengthDate,
+		&stats.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan habit stats: %w", err)
+	}
+
+	if stats.LastCompletedDate, err = parseStatsDate(lastCompletedDate, "last_completed_date"); err != nil {
+		return nil, err
+	}
+	if stats.StrengthDate, err = parseStatsDate(strengthDate, "strength_date"); err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+func parseStatsDate(value sql.NullString, column string) (*time.Time, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value.String)
+	if err != nil {
+		date, err = time.Parse(time.RFC3339, value.String)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", column, err)
+		}
+	}
+	return &date, nil
+}
+
+func (r *HabitStatsRepository) FindByUserID(ctx context.Context, userID string) ([]*entities.HabitStats, error) {
+	query := `
+		SELECT s.habit_id, s.total_completions, s.scheduled_completions, s.current_streak,
+			   s.longest_streak, s.last_completed_date, s.strength, s.strength_date, s.updated_at
+		FROM habit_stats s
+		JOIN habits h ON h.id = s.habit_id
+		WHERE h.user_id = ?
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find habit stats: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*entities.HabitStats
+	for rows.Next() {
+		stats, err := scanHabitStats(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, stats)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate habit stats: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *HabitStatsRepository) Save(ctx context.Context, stats *entities.HabitStats) error {
+	query := `
+		INSERT INTO habit_stats (
+			habit_id, total_completions, scheduled_completions, current_streak,
+			longest_streak, last_completed_date, strength, strength_date, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(habit_id) DO UPDATE SET
+			total_completions = excluded.total_completions,
+			scheduled_completions = excluded.scheduled_completions,
+			current_streak = excluded.current_streak,
+			longest_streak = excluded.longest_streak,
+			last_completed_date = excluded.last_completed_date,
+			strength = excluded.strength,
+			strength_date = excluded.strength_date,
+			updated_at = excluded.updated_at
+	`
+
+	var lastCompletedDate, strengthDate *string
+	if stats.LastCompletedDate != nil {
+		date := stats.LastCompletedDate.Format("2006-01-02")
+		lastCompletedDate = &date
+	}
+	if stats.StrengthDate != nil {
+		date := stats.StrengthDate.Format("2006-01-02")
+		strengthDate = &date
+	}
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		stats.HabitID,
+		stats.TotalCompletions,
+		stats.ScheduledCompletions,
+		stats.CurrentStreak,
+		stats.LongestStreak,
+		lastCompletedDate,
+		stats.Strength,
+		strengthDate,
+		stats.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save habit stats: %w", err)
+	}
+
+	return nil
+}
+
+func (r *HabitStatsRepository) SaveStrength(ctx context.Context, habitID string, date time.Time, strength float64) error {
+	query := `UPDATE habit_stats SET strength = ?, strength_date = ? WHERE habit_id = ?`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, strength, date.Format("2006-01-02"), habitID); err != nil {
+		return fmt.Errorf("failed to save habit strength: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/shared/errors"
+)
+
+func TestHabitStatsRepositorySaveAndFind(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	habitRepo := NewHabitRepository(db)
+	repo := NewHabitStatsRepository(db)
+	ctx := context.Background()
+
+	habit := entities.NewHabit("user-123", "Reading", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habitRepo.Create(ctx, habit)
+
+	if _, err := repo.FindByHabitID(ctx, habit.ID); err != errors.ErrNotFound {
+		t.Fatalf("Expected ErrNotFound before saving, got %v", err)
+	}
+
+	lastCompleted := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+	stats := &entities.HabitStats{
+		HabitID:              habit.ID,
+		TotalCompletions:     10,
+		ScheduledCompletions: 9,
+		CurrentStreak:        3,
+		LongestStreak:        7,
+		LastCompletedDate:    &lastCompleted,
+		UpdatedAt:            time.Now(),
+	}
+
+	if err := repo.Save(ctx, stats); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	stats.TotalCompletions = 11
+	stats.CurrentStreak = 4
+	if err := repo.Save(ctx, stats); err != nil {
+		t.Fatalf("Second save failed: %v", err)
+	}
+
+	found, err := repo.FindByHabitID(ctx, habit.ID)
+	if err != nil {
+		t.Fatalf("FindByHabitID failed: %v", err)
+	}
+
+	if found.TotalCompletions != 11 || found.ScheduledCompletions != 9 || found.CurrentStreak != 4 || found.LongestStreak != 7 {
+		t.Errorf("Unexpected stats: %+v", found)
+	}
+	if found.LastCompletedDate == nil || !found.LastCompletedDate.Equal(lastCompleted) {
+		t.Errorf("Expected last completed date %s, got %v", lastCompleted, found.LastCompletedDate)
+	}
+}
+
+func TestTransactorRollsBackOnError(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	habitRepo := NewHabitRepository(db)
+	entryRepo := NewHabitEntryRepository(db)
+	transactor := NewTransactor(db)
+	ctx := context.Background()
+
+	habit := entities.NewHabit("user-123", "Reading", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habitRepo.Create(ctx, habit)
+
+	err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := entryRepo.Create(ctx, entities.NewHabitEntry(habit.ID, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), nil)); err != nil {
+			return err
+		}
+		return fmt.Errorf("stats update failed")
+	})
+	if err == nil {
+		t.Fatal("Expected the transaction error to be returned")
+	}
+
+	entries, err := entryRepo.FindByHabitID(ctx, habit.ID)
+	if err != nil {
+		t.Fatalf("FindByHabitID failed: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected entry creation to be rolled back, got %d entries", len(entries))
+	}
+}
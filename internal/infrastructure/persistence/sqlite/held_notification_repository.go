@@ -0,0 +1,120 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type HeldNotificationRepository struct {
+	db *sql.DB
+}
+
+func NewHeldNotificationRepository(db *sql.DB) *HeldNotificationRepository {
+	return &HeldNotificationRepository{db: db}
+}
+
+func (r *HeldNotificationRepository) Create(ctx context.Context, notification *entities.HeldNotification) error {
+	notification.ID = uuid.New().String()
+
+	query := `
+		INSERT INTO held_notifications (id, user_id, category, channel, title, content, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		notification.ID,
+		notification.UserID,
+		string(notification.Category),
+		string(notification.Channel),
+		notification.Title,
+		notification.Content,
+		notification.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to hold notification: %w", err)
+	}
+
+	return nil
+}
+
+func (r *HeldNotificationRepository) FindByUserID(ctx context.Context, userID string) ([]*entities.HeldNotification, error) {
+	query := `
+		SELECT id, user_id, category, channel, title, content, created_at
+		FROM held_notifications
+		WHERE user_id = ?
+		ORDER BY created_at ASC
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find held notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []*entities.HeldNotification
+	for rows.Next() {
+		var notification entities.HeldNotification
+		err := rows.Scan(
+			&notification.ID,
+			&notification.UserID,
+			&notification.Category,
+			&notification.Channel,
+			&notification.Title,
+			&notification.Content,
+			&notification.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan held notification: %w", err)
+		}
+		notifications = append(notifications, &notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate held notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (r *HeldNotificationRepository) FindUserIDs(ctx context.Context) ([]string, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT DISTINCT user_id FROM held_notifications`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find users with held notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan user id: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate users with held notifications: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+func (r *HeldNotificationRepository) Delete(ctx context.Context, id string) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM held_notifications WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete held notification: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
@@ -12,6 +12,25 @@ func RunMigrations(db *sql.DB) error {
 		createHabitEntriesTable,
 		createRefreshTokensTable,
 		createPasswordResetTokensTable,
+		createHabitStatsTable,
+		createAchievementsTable,
+		createRewardsTable,
+		createPointTransactionsTable,
+		createDigestSubscriptionsTable,
+		createRecapSharesTable,
+		createHabitRemindersTable,
+		createPushSubscriptionsTable,
+		createWebhooksTable,
+		createWebhookDeliveriesTable,
+		createEventOutboxTable,
+		createEmailOutboxTable,
+		createNotificationPreferencesTable,
+		createNotificationSchedulesTable,
+		createHeldNotificationsTable,
+		createNotificationLogTable,
+		createChatLinksTable,
+		createChatLinkCodesTable,
+		createMQTTIntegrationsTable,
 		createIndexes,
 	}
 
@@ -29,6 +48,14 @@ func RunMigrations(db *sql.DB) error {
 		return err
 	}
 
+	if err := addColumn(db, "habits", "difficulty", "TEXT NOT NULL DEFAULT 'MEDIUM'"); err != nil {
+		return err
+	}
+
+	if err := addColumn(db, "users", "language", "TEXT NOT NULL DEFAULT 'en'"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -75,6 +102,23 @@ func removeTimezoneColumn(db *sql.DB) error {
 	return nil
 }
 
+func addColumn(db *sql.DB, table, column, definition string) error {
+	exists, err := columnExists(db, table, column)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return nil
+	}
+
+	if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return fmt.Errorf("failed to add %s column: %w", column, err)
+	}
+
+	return nil
+}
+
 func columnExists(db *sql.DB, table, column string) (bool, error) {
 	query := fmt.Sprintf("SELECT COUNT(*) FROM pragma_table_info('%s') WHERE name = ?", table)
 	var count int
@@ -150,6 +194,267 @@ CREATE TABLE IF NOT EXISTS password_reset_tokens (
 );
 `
 
+const createHabitStatsTable = `
+CREATE TABLE IF NOT EXISTS habit_stats (
+	habit_id TEXT PRIMARY KEY,
+	total_completions INTEGER NOT NULL DEFAULT 0,
+	scheduled_completions INTEGER NOT NULL DEFAULT 0,
+	current_streak INTEGER NOT NULL DEFAULT 0,
+	longest_streak INTEGER NOT NULL DEFAULT 0,
+	last_completed_date DATE,
+	strength REAL NOT NULL DEFAULT 0,
+	strength_date DATE,
+	updated_at DATETIME NOT NULL,
+	FOREIGN KEY (habit_id) REFERENCES habits(id) ON DELETE CASCADE
+);
+`
+
+const createAchievementsTable = `
+CREATE TABLE IF NOT EXISTS achievements (
+	id TEXT PRIMARY KEY,
+	user_id TEXT NOT NULL,
+	code TEXT NOT NULL,
+	habit_id TEXT,
+	unlocked_at DATETIME NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+	FOREIGN KEY (habit_id) REFERENCES habits(id) ON DELETE SET NULL,
+	UNIQUE(user_id, code)
+);
+`
+
+const createRewardsTable = `
+CREATE TABLE IF NOT EXISTS rewards (
+	id TEXT PRIMARY KEY,
+	user_id TEXT NOT NULL,
+	name TEXT NOT NULL,
+	cost INTEGER NOT NULL,
+	created_at DATETIME NOT NULL,
+	archived_at DATETIME,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+`
+
+const createPointTransactionsTable = `
+CREATE TABLE IF NOT EXISTS point_transactions (
+	id TEXT PRIMARY KEY,
+	user_id TEXT NOT NULL,
+	type TEXT NOT NULL,
+	points INTEGER NOT NULL,
+	habit_id TEXT,
+	entry_id TEXT,
+	reward_id TEXT,
+	created_at DATETIME NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+	FOREIGN KEY (habit_id) REFERENCES habits(id) ON DELETE SET NULL,
+	FOREIGN KEY (reward_id) REFERENCES rewards(id) ON DELETE SET NULL
+);
+`
+
+const createDigestSubscriptionsTable = `
+CREATE TABLE IF NOT EXISTS digest_subscriptions (
+	user_id TEXT PRIMARY KEY,
+	weekly BOOLEAN NOT NULL DEFAULT 0,
+	monthly BOOLEAN NOT NULL DEFAULT 0,
+	last_weekly_period DATE,
+	last_monthly_period DATE,
+	updated_at DATETIME NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+`
+
+const createRecapSharesTable = `
+CREATE TABLE IF NOT EXISTS recap_shares (
+	id TEXT PRIMARY KEY,
+	user_id TEXT NOT NULL,
+	year INTEGER NOT NULL,
+	token TEXT NOT NULL UNIQUE,
+	created_at DATETIME NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+	UNIQUE(user_id, year)
+);
+`
+
+const createHabitRemindersTable = `
+CREATE TABLE IF NOT EXISTS habit_reminders (
+	id TEXT PRIMARY KEY,
+	habit_id TEXT NOT NULL,
+	user_id TEXT NOT NULL,
+	time_of_day TEXT NOT NULL,
+	timezone TEXT NOT NULL,
+	enabled BOOLEAN NOT NULL DEFAULT 1,
+	last_sent_on DATE,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL,
+	FOREIGN KEY (habit_id) REFERENCES habits(id) ON DELETE CASCADE,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+`
+
+const createPushSubscriptionsTable = `
+CREATE TABLE IF NOT EXISTS push_subscriptions (
+	id TEXT PRIMARY KEY,
+	user_id TEXT NOT NULL,
+	endpoint TEXT NOT NULL UNIQUE,
+	p256dh TEXT NOT NULL,
+	auth TEXT NOT NULL,
+	user_agent TEXT NOT NULL DEFAULT '',
+	created_at DATETIME NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+`
+
+const createWebhooksTable = `
+CREATE TABLE IF NOT EXISTS webhooks (
+	id TEXT PRIMARY KEY,
+	user_id TEXT NOT NULL,
+	url TEXT NOT NULL,
+	secret TEXT NOT NULL,
+	events TEXT NOT NULL,
+	enabled BOOLEAN NOT NULL DEFAULT 1,
+	consecutive_failures INTEGER NOT NULL DEFAULT 0,
+	disabled_at DATETIME,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+`
+
+const createWebhookDeliveriesTable = `
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+	id TEXT PRIMARY KEY,
+	webhook_id TEXT NOT NULL,
+	event_id TEXT NOT NULL,
+	event_type TEXT NOT NULL,
+	payload TEXT NOT NULL,
+	status TEXT NOT NULL,
+	attempts INTEGER NOT NULL DEFAULT 0,
+	next_attempt_at DATETIME NOT NULL,
+	last_status_code INTEGER,
+	last_error TEXT NOT NULL DEFAULT '',
+	created_at DATETIME NOT NULL,
+	delivered_at DATETIME,
+	UNIQUE (webhook_id, event_id),
+	FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE
+);
+`
+
+const createEventOutboxTable = `
+CREATE TABLE IF NOT EXISTS event_outbox (
+	id TEXT PRIMARY KEY,
+	type TEXT NOT NULL,
+	user_id TEXT NOT NULL,
+	data TEXT NOT NULL,
+	occurred_at DATETIME NOT NULL,
+	status TEXT NOT NULL,
+	attempts INTEGER NOT NULL DEFAULT 0,
+	next_attempt_at DATETIME NOT NULL,
+	last_error TEXT NOT NULL DEFAULT '',
+	handled_by TEXT NOT NULL DEFAULT '[]',
+	processed_at DATETIME,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+`
+
+const createEmailOutboxTable = `
+CREATE TABLE IF NOT EXISTS email_outbox (
+	id TEXT PRIMARY KEY,
+	recipient TEXT NOT NULL,
+	subject TEXT NOT NULL,
+	body TEXT NOT NULL,
+	text_body TEXT NOT NULL DEFAULT '',
+	headers TEXT NOT NULL DEFAULT '{}',
+	is_html BOOLEAN NOT NULL DEFAULT 0,
+	status TEXT NOT NULL,
+	attempts INTEGER NOT NULL DEFAULT 0,
+	next_attempt_at DATETIME NOT NULL,
+	last_error TEXT NOT NULL DEFAULT '',
+	created_at DATETIME NOT NULL,
+	sent_at DATETIME
+);
+`
+
+const createNotificationPreferencesTable = `
+CREATE TABLE IF NOT EXISTS notification_preferences (
+	user_id TEXT NOT NULL,
+	category TEXT NOT NULL,
+	channel TEXT NOT NULL,
+	enabled BOOLEAN NOT NULL,
+	updated_at DATETIME NOT NULL,
+	PRIMARY KEY (user_id, category, channel),
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+`
+
+const createNotificationSchedulesTable = `
+CREATE TABLE IF NOT EXISTS notification_schedules (
+	user_id TEXT PRIMARY KEY,
+	quiet_hours_start TEXT NOT NULL DEFAULT '',
+	quiet_hours_end TEXT NOT NULL DEFAULT '',
+	timezone TEXT NOT NULL DEFAULT 'UTC',
+	max_per_hour INTEGER NOT NULL DEFAULT 0,
+	updated_at DATETIME NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+`
+
+const createHeldNotificationsTable = `
+CREATE TABLE IF NOT EXISTS held_notifications (
+	id TEXT PRIMARY KEY,
+	user_id TEXT NOT NULL,
+	category TEXT NOT NULL,
+	channel TEXT NOT NULL,
+	title TEXT NOT NULL,
+	content TEXT NOT NULL DEFAULT '',
+	created_at DATETIME NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+`
+
+const createNotificationLogTable = `
+CREATE TABLE IF NOT EXISTS notification_log (
+	user_id TEXT NOT NULL,
+	channel TEXT NOT NULL,
+	sent_at DATETIME NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+`
+
+const createChatLinksTable = `
+CREATE TABLE IF NOT EXISTS chat_links (
+	user_id TEXT PRIMARY KEY,
+	chat_id INTEGER NOT NULL UNIQUE,
+	timezone TEXT NOT NULL,
+	created_at DATETIME NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+`
+
+const createChatLinkCodesTable = `
+CREATE TABLE IF NOT EXISTS chat_link_codes (
+	code TEXT PRIMARY KEY,
+	user_id TEXT NOT NULL,
+	timezone TEXT NOT NULL,
+	expires_at DATETIME NOT NULL,
+	created_at DATETIME NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+`
+
+const createMQTTIntegrationsTable = `
+CREATE TABLE IF NOT EXISTS mqtt_integrations (
+	user_id TEXT PRIMARY KEY,
+	broker_url TEXT NOT NULL,
+	username TEXT NOT NULL,
+	password TEXT NOT NULL,
+	topic_prefix TEXT NOT NULL,
+	discovery_prefix TEXT NOT NULL,
+	timezone TEXT NOT NULL,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+`
+
 const createIndexes = `
 CREATE INDEX IF NOT EXISTS idx_habits_user ON habits(user_id);
 CREATE INDEX IF NOT EXISTS idx_habits_active ON habits(user_id, archived_at);
@@ -159,4 +464,19 @@ CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user ON refresh_tokens(user_id);
 CREATE INDEX IF NOT EXISTS idx_refresh_tokens_token ON refresh_tokens(token);
 CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_user ON password_reset_tokens(user_id);
 CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_token ON password_reset_tokens(token);
+CREATE INDEX IF NOT EXISTS idx_achievements_user ON achievements(user_id);
+CREATE INDEX IF NOT EXISTS idx_rewards_user ON rewards(user_id);
+CREATE INDEX IF NOT EXISTS idx_point_transactions_user ON point_transactions(user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_point_transactions_entry ON point_transactions(entry_id);
+CREATE INDEX IF NOT EXISTS idx_habit_reminders_habit ON habit_reminders(habit_id);
+CREATE INDEX IF NOT EXISTS idx_habit_reminders_enabled ON habit_reminders(enabled);
+CREATE INDEX IF NOT EXISTS idx_push_subscriptions_user ON push_subscriptions(user_id);
+CREATE INDEX IF NOT EXISTS idx_webhooks_user ON webhooks(user_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_event_outbox_due ON event_outbox(status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_email_outbox_due ON email_outbox(status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_held_notifications_user ON held_notifications(user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_notification_log_user ON notification_log(user_id, sent_at);
+CREATE INDEX IF NOT EXISTS idx_chat_link_codes_user ON chat_link_codes(user_id);
 `
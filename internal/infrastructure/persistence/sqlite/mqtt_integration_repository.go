@@ -0,0 +1,126 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type MQTTIntegrationRepository struct {
+	db *sql.DB
+}
+
+func NewMQTTIntegrationRepository(db *sql.DB) *MQTTIntegrationRepository {
+	return &MQTTIntegrationRepository{db: db}
+}
+
+func (r *MQTTIntegrationRepository) Save(ctx context.Context, integration *entities.MQTTIntegration) error {
+	query := `
+		INSERT INTO mqtt_integrations (user_id, broker_url, username, password, topic_prefix, discovery_prefix, timezone, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(user_id) DO UPDATE SET
+			broker_url = excluded.broker_url,
+			username = excluded.username,
+			password = excluded.password,
+			topic_prefix = excluded.topic_prefix,
+			discovery_prefix = excluded.discovery_prefix,
+			timezone = excluded.timezone,
+			updated_at = excluded.updated_at
+	`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		integration.UserID,
+		integration.BrokerURL,
+		integration.Username,
+		integration.Password,
+		integration.TopicPrefix,
+		integration.DiscoveryPrefix,
+		integration.Timezone,
+		integration.CreatedAt,
+		integration.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save mqtt integration: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MQTTIntegrationRepository) FindByUserID(ctx context.Context, userID string) (*entities.MQTTIntegration, error) {
+	query := `
+		SELECT user_id, broker_url, username, password, topic_prefix, discovery_prefix, timezone, created_at, updated_at
+		FROM mqtt_integrations
+		WHERE user_id = ?
+	`
+
+	integration, err := scanMQTTIntegration(conn(ctx, r.db).QueryRowContext(ctx, query, userID))
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find mqtt integration: %w", err)
+	}
+
+	return integration, nil
+}
+
+func (r *MQTTIntegrationRepository) FindAll(ctx context.Context) ([]*entities.MQTTIntegration, error) {
+	query := `
+		SELECT user_id, broker_url, username, password, topic_prefix, discovery_prefix, timezone, created_at, updated_at
+		FROM mqtt_integrations
+		ORDER BY created_at ASC
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find mqtt integrations: %w", err)
+	}
+	defer rows.Close()
+
+	var integrations []*entities.MQTTIntegration
+	for rows.Next() {
+		integration, err := scanMQTTIntegration(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan mqtt integration: %w", err)
+		}
+		integrations = append(integrations, integration)
+	}
+
+	return integrations, rows.Err()
+}
+
+func (r *MQTTIntegrationRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM mqtt_integrations WHERE user_id = ?`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete mqtt integration: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func scanMQTTIntegration(row scanner) (*entities.MQTTIntegration, error) {
+	var integration entities.MQTTIntegration
+	err := row.Scan(
+		&integration.UserID,
+		&integration.BrokerURL,
+		&integration.Username,
+		&integration.Password,
+		&integration.TopicPrefix,
+		&integration.DiscoveryPrefix,
+		&integration.Timezone,
+		&integration.CreatedAt,
+		&integration.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &integration, nil
+}
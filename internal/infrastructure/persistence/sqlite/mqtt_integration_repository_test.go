@@ -0,0 +1,66 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+func TestMQTTIntegrationRepositorySaveFindAndDelete(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewUserRepository(db)
+	repo := NewMQTTIntegrationRepository(db)
+	ctx := context.Background()
+
+	user := entities.NewUser("mqtt@example.com", "hash")
+	other := entities.NewUser("other@example.com", "hash")
+	userRepo.Create(ctx, user)
+	userRepo.Create(ctx, other)
+
+	if _, err := repo.FindByUserID(ctx, user.ID); err != errors.ErrNotFound {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+
+	integration := entities.NewMQTTIntegration(user.ID, "mqtt://broker.local:1883", "ha", "secret", entities.DefaultMQTTTopicPrefix, entities.DefaultMQTTDiscoveryPrefix, "UTC")
+	if err := repo.Save(ctx, integration); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	integration.BrokerURL = "mqtts://broker.example.com"
+	integration.TopicPrefix = "home/habits"
+	if err := repo.Save(ctx, integration); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := repo.Save(ctx, entities.NewMQTTIntegration(other.ID, "mqtt://other.local", "", "", entities.DefaultMQTTTopicPrefix, entities.DefaultMQTTDiscoveryPrefix, "UTC")); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	found, err := repo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("FindByUserID failed: %v", err)
+	}
+	if found.BrokerURL != "mqtts://broker.example.com" || found.TopicPrefix != "home/habits" || found.Username != "ha" || found.Password != "secret" {
+		t.Errorf("Expected the second save to replace the first, got %+v", found)
+	}
+
+	all, err := repo.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll failed: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("Expected 2 integrations, got %d", len(all))
+	}
+
+	if err := repo.DeleteByUserID(ctx, user.ID); err != nil {
+		t.Fatalf("DeleteByUserID failed: %v", err)
+	}
+	if err := repo.DeleteByUserID(ctx, user.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound deleting twice, got %v", err)
+	}
+	if _, err := repo.FindByUserID(ctx, other.ID); err != nil {
+		t.Errorf("Expected the other user's integration to remain, got %v", err)
+	}
+}
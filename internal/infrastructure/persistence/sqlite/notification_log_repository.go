@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+// NotificationLogRepository stores times in UTC so that they can be compared
+// as text.
+type NotificationLogRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationLogRepository(db *sql.DB) *NotificationLogRepository {
+	return &NotificationLogRepository{db: db}
+}
+
+func (r *NotificationLogRepository) Record(ctx context.Context, userID string, channel entities.NotificationChannel, sentAt time.Time) error {
+	query := `INSERT INTO notification_log (user_id, channel, sent_at) VALUES (?, ?, ?)`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, userID, string(channel), sentAt.UTC()); err != nil {
+		return fmt.Errorf("failed to record notification: %w", err)
+	}
+
+	return nil
+}
+
+func (r *NotificationLogRepository) CountSince(ctx context.Context, userID string, since time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM notification_log WHERE user_id = ? AND sent_at > ?`
+
+	var count int
+	if err := conn(ctx, r.db).QueryRowContext(ctx, query, userID, since.UTC()).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *NotificationLogRepository) DeleteBefore(ctx context.Context, before time.Time) (int, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM notification_log WHERE sent_at < ?`, before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete notification log: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	return int(rows), nil
+}
@@ -0,0 +1,77 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+type NotificationPreferenceRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationPreferenceRepository(db *sql.DB) *NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{db: db}
+}
+
+func (r *NotificationPreferenceRepository) FindByUserID(ctx context.Context, userID string) (entities.NotificationPreferences, error) {
+	query := `
+		SELECT user_id, category, channel, enabled, updated_at
+		FROM notification_preferences
+		WHERE user_id = ?
+		ORDER BY category, channel
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification preferences: %w", err)
+	}
+	defer rows.Close()
+
+	var preferences entities.NotificationPreferences
+	for rows.Next() {
+		var preference entities.NotificationPreference
+		err := rows.Scan(
+			&preference.UserID,
+			&preference.Category,
+			&preference.Channel,
+			&preference.Enabled,
+			&preference.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification preference: %w", err)
+		}
+		preferences = append(preferences, &preference)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate notification preferences: %w", err)
+	}
+
+	return preferences, nil
+}
+
+func (r *NotificationPreferenceRepository) Save(ctx context.Context, preference *entities.NotificationPreference) error {
+	query := `
+		INSERT INTO notification_preferences (user_id, category, channel, enabled, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(user_id, category, channel) DO UPDATE SET
+			enabled = excluded.enabled,
+			updated_at = excluded.updated_at
+	`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		preference.UserID,
+		string(preference.Category),
+		string(preference.Channel),
+		preference.Enabled,
+		preference.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save notification preference: %w", err)
+	}
+
+	return nil
+}
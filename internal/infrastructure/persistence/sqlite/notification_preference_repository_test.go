@@ -0,0 +1,59 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+func TestNotificationPreferenceRepositorySaveAndFind(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewUserRepository(db)
+	repo := NewNotificationPreferenceRepository(db)
+	ctx := context.Background()
+
+	user := entities.NewUser("prefs@example.com", "hash")
+	other := entities.NewUser("other@example.com", "hash")
+	userRepo.Create(ctx, user)
+	userRepo.Create(ctx, other)
+
+	preferences, err := repo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("FindByUserID failed: %v", err)
+	}
+	if len(preferences) != 0 || !preferences.IsEnabled(entities.NotificationDigests, entities.NotificationEmail) {
+		t.Fatalf("Expected no stored preferences and everything enabled, got %+v", preferences)
+	}
+
+	saves := []*entities.NotificationPreference{
+		entities.NewNotificationPreference(user.ID, entities.NotificationDigests, entities.NotificationEmail, false),
+		entities.NewNotificationPreference(user.ID, entities.NotificationReminders, entities.NotificationPush, false),
+		entities.NewNotificationPreference(user.ID, entities.NotificationReminders, entities.NotificationPush, true),
+		entities.NewNotificationPreference(other.ID, entities.NotificationAchievements, entities.NotificationEmail, false),
+	}
+	for _, preference := range saves {
+		if err := repo.Save(ctx, preference); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	preferences, err = repo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("FindByUserID failed: %v", err)
+	}
+	if len(preferences) != 2 {
+		t.Fatalf("Expected 2 preferences, got %d", len(preferences))
+	}
+	if preferences.IsEnabled(entities.NotificationDigests, entities.NotificationEmail) {
+		t.Error("Expected digest emails to be off")
+	}
+	if !preferences.IsEnabled(entities.NotificationReminders, entities.NotificationPush) {
+		t.Error("Expected the second save to turn push reminders back on")
+	}
+	if !preferences.IsEnabled(entities.NotificationAchievements, entities.NotificationEmail) {
+		t.Error("Expected another user's preference not to apply")
+	}
+}
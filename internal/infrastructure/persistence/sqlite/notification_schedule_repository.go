@@ -0,0 +1,72 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type NotificationScheduleRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationScheduleRepository(db *sql.DB) *NotificationScheduleRepository {
+	return &NotificationScheduleRepository{db: db}
+}
+
+func (r *NotificationScheduleRepository) FindByUserID(ctx context.Context, userID string) (*entities.NotificationSchedule, error) {
+	query := `
+		SELECT user_id, quiet_hours_start, quiet_hours_end, timezone, max_per_hour, updated_at
+		FROM notification_schedules
+		WHERE user_id = ?
+	`
+
+	var schedule entities.NotificationSchedule
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, userID).Scan(
+		&schedule.UserID,
+		&schedule.QuietHoursStart,
+		&schedule.QuietHoursEnd,
+		&schedule.Timezone,
+		&schedule.MaxPerHour,
+		&schedule.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification schedule: %w", err)
+	}
+
+	return &schedule, nil
+}
+
+func (r *NotificationScheduleRepository) Save(ctx context.Context, schedule *entities.NotificationSchedule) error {
+	query := `
+		INSERT INTO notification_schedules (
+			user_id, quiet_hours_start, quiet_hours_end, timezone, max_per_hour, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(user_id) DO UPDATE SET
+			quiet_hours_start = excluded.quiet_hours_start,
+			quiet_hours_end = excluded.quiet_hours_end,
+			timezone = excluded.timezone,
+			max_per_hour = excluded.max_per_hour,
+			updated_at = excluded.updated_at
+	`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		schedule.UserID,
+		schedule.QuietHoursStart,
+		schedule.QuietHoursEnd,
+		schedule.Timezone,
+		schedule.MaxPerHour,
+		schedule.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save notification schedule: %w", err)
+	}
+
+	return nil
+}
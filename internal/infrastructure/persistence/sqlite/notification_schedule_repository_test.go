@@ -0,0 +1,134 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+func TestNotificationScheduleRepositorySaveAndFind(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewUserRepository(db)
+	repo := NewNotificationScheduleRepository(db)
+	ctx := context.Background()
+
+	user := entities.NewUser("schedule@example.com", "hash")
+	userRepo.Create(ctx, user)
+
+	if _, err := repo.FindByUserID(ctx, user.ID); err != errors.ErrNotFound {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+
+	schedule := entities.NewNotificationSchedule(user.ID)
+	schedule.QuietHoursStart = "22:00"
+	schedule.QuietHoursEnd = "07:00"
+	schedule.Timezone = "Europe/Madrid"
+	schedule.MaxPerHour = 3
+	if err := repo.Save(ctx, schedule); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	schedule.MaxPerHour = 5
+	if err := repo.Save(ctx, schedule); err != nil {
+		t.Fatalf("Second save failed: %v", err)
+	}
+
+	found, err := repo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("FindByUserID failed: %v", err)
+	}
+	if found.QuietHoursStart != "22:00" || found.QuietHoursEnd != "07:00" || found.Timezone != "Europe/Madrid" || found.MaxPerHour != 5 {
+		t.Errorf("Unexpected schedule: %+v", found)
+	}
+}
+
+func TestHeldNotificationRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewUserRepository(db)
+	repo := NewHeldNotificationRepository(db)
+	ctx := context.Background()
+
+	user := entities.NewUser("held@example.com", "hash")
+	other := entities.NewUser("other@example.com", "hash")
+	userRepo.Create(ctx, user)
+	userRepo.Create(ctx, other)
+
+	first := entities.NewHeldNotification(user.ID, entities.NotificationReminders, entities.NotificationPush, "Read")
+	first.CreatedAt = time.Now().Add(-time.Hour)
+	second := entities.NewHeldNotification(user.ID, entities.NotificationAchievements, entities.NotificationEmail, "Week streak")
+	second.Content = `{"Subject":"Week streak"}`
+	third := entities.NewHeldNotification(other.ID, entities.NotificationReminders, entities.NotificationPush, "Run")
+	for _, notification := range []*entities.HeldNotification{second, first, third} {
+		if err := repo.Create(ctx, notification); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	held, err := repo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("FindByUserID failed: %v", err)
+	}
+	if len(held) != 2 || held[0].ID != first.ID || held[1].Channel != entities.NotificationEmail {
+		t.Fatalf("Expected both notifications oldest first, got %+v", held)
+	}
+	if held[0].Content != "" || held[1].Content != second.Content {
+		t.Errorf("Expected the content to be kept, got %q and %q", held[0].Content, held[1].Content)
+	}
+
+	userIDs, err := repo.FindUserIDs(ctx)
+	if err != nil {
+		t.Fatalf("FindUserIDs failed: %v", err)
+	}
+	if len(userIDs) != 2 {
+		t.Errorf("Expected 2 users, got %v", userIDs)
+	}
+
+	if err := repo.Delete(ctx, first.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := repo.Delete(ctx, first.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestNotificationLogRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewUserRepository(db)
+	repo := NewNotificationLogRepository(db)
+	ctx := context.Background()
+
+	user := entities.NewUser("log@example.com", "hash")
+	userRepo.Create(ctx, user)
+
+	now := time.Now()
+	for _, sentAt := range []time.Time{now.Add(-2 * time.Hour), now.Add(-30 * time.Minute), now} {
+		if err := repo.Record(ctx, user.ID, entities.NotificationEmail, sentAt); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+
+	count, err := repo.CountSince(ctx, user.ID, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("CountSince failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 notifications in the last hour, got %d", count)
+	}
+
+	deleted, err := repo.DeleteBefore(ctx, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("DeleteBefore failed: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("Expected 1 deleted entry, got %d", deleted)
+	}
+}
@@ -0,0 +1,150 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/pagination"
+
+	"github.com/google/uuid"
+)
+
+type PointsRepository struct {
+	db *sql.DB
+}
+
+func NewPointsRepository(db *sql.DB) *PointsRepository {
+	return &PointsRepository{db: db}
+}
+
+func (r *PointsRepository) Create(ctx context.Context, transaction *entities.PointTransaction) error {
+	transaction.ID = uuid.New().String()
+
+	query := `
+		INSERT INTO point_transactions (id, user_id, type, points, habit_id, entry_id, reward_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		transaction.ID,
+		transaction.UserID,
+		string(transaction.Type),
+		transaction.Points,
+		transaction.HabitID,
+		transaction.EntryID,
+		transaction.RewardID,
+		transaction.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create point transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PointsRepository) FindByUserID(ctx context.Context, userID string, params pagination.Params) ([]*entities.PointTransaction, error) {
+	query := `
+		SELECT id, user_id, type, points, habit_id, entry_id, reward_id, created_at
+		FROM point_transactions
+		WHERE user_id = ?
+		ORDER BY created_at DESC, rowid DESC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID, params.Limit(), params.Offset())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find point transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var transactions []*entities.PointTransaction
+	for rows.Next() {
+		var (
+			transaction entities.PointTransaction
+			txType      string
+			habitID     sql.NullString
+			entryID     sql.NullString
+			rewardID    sql.NullString
+		)
+
+		err := rows.Scan(
+			&transaction.ID,
+			&transaction.UserID,
+			&txType,
+			&transaction.Points,
+			&habitID,
+			&entryID,
+			&rewardID,
+			&transaction.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan point transaction: %w", err)
+		}
+
+		transaction.Type = entities.PointTransactionType(txType)
+		if habitID.Valid {
+			transaction.HabitID = &habitID.String
+		}
+		if entryID.Valid {
+			transaction.EntryID = &entryID.String
+		}
+		if rewardID.Valid {
+			transaction.RewardID = &rewardID.String
+		}
+
+		transactions = append(transactions, &transaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate point transactions: %w", err)
+	}
+
+	return transactions, nil
+}
+
+func (r *PointsRepository) CountByUserID(ctx context.Context, userID string) (int, error) {
+	query := `SELECT COUNT(*) FROM point_transactions WHERE user_id = ?`
+
+	var count int
+	if err := conn(ctx, r.db).QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count point transactions: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *PointsRepository) SumByEntryID(ctx context.Context, entryID string) (int, error) {
+	query := `SELECT COALESCE(SUM(points), 0) FROM point_transactions WHERE entry_id = ?`
+
+	var sum int
+	if err := conn(ctx, r.db).QueryRowContext(ctx, query, entryID).Scan(&sum); err != nil {
+		return 0, fmt.Errorf("failed to sum entry points: %w", err)
+	}
+
+	return sum, nil
+}
+
+func (r *PointsRepository) GetBalance(ctx context.Context, userID string) (entities.PointsBalance, error) {
+	query := `
+		SELECT
+			COALESCE(SUM(CASE WHEN type IN (?, ?) THEN points ELSE 0 END), 0),
+			COALESCE(SUM(points), 0)
+		FROM point_transactions
+		WHERE user_id = ?
+	`
+
+	var balance entities.PointsBalance
+	err := conn(ctx, r.db).QueryRowContext(ctx, query,
+		string(entities.PointTransactionCompletion),
+		string(entities.PointTransactionReversal),
+		userID,
+	).Scan(&balance.XP, &balance.Balance)
+	if err != nil {
+		return balance, fmt.Errorf("failed to get points balance: %w", err)
+	}
+
+	return balance, nil
+}
@@ -0,0 +1,133 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/shared/pagination"
+)
+
+func TestPointsRepositoryLedger(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewUserRepository(db)
+	habitRepo := NewHabitRepository(db)
+	rewardRepo := NewRewardRepository(db)
+	repo := NewPointsRepository(db)
+	ctx := context.Background()
+
+	user := entities.NewUser("points@example.com", "hash")
+	if err := userRepo.Create(ctx, user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	habit := entities.NewHabit(user.ID, "Running", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.Difficulty = value_objects.HabitDifficultyHard
+	habitRepo.Create(ctx, habit)
+
+	found, err := habitRepo.FindByID(ctx, habit.ID)
+	if err != nil {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+	if found.Difficulty != value_objects.HabitDifficultyHard {
+		t.Errorf("Expected difficulty HARD, got %s", found.Difficulty)
+	}
+
+	reward := entities.NewReward(user.ID, "Buy a book", 15)
+	if err := rewardRepo.Create(ctx, reward); err != nil {
+		t.Fatalf("Failed to create reward: %v", err)
+	}
+
+	first := &entities.HabitEntry{ID: "entry-1", HabitID: habit.ID}
+	second := &entities.HabitEntry{ID: "entry-2", HabitID: habit.ID}
+
+	transactions := []*entities.PointTransaction{
+		entities.NewCompletionCredit(habit, first),
+		entities.NewCompletionCredit(habit, second),
+		entities.NewCompletionReversal(habit, second.ID, habit.Difficulty.Points()),
+		entities.NewRedemption(reward),
+	}
+	for _, transaction := range transactions {
+		if err := repo.Create(ctx, transaction); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	balance, err := repo.GetBalance(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetBalance failed: %v", err)
+	}
+	if balance.XP != 20 || balance.Balance != 5 {
+		t.Errorf("Expected 20 XP and a balance of 5, got %+v", balance)
+	}
+
+	if sum, _ := repo.SumByEntryID(ctx, second.ID); sum != 0 {
+		t.Errorf("Expected reversed entry to sum to 0, got %d", sum)
+	}
+	if sum, _ := repo.SumByEntryID(ctx, first.ID); sum != 20 {
+		t.Errorf("Expected entry-1 to sum to 20, got %d", sum)
+	}
+
+	page, err := repo.FindByUserID(ctx, user.ID, pagination.NewParams(1, 3))
+	if err != nil {
+		t.Fatalf("FindByUserID failed: %v", err)
+	}
+	if len(page) != 3 {
+		t.Fatalf("Expected 3 transactions on the first page, got %d", len(page))
+	}
+	if page[0].Type != entities.PointTransactionRedemption || page[0].RewardID == nil || *page[0].RewardID != reward.ID {
+		t.Errorf("Expected the redemption first, got %+v", page[0])
+	}
+
+	if count, _ := repo.CountByUserID(ctx, user.ID); count != 4 {
+		t.Errorf("Expected 4 transactions, got %d", count)
+	}
+}
+
+func TestRewardRepositoryArchive(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewUserRepository(db)
+	repo := NewRewardRepository(db)
+	ctx := context.Background()
+
+	user := entities.NewUser("rewards@example.com", "hash")
+	if err := userRepo.Create(ctx, user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	cheap := entities.NewReward(user.ID, "Coffee", 50)
+	expensive := entities.NewReward(user.ID, "Buy a book", 500)
+	repo.Create(ctx, expensive)
+	repo.Create(ctx, cheap)
+
+	rewards, err := repo.FindActiveByUserID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("FindActiveByUserID failed: %v", err)
+	}
+	if len(rewards) != 2 || rewards[0].ID != cheap.ID {
+		t.Fatalf("Expected 2 rewards, cheapest first, got %+v", rewards)
+	}
+
+	cheap.Archive()
+	if err := repo.Update(ctx, cheap); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	rewards, _ = repo.FindActiveByUserID(ctx, user.ID)
+	if len(rewards) != 1 || rewards[0].ID != expensive.ID {
+		t.Errorf("Expected only the active reward, got %+v", rewards)
+	}
+
+	found, err := repo.FindByID(ctx, cheap.ID)
+	if err != nil {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+	if found.IsActive() {
+		t.Error("Expected archived reward to stay archived")
+	}
+}
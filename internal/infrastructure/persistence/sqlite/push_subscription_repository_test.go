@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+func TestPushSubscriptionRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewUserRepository(db)
+	repo := NewPushSubscriptionRepository(db)
+	ctx := context.Background()
+
+	first := entities.NewUser("push-first@example.com", "hash")
+	second := entities.NewUser("push-second@example.com", "hash")
+	userRepo.Create(ctx, first)
+	userRepo.Create(ctx, second)
+
+	laptop := entities.NewPushSubscription(first.ID, "https://push.example.com/laptop", "key-1", "auth-1", "Firefox")
+	phone := entities.NewPushSubscription(first.ID, "https://push.example.com/phone", "key-2", "auth-2", "Chrome")
+	for _, subscription := range []*entities.PushSubscription{laptop, phone} {
+		if err := repo.Save(ctx, subscription); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	// The browser profile is signed in with another account on the laptop.
+	relinked := entities.NewPushSubscription(second.ID, laptop.Endpoint, "key-3", "auth-3", "Firefox")
+	if err := repo.Save(ctx, relinked); err != nil {
+		t.Fatalf("Save failed on an existing endpoint: %v", err)
+	}
+	if relinked.ID != laptop.ID {
+		t.Errorf("Expected the existing subscription to be updated, got a new ID %s", relinked.ID)
+	}
+
+	subscriptions, err := repo.FindByUserID(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("FindByUserID failed: %v", err)
+	}
+	if len(subscriptions) != 1 || subscriptions[0].Endpoint != phone.Endpoint {
+		t.Errorf("Expected only the phone subscription, got %+v", subscriptions)
+	}
+
+	found, err := repo.FindByEndpoint(ctx, laptop.Endpoint)
+	if err != nil {
+		t.Fatalf("FindByEndpoint failed: %v", err)
+	}
+	if found.UserID != second.ID || found.P256dh != "key-3" || found.Auth != "auth-3" {
+		t.Errorf("Unexpected subscription: %+v", found)
+	}
+
+	if err := repo.Delete(ctx, phone.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := repo.Delete(ctx, phone.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound on a second delete, got %v", err)
+	}
+}
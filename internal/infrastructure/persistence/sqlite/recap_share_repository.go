@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+type RecapShareRepository struct {
+	db *sql.DB
+}
+
+func NewRecapShareRepository(db *sql.DB) *RecapShareRepository {
+	return &RecapShareRepository{db: db}
+}
+
+func (r *RecapShareRepository) Create(ctx context.Context, share *entities.RecapShare) error {
+	query := `
+		INSERT INTO recap_shares (id, user_id, year, token, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		share.ID,
+		share.UserID,
+		share.Year,
+		share.Token,
+		share.CreatedAt,
+	)
+	if err != nil {
+		if isUniqueConstraintError(err) {
+			return errors.ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create recap share: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RecapShareRepository) FindByToken(ctx context.Context, token string) (*entities.RecapShare, error) {
+	query := `
+		SELECT id, user_id, year, token, created_at
+		FROM recap_shares
+		WHERE token = ?
+	`
+
+	return r.findOne(ctx, query, token)
+}
+
+func (r *RecapShareRepository) FindByUserIDAndYear(ctx context.Context, userID string, year int) (*entities.RecapShare, error) {
+	query := `
+		SELECT id, user_id, year, token, created_at
+		FROM recap_shares
+		WHERE user_id = ? AND year = ?
+	`
+
+	return r.findOne(ctx, query, userID, year)
+}
+
+func (r *RecapShareRepository) Delete(ctx context.Context, id string) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM recap_shares WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete recap share: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *RecapShareRepository) findOne(ctx context.Context, query string, args ...interface{}) (*entities.RecapShare, error) {
+	var share entities.RecapShare
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(
+		&share.ID,
+		&share.UserID,
+		&share.Year,
+		&share.Token,
+		&share.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find recap share: %w", err)
+	}
+
+	return &share, nil
+}
@@ -0,0 +1,54 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+func TestRecapShareRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewUserRepository(db)
+	repo := NewRecapShareRepository(db)
+	ctx := context.Background()
+
+	user := entities.NewUser("recap@example.com", "hash")
+	userRepo.Create(ctx, user)
+
+	share := entities.NewRecapShare(user.ID, 2024, "token-2024")
+	if err := repo.Create(ctx, share); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if err := repo.Create(ctx, entities.NewRecapShare(user.ID, 2024, "another-token")); err != errors.ErrAlreadyExists {
+		t.Errorf("Expected ErrAlreadyExists for a second share of the same year, got %v", err)
+	}
+
+	found, err := repo.FindByToken(ctx, "token-2024")
+	if err != nil {
+		t.Fatalf("FindByToken failed: %v", err)
+	}
+	if found.ID != share.ID || found.UserID != user.ID || found.Year != 2024 {
+		t.Errorf("Unexpected share: %+v", found)
+	}
+
+	found, err = repo.FindByUserIDAndYear(ctx, user.ID, 2024)
+	if err != nil || found.Token != "token-2024" {
+		t.Errorf("Expected to find the share by year, got %+v, %v", found, err)
+	}
+
+	if _, err := repo.FindByUserIDAndYear(ctx, user.ID, 2023); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	if err := repo.Delete(ctx, share.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := repo.FindByToken(ctx, "token-2024"); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type RewardRepository struct {
+	db *sql.DB
+}
+
+func NewRewardRepository(db *sql.DB) *RewardRepository {
+	return &RewardRepository{db: db}
+}
+
+func (r *RewardRepository) Create(ctx context.Context, reward *entities.Reward) error {
+	reward.ID = uuid.New().String()
+
+	query := `
+		INSERT INTO rewards (id, user_id, name, cost, created_at, archived_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		reward.ID,
+		reward.UserID,
+		reward.Name,
+		reward.Cost,
+		reward.CreatedAt,
+		reward.ArchivedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create reward: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RewardRepository) FindByID(ctx context.Context, id string) (*entities.Reward, error) {
+	query := `
+		SELECT id, user_id, name, cost, created_at, archived_at
+		FROM rewards
+		WHERE id = ?
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reward: %w", err)
+	}
+	defer rows.Close()
+
+	rewards, err := r.scanRewards(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rewards) == 0 {
+		return nil, errors.ErrNotFound
+	}
+
+	return rewards[0], nil
+}
+
+func (r *RewardRepository) FindActiveByUserID(ctx context.Context, userID string) ([]*entities.Reward, error) {
+	query := `
+		SELECT id, user_id, name, cost, created_at, archived_at
+		FROM rewards
+		WHERE user_id = ? AND archived_at IS NULL
+		ORDER BY cost ASC, created_at ASC
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find rewards: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanRewards(rows)
+}
+
+func (r *RewardRepository) Update(ctx context.Context, reward *entities.Reward) error {
+	query := `
+		UPDATE rewards
+		SET name = ?, cost = ?, archived_at = ?
+		WHERE id = ?
+	`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query,
+		reward.Name,
+		reward.Cost,
+		reward.ArchivedAt,
+		reward.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update reward: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *RewardRepository) scanRewards(rows *sql.Rows) ([]*entities.Reward, error) {
+	var rewards []*entities.Reward
+
+	for rows.Next() {
+		var (
+			reward     entities.Reward
+			archivedAt sql.NullTime
+		)
+
+		err := rows.Scan(
+			&reward.ID,
+			&reward.UserID,
+			&reward.Name,
+			&reward.Cost,
+			&reward.CreatedAt,
+			&archivedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reward: %w", err)
+		}
+
+		if archivedAt.Valid {
+			reward.ArchivedAt = &archivedAt.Time
+		}
+
+		rewards = append(rewards, &reward)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rewards: %w", err)
+	}
+
+	return rewards, nil
+}
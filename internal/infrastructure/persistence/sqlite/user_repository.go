@@ -24,8 +24,8 @@ func (r *UserRepository) Create(ctx context.Context, user *entities.User) error
 	user.ID = uuid.New().String()
 
 	query := `
-		INSERT INTO users (id, email, password_hash, email_verified, email_verification_token, email_verification_expiry, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO users (id, email, password_hash, email_verified, email_verification_token, email_verification_expiry, language, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
@@ -35,6 +35,7 @@ func (r *UserRepository) Create(ctx context.Context, user *entities.User) error
 		user.EmailVerified,
 		user.EmailVerificationToken,
 		user.EmailVerificationExpiry,
+		user.Language,
 		user.CreatedAt,
 		user.UpdatedAt,
 	)
@@ -51,7 +52,7 @@ func (r *UserRepository) Create(ctx context.Context, user *entities.User) error
 
 func (r *UserRepository) FindByID(ctx context.Context, id string) (*entities.User, error) {
 	query := `
-		SELECT id, email, password_hash, email_verified, email_verification_token, email_verification_expiry, created_at, updated_at
+		SELECT id, email, password_hash, email_verified, email_verification_token, email_verification_expiry, language, created_at, updated_at
 		FROM users
 		WHERE id = ?
 	`
@@ -64,6 +65,7 @@ func (r *UserRepository) FindByID(ctx context.Context, id string) (*entities.Use
 		&user.EmailVerified,
 		&user.EmailVerificationToken,
 		&user.EmailVerificationExpiry,
+		&user.Language,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -80,7 +82,7 @@ func (r *UserRepository) FindByID(ctx context.Context, id string) (*entities.Use
 
 func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*entities.User, error) {
 	query := `
-		SELECT id, email, password_hash, email_verified, email_verification_token, email_verification_expiry, created_at, updated_at
+		SELECT id, email, password_hash, email_verified, email_verification_token, email_verification_expiry, language, created_at, updated_at
 		FROM users
 		WHERE email = ?
 	`
@@ -93,6 +95,7 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*entiti
 		&user.EmailVerified,
 		&user.EmailVerificationToken,
 		&user.EmailVerificationExpiry,
+		&user.Language,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -109,7 +112,7 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*entiti
 
 func (r *UserRepository) FindByVerificationToken(ctx context.Context, token string) (*entities.User, error) {
 	query := `
-		SELECT id, email, password_hash, email_verified, email_verification_token, email_verification_expiry, created_at, updated_at
+		SELECT id, email, password_hash, email_verified, email_verification_token, email_verification_expiry, language, created_at, updated_at
 		FROM users
 		WHERE email_verification_token = ?
 	`
@@ -122,6 +125,7 @@ func (r *UserRepository) FindByVerificationToken(ctx context.Context, token stri
 		&user.EmailVerified,
 		&user.EmailVerificationToken,
 		&user.EmailVerificationExpiry,
+		&user.Language,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -139,7 +143,7 @@ func (r *UserRepository) FindByVerificationToken(ctx context.Context, token stri
 func (r *UserRepository) Update(ctx context.Context, user *entities.User) error {
 	query := `
 		UPDATE users
-		SET email = ?, password_hash = ?, email_verified = ?, email_verification_token = ?, email_verification_expiry = ?, updated_at = ?
+		SET email = ?, password_hash = ?, email_verified = ?, email_verification_token = ?, email_verification_expiry = ?, language = ?, updated_at = ?
 		WHERE id = ?
 	`
 
@@ -149,6 +153,7 @@ func (r *UserRepository) Update(ctx context.Context, user *entities.User) error
 		user.EmailVerified,
 		user.EmailVerificationToken,
 		user.EmailVerificationExpiry,
+		user.Language,
 		user.UpdatedAt,
 		user.ID,
 	)
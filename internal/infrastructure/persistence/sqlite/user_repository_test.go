@@ -191,6 +191,7 @@ func TestUserRepositoryUpdate(t *testing.T) {
 	}
 
 	user.Email = "updated@example.com"
+	user.Language = "es"
 	user.UpdatedAt = time.Now()
 
 	err = repo.Update(ctx, user)
@@ -206,6 +207,9 @@ func TestUserRepositoryUpdate(t *testing.T) {
 	if found.Email != "updated@example.com" {
 		t.Errorf("Expected email updated@example.com, got %s", found.Email)
 	}
+	if found.Language != "es" {
+		t.Errorf("Expected language es, got %s", found.Language)
+	}
 }
 
 func TestUserRepositoryUpdateNotFound(t *testing.T) {
@@ -0,0 +1,202 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, webhook *entities.Webhook) error {
+	webhook.ID = uuid.New().String()
+	events, _ := json.Marshal(webhook.Events)
+
+	query := `
+		INSERT INTO webhooks (
+			id, user_id, url, secret, events, enabled, consecutive_failures, disabled_at, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		webhook.ID,
+		webhook.UserID,
+		webhook.URL,
+		webhook.Secret,
+		string(events),
+		webhook.Enabled,
+		webhook.ConsecutiveFailures,
+		webhook.DisabledAt,
+		webhook.CreatedAt,
+		webhook.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) FindByID(ctx context.Context, id string) (*entities.Webhook, error) {
+	query := `
+		SELECT id, user_id, url, secret, events, enabled, consecutive_failures, disabled_at, created_at, updated_at
+		FROM webhooks
+		WHERE id = ?
+	`
+
+	webhook, err := scanWebhook(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (r *WebhookRepository) FindByUserID(ctx context.Context, userID string) ([]*entities.Webhook, error) {
+	query := `
+		SELECT id, user_id, url, secret, events, enabled, consecutive_failures, disabled_at, created_at, updated_at
+		FROM webhooks
+		WHERE user_id = ?
+		ORDER BY created_at ASC
+	`
+
+	return r.findMany(ctx, query, userID)
+}
+
+func (r *WebhookRepository) FindEnabled(ctx context.Context) ([]*entities.Webhook, error) {
+	query := `
+		SELECT id, user_id, url, secret, events, enabled, consecutive_failures, disabled_at, created_at, updated_at
+		FROM webhooks
+		WHERE enabled = 1
+	`
+
+	return r.findMany(ctx, query)
+}
+
+func (r *WebhookRepository) CountByUserID(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM webhooks WHERE user_id = ?`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count webhooks: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *WebhookRepository) Update(ctx context.Context, webhook *entities.Webhook) error {
+	events, _ := json.Marshal(webhook.Events)
+
+	query := `
+		UPDATE webhooks
+		SET url = ?, events = ?, enabled = ?, consecutive_failures = ?, disabled_at = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query,
+		webhook.URL,
+		string(events),
+		webhook.Enabled,
+		webhook.ConsecutiveFailures,
+		webhook.DisabledAt,
+		webhook.UpdatedAt,
+		webhook.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id string) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM webhooks WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) findMany(ctx context.Context, query string, args ...interface{}) ([]*entities.Webhook, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []*entities.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func scanWebhook(row scanner) (*entities.Webhook, error) {
+	var (
+		webhook    entities.Webhook
+		events     string
+		disabledAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.UserID,
+		&webhook.URL,
+		&webhook.Secret,
+		&events,
+		&webhook.Enabled,
+		&webhook.ConsecutiveFailures,
+		&disabledAt,
+		&webhook.CreatedAt,
+		&webhook.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan webhook: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(events), &webhook.Events); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook events: %w", err)
+	}
+	if disabledAt.Valid {
+		webhook.DisabledAt = &disabledAt.Time
+	}
+
+	return &webhook, nil
+}
@@ -0,0 +1,123 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/shared/errors"
+)
+
+func TestWebhookRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewUserRepository(db)
+	repo := NewWebhookRepository(db)
+	ctx := context.Background()
+
+	user := entities.NewUser("webhooks@example.com", "hash")
+	userRepo.Create(ctx, user)
+
+	webhook := entities.NewWebhook(user.ID, "https://example.com/hook", "secret", []entities.EventType{
+		entities.EventHabitMarked,
+		entities.EventStreakBroken,
+	})
+	if err := repo.Create(ctx, webhook); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	found, err := repo.FindByID(ctx, webhook.ID)
+	if err != nil {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+	if found.URL != webhook.URL || found.Secret != "secret" || len(found.Events) != 2 || !found.Enabled {
+		t.Errorf("Unexpected webhook: %+v", found)
+	}
+
+	for i := 0; i < entities.WebhookMaxConsecutiveFailures; i++ {
+		found.RecordFailure()
+	}
+	if err := repo.Update(ctx, found); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	enabled, err := repo.FindEnabled(ctx)
+	if err != nil {
+		t.Fatalf("FindEnabled failed: %v", err)
+	}
+	if len(enabled) != 0 {
+		t.Errorf("Expected the disabled webhook to be excluded, got %d", len(enabled))
+	}
+
+	stored, _ := repo.FindByID(ctx, webhook.ID)
+	if stored.Enabled || stored.DisabledAt == nil || stored.ConsecutiveFailures != entities.WebhookMaxConsecutiveFailures {
+		t.Errorf("Expected the webhook to be stored as disabled, got %+v", stored)
+	}
+
+	if count, _ := repo.CountByUserID(ctx, user.ID); count != 1 {
+		t.Errorf("Expected 1 webhook, got %d", count)
+	}
+
+	if err := repo.Delete(ctx, webhook.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := repo.FindByID(ctx, webhook.ID); err != errors.ErrNotFound {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestWebhookDeliveryRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewUserRepository(db)
+	webhookRepo := NewWebhookRepository(db)
+	repo := NewWebhookDeliveryRepository(db)
+	ctx := context.Background()
+
+	user := entities.NewUser("deliveries@example.com", "hash")
+	userRepo.Create(ctx, user)
+	webhook := entities.NewWebhook(user.ID, "https://example.com/hook", "secret", []entities.EventType{entities.EventHabitMarked})
+	webhookRepo.Create(ctx, webhook)
+
+	event := entities.NewEvent(entities.EventHabitMarked, user.ID, nil)
+	delivery := entities.NewWebhookDelivery(webhook.ID, event, `{"type":"habit.marked"}`)
+	if err := repo.Create(ctx, delivery); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := repo.Create(ctx, entities.NewWebhookDelivery(webhook.ID, event, "{}")); err != errors.ErrAlreadyExists {
+		t.Errorf("Expected ErrAlreadyExists for a duplicate event, got %v", err)
+	}
+
+	now := time.Now()
+	due, err := repo.FindDue(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("FindDue failed: %v", err)
+	}
+	if len(due) != 1 || due[0].ID != delivery.ID {
+		t.Fatalf("Expected the new delivery to be due, got %+v", due)
+	}
+
+	status := 503
+	delivery.RecordFailure(&status, "unavailable", now)
+	if err := repo.Update(ctx, delivery); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	if due, _ := repo.FindDue(ctx, now, 10); len(due) != 0 {
+		t.Errorf("Expected no due deliveries during the backoff, got %d", len(due))
+	}
+	if due, _ := repo.FindDue(ctx, now.Add(time.Minute), 10); len(due) != 1 {
+		t.Errorf("Expected the delivery to be due after the backoff, got %d", len(due))
+	}
+
+	log, err := repo.FindByWebhookID(ctx, webhook.ID, 10)
+	if err != nil {
+		t.Fatalf("FindByWebhookID failed: %v", err)
+	}
+	if len(log) != 1 || log[0].Attempts != 1 || log[0].LastStatusCode == nil || *log[0].LastStatusCode != 503 {
+		t.Errorf("Unexpected delivery log: %+v", log)
+	}
+}
@@ -0,0 +1,173 @@
+package reminder
+
+import (
+	"context"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/domain/services"
+	"apocapoc-api/internal/infrastructure/logger"
+)
+
+type Config struct {
+	Enabled  bool
+	Interval time.Duration
+}
+
+// Dispatcher periodically delivers the habit reminders that are due through
+// every configured channel.
+type Dispatcher struct {
+	reminderRepo repositories.HabitReminderRepository
+	habitRepo    repositories.HabitRepository
+	entryRepo    repositories.HabitEntryRepository
+	userRepo     repositories.UserRepository
+	channels     []services.ReminderChannel
+	config       Config
+	stopCh       chan struct{}
+}
+
+func NewDispatcher(
+	reminderRepo repositories.HabitReminderRepository,
+	habitRepo repositories.HabitRepository,
+	entryRepo repositories.HabitEntryRepository,
+	userRepo repositories.UserRepository,
+	channels []services.ReminderChannel,
+	config Config,
+) *Dispatcher {
+	return &Dispatcher{
+		reminderRepo: reminderRepo,
+		habitRepo:    habitRepo,
+		entryRepo:    entryRepo,
+		userRepo:     userRepo,
+		channels:     channels,
+		config:       config,
+		stopCh:       make(chan struct{}),
+	}
+}
+
+func (d *Dispatcher) Start() {
+	if !d.config.Enabled {
+		logger.Info().Msg("Reminder dispatcher is disabled")
+		return
+	}
+
+	channels := make([]string, len(d.channels))
+	for i, channel := range d.channels {
+		channels[i] = channel.Name()
+	}
+
+	logger.Info().
+		Dur("interval", d.config.Interval).
+		Strs("channels", channels).
+		Msg("Starting reminder dispatcher")
+
+	go d.run()
+}
+
+func (d *Dispatcher) run() {
+	d.DispatchDue(context.Background(), time.Now())
+
+	ticker := time.NewTicker(d.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			logger.Debug().Msg("Checking for due reminders")
+			d.DispatchDue(context.Background(), time.Now())
+
+		case <-d.stopCh:
+			logger.Info().Msg("Reminder dispatcher stopped")
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) Stop() {
+	if d.config.Enabled {
+		close(d.stopCh)
+	}
+}
+
+// DispatchDue delivers every reminder due at now and returns how many were
+// sent. A reminder is recorded as sent for the day once at least one channel
+// delivered it, so restarts never repeat it; if every channel fails it is
+// retried on the next run within the grace period.
+func (d *Dispatcher) DispatchDue(ctx context.Context, now time.Time) int {
+	reminders, err := d.reminderRepo.FindEnabled(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to load reminders")
+		return 0
+	}
+
+	sent := 0
+	for _, reminder := range reminders {
+		date, due := reminder.DueDate(now)
+		if !due {
+			continue
+		}
+
+		delivered, err := d.dispatch(ctx, reminder, date)
+		if err != nil {
+			logger.Error().Err(err).
+				Str("reminder_id", reminder.ID).
+				Str("habit_id", reminder.HabitID).
+				Msg("Failed to send reminder")
+			continue
+		}
+
+		if delivered {
+			sent++
+		}
+	}
+
+	return sent
+}
+
+// dispatch reports whether the reminder was delivered. Reminders for habits
+// that are not scheduled on date or already completed are skipped.
+func (d *Dispatcher) dispatch(ctx context.Context, reminder *entities.HabitReminder, date time.Time) (bool, error) {
+	habit, err := d.habitRepo.FindByID(ctx, reminder.HabitID)
+	if err != nil {
+		return false, err
+	}
+
+	if !habit.IsActive() || !habit.IsScheduledOn(date) {
+		return false, nil
+	}
+
+	entries, err := d.entryRepo.FindByHabitIDAndDateRange(ctx, habit.ID, date, date)
+	if err != nil {
+		return false, err
+	}
+	if len(entries) > 0 {
+		return false, nil
+	}
+
+	user, err := d.userRepo.FindByID(ctx, reminder.UserID)
+	if err != nil {
+		return false, err
+	}
+
+	var lastErr error
+	delivered := false
+	for _, channel := range d.channels {
+		if err := channel.SendReminder(ctx, user, habit); err != nil {
+			logger.Warn().Err(err).
+				Str("channel", channel.Name()).
+				Str("reminder_id", reminder.ID).
+				Msg("Reminder channel failed")
+			lastErr = err
+			continue
+		}
+		delivered = true
+	}
+
+	if !delivered {
+		return false, lastErr
+	}
+
+	reminder.MarkSent(date)
+	return true, d.reminderRepo.Update(ctx, reminder)
+}
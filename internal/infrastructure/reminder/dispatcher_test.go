@@ -0,0 +1,103 @@
+package reminder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/services"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/infrastructure/persistence/sqlite"
+)
+
+type recordingChannel struct {
+	sent []string
+	fail bool
+}
+
+func (c *recordingChannel) Name() string {
+	return "recording"
+}
+
+func (c *recordingChannel) SendReminder(ctx context.Context, user *entities.User, habit *entities.Habit) error {
+	if c.fail {
+		return fmt.Errorf("channel unavailable")
+	}
+	c.sent = append(c.sent, habit.Name)
+	return nil
+}
+
+func TestDispatcher_DispatchDue(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := sqlite.RunMigrations(db); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	userRepo := sqlite.NewUserRepository(db)
+	habitRepo := sqlite.NewHabitRepository(db)
+	entryRepo := sqlite.NewHabitEntryRepository(db)
+	reminderRepo := sqlite.NewHabitReminderRepository(db)
+
+	user := entities.NewUser("reminded@example.com", "hash")
+	userRepo.Create(ctx, user)
+
+	createdAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	newHabit := func(name string, frequency value_objects.Frequency, specificDays []int) *entities.Habit {
+		habit := entities.NewHabit(user.ID, name, value_objects.HabitTypeBoolean, frequency, false, false)
+		habit.SpecificDays = specificDays
+		habit.CreatedAt = createdAt
+		habitRepo.Create(ctx, habit)
+		return habit
+	}
+
+	pending := newHabit("Stretch", value_objects.FrequencyDaily, nil)
+	completed := newHabit("Read", value_objects.FrequencyDaily, nil)
+	notToday := newHabit("Long run", value_objects.FrequencyWeekly, []int{int(time.Saturday)})
+
+	// Monday March 10th, 2025 in Madrid.
+	madridDay := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	entryRepo.Create(ctx, entities.NewHabitEntry(completed.ID, madridDay, nil))
+
+	for _, habit := range []*entities.Habit{pending, completed, notToday} {
+		reminderRepo.Create(ctx, entities.NewHabitReminder(habit.ID, user.ID, "08:00", "Europe/Madrid"))
+	}
+
+	channel := &recordingChannel{fail: true}
+	dispatcher := NewDispatcher(reminderRepo, habitRepo, entryRepo, userRepo, []services.ReminderChannel{channel}, Config{Enabled: true, Interval: time.Minute})
+
+	beforeTime := time.Date(2025, 3, 10, 6, 59, 0, 0, time.UTC)
+	if sent := dispatcher.DispatchDue(ctx, beforeTime); sent != 0 {
+		t.Fatalf("Expected no reminders before 08:00 Madrid time, got %d", sent)
+	}
+
+	atTime := time.Date(2025, 3, 10, 7, 0, 0, 0, time.UTC)
+	if sent := dispatcher.DispatchDue(ctx, atTime); sent != 0 {
+		t.Fatalf("Expected no reminders while the channel fails, got %d", sent)
+	}
+
+	channel.fail = false
+	if sent := dispatcher.DispatchDue(ctx, atTime.Add(time.Minute)); sent != 1 {
+		t.Fatalf("Expected 1 reminder after the channel recovered, got %d", sent)
+	}
+	if len(channel.sent) != 1 || channel.sent[0] != "Stretch" {
+		t.Errorf("Expected only the pending habit to be reminded, got %v", channel.sent)
+	}
+
+	restarted := NewDispatcher(reminderRepo, habitRepo, entryRepo, userRepo, []services.ReminderChannel{channel}, Config{Enabled: true, Interval: time.Minute})
+	if sent := restarted.DispatchDue(ctx, atTime.Add(2*time.Minute)); sent != 0 {
+		t.Errorf("Expected the reminder not to be repeated after a restart, got %d", sent)
+	}
+
+	if sent := restarted.DispatchDue(ctx, atTime.AddDate(0, 0, 1)); sent != 2 {
+		t.Errorf("Expected both daily habits to be reminded the next day, got %d", sent)
+	}
+}
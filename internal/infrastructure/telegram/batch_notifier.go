@@ -0,0 +1,52 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/i18n"
+	"apocapoc-api/internal/shared/errors"
+)
+
+// BatchNotifier sends the notifications held back by quiet hours or the
+// hourly limit to the user's linked chat as a single message. It implements
+// services.NotificationBatchSender.
+type BatchNotifier struct {
+	client     *Client
+	linkRepo   repositories.ChatLinkRepository
+	translator *i18n.Translator
+}
+
+func NewBatchNotifier(client *Client, linkRepo repositories.ChatLinkRepository, translator *i18n.Translator) *BatchNotifier {
+	return &BatchNotifier{
+		client:     client,
+		linkRepo:   linkRepo,
+		translator: translator,
+	}
+}
+
+func (n *BatchNotifier) Channel() entities.NotificationChannel {
+	return entities.NotificationChat
+}
+
+// SendBatch drops the notifications when the chat was unlinked since they
+// were held back.
+func (n *BatchNotifier) SendBatch(ctx context.Context, user *entities.User, notifications []*entities.HeldNotification) error {
+	link, err := n.linkRepo.FindByUserID(ctx, user.ID)
+	if err == errors.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	lang := n.translator.GetLanguage(user.Language)
+	lines := []string{n.translator.Chat(lang, "batch_title")}
+	for _, notification := range notifications {
+		lines = append(lines, "• "+notification.Title)
+	}
+
+	return n.client.SendMessage(ctx, link.ChatID, strings.Join(lines, "\n"))
+}
@@ -0,0 +1,386 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"apocapoc-api/internal/application/commands"
+	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/i18n"
+	"apocapoc-api/internal/infrastructure/logger"
+	"apocapoc-api/internal/shared/errors"
+	"apocapoc-api/internal/shared/utils"
+
+	"golang.org/x/text/language"
+)
+
+// retryDelay is how long the bot waits after a failed poll.
+const retryDelay = 5 * time.Second
+
+type Config struct {
+	Enabled     bool
+	PollTimeout time.Duration
+}
+
+// Bot lets users check in from a chat. It long-polls the Bot API for
+// messages, links chats to accounts with the one-time codes created in the
+// app, and answers commands to list today's habits and mark or unmark them.
+type Bot struct {
+	client        *Client
+	linkRepo      repositories.ChatLinkRepository
+	userRepo      repositories.UserRepository
+	linkHandler   *commands.LinkChatHandler
+	unlinkHandler *commands.UnlinkChatHandler
+	todaysHandler *queries.GetTodaysHabitsHandler
+	markHandler   *commands.MarkHabitHandler
+	unmarkHandler *commands.UnmarkHabitHandler
+	translator    *i18n.Translator
+	config        Config
+	offset        int64
+	now           func() time.Time
+	cancel        context.CancelFunc
+}
+
+func NewBot(
+	client *Client,
+	linkRepo repositories.ChatLinkRepository,
+	userRepo repositories.UserRepository,
+	linkHandler *commands.LinkChatHandler,
+	unlinkHandler *commands.UnlinkChatHandler,
+	todaysHandler *queries.GetTodaysHabitsHandler,
+	markHandler *commands.MarkHabitHandler,
+	unmarkHandler *commands.UnmarkHabitHandler,
+	translator *i18n.Translator,
+	config Config,
+) *Bot {
+	return &Bot{
+		client:        client,
+		linkRepo:      linkRepo,
+		userRepo:      userRepo,
+		linkHandler:   linkHandler,
+		unlinkHandler: unlinkHandler,
+		todaysHandler: todaysHandler,
+		markHandler:   markHandler,
+		unmarkHandler: unmarkHandler,
+		translator:    translator,
+		config:        config,
+		now:           time.Now,
+	}
+}
+
+func (b *Bot) Start() {
+	if !b.config.Enabled {
+		logger.Info().Msg("Chat bot is disabled")
+		return
+	}
+
+	logger.Info().
+		Dur("poll_timeout", b.config.PollTimeout).
+		Msg("Starting chat bot")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b.cancel = cancel
+	go b.run(ctx)
+}
+
+func (b *Bot) run(ctx context.Context) {
+	for {
+		_, err := b.Poll(ctx)
+		if ctx.Err() != nil {
+			logger.Info().Msg("Chat bot stopped")
+			return
+		}
+		if err == nil {
+			continue
+		}
+
+		logger.Error().Err(err).Msg("Failed to poll for chat messages")
+		select {
+		case <-time.After(retryDelay):
+		case <-ctx.Done():
+			logger.Info().Msg("Chat bot stopped")
+			return
+		}
+	}
+}
+
+func (b *Bot) Stop() {
+	if b.cancel != nil {
+		b.cancel()
+	}
+}
+
+// Poll waits for new messages, answers them and returns how many were
+// answered. Messages are acknowledged even when answering them fails, so a
+// message that cannot be answered is not retried forever.
+func (b *Bot) Poll(ctx context.Context) (int, error) {
+	updates, err := b.client.GetUpdates(ctx, b.offset, b.config.PollTimeout)
+	if err != nil {
+		return 0, err
+	}
+
+	answered := 0
+	for _, update := range updates {
+		if update.UpdateID >= b.offset {
+			b.offset = update.UpdateID + 1
+		}
+		if update.Message == nil || update.Message.Text == "" {
+			continue
+		}
+
+		reply := b.HandleMessage(ctx, update.Message)
+		if err := b.client.SendMessage(ctx, update.Message.Chat.ID, reply); err != nil {
+			logger.Error().Err(err).Int64("chat_id", update.Message.Chat.ID).Msg("Failed to answer chat message")
+			continue
+		}
+		answered++
+	}
+
+	return answered, nil
+}
+
+// HandleMessage runs the command in a message and returns the reply.
+// Commands may be written with or without a leading slash, so "/done gym"
+// and "done gym" are the same.
+func (b *Bot) HandleMessage(ctx context.Context, message *Message) string {
+	command, args := parseCommand(message.Text)
+	chatID := message.Chat.ID
+
+	lang := language.English
+	if message.From != nil {
+		lang = b.translator.GetLanguage(message.From.LanguageCode)
+	}
+
+	if command == "start" || command == "link" {
+		if args == "" {
+			return b.translator.Chat(lang, "link_required")
+		}
+		return b.link(ctx, lang, chatID, args)
+	}
+
+	link, user, err := b.linkedUser(ctx, chatID)
+	if err == errors.ErrNotFound {
+		if command == "help" {
+			return b.translator.Chat(lang, "help")
+		}
+		return b.translator.Chat(lang, "link_required")
+	}
+	if err != nil {
+		logger.Error().Err(err).Int64("chat_id", chatID).Msg("Failed to find chat link")
+		return b.translator.Chat(lang, "failed")
+	}
+	lang = b.translator.GetLanguage(user.Language)
+
+	switch command {
+	case "help":
+		return b.translator.Chat(lang, "help")
+	case "today", "list":
+		return b.today(ctx, lang, link)
+	case "done":
+		return b.mark(ctx, lang, link, args)
+	case "undo":
+		return b.unmark(ctx, lang, link, args)
+	case "unlink":
+		if err := b.unlinkHandler.Handle(ctx, commands.UnlinkChatCommand{UserID: user.ID}); err != nil && err != errors.ErrNotFound {
+			logger.Error().Err(err).Str("user_id", user.ID).Msg("Failed to unlink chat")
+			return b.translator.Chat(lang, "failed")
+		}
+		return b.translator.Chat(lang, "unlinked")
+	default:
+		return b.translator.Chat(lang, "unknown_command")
+	}
+}
+
+// linkedUser returns ErrNotFound when the chat is not linked, or is linked
+// to an account that no longer exists.
+func (b *Bot) linkedUser(ctx context.Context, chatID int64) (*entities.ChatLink, *entities.User, error) {
+	link, err := b.linkRepo.FindByChatID(ctx, chatID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := b.userRepo.FindByID(ctx, link.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return link, user, nil
+}
+
+func (b *Bot) link(ctx context.Context, lang language.Tag, chatID int64, code string) string {
+	user, err := b.linkHandler.Handle(ctx, commands.LinkChatCommand{Code: code, ChatID: chatID})
+	if err == errors.ErrInvalidInput || err == errors.ErrNotFound {
+		return b.translator.Chat(lang, "invalid_code")
+	}
+	if err != nil {
+		logger.Error().Err(err).Int64("chat_id", chatID).Msg("Failed to link chat")
+		return b.translator.Chat(lang, "failed")
+	}
+
+	lang = b.translator.GetLanguage(user.Language)
+	return fmt.Sprintf(b.translator.Chat(lang, "linked"), user.Email)
+}
+
+func (b *Bot) todaysHabits(ctx context.Context, link *entities.ChatLink) ([]queries.TodaysHabitDTO, error) {
+	return b.todaysHandler.Handle(ctx, queries.GetTodaysHabitsQuery{
+		UserID:   link.UserID,
+		Timezone: link.Timezone,
+		Date:     link.Today(b.now()),
+	})
+}
+
+func (b *Bot) today(ctx context.Context, lang language.Tag, link *entities.ChatLink) string {
+	habits, err := b.todaysHabits(ctx, link)
+	if err != nil {
+		logger.Error().Err(err).Str("user_id", link.UserID).Msg("Failed to get today's habits")
+		return b.translator.Chat(lang, "failed")
+	}
+	if len(habits) == 0 {
+		return b.translator.Chat(lang, "no_habits")
+	}
+
+	lines := []string{b.translator.Chat(lang, "today_title")}
+	for i, habit := range habits {
+		mark := "⬜"
+		if habit.Entry != nil {
+			mark = "✅"
+		}
+		line := fmt.Sprintf("%d. %s %s", i+1, mark, habit.Name)
+		if habit.Entry != nil && habit.Entry.Value != nil && habit.TargetValue != nil {
+			line += fmt.Sprintf(" (%s/%s)", formatValue(*habit.Entry.Value), formatValue(*habit.TargetValue))
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (b *Bot) mark(ctx context.Context, lang language.Tag, link *entities.ChatLink, args string) string {
+	selector, value := splitValue(args)
+	habit, reply := b.findHabit(ctx, lang, link, selector)
+	if habit == nil {
+		return reply
+	}
+
+	err := b.markHandler.Handle(ctx, commands.MarkHabitCommand{
+		HabitID:       habit.ID,
+		ScheduledDate: habit.ScheduledDate,
+		Value:         value,
+	})
+	if err == errors.ErrAlreadyExists {
+		return fmt.Sprintf(b.translator.Chat(lang, "already_marked"), habit.Name)
+	}
+	if err != nil {
+		logger.Error().Err(err).Str("habit_id", habit.ID).Msg("Failed to mark habit from chat")
+		return b.translator.Chat(lang, "failed")
+	}
+
+	return fmt.Sprintf(b.translator.Chat(lang, "marked"), habit.Name)
+}
+
+func (b *Bot) unmark(ctx context.Context, lang language.Tag, link *entities.ChatLink, args string) string {
+	habit, reply := b.findHabit(ctx, lang, link, args)
+	if habit == nil {
+		return reply
+	}
+
+	err := b.unmarkHandler.Handle(ctx, commands.UnmarkHabitCommand{
+		HabitID:       habit.ID,
+		UserID:        link.UserID,
+		ScheduledDate: habit.ScheduledDate,
+	})
+	if err == errors.ErrNotFound {
+		return fmt.Sprintf(b.translator.Chat(lang, "not_marked"), habit.Name)
+	}
+	if err != nil {
+		logger.Error().Err(err).Str("habit_id", habit.ID).Msg("Failed to unmark habit from chat")
+		return b.translator.Chat(lang, "failed")
+	}
+
+	return fmt.Sprintf(b.translator.Chat(lang, "unmarked"), habit.Name)
+}
+
+// findHabit picks one of today's habits by its number in the /today list or
+// by name, and otherwise returns the reply explaining why none was picked.
+func (b *Bot) findHabit(ctx context.Context, lang language.Tag, link *entities.ChatLink, selector string) (*queries.TodaysHabitDTO, string) {
+	if selector == "" {
+		return nil, b.translator.Chat(lang, "habit_required")
+	}
+
+	habits, err := b.todaysHabits(ctx, link)
+	if err != nil {
+		logger.Error().Err(err).Str("user_id", link.UserID).Msg("Failed to get today's habits")
+		return nil, b.translator.Chat(lang, "failed")
+	}
+
+	matches := matchHabits(habits, selector)
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Sprintf(b.translator.Chat(lang, "habit_not_found"), selector)
+	case 1:
+		return matches[0], ""
+	default:
+		names := make([]string, len(matches))
+		for i, habit := range matches {
+			names[i] = habit.Name
+		}
+		return nil, fmt.Sprintf(b.translator.Chat(lang, "habit_ambiguous"), selector, strings.Join(names, ", "))
+	}
+}
+
+// matchHabits returns the habit with the given number, or else the habits
+// whose name matches selector.
+func matchHabits(habits []queries.TodaysHabitDTO, selector string) []*queries.TodaysHabitDTO {
+	if n, err := strconv.Atoi(selector); err == nil {
+		if n >= 1 && n <= len(habits) {
+			return []*queries.TodaysHabitDTO{&habits[n-1]}
+		}
+		return nil
+	}
+
+	names := make([]string, len(habits))
+	for i, habit := range habits {
+		names[i] = habit.Name
+	}
+
+	var found []*queries.TodaysHabitDTO
+	for _, i := range utils.MatchHabitNames(names, selector) {
+		found = append(found, &habits[i])
+	}
+	return found
+}
+
+// parseCommand splits a message into its lowercased command, without the
+// leading slash or the "@botname" suffix, and the rest of the text.
+func parseCommand(text string) (string, string) {
+	text = strings.TrimSpace(text)
+	command, args, _ := strings.Cut(text, " ")
+	command = strings.TrimPrefix(command, "/")
+	command, _, _ = strings.Cut(command, "@")
+	return strings.ToLower(command), strings.TrimSpace(args)
+}
+
+// splitValue takes a trailing number off "water 3" as the value to record,
+// but leaves "2" alone, which is a habit number.
+func splitValue(args string) (string, *float64) {
+	fields := strings.Fields(args)
+	if len(fields) < 2 {
+		return args, nil
+	}
+
+	value, err := strconv.ParseFloat(fields[len(fields)-1], 64)
+	if err != nil {
+		return args, nil
+	}
+
+	return strings.Join(fields[:len(fields)-1], " "), &value
+}
+
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
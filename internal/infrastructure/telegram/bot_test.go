@@ -0,0 +1,307 @@
+package telegram
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/application/commands"
+	"apocapoc-api/internal/application/queries"
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/i18n"
+	"apocapoc-api/internal/infrastructure/notifications"
+	"apocapoc-api/internal/infrastructure/persistence/sqlite"
+)
+
+type sentMessage struct {
+	ChatID int64  `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+// fakeBotAPI serves getUpdates from a queue of messages and records what
+// the bot sends.
+type fakeBotAPI struct {
+	mu      sync.Mutex
+	nextID  int64
+	pending []Update
+	offsets []int64
+	sent    []sentMessage
+}
+
+func newFakeBotAPI(t *testing.T) (*fakeBotAPI, *httptest.Server) {
+	api := &fakeBotAPI{nextID: 1}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+
+		switch r.URL.Path {
+		case "/bottest-token/getUpdates":
+			var params struct {
+				Offset int64 `json:"offset"`
+			}
+			json.NewDecoder(r.Body).Decode(&params)
+			api.offsets = append(api.offsets, params.Offset)
+
+			var updates []Update
+			for _, update := range api.pending {
+				if update.UpdateID >= params.Offset {
+					updates = append(updates, update)
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "result": updates})
+		case "/bottest-token/sendMessage":
+			var message sentMessage
+			json.NewDecoder(r.Body).Decode(&message)
+			api.sent = append(api.sent, message)
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "result": map[string]interface{}{}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "description": "Not Found"})
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return api, server
+}
+
+func (a *fakeBotAPI) say(chatID int64, text string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pending = append(a.pending, Update{
+		UpdateID: a.nextID,
+		Message:  &Message{MessageID: a.nextID, Chat: Chat{ID: chatID}, Text: text},
+	})
+	a.nextID++
+}
+
+func (a *fakeBotAPI) lastReply(t *testing.T) string {
+	t.Helper()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.sent) == 0 {
+		t.Fatal("Expected a reply")
+	}
+	return a.sent[len(a.sent)-1].Text
+}
+
+type testEnv struct {
+	db         *sql.DB
+	api        *fakeBotAPI
+	client     *Client
+	bot        *Bot
+	user       *entities.User
+	linkRepo   *sqlite.ChatLinkRepository
+	codeRepo   *sqlite.ChatLinkCodeRepository
+	translator *i18n.Translator
+}
+
+func setupBot(t *testing.T) *testEnv {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := sqlite.RunMigrations(db); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	translator, err := i18n.NewTranslator()
+	if err != nil {
+		t.Fatalf("Failed to create translator: %v", err)
+	}
+
+	userRepo := sqlite.NewUserRepository(db)
+	habitRepo := sqlite.NewHabitRepository(db)
+	entryRepo := sqlite.NewHabitEntryRepository(db)
+	statsRepo := sqlite.NewHabitStatsRepository(db)
+	pointsRepo := sqlite.NewPointsRepository(db)
+	linkRepo := sqlite.NewChatLinkRepository(db)
+	codeRepo := sqlite.NewChatLinkCodeRepository(db)
+	transactor := sqlite.NewTransactor(db)
+
+	user := entities.NewUser("chat@example.com", "hash")
+	userRepo.Create(context.Background(), user)
+
+	api, server := newFakeBotAPI(t)
+	client := NewClient(server.URL, "test-token", server.Client())
+	bot := NewBot(
+		client,
+		linkRepo,
+		userRepo,
+		commands.NewLinkChatHandler(codeRepo, linkRepo, userRepo, transactor),
+		commands.NewUnlinkChatHandler(linkRepo),
+		queries.NewGetTodaysHabitsHandler(habitRepo, entryRepo),
+		commands.NewMarkHabitHandler(entryRepo, habitRepo, statsRepo, pointsRepo, transactor, nil, nil),
+		commands.NewUnmarkHabitHandler(habitRepo, entryRepo, statsRepo, pointsRepo, transactor, nil),
+		translator,
+		Config{Enabled: true, PollTimeout: time.Second},
+	)
+
+	return &testEnv{db, api, client, bot, user, linkRepo, codeRepo, translator}
+}
+
+func (e *testEnv) createHabit(t *testing.T, name string, habitType value_objects.HabitType) {
+	habit := entities.NewHabit(e.user.ID, name, habitType, value_objects.FrequencyDaily, false, false)
+	if err := sqlite.NewHabitRepository(e.db).Create(context.Background(), habit); err != nil {
+		t.Fatalf("Failed to create habit: %v", err)
+	}
+}
+
+// converse sends a message to the bot and returns its reply.
+func (e *testEnv) converse(t *testing.T, chatID int64, text string) string {
+	t.Helper()
+	e.api.say(chatID, text)
+	if answered, err := e.bot.Poll(context.Background()); err != nil || answered != 1 {
+		t.Fatalf("Expected one answered message, got %d (%v)", answered, err)
+	}
+	return e.api.lastReply(t)
+}
+
+func TestBot_LinksChatAndChecksIn(t *testing.T) {
+	env := setupBot(t)
+	ctx := context.Background()
+	// Listed newest first.
+	env.createHabit(t, "Gym", value_objects.HabitTypeBoolean)
+	env.createHabit(t, "Read", value_objects.HabitTypeBoolean)
+	env.createHabit(t, "Reading list", value_objects.HabitTypeBoolean)
+
+	if reply := env.converse(t, 42, "/today"); !strings.Contains(reply, "not linked") {
+		t.Errorf("Expected an unlinked chat to be asked to link, got %q", reply)
+	}
+	if reply := env.converse(t, 42, "/start WRONG234"); !strings.Contains(reply, "invalid or has expired") {
+		t.Errorf("Expected an unknown code to be rejected, got %q", reply)
+	}
+
+	code, err := commands.NewCreateChatLinkCodeHandler(env.codeRepo).Handle(ctx, commands.CreateChatLinkCodeCommand{UserID: env.user.ID})
+	if err != nil {
+		t.Fatalf("Failed to create link code: %v", err)
+	}
+	if reply := env.converse(t, 42, "/start "+strings.ToLower(code.Code)); !strings.Contains(reply, "linked to chat@example.com") {
+		t.Fatalf("Expected the chat to be linked, got %q", reply)
+	}
+
+	reply := env.converse(t, 42, "/today@ApocapocBot")
+	if !strings.Contains(reply, "1. ⬜ Reading list") || !strings.Contains(reply, "3. ⬜ Gym") {
+		t.Errorf("Unexpected list: %q", reply)
+	}
+
+	if reply := env.converse(t, 42, "done gym"); reply != "Done: Gym ✅" {
+		t.Errorf("Unexpected reply: %q", reply)
+	}
+	if reply := env.converse(t, 42, "/done Gym"); reply != "Gym is already done today." {
+		t.Errorf("Unexpected reply: %q", reply)
+	}
+	if reply := env.converse(t, 42, "done rea"); !strings.Contains(reply, "more than one habit: Reading list, Read") {
+		t.Errorf("Expected an ambiguous name to be rejected, got %q", reply)
+	}
+	if reply := env.converse(t, 42, "done read"); reply != "Done: Read ✅" {
+		t.Errorf("Expected an exact name to win, got %q", reply)
+	}
+	if reply := env.converse(t, 42, "done 1"); reply != "Done: Reading list ✅" {
+		t.Errorf("Expected a habit number to be accepted, got %q", reply)
+	}
+	if reply := env.converse(t, 42, "done swim"); !strings.Contains(reply, `No habit for today matches "swim"`) {
+		t.Errorf("Unexpected reply: %q", reply)
+	}
+
+	if reply := env.converse(t, 42, "undo 3"); reply != "Undone: Gym" {
+		t.Errorf("Unexpected reply: %q", reply)
+	}
+	if reply := env.converse(t, 42, "undo gym"); reply != "Gym is not done today." {
+		t.Errorf("Unexpected reply: %q", reply)
+	}
+	if reply := env.converse(t, 42, "/today"); !strings.Contains(reply, "2. ✅ Read") || !strings.Contains(reply, "3. ⬜ Gym") {
+		t.Errorf("Unexpected list: %q", reply)
+	}
+
+	if reply := env.converse(t, 42, "dance"); !strings.Contains(reply, "/help") {
+		t.Errorf("Expected unknown commands to point at /help, got %q", reply)
+	}
+
+	if reply := env.converse(t, 42, "/unlink"); reply != "This chat is no longer linked to your account." {
+		t.Errorf("Unexpected reply: %q", reply)
+	}
+	if _, err := env.linkRepo.FindByUserID(ctx, env.user.ID); err == nil {
+		t.Error("Expected the link to be deleted")
+	}
+}
+
+func TestBot_RecordsCounterValues(t *testing.T) {
+	env := setupBot(t)
+	ctx := context.Background()
+	env.createHabit(t, "Glasses of water", value_objects.HabitTypeCounter)
+	env.linkRepo.Save(ctx, entities.NewChatLink(env.user.ID, 7, "UTC"))
+
+	env.converse(t, 7, "done water 3")
+	env.converse(t, 7, "done water")
+
+	if reply := env.converse(t, 7, "today"); !strings.Contains(reply, "✅ Glasses of water") {
+		t.Fatalf("Expected the counter to be marked, got %q", reply)
+	}
+
+	var value float64
+	env.db.QueryRow("SELECT value FROM habit_entries").Scan(&value)
+	if value != 4 {
+		t.Errorf("Expected the counter to reach 4, got %v", value)
+	}
+}
+
+func TestBot_PollAcknowledgesUpdates(t *testing.T) {
+	env := setupBot(t)
+
+	env.api.say(1, "/help")
+	env.api.say(2, "/help")
+	if answered, err := env.bot.Poll(context.Background()); err != nil || answered != 2 {
+		t.Fatalf("Expected two answered messages, got %d (%v)", answered, err)
+	}
+	if answered, _ := env.bot.Poll(context.Background()); answered != 0 {
+		t.Errorf("Expected answered messages not to be seen again, got %d", answered)
+	}
+	if offsets := env.api.offsets; offsets[len(offsets)-1] != 3 {
+		t.Errorf("Expected the next poll to start after the last update, got offsets %v", offsets)
+	}
+}
+
+func TestReminderChannel_SendsToLinkedChat(t *testing.T) {
+	env := setupBot(t)
+	ctx := context.Background()
+
+	gate := notifications.NewGate(
+		sqlite.NewNotificationPreferenceRepository(env.db),
+		sqlite.NewNotificationScheduleRepository(env.db),
+		sqlite.NewHeldNotificationRepository(env.db),
+		sqlite.NewNotificationLogRepository(env.db),
+	)
+	channel := NewReminderChannel(env.client, env.linkRepo, gate, env.translator)
+	habit := entities.NewHabit(env.user.ID, "Stretch", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+
+	if err := channel.SendReminder(ctx, env.user, habit); err != nil {
+		t.Fatalf("SendReminder failed: %v", err)
+	}
+	if len(env.api.sent) != 0 {
+		t.Fatalf("Expected no message without a linked chat, got %d", len(env.api.sent))
+	}
+
+	env.linkRepo.Save(ctx, entities.NewChatLink(env.user.ID, 99, "UTC"))
+	if err := channel.SendReminder(ctx, env.user, habit); err != nil {
+		t.Fatalf("SendReminder failed: %v", err)
+	}
+	if len(env.api.sent) != 1 || env.api.sent[0].ChatID != 99 || env.api.sent[0].Text != "⏰ Time for Stretch\nReply \"done Stretch\" once it is done." {
+		t.Fatalf("Unexpected messages: %+v", env.api.sent)
+	}
+
+	sqlite.NewNotificationPreferenceRepository(env.db).Save(ctx, entities.NewNotificationPreference(env.user.ID, entities.NotificationReminders, entities.NotificationChat, false))
+	channel.SendReminder(ctx, env.user, habit)
+	if len(env.api.sent) != 1 {
+		t.Errorf("Expected chat reminders turned off to be skipped, got %d messages", len(env.api.sent))
+	}
+}
@@ -0,0 +1,59 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/domain/services"
+	"apocapoc-api/internal/i18n"
+	"apocapoc-api/internal/shared/errors"
+)
+
+// ReminderChannel sends habit reminders to the user's linked chat, where
+// they can be answered with "done". Users without a linked chat are
+// skipped. Reminders go through the notification gate.
+type ReminderChannel struct {
+	client     *Client
+	linkRepo   repositories.ChatLinkRepository
+	gate       services.NotificationGate
+	translator *i18n.Translator
+}
+
+func NewReminderChannel(client *Client, linkRepo repositories.ChatLinkRepository, gate services.NotificationGate, translator *i18n.Translator) *ReminderChannel {
+	return &ReminderChannel{
+		client:     client,
+		linkRepo:   linkRepo,
+		gate:       gate,
+		translator: translator,
+	}
+}
+
+func (c *ReminderChannel) Name() string {
+	return "chat"
+}
+
+func (c *ReminderChannel) SendReminder(ctx context.Context, user *entities.User, habit *entities.Habit) error {
+	link, err := c.linkRepo.FindByUserID(ctx, user.ID)
+	if err == errors.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	lang := c.translator.GetLanguage(user.Language)
+	title := fmt.Sprintf(c.translator.Email(lang, "reminder_title"), habit.Name)
+
+	decision, err := c.gate.Admit(ctx, user.ID, entities.NotificationReminders, entities.NotificationChat, title, "")
+	if err != nil {
+		return err
+	}
+	if decision != services.NotificationSend {
+		return nil
+	}
+
+	text := "⏰ " + title + "\n" + fmt.Sprintf(c.translator.Chat(lang, "reminder_reply"), habit.Name)
+	return c.client.SendMessage(ctx, link.ChatID, text)
+}
@@ -0,0 +1,33 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+const (
+	HeaderEvent     = "X-Apocapoc-Event"
+	HeaderDelivery  = "X-Apocapoc-Delivery"
+	HeaderTimestamp = "X-Apocapoc-Timestamp"
+	HeaderSignature = "X-Apocapoc-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+// Sign returns the signature header value of a delivery: the hex HMAC-SHA256
+// of "<timestamp>.<body>" keyed with the webhook secret. Covering the timestamp
+// lets receivers reject replayed deliveries.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature header value in constant time.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
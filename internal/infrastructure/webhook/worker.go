@@ -0,0 +1,271 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"apocapoc-api/internal/application/commands"
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/infrastructure/logger"
+	"apocapoc-api/internal/shared/constants"
+	"apocapoc-api/internal/shared/errors"
+	"apocapoc-api/internal/shared/utils"
+)
+
+const (
+	deliveryBatchSize   = 50
+	deliveryTimeout     = 10 * time.Second
+	streakCheckInterval = time.Hour
+	maxErrorLength      = 500
+)
+
+type Config struct {
+	Enabled  bool
+	Interval time.Duration
+	// Location is the timezone whose days decide when a streak is broken.
+	Location *time.Location
+	// AllowPrivateNetworks lets deliveries reach receivers on the local
+	// network. Loopback and link-local addresses are refused either way.
+	AllowPrivateNetworks bool
+}
+
+// Worker periodically sends the pending webhook deliveries and looks for
+// broken streaks to publish.
+type Worker struct {
+	webhookRepo     repositories.WebhookRepository
+	deliveryRepo    repositories.WebhookDeliveryRepository
+	streaks         *commands.DetectBrokenStreaksHandler
+	client          *http.Client
+	config          Config
+	lastStreakCheck time.Time
+	stopCh          chan struct{}
+}
+
+func NewWorker(
+	webhookRepo repositories.WebhookRepository,
+	deliveryRepo repositories.WebhookDeliveryRepository,
+	streaks *commands.DetectBrokenStreaksHandler,
+	client *http.Client,
+	config Config,
+) *Worker {
+	if config.Location == nil {
+		config.Location = time.UTC
+	}
+	if client == nil {
+		client = &http.Client{
+			Timeout:   deliveryTimeout,
+			Transport: newTransport(config.AllowPrivateNetworks),
+			// A redirect is reported as a failed delivery rather than followed,
+			// so the payload only ever goes to the registered URL.
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+	}
+
+	return &Worker{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		streaks:      streaks,
+		client:       client,
+		config:       config,
+		stopCh:       make(chan struct{}),
+	}
+}
+
+// newTransport checks every address a delivery connects to, after DNS
+// resolution, so a host name cannot point a webhook at an internal service.
+// Proxies are not used, since the check would only see the proxy.
+func newTransport(allowPrivateNetworks bool) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout: deliveryTimeout,
+		Control: func(network, address string, conn syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || !entities.IsAllowedWebhookIP(ip, allowPrivateNetworks) {
+				return fmt.Errorf("webhook address %s is not allowed", host)
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return transport
+}
+
+func (w *Worker) Start() {
+	if !w.config.Enabled {
+		logger.Info().Msg("Webhook worker is disabled")
+		return
+	}
+
+	logger.Info().
+		Dur("interval", w.config.Interval).
+		Str("timezone", w.config.Location.String()).
+		Msg("Starting webhook worker")
+
+	go w.run()
+}
+
+func (w *Worker) run() {
+	w.tick(context.Background(), time.Now())
+
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			logger.Debug().Msg("Checking for due webhook deliveries")
+			w.tick(context.Background(), time.Now())
+
+		case <-w.stopCh:
+			logger.Info().Msg("Webhook worker stopped")
+			return
+		}
+	}
+}
+
+func (w *Worker) Stop() {
+	if w.config.Enabled {
+		close(w.stopCh)
+	}
+}
+
+func (w *Worker) tick(ctx context.Context, now time.Time) {
+	if now.Sub(w.lastStreakCheck) >= streakCheckInterval {
+		w.CheckStreaks(ctx, now)
+		w.lastStreakCheck = now
+	}
+	w.DeliverDue(ctx, now)
+}
+
+// CheckStreaks publishes the streaks broken as of now and returns how many were
+// found.
+func (w *Worker) CheckStreaks(ctx context.Context, now time.Time) int {
+	if w.streaks == nil {
+		return 0
+	}
+
+	found, err := w.streaks.Handle(ctx, now.In(w.config.Location))
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to check for broken streaks")
+	}
+	return found
+}
+
+// DeliverDue attempts every pending delivery whose next attempt is due and
+// returns how many succeeded. Deliveries of disabled webhooks are failed
+// without being sent.
+func (w *Worker) DeliverDue(ctx context.Context, now time.Time) int {
+	deliveries, err := w.deliveryRepo.FindDue(ctx, now, deliveryBatchSize)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to load due webhook deliveries")
+		return 0
+	}
+
+	webhooks := make(map[string]*entities.Webhook)
+	delivered := 0
+
+	for _, delivery := range deliveries {
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			webhook, err = w.webhookRepo.FindByID(ctx, delivery.WebhookID)
+			if err != nil && err != errors.ErrNotFound {
+				logger.Error().Err(err).Str("webhook_id", delivery.WebhookID).Msg("Failed to load webhook")
+				continue
+			}
+			webhooks[delivery.WebhookID] = webhook
+		}
+
+		if webhook == nil || !webhook.Enabled {
+			delivery.Abandon("webhook is disabled")
+			w.saveDelivery(ctx, delivery)
+			continue
+		}
+
+		if w.attempt(ctx, webhook, delivery, now) {
+			delivered++
+		}
+	}
+
+	return delivered
+}
+
+func (w *Worker) attempt(ctx context.Context, webhook *entities.Webhook, delivery *entities.WebhookDelivery, now time.Time) bool {
+	statusCode, err := w.send(ctx, webhook, delivery, now)
+
+	success := err == nil
+	if success {
+		delivery.RecordSuccess(*statusCode, now)
+		webhook.RecordSuccess()
+	} else {
+		delivery.RecordFailure(statusCode, utils.Truncate(err.Error(), maxErrorLength), now)
+		if webhook.RecordFailure() {
+			delivery.Abandon(delivery.LastError)
+			logger.Warn().
+				Str("webhook_id", webhook.ID).
+				Int("failures", webhook.ConsecutiveFailures).
+				Msg("Disabled webhook after repeated failures")
+		}
+	}
+
+	w.saveDelivery(ctx, delivery)
+	if err := w.webhookRepo.Update(ctx, webhook); err != nil && err != errors.ErrNotFound {
+		logger.Error().Err(err).Str("webhook_id", webhook.ID).Msg("Failed to update webhook")
+	}
+
+	return success
+}
+
+// send posts the payload and returns the response status, which is nil when
+// no response was received. Any status other than 2xx is an error.
+func (w *Worker) send(ctx context.Context, webhook *entities.Webhook, delivery *entities.WebhookDelivery, now time.Time) (*int, error) {
+	body := []byte(delivery.Payload)
+	timestamp := now.Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", constants.AppName+"-Webhooks")
+	req.Header.Set(HeaderEvent, string(delivery.EventType))
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	statusCode := resp.StatusCode
+	if statusCode < 200 || statusCode > 299 {
+		return &statusCode, fmt.Errorf("unexpected status %d", statusCode)
+	}
+
+	return &statusCode, nil
+}
+
+func (w *Worker) saveDelivery(ctx context.Context, delivery *entities.WebhookDelivery) {
+	if err := w.deliveryRepo.Update(ctx, delivery); err != nil && err != errors.ErrNotFound {
+		logger.Error().Err(err).Str("delivery_id", delivery.ID).Msg("Failed to update webhook delivery")
+	}
+}
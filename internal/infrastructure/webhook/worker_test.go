@@ -0,0 +1,248 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/application/commands"
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/infrastructure/events"
+	"apocapoc-api/internal/infrastructure/persistence/sqlite"
+)
+
+type receivedDelivery struct {
+	header http.Header
+	body   []byte
+}
+
+type receiver struct {
+	mu       sync.Mutex
+	status   int
+	received []receivedDelivery
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.received = append(r.received, receivedDelivery{header: req.Header.Clone(), body: body})
+	w.WriteHeader(r.status)
+}
+
+type testEnv struct {
+	ctx          context.Context
+	db           *sql.DB
+	user         *entities.User
+	webhookRepo  *sqlite.WebhookRepository
+	deliveryRepo *sqlite.WebhookDeliveryRepository
+	publisher    *commands.WebhookEventHandler
+}
+
+func setupTestEnv(t *testing.T) *testEnv {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := sqlite.RunMigrations(db); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	env := &testEnv{
+		ctx:          context.Background(),
+		db:           db,
+		user:         entities.NewUser("hooks@example.com", "hash"),
+		webhookRepo:  sqlite.NewWebhookRepository(db),
+		deliveryRepo: sqlite.NewWebhookDeliveryRepository(db),
+	}
+	env.publisher = commands.NewWebhookEventHandler(env.webhookRepo, env.deliveryRepo)
+	sqlite.NewUserRepository(db).Create(env.ctx, env.user)
+
+	return env
+}
+
+func (env *testEnv) createWebhook(t *testing.T, url string, events ...entities.EventType) *entities.Webhook {
+	webhook := entities.NewWebhook(env.user.ID, url, "whsec_test", events)
+	if err := env.webhookRepo.Create(env.ctx, webhook); err != nil {
+		t.Fatalf("Failed to create webhook: %v", err)
+	}
+	return webhook
+}
+
+func (env *testEnv) publish(t *testing.T, eventType entities.EventType) {
+	if err := env.publisher.Handle(env.ctx, entities.NewEvent(eventType, env.user.ID, map[string]interface{}{})); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+}
+
+func TestWorker_DeliverDueSignsDeliveries(t *testing.T) {
+	env := setupTestEnv(t)
+	recv := &receiver{status: http.StatusNoContent}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	webhook := env.createWebhook(t, server.URL, entities.EventHabitMarked)
+	env.publish(t, entities.EventHabitMarked)
+	env.publish(t, entities.EventHabitCreated)
+
+	worker := NewWorker(env.webhookRepo, env.deliveryRepo, nil, server.Client(), Config{Enabled: true, Interval: time.Minute})
+	now := time.Now()
+
+	if delivered := worker.DeliverDue(env.ctx, now); delivered != 1 {
+		t.Fatalf("Expected 1 delivery, got %d", delivered)
+	}
+
+	got := recv.received[0]
+	timestamp, err := strconv.ParseInt(got.header.Get(HeaderTimestamp), 10, 64)
+	if err != nil || timestamp != now.Unix() {
+		t.Errorf("Unexpected timestamp header %q", got.header.Get(HeaderTimestamp))
+	}
+	if !Verify(webhook.Secret, timestamp, got.body, got.header.Get(HeaderSignature)) {
+		t.Errorf("Signature %q does not verify", got.header.Get(HeaderSignature))
+	}
+	if Verify("other-secret", timestamp, got.body, got.header.Get(HeaderSignature)) {
+		t.Error("Signature verified with the wrong secret")
+	}
+	if got.header.Get(HeaderEvent) != "habit.marked" || got.header.Get(HeaderDelivery) == "" {
+		t.Errorf("Unexpected event headers: %v", got.header)
+	}
+
+	log, _ := env.deliveryRepo.FindByWebhookID(env.ctx, webhook.ID, 10)
+	if len(log) != 1 || log[0].Status != entities.WebhookDeliverySucceeded || log[0].DeliveredAt == nil {
+		t.Errorf("Expected a succeeded delivery, got %+v", log)
+	}
+
+	if delivered := worker.DeliverDue(env.ctx, now); delivered != 0 {
+		t.Errorf("Expected nothing left to deliver, got %d", delivered)
+	}
+}
+
+func TestWorker_RefusesLoopbackAddresses(t *testing.T) {
+	env := setupTestEnv(t)
+	recv := &receiver{status: http.StatusNoContent}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	webhook := env.createWebhook(t, server.URL, entities.EventHabitMarked)
+	env.publish(t, entities.EventHabitMarked)
+
+	// Even with LAN receivers allowed, the default client does not connect
+	// to the server's own loopback address.
+	worker := NewWorker(env.webhookRepo, env.deliveryRepo, nil, nil, Config{Enabled: true, Interval: time.Minute, AllowPrivateNetworks: true})
+
+	if delivered := worker.DeliverDue(env.ctx, time.Now()); delivered != 0 {
+		t.Fatalf("Expected no delivery, got %d", delivered)
+	}
+	if len(recv.received) != 0 {
+		t.Errorf("Expected the receiver not to be called, got %d requests", len(recv.received))
+	}
+
+	log, _ := env.deliveryRepo.FindByWebhookID(env.ctx, webhook.ID, 10)
+	if len(log) != 1 || !strings.Contains(log[0].LastError, "not allowed") {
+		t.Errorf("Expected a refused delivery, got %+v", log)
+	}
+}
+
+func TestWorker_RetriesAndDisablesFailingWebhooks(t *testing.T) {
+	env := setupTestEnv(t)
+	recv := &receiver{status: http.StatusInternalServerError}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	webhook := env.createWebhook(t, server.URL, entities.EventHabitMarked)
+	env.publish(t, entities.EventHabitMarked)
+
+	worker := NewWorker(env.webhookRepo, env.deliveryRepo, nil, server.Client(), Config{Enabled: true, Interval: time.Minute})
+	now := time.Now()
+
+	worker.DeliverDue(env.ctx, now)
+	if len(recv.received) != 1 {
+		t.Fatalf("Expected 1 attempt, got %d", len(recv.received))
+	}
+
+	worker.DeliverDue(env.ctx, now.Add(10*time.Second))
+	if len(recv.received) != 1 {
+		t.Errorf("Expected no attempt during the backoff, got %d", len(recv.received))
+	}
+
+	worker.DeliverDue(env.ctx, now.Add(time.Minute))
+	if len(recv.received) != 2 {
+		t.Errorf("Expected a retry after the backoff, got %d attempts", len(recv.received))
+	}
+
+	log, _ := env.deliveryRepo.FindByWebhookID(env.ctx, webhook.ID, 10)
+	if log[0].Status != entities.WebhookDeliveryPending || log[0].Attempts != 2 || *log[0].LastStatusCode != 500 {
+		t.Errorf("Expected a pending delivery after 2 attempts, got %+v", log[0])
+	}
+
+	stored, _ := env.webhookRepo.FindByID(env.ctx, webhook.ID)
+	stored.ConsecutiveFailures = entities.WebhookMaxConsecutiveFailures - 1
+	env.webhookRepo.Update(env.ctx, stored)
+	env.publish(t, entities.EventHabitMarked)
+
+	worker.DeliverDue(env.ctx, now.Add(time.Hour))
+
+	stored, _ = env.webhookRepo.FindByID(env.ctx, webhook.ID)
+	if stored.Enabled || stored.DisabledAt == nil {
+		t.Fatal("Expected the webhook to be disabled after repeated failures")
+	}
+
+	log, _ = env.deliveryRepo.FindByWebhookID(env.ctx, webhook.ID, 10)
+	for _, delivery := range log {
+		if delivery.Status != entities.WebhookDeliveryFailed {
+			t.Errorf("Expected every delivery of the disabled webhook to fail, got %s", delivery.Status)
+		}
+	}
+	if len(recv.received) != 3 {
+		t.Errorf("Expected the remaining delivery to be dropped unsent, got %d attempts", len(recv.received))
+	}
+}
+
+func TestWorker_CheckStreaks(t *testing.T) {
+	env := setupTestEnv(t)
+	habitRepo := sqlite.NewHabitRepository(env.db)
+	statsRepo := sqlite.NewHabitStatsRepository(env.db)
+
+	habit := entities.NewHabit(env.user.ID, "Meditate", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.CreatedAt = time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	habitRepo.Create(env.ctx, habit)
+
+	lastCompleted := time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)
+	statsRepo.Save(env.ctx, &entities.HabitStats{HabitID: habit.ID, CurrentStreak: 5, LongestStreak: 5, LastCompletedDate: &lastCompleted, UpdatedAt: time.Now()})
+
+	webhook := env.createWebhook(t, "https://example.com/hook", entities.EventStreakBroken)
+
+	bus := events.NewBus(sqlite.NewEventOutboxRepository(env.db), events.Config{})
+	bus.SubscribeAsync("webhooks", env.publisher.Handle, entities.WebhookEventTypes...)
+
+	madrid, _ := time.LoadLocation("Europe/Madrid")
+	worker := NewWorker(env.webhookRepo, env.deliveryRepo,
+		commands.NewDetectBrokenStreaksHandler(env.webhookRepo, habitRepo, statsRepo, bus), nil,
+		Config{Enabled: true, Interval: time.Minute, Location: madrid})
+
+	// 23:30 UTC on March 10th is already March 11th in Madrid.
+	if found := worker.CheckStreaks(env.ctx, time.Date(2025, 3, 10, 22, 30, 0, 0, time.UTC)); found != 0 {
+		t.Errorf("Expected the streak to hold while March 10th is in progress, got %d", found)
+	}
+	if found := worker.CheckStreaks(env.ctx, time.Date(2025, 3, 10, 23, 30, 0, 0, time.UTC)); found != 1 {
+		t.Fatalf("Expected 1 broken streak, got %d", found)
+	}
+	worker.CheckStreaks(env.ctx, time.Date(2025, 3, 11, 9, 0, 0, 0, time.UTC))
+	bus.DispatchDue(env.ctx, time.Now())
+
+	log, _ := env.deliveryRepo.FindByWebhookID(env.ctx, webhook.ID, 10)
+	if len(log) != 1 || log[0].EventType != entities.EventStreakBroken {
+		t.Errorf("Expected a single streak.broken delivery, got %+v", log)
+	}
+}
@@ -0,0 +1,51 @@
+package webpush
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/i18n"
+)
+
+// BatchNotifier pushes the notifications held back by quiet hours or the
+// hourly limit as a single notification listing them. It implements
+// services.NotificationBatchSender.
+type BatchNotifier struct {
+	sender           *Sender
+	subscriptionRepo repositories.PushSubscriptionRepository
+	translator       *i18n.Translator
+}
+
+func NewBatchNotifier(sender *Sender, subscriptionRepo repositories.PushSubscriptionRepository, translator *i18n.Translator) *BatchNotifier {
+	return &BatchNotifier{
+		sender:           sender,
+		subscriptionRepo: subscriptionRepo,
+		translator:       translator,
+	}
+}
+
+func (n *BatchNotifier) Channel() entities.NotificationChannel {
+	return entities.NotificationPush
+}
+
+func (n *BatchNotifier) SendBatch(ctx context.Context, user *entities.User, notifications []*entities.HeldNotification) error {
+	titles := make([]string, 0, len(notifications))
+	for _, notification := range notifications {
+		titles = append(titles, notification.Title)
+	}
+
+	lang := n.translator.GetLanguage(user.Language)
+	payload, err := json.Marshal(Notification{
+		Title: fmt.Sprintf(n.translator.Email(lang, "notification_batch_push_title"), len(notifications)),
+		Body:  strings.Join(titles, "\n"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return pushToAll(ctx, n.sender, n.subscriptionRepo, user.ID, payload)
+}
@@ -0,0 +1,96 @@
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	recordSize = 4096
+	saltLength = 16
+	// Push services accept 4096 byte bodies, which leaves this much room for
+	// the payload after the header, the padding delimiter and the GCM tag.
+	maxPayloadSize = recordSize - (saltLength + 4 + 1 + 65) - 1 - 16
+)
+
+var ErrPayloadTooLarge = fmt.Errorf("push payload exceeds %d bytes", maxPayloadSize)
+
+// encrypt encrypts payload for the user agent's keys as a single record of the
+// aes128gcm content coding (RFC 8188), deriving the key as RFC 8291 describes.
+func encrypt(userAgentPublicKey, authSecret, payload []byte) ([]byte, error) {
+	serverKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+
+	return encryptWithKey(serverKey, salt, userAgentPublicKey, authSecret, payload)
+}
+
+func encryptWithKey(serverKey *ecdh.PrivateKey, salt, userAgentPublicKey, authSecret, payload []byte) ([]byte, error) {
+	if len(payload) > maxPayloadSize {
+		return nil, ErrPayloadTooLarge
+	}
+
+	userAgentKey, err := ecdh.P256().NewPublicKey(userAgentPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription key: %w", err)
+	}
+
+	sharedSecret, err := serverKey.ECDH(userAgentKey)
+	if err != nil {
+		return nil, err
+	}
+
+	serverPublicKey := serverKey.PublicKey().Bytes()
+
+	keyInfo := append([]byte("WebPush: info\x00"), userAgentPublicKey...)
+	keyInfo = append(keyInfo, serverPublicKey...)
+
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, authSecret, string(keyInfo), 32)
+	if err != nil {
+		return nil, err
+	}
+
+	contentKey, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, saltLength+4+1+len(serverPublicKey))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, recordSize)
+	header = append(header, byte(len(serverPublicKey)))
+	header = append(header, serverPublicKey...)
+
+	// 0x02 marks the last (and only) record, with no further padding.
+	plaintext := append(append([]byte{}, payload...), 0x02)
+
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
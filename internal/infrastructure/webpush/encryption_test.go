@@ -0,0 +1,61 @@
+package webpush
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+)
+
+// Test vector from RFC 8291, section 5.
+func TestEncryptWithKey_RFC8291Example(t *testing.T) {
+	decode := func(value string) []byte {
+		decoded, err := base64.RawURLEncoding.DecodeString(value)
+		if err != nil {
+			t.Fatalf("Failed to decode %s: %v", value, err)
+		}
+		return decoded
+	}
+
+	serverKey, err := ecdh.P256().NewPrivateKey(decode("yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"))
+	if err != nil {
+		t.Fatalf("Failed to load server key: %v", err)
+	}
+
+	body, err := encryptWithKey(
+		serverKey,
+		decode("DGv6ra1nlYgDCS1FRnbzlw"),
+		decode("BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"),
+		decode("BTBZMqHH6r4Tts7J_aSIgg"),
+		[]byte("When I grow up, I want to be a watermelon"),
+	)
+	if err != nil {
+		t.Fatalf("encryptWithKey failed: %v", err)
+	}
+
+	expected := decode("DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN")
+	if !bytes.Equal(body, expected) {
+		t.Errorf("Encrypted body does not match the RFC example:\n got %s", base64.RawURLEncoding.EncodeToString(body))
+	}
+}
+
+func TestEncrypt_RejectsLargePayloads(t *testing.T) {
+	userAgentKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	authSecret := make([]byte, 16)
+
+	body, err := encrypt(userAgentKey.PublicKey().Bytes(), authSecret, make([]byte, maxPayloadSize))
+	if err != nil {
+		t.Fatalf("Expected the largest payload to be accepted, got %v", err)
+	}
+	if len(body) != recordSize {
+		t.Errorf("Expected a %d byte body, got %d", recordSize, len(body))
+	}
+
+	if _, err := encrypt(userAgentKey.PublicKey().Bytes(), authSecret, make([]byte, maxPayloadSize+1)); err != ErrPayloadTooLarge {
+		t.Errorf("Expected ErrPayloadTooLarge, got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package webpush
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/repositories"
+	"apocapoc-api/internal/domain/services"
+	"apocapoc-api/internal/i18n"
+	"apocapoc-api/internal/infrastructure/logger"
+)
+
+// Notification is the JSON payload the client's service worker receives.
+type Notification struct {
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	HabitID string `json:"habit_id,omitempty"`
+}
+
+// ReminderChannel pushes habit reminders to every device the user registered,
+// deleting the subscriptions the push service reports as gone. Reminders go
+// through the notification gate, so users who turned off push reminders are
+// skipped and quiet hours and the hourly limit apply.
+type ReminderChannel struct {
+	sender           *Sender
+	subscriptionRepo repositories.PushSubscriptionRepository
+	gate             services.NotificationGate
+	translator       *i18n.Translator
+}
+
+func NewReminderChannel(sender *Sender, subscriptionRepo repositories.PushSubscriptionRepository, gate services.NotificationGate, translator *i18n.Translator) *ReminderChannel {
+	return &ReminderChannel{
+		sender:           sender,
+		subscriptionRepo: subscriptionRepo,
+		gate:             gate,
+		translator:       translator,
+	}
+}
+
+func (c *ReminderChannel) Name() string {
+	return "webpush"
+}
+
+func (c *ReminderChannel) SendReminder(ctx context.Context, user *entities.User, habit *entities.Habit) error {
+	lang := c.translator.GetLanguage(user.Language)
+	title := fmt.Sprintf(c.translator.Email(lang, "reminder_title"), habit.Name)
+
+	decision, err := c.gate.Admit(ctx, user.ID, entities.NotificationReminders, entities.NotificationPush, title, "")
+	if err != nil {
+		return err
+	}
+	if decision != services.NotificationSend {
+		return nil
+	}
+
+	payload, err := json.Marshal(Notification{
+		Title:   title,
+		Body:    c.translator.Email(lang, "reminder_body"),
+		HabitID: habit.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return pushToAll(ctx, c.sender, c.subscriptionRepo, user.ID, payload)
+}
+
+// pushToAll succeeds when at least one device received the payload, or when
+// the user has no devices left to notify.
+func pushToAll(ctx context.Context, sender *Sender, subscriptionRepo repositories.PushSubscriptionRepository, userID string, payload []byte) error {
+	subscriptions, err := subscriptionRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	delivered := false
+	for _, subscription := range subscriptions {
+		err := sender.Send(ctx, subscription, payload)
+		if errors.Is(err, ErrSubscriptionGone) {
+			logger.Info().Str("user_id", userID).Str("subscription_id", subscription.ID).Msg("Pruning expired push subscription")
+			if err := subscriptionRepo.Delete(ctx, subscription.ID); err != nil {
+				logger.Error().Err(err).Str("subscription_id", subscription.ID).Msg("Failed to delete push subscription")
+			}
+			continue
+		}
+
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		delivered = true
+	}
+
+	if delivered {
+		return nil
+	}
+
+	return lastErr
+}
@@ -0,0 +1,111 @@
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+)
+
+const defaultTTL = 12 * time.Hour
+
+// ErrSubscriptionGone is returned when the push service reports that the
+// subscription expired or was revoked, so it should be deleted.
+var ErrSubscriptionGone = errors.New("push subscription is gone")
+
+type Config struct {
+	PublicKey  string
+	PrivateKey string
+	// Subject is the contact URI (mailto: or https:) push services can use to
+	// reach the server operator.
+	Subject string
+	TTL     time.Duration
+}
+
+// Sender delivers encrypted Web Push messages (RFC 8030) signed with VAPID.
+type Sender struct {
+	keys    *VAPIDKeys
+	subject string
+	ttl     time.Duration
+	client  *http.Client
+}
+
+func NewSender(config Config, client *http.Client) (*Sender, error) {
+	keys, err := NewVAPIDKeys(config.PublicKey, config.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.TTL <= 0 {
+		config.TTL = defaultTTL
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &Sender{
+		keys:    keys,
+		subject: config.Subject,
+		ttl:     config.TTL,
+		client:  client,
+	}, nil
+}
+
+func (s *Sender) PublicKey() string {
+	return s.keys.PublicKey()
+}
+
+func (s *Sender) Send(ctx context.Context, subscription *entities.PushSubscription, payload []byte) error {
+	userAgentKey, err := entities.DecodePushKey(subscription.P256dh)
+	if err != nil {
+		return fmt.Errorf("invalid subscription key: %w", err)
+	}
+
+	authSecret, err := entities.DecodePushKey(subscription.Auth)
+	if err != nil {
+		return fmt.Errorf("invalid subscription auth secret: %w", err)
+	}
+
+	body, err := encrypt(userAgentKey, authSecret, payload)
+	if err != nil {
+		return err
+	}
+
+	authorization, err := s.keys.authorization(subscription.Endpoint, s.subject, time.Now())
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create push request: %w", err)
+	}
+
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(s.ttl.Seconds())))
+	req.Header.Set("Urgency", "normal")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send push message: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrSubscriptionGone
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return fmt.Errorf("push service responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
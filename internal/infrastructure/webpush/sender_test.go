@@ -0,0 +1,365 @@
+package webpush
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+	"apocapoc-api/internal/domain/value_objects"
+	"apocapoc-api/internal/i18n"
+	"apocapoc-api/internal/infrastructure/notifications"
+	"apocapoc-api/internal/infrastructure/persistence/sqlite"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// fakeBrowser holds the keys a user agent generates for a subscription.
+type fakeBrowser struct {
+	key        *ecdh.PrivateKey
+	authSecret []byte
+}
+
+func newFakeBrowser(t *testing.T) *fakeBrowser {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate browser key: %v", err)
+	}
+	authSecret := make([]byte, 16)
+	rand.Read(authSecret)
+	return &fakeBrowser{key: key, authSecret: authSecret}
+}
+
+func (b *fakeBrowser) subscription(userID, endpoint string) *entities.PushSubscription {
+	return entities.NewPushSubscription(
+		userID,
+		endpoint,
+		base64.RawURLEncoding.EncodeToString(b.key.PublicKey().Bytes()),
+		base64.RawURLEncoding.EncodeToString(b.authSecret),
+		"test",
+	)
+}
+
+// decrypt reverses the aes128gcm encoding as a browser would.
+func (b *fakeBrowser) decrypt(t *testing.T, body []byte) []byte {
+	salt, keyLength := body[:16], int(body[20])
+	serverKey, err := ecdh.P256().NewPublicKey(body[21 : 21+keyLength])
+	if err != nil {
+		t.Fatalf("Invalid server key in header: %v", err)
+	}
+
+	sharedSecret, _ := b.key.ECDH(serverKey)
+	keyInfo := append([]byte("WebPush: info\x00"), b.key.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, serverKey.Bytes()...)
+	ikm, _ := hkdf.Key(sha256.New, sharedSecret, b.authSecret, string(keyInfo), 32)
+	contentKey, _ := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: aes128gcm\x00", 16)
+	nonce, _ := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: nonce\x00", 12)
+
+	block, _ := aes.NewCipher(contentKey)
+	gcm, _ := cipher.NewGCM(block)
+	plaintext, err := gcm.Open(nil, nonce, body[21+keyLength:], nil)
+	if err != nil {
+		t.Fatalf("Failed to decrypt push message: %v", err)
+	}
+
+	return plaintext[:strings.LastIndexByte(string(plaintext), 0x02)]
+}
+
+type pushRequest struct {
+	path    string
+	headers http.Header
+	body    []byte
+}
+
+// fakePushService accepts messages on /ok/* and reports /gone/* as expired.
+func newFakePushService(t *testing.T) (*httptest.Server, *[]pushRequest) {
+	var (
+		mu       sync.Mutex
+		requests []pushRequest
+	)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, pushRequest{path: r.URL.Path, headers: r.Header.Clone(), body: body})
+		mu.Unlock()
+
+		if strings.HasPrefix(r.URL.Path, "/gone/") {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func newTestSender(t *testing.T, server *httptest.Server) *Sender {
+	publicKey, privateKey, err := GenerateVAPIDKeys()
+	if err != nil {
+		t.Fatalf("GenerateVAPIDKeys failed: %v", err)
+	}
+
+	sender, err := NewSender(Config{PublicKey: publicKey, PrivateKey: privateKey, Subject: "mailto:ops@example.com"}, server.Client())
+	if err != nil {
+		t.Fatalf("NewSender failed: %v", err)
+	}
+	return sender
+}
+
+func newTestGate(db *sql.DB) *notifications.Gate {
+	return notifications.NewGate(
+		sqlite.NewNotificationPreferenceRepository(db),
+		sqlite.NewNotificationScheduleRepository(db),
+		sqlite.NewHeldNotificationRepository(db),
+		sqlite.NewNotificationLogRepository(db),
+	)
+}
+
+func TestNewVAPIDKeys_RejectsMismatchedKeys(t *testing.T) {
+	publicKey, _, _ := GenerateVAPIDKeys()
+	_, privateKey, _ := GenerateVAPIDKeys()
+
+	if _, err := NewVAPIDKeys(publicKey, privateKey); err == nil {
+		t.Error("Expected an error for keys that do not belong together")
+	}
+}
+
+func TestSender_Send(t *testing.T) {
+	server, requests := newFakePushService(t)
+	sender := newTestSender(t, server)
+	browser := newFakeBrowser(t)
+
+	err := sender.Send(context.Background(), browser.subscription("user-1", server.URL+"/ok/device-1"), []byte(`{"title":"Hello"}`))
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	if len(*requests) != 1 {
+		t.Fatalf("Expected 1 push request, got %d", len(*requests))
+	}
+	request := (*requests)[0]
+
+	if request.headers.Get("Content-Encoding") != "aes128gcm" || request.headers.Get("TTL") != "43200" {
+		t.Errorf("Unexpected headers: %v", request.headers)
+	}
+
+	if payload := browser.decrypt(t, request.body); string(payload) != `{"title":"Hello"}` {
+		t.Errorf("Unexpected decrypted payload %q", payload)
+	}
+
+	var token, key string
+	for _, part := range strings.Split(strings.TrimPrefix(request.headers.Get("Authorization"), "vapid "), ", ") {
+		if value, ok := strings.CutPrefix(part, "t="); ok {
+			token = value
+		}
+		if value, ok := strings.CutPrefix(part, "k="); ok {
+			key = value
+		}
+	}
+	if key != sender.PublicKey() {
+		t.Errorf("Expected the VAPID public key in the header, got %q", key)
+	}
+
+	rawKey, _ := base64.RawURLEncoding.DecodeString(key)
+	ecdhKey, err := ecdh.P256().NewPublicKey(rawKey)
+	if err != nil {
+		t.Fatalf("Invalid VAPID public key: %v", err)
+	}
+	der, _ := x509.MarshalPKIXPublicKey(ecdhKey)
+	verifyKey, _ := x509.ParsePKIXPublicKey(der)
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return verifyKey.(*ecdsa.PublicKey), nil
+	}, jwt.WithValidMethods([]string{"ES256"}))
+	if err != nil {
+		t.Fatalf("Invalid VAPID token: %v", err)
+	}
+	if claims["aud"] != server.URL || claims["sub"] != "mailto:ops@example.com" {
+		t.Errorf("Unexpected VAPID claims: %v", claims)
+	}
+
+	err = sender.Send(context.Background(), browser.subscription("user-1", server.URL+"/gone/device-2"), []byte("{}"))
+	if err != ErrSubscriptionGone {
+		t.Errorf("Expected ErrSubscriptionGone, got %v", err)
+	}
+}
+
+func TestReminderChannel_PrunesGoneSubscriptions(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := sqlite.RunMigrations(db); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	translator, err := i18n.NewTranslator()
+	if err != nil {
+		t.Fatalf("Failed to create translator: %v", err)
+	}
+
+	ctx := context.Background()
+	userRepo := sqlite.NewUserRepository(db)
+	subscriptionRepo := sqlite.NewPushSubscriptionRepository(db)
+
+	user := entities.NewUser("push@example.com", "hash")
+	userRepo.Create(ctx, user)
+
+	server, requests := newFakePushService(t)
+	browser := newFakeBrowser(t)
+	subscriptionRepo.Save(ctx, browser.subscription(user.ID, server.URL+"/ok/laptop"))
+	subscriptionRepo.Save(ctx, browser.subscription(user.ID, server.URL+"/gone/old-phone"))
+
+	channel := NewReminderChannel(newTestSender(t, server), subscriptionRepo, newTestGate(db), translator)
+	habit := entities.NewHabit(user.ID, "Stretch", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+	habit.ID = "habit-1"
+
+	if err := channel.SendReminder(ctx, user, habit); err != nil {
+		t.Fatalf("SendReminder failed: %v", err)
+	}
+
+	if len(*requests) != 2 {
+		t.Fatalf("Expected a push to both devices, got %d", len(*requests))
+	}
+
+	var notification Notification
+	for _, request := range *requests {
+		if strings.HasPrefix(request.path, "/ok/") {
+			json.Unmarshal(browser.decrypt(t, request.body), &notification)
+		}
+	}
+	if notification.Title != "Time for Stretch" || notification.HabitID != "habit-1" {
+		t.Errorf("Unexpected notification: %+v", notification)
+	}
+
+	remaining, _ := subscriptionRepo.FindByUserID(ctx, user.ID)
+	if len(remaining) != 1 || !strings.HasSuffix(remaining[0].Endpoint, "/ok/laptop") {
+		t.Errorf("Expected the gone subscription to be pruned, got %+v", remaining)
+	}
+}
+
+func TestReminderChannel_SkipsUsersWhoTurnedOffPushReminders(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := sqlite.RunMigrations(db); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	translator, err := i18n.NewTranslator()
+	if err != nil {
+		t.Fatalf("Failed to create translator: %v", err)
+	}
+
+	ctx := context.Background()
+	userRepo := sqlite.NewUserRepository(db)
+	subscriptionRepo := sqlite.NewPushSubscriptionRepository(db)
+	preferenceRepo := sqlite.NewNotificationPreferenceRepository(db)
+
+	user := entities.NewUser("nopush@example.com", "hash")
+	userRepo.Create(ctx, user)
+	preferenceRepo.Save(ctx, entities.NewNotificationPreference(user.ID, entities.NotificationReminders, entities.NotificationPush, false))
+
+	server, requests := newFakePushService(t)
+	browser := newFakeBrowser(t)
+	subscriptionRepo.Save(ctx, browser.subscription(user.ID, server.URL+"/ok/laptop"))
+
+	channel := NewReminderChannel(newTestSender(t, server), subscriptionRepo, newTestGate(db), translator)
+	habit := entities.NewHabit(user.ID, "Stretch", value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+
+	if err := channel.SendReminder(ctx, user, habit); err != nil {
+		t.Fatalf("SendReminder failed: %v", err)
+	}
+	if len(*requests) != 0 {
+		t.Errorf("Expected no push, got %d", len(*requests))
+	}
+}
+
+func TestReminderChannel_HoldsRemindersDuringQuietHours(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := sqlite.RunMigrations(db); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	translator, err := i18n.NewTranslator()
+	if err != nil {
+		t.Fatalf("Failed to create translator: %v", err)
+	}
+
+	ctx := context.Background()
+	userRepo := sqlite.NewUserRepository(db)
+	subscriptionRepo := sqlite.NewPushSubscriptionRepository(db)
+	heldRepo := sqlite.NewHeldNotificationRepository(db)
+
+	user := entities.NewUser("quiet@example.com", "hash")
+	userRepo.Create(ctx, user)
+
+	now := time.Now().UTC()
+	schedule := entities.NewNotificationSchedule(user.ID)
+	schedule.QuietHoursStart = now.Add(-time.Hour).Format(entities.ReminderTimeLayout)
+	schedule.QuietHoursEnd = now.Add(time.Hour).Format(entities.ReminderTimeLayout)
+	sqlite.NewNotificationScheduleRepository(db).Save(ctx, schedule)
+
+	server, requests := newFakePushService(t)
+	browser := newFakeBrowser(t)
+	subscriptionRepo.Save(ctx, browser.subscription(user.ID, server.URL+"/ok/laptop"))
+
+	sender := newTestSender(t, server)
+	channel := NewReminderChannel(sender, subscriptionRepo, newTestGate(db), translator)
+	for _, name := range []string{"Stretch", "Read"} {
+		habit := entities.NewHabit(user.ID, name, value_objects.HabitTypeBoolean, value_objects.FrequencyDaily, false, false)
+		if err := channel.SendReminder(ctx, user, habit); err != nil {
+			t.Fatalf("SendReminder failed: %v", err)
+		}
+	}
+	if len(*requests) != 0 {
+		t.Fatalf("Expected no push during quiet hours, got %d", len(*requests))
+	}
+
+	held, _ := heldRepo.FindByUserID(ctx, user.ID)
+	if len(held) != 2 {
+		t.Fatalf("Expected 2 held reminders, got %d", len(held))
+	}
+
+	if err := NewBatchNotifier(sender, subscriptionRepo, translator).SendBatch(ctx, user, held); err != nil {
+		t.Fatalf("SendBatch failed: %v", err)
+	}
+	if len(*requests) != 1 {
+		t.Fatalf("Expected a single push for the batch, got %d", len(*requests))
+	}
+
+	var notification Notification
+	json.Unmarshal(browser.decrypt(t, (*requests)[0].body), &notification)
+	if notification.Title != "2 notifications while you were away" || notification.Body != "Time for Stretch\nTime for Read" {
+		t.Errorf("Unexpected batch notification: %+v", notification)
+	}
+}
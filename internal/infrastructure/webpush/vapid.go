@@ -0,0 +1,103 @@
+package webpush
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"time"
+
+	"apocapoc-api/internal/domain/entities"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const vapidTokenExpiry = 12 * time.Hour
+
+// VAPIDKeys identifies this server to push services (RFC 8292). Keys are
+// exchanged as base64url: the public key as an uncompressed P-256 point and the
+// private key as the raw 32 byte scalar, like other Web Push libraries do.
+type VAPIDKeys struct {
+	publicKey  string
+	privateKey *ecdsa.PrivateKey
+}
+
+// GenerateVAPIDKeys returns a new base64url encoded key pair.
+func GenerateVAPIDKeys() (publicKey, privateKey string, err error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate VAPID keys: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		base64.RawURLEncoding.EncodeToString(key.Bytes()),
+		nil
+}
+
+func NewVAPIDKeys(publicKey, privateKey string) (*VAPIDKeys, error) {
+	rawPrivateKey, err := entities.DecodePushKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+
+	ecdhKey, err := ecdh.P256().NewPrivateKey(rawPrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+
+	rawPublicKey, err := entities.DecodePushKey(publicKey)
+	if err != nil || !bytes.Equal(rawPublicKey, ecdhKey.PublicKey().Bytes()) {
+		return nil, fmt.Errorf("VAPID public key does not match the private key")
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(ecdhKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+
+	signingKey, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok || signingKey.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("invalid VAPID private key")
+	}
+
+	return &VAPIDKeys{
+		publicKey:  base64.RawURLEncoding.EncodeToString(rawPublicKey),
+		privateKey: signingKey,
+	}, nil
+}
+
+func (k *VAPIDKeys) PublicKey() string {
+	return k.publicKey
+}
+
+// authorization builds the "vapid" Authorization header for a push endpoint.
+// The token audience is the origin of the endpoint.
+func (k *VAPIDKeys) authorization(endpoint, subject string, now time.Time) (string, error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid push endpoint: %w", err)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": endpointURL.Scheme + "://" + endpointURL.Host,
+		"exp": now.Add(vapidTokenExpiry).Unix(),
+		"sub": subject,
+	})
+
+	signed, err := token.SignedString(k.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign VAPID token: %w", err)
+	}
+
+	return fmt.Sprintf("vapid t=%s, k=%s", signed, k.publicKey), nil
+}
@@ -10,4 +10,5 @@ var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	ErrEmailNotVerified   = errors.New("email not verified")
 	ErrRegistrationClosed = errors.New("registration is closed")
+	ErrInsufficientPoints = errors.New("insufficient points")
 )
@@ -104,3 +104,56 @@ func TestShouldAppearToday_InvalidFrequency(t *testing.T) {
 		t.Error("Invalid frequency should return false")
 	}
 }
+
+func TestParseWeekday(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Weekday
+		valid    bool
+	}{
+		{"monday", time.Monday, true},
+		{"Sunday", time.Sunday, true},
+		{"SATURDAY", time.Saturday, true},
+		{"funday", time.Sunday, false},
+		{"", time.Sunday, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := ParseWeekday(tt.input)
+			if tt.valid && err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Fatal("Expected error for invalid weekday")
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestStartOfWeek(t *testing.T) {
+	wednesday := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		weekStart time.Weekday
+		expected  time.Time
+	}{
+		{"Monday start", time.Monday, time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)},
+		{"Sunday start", time.Sunday, time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC)},
+		{"Wednesday start", time.Wednesday, wednesday},
+		{"Thursday start", time.Thursday, time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := StartOfWeek(wednesday, tt.weekStart)
+			if !result.Equal(tt.expected) {
+				t.Errorf("Expected %s, got %s", tt.expected.Format("2006-01-02"), result.Format("2006-01-02"))
+			}
+		})
+	}
+}
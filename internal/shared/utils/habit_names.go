@@ -0,0 +1,29 @@
+package utils
+
+import "strings"
+
+// MatchHabitNames returns the indexes of the names equal to query, or else of
+// those starting with it, or else of those containing it, ignoring case. It
+// is how habits are picked by name in chat messages and email replies.
+func MatchHabitNames(names []string, query string) []int {
+	needle := strings.ToLower(query)
+	matchers := []func(name string) bool{
+		func(name string) bool { return name == needle },
+		func(name string) bool { return strings.HasPrefix(name, needle) },
+		func(name string) bool { return strings.Contains(name, needle) },
+	}
+
+	for _, matches := range matchers {
+		var found []int
+		for i, name := range names {
+			if matches(strings.ToLower(name)) {
+				found = append(found, i)
+			}
+		}
+		if len(found) > 0 {
+			return found
+		}
+	}
+
+	return nil
+}
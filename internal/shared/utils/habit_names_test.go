@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchHabitNames(t *testing.T) {
+	names := []string{"Walk the dog", "Walk 10k steps", "Read", "Bread baking"}
+
+	tests := []struct {
+		query    string
+		expected []int
+	}{
+		{"read", []int{2}},
+		{"walk", []int{0, 1}},
+		{"WALK THE", []int{0}},
+		{"baking", []int{3}},
+		{"piano", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := MatchHabitNames(names, tt.query); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
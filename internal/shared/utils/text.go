@@ -0,0 +1,17 @@
+package utils
+
+import "unicode/utf8"
+
+// Truncate cuts message to at most maxBytes bytes without splitting a
+// multibyte character, so the result is still valid UTF-8.
+func Truncate(message string, maxBytes int) string {
+	if len(message) <= maxBytes {
+		return message
+	}
+
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(message[cut]) {
+		cut--
+	}
+	return message[:cut]
+}
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		message  string
+		maxBytes int
+		expected string
+	}{
+		{"timeout", 10, "timeout"},
+		{"timeout", 4, "time"},
+		{"año", 2, "a"},
+		{"año", 3, "añ"},
+		{"🔥🔥", 5, "🔥"},
+	}
+
+	for _, tt := range tests {
+		got := Truncate(tt.message, tt.maxBytes)
+		if got != tt.expected || !utf8.ValidString(got) {
+			t.Errorf("Truncate(%q, %d) = %q, expected %q", tt.message, tt.maxBytes, got, tt.expected)
+		}
+	}
+}